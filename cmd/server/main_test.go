@@ -10,6 +10,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/vkupriya/gophkeeper/internal/server"
+	"github.com/vkupriya/gophkeeper/internal/server/models"
 )
 
 func TestServer(t *testing.T) {
@@ -19,6 +20,9 @@ func TestServer(t *testing.T) {
 		t.Error(err)
 	}
 
+	// server runs standalone without Postgres DB
+	t.Setenv("STORAGE_BACKEND", models.BackendMemory)
+
 	g := errgroup.Group{}
 
 	g.Go(func() error {
@@ -0,0 +1,102 @@
+// Package audit implements gkcli command printing the audit log of the user.
+package audit
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	grpcclient "github.com/vkupriya/gophkeeper/internal/client/grpc"
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
+	"github.com/vkupriya/gophkeeper/internal/client/models"
+)
+
+const tokenJWT string = "token"
+const refreshJWT string = "refresh_token"
+const hostGRPC string = "server"
+
+var AuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "list audit log of your secret access",
+	Long: `Audit command lists secret reads and changes made with your account, newest first.
+Time range is set with --from and --to as date, e.g. 2024-05-01, RFC 3339 time or time ago,
+e.g. 24h or 7d. Calls denied by the server are listed too, e.g. with PermissionDenied code.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		now := time.Now()
+		limit, _ := cmd.Flags().GetInt("limit")
+		filter := models.AuditFilter{Limit: limit}
+		for flag, t := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+			value, _ := cmd.Flags().GetString(flag)
+			if value == "" {
+				continue
+			}
+			parsed, err := helpers.ParseTime(value, now)
+			if err != nil {
+				cobra.CheckErr(fmt.Sprintf("invalid --%s: %v", flag, err))
+			}
+			*t = parsed
+		}
+
+		token, svc := auditService()
+		events, err := svc.ListAuditEvents(token, filter)
+		if err != nil {
+			msg := fmt.Sprintf("error getting audit events: %v", err)
+			cobra.CheckErr(msg)
+		}
+		printEvents(events)
+	},
+}
+
+// auditService returns access token and grpc client of the logged in user.
+func auditService() (string, *grpcclient.Service) {
+	server := viper.GetViper().GetString(hostGRPC)
+	if server == "" {
+		cobra.CheckErr("missing grpc server address and port")
+	}
+	token := viper.GetViper().GetString(tokenJWT)
+	if token == "" {
+		cobra.CheckErr("missing user token, please, login")
+	}
+
+	svc := grpcclient.NewService()
+	if err := grpcclient.NewGRPCClient(svc, server, helpers.GetTLSConfig()); err != nil {
+		msg := fmt.Sprintf("error initializing GRPC client: %v", err)
+		cobra.CheckErr(msg)
+	}
+	svc.SetRefreshToken(viper.GetViper().GetString(refreshJWT), saveTokens)
+	return token, svc
+}
+
+// saveTokens writes tokens refreshed by grpc client into configuration file.
+func saveTokens(tokens *models.Tokens) error {
+	viper.Set(tokenJWT, tokens.Token)
+	viper.Set(refreshJWT, tokens.RefreshToken)
+	if err := viper.WriteConfig(); err != nil {
+		return fmt.Errorf("error writing configuration file: %w", err)
+	}
+	return nil
+}
+
+// printEvents prints audit events as a table.
+func printEvents(events []*models.AuditEvent) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tMETHOD\tNAME\tOWNER\tCLIENT\tRESULT")
+	for _, e := range events {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", e.CreatedAt.Local().Format("2006-01-02 15:04:05"), e.Method,
+			e.Name, e.Owner, e.ClientIP, e.Code)
+	}
+	if err := w.Flush(); err != nil {
+		cobra.CheckErr(err)
+	}
+}
+
+func init() {
+	AuditCmd.Flags().String("from", "", "Start of time range, e.g. 2024-05-01, 2024-05-01T10:00:00Z or 24h ago.")
+	AuditCmd.Flags().String("to", "", "End of time range, not included, in the same formats as --from.")
+	AuditCmd.Flags().Int("limit", 0, "Maximum number of events, 0 is server default (100).")
+
+	AuditCmd.AddCommand(VerifyCmd)
+}
@@ -0,0 +1,36 @@
+package audit
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var VerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "verify integrity of the audit log",
+	Long: `Verify command asks the server to walk the hash chain of the whole audit log and to compare it
+with signed checkpoints, if the server exports them. The first event changed or deleted after it was
+recorded is reported and the command fails.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		token, svc := auditService()
+		result, err := svc.VerifyAuditLog(token)
+		if err != nil {
+			msg := fmt.Sprintf("error verifying audit log: %v", err)
+			cobra.CheckErr(msg)
+		}
+
+		fmt.Printf("verified %d events against %d checkpoints\n", result.Verified, result.Checkpoints)
+		if result.Unchained != 0 {
+			fmt.Printf("%d events recorded before the hash chain was introduced are not verified\n",
+				result.Unchained)
+		}
+		if !result.Intact {
+			if result.BrokenID != 0 {
+				cobra.CheckErr(fmt.Sprintf("audit log is broken at event %d: %s", result.BrokenID, result.Reason))
+			}
+			cobra.CheckErr(fmt.Sprintf("audit log checkpoints are broken: %s", result.Reason))
+		}
+		fmt.Println("audit log is intact")
+	},
+}
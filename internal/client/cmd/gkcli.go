@@ -6,8 +6,11 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/vkupriya/gophkeeper/internal/client/cmd/audit"
 	"github.com/vkupriya/gophkeeper/internal/client/cmd/login"
 	"github.com/vkupriya/gophkeeper/internal/client/cmd/secret"
+	"github.com/vkupriya/gophkeeper/internal/client/cmd/vault"
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
 	"go.uber.org/zap"
 )
 
@@ -32,9 +35,21 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.gk.yaml)")
 	rootCmd.PersistentFlags().StringVar(&server, "server", "127.0.0.1:3200", "gophkeeper server address:port")
 	rootCmd.PersistentFlags().StringVar(&dbpath, "dbpath", "./secrets.db", "path to sqlite local database")
+	rootCmd.PersistentFlags().Bool("tls", false, "use TLS for connection to gophkeeper server")
+	rootCmd.PersistentFlags().String("tls-ca", "", "path to CA bundle verifying gophkeeper server certificate")
+	rootCmd.PersistentFlags().String("tls-cert", "", "path to client certificate for mutual TLS")
+	rootCmd.PersistentFlags().String("tls-key", "", "path to client certificate key for mutual TLS")
+	cobra.CheckErr(viper.BindPFlag(helpers.TLSEnabled, rootCmd.PersistentFlags().Lookup("tls")))
+	cobra.CheckErr(viper.BindPFlag(helpers.TLSCAFile, rootCmd.PersistentFlags().Lookup("tls-ca")))
+	cobra.CheckErr(viper.BindPFlag(helpers.TLSCertFile, rootCmd.PersistentFlags().Lookup("tls-cert")))
+	cobra.CheckErr(viper.BindPFlag(helpers.TLSKeyFile, rootCmd.PersistentFlags().Lookup("tls-key")))
 	rootCmd.AddCommand(InitCmd)
 	rootCmd.AddCommand(login.LoginCmd)
+	rootCmd.AddCommand(login.LogoutCmd)
 	rootCmd.AddCommand(secret.SecretCmd)
+	rootCmd.AddCommand(secret.VerifyCmd)
+	rootCmd.AddCommand(vault.VaultCmd)
+	rootCmd.AddCommand(audit.AuditCmd)
 	rootCmd.AddCommand(VersionCmd)
 }
 
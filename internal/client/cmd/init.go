@@ -18,16 +18,14 @@ var InitCmd = &cobra.Command{
 		if dbpath == "" {
 			cobra.CheckErr("missing local db path")
 		}
+		// migrations are applied when DB is opened
 		Store, err := storage.NewSQLiteDB(dbpath)
 		if err != nil {
 			msg = fmt.Sprintf("failed setting up DB: %v", err)
 			cobra.CheckErr(msg)
 		}
-
-		err = storage.RunMigrations(Store)
-		if err != nil {
-			msg = fmt.Sprintf("failed running migrations: %v", err)
-			cobra.CheckErr(msg)
+		if err = Store.DB.Close(); err != nil {
+			cobra.CheckErr("failed to close local DB")
 		}
 	},
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/viper"
 	grpcclient "github.com/vkupriya/gophkeeper/internal/client/grpc"
 	"github.com/vkupriya/gophkeeper/internal/client/helpers"
+	"github.com/vkupriya/gophkeeper/internal/client/models"
 )
 
 const serverStr = "server"
@@ -40,32 +41,43 @@ var LoginCmd = &cobra.Command{
 
 			svc := grpcclient.NewService()
 
-			if err := grpcclient.NewGRPCClient(svc, server); err != nil {
+			if err := grpcclient.NewGRPCClient(svc, server, helpers.GetTLSConfig()); err != nil {
 				msg = fmt.Sprintf("error initializing GRPC client: %v", err)
 				cobra.CheckErr(msg)
 			}
-			var token string
+			var tokens *models.Tokens
 			var err error
 			reg, _ := cmd.Flags().GetBool("register")
 			if reg {
-				token, err = svc.Register(user, password)
+				tokens, err = svc.Register(user, password)
 				if err != nil {
 					msg = fmt.Sprintf("error registering user: %v", err)
 					cobra.CheckErr(msg)
 				}
 			} else {
-				token, err = svc.Login(user, password)
+				tokens, err = svc.Login(user, password)
 				if err != nil {
 					msg = fmt.Sprintf("login error: %v ", err)
 					cobra.CheckErr(msg)
 				}
 			}
 			viper.Set(serverStr, server)
-			viper.Set("token", token)
+			viper.Set("token", tokens.Token)
+			viper.Set("refresh_token", tokens.RefreshToken)
+			// user name is a salt for the encryption key derivation
+			viper.Set("user", user)
 			if err = viper.WriteConfig(); err != nil {
 				msg = fmt.Sprintf("Error writing configuration file: %v", err)
 				cobra.CheckErr(msg)
 			}
+
+			// public key lets other users share secrets with the user
+			if password := viper.GetViper().GetString("secretkey"); password != "" {
+				key := helpers.NewSecretKey(password, user)
+				if err = svc.RegisterPublicKey(tokens.Token, key); err != nil {
+					fmt.Printf("failed to register public key: %v\n", err)
+				}
+			}
 		}
 	},
 }
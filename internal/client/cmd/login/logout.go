@@ -0,0 +1,59 @@
+package login
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	grpcclient "github.com/vkupriya/gophkeeper/internal/client/grpc"
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
+)
+
+// LogoutCmd represents the logout command.
+var LogoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Logout from Gophkeeper Server",
+	Long: `Logout revokes tokens on the server and removes them from configuration file.
+With --all flag all sessions of the user on every device are revoked.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var msg string
+		server := viper.GetViper().GetString(serverStr)
+		if server == "" {
+			cobra.CheckErr("server not specified.")
+		}
+
+		token := viper.GetViper().GetString("token")
+		refreshToken := viper.GetViper().GetString("refresh_token")
+		all, _ := cmd.Flags().GetBool("all")
+
+		if refreshToken != "" || all {
+			svc := grpcclient.NewService()
+
+			if err := grpcclient.NewGRPCClient(svc, server, helpers.GetTLSConfig()); err != nil {
+				msg = fmt.Sprintf("error initializing GRPC client: %v", err)
+				cobra.CheckErr(msg)
+			}
+
+			if all {
+				svc.SetRefreshToken(refreshToken, nil)
+				if err := svc.RevokeSessions(token); err != nil {
+					msg = fmt.Sprintf("failed to revoke sessions on server: %v", err)
+					cobra.CheckErr(msg)
+				}
+			} else if err := svc.Logout(token, refreshToken); err != nil {
+				fmt.Println("failed to revoke refresh token on server: ", err)
+			}
+		}
+
+		viper.Set("token", "")
+		viper.Set("refresh_token", "")
+		if err := viper.WriteConfig(); err != nil {
+			msg = fmt.Sprintf("Error writing configuration file: %v", err)
+			cobra.CheckErr(msg)
+		}
+	},
+}
+
+func init() {
+	LogoutCmd.Flags().Bool("all", false, "Flag to revoke all sessions of the user.")
+}
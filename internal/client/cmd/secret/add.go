@@ -1,13 +1,20 @@
 package secret
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	grpcclient "github.com/vkupriya/gophkeeper/internal/client/grpc"
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
 	"github.com/vkupriya/gophkeeper/internal/client/models"
+	"github.com/vkupriya/gophkeeper/internal/client/storage"
+	"github.com/vkupriya/gophkeeper/internal/labels"
 )
 
 var AddCmd = &cobra.Command{
@@ -25,61 +32,316 @@ var AddCmd = &cobra.Command{
 			cobra.CheckErr(msgErrMissingToken)
 		}
 
-		key := viper.GetViper().GetString("secretkey")
-		if key == "" {
-			cobra.CheckErr("Missing secretkey, update configuration file.")
-		}
+		key := secretKey()
 		svc := grpcclient.NewService()
 
-		if err := grpcclient.NewGRPCClient(svc, server); err != nil {
+		if err := grpcclient.NewGRPCClient(svc, server, helpers.GetTLSConfig()); err != nil {
 			cobra.CheckErr(err)
 		}
-		var data []byte
-		var err error
+		svc.SetRefreshToken(viper.GetViper().GetString(refreshJWT), saveTokens)
+		key = useVault(cmd, svc, token, key)
 
 		name, _ := cmd.Flags().GetString(secretName)
-		meta, _ := cmd.Flags().GetString("metadata")
+		secretLabels := labelsFlag(cmd)
 		stype, _ := cmd.Flags().GetString("stype")
 		update, _ := cmd.Flags().GetBool("update")
+		version, _ := cmd.Flags().GetInt64(secretVersion)
 		file, _ := cmd.Flags().GetString("file")
+		dataStr, _ := cmd.Flags().GetString("data")
 
-		if file != "" {
-			data, err = os.ReadFile(file)
-			if err != nil {
-				msg := fmt.Sprintf("failed to read file %s: %v", file, err)
+		data := []byte(dataStr)
+		switch stype {
+		case "card":
+			data = cardData(cmd, dataStr)
+		case "login":
+			data = credentialsData(cmd, dataStr)
+		case "otp":
+			if _, err := helpers.ParseOTPURI(dataStr); err != nil {
+				msg := fmt.Sprintf("otp data must be otpauth://totp/ URI: %v", err)
 				cobra.CheckErr(msg)
 			}
-		} else {
-			dataStr, _ := cmd.Flags().GetString("data")
-			data = []byte(dataStr)
 		}
 
 		secret := &models.Secret{
-			Name: name,
-			Data: data,
-			Type: stype,
-			Meta: meta,
+			Name:   name,
+			Data:   data,
+			Type:   stype,
+			Labels: secretLabels,
 		}
+		dbpath, _ := cmd.Flags().GetString("dbpath")
 		if update {
-			err := svc.UpdateSecret(token, key, secret)
-			if err != nil {
-				fmt.Println("error updating secret: ", err)
+			if version == 0 && !inVault(cmd) {
+				version = localVersion(dbpath, name)
+			}
+			// update without the expected version would silently overwrite changes made on server
+			if version == 0 {
+				msg := fmt.Sprintf("version of secret %s you are updating is unknown, set it with --version "+
+					"after reviewing the secret with 'secret get' and 'secret history'", name)
+				if !inVault(cmd) {
+					msg += " or run 'secret sync'"
+				}
+				cobra.CheckErr(msg)
 			}
-		} else {
-			err := svc.AddSecret(token, key, secret)
+			secret.Version = version
+		}
+
+		var err error
+		switch {
+		case file != "":
+			err = uploadFile(svc, token, key, secret, update, file)
+		case update:
+			err = svc.UpdateSecret(token, key, secret)
+		default:
+			err = svc.AddSecret(token, key, secret)
+		}
+
+		if errors.Is(err, grpcclient.ErrVersionConflict) {
+			current, err := svc.GetSecret(token, key, name)
 			if err != nil {
+				msg := fmt.Sprintf("secret has been changed on server, failed to get current version: %v", err)
+				cobra.CheckErr(msg)
+			}
+			msg := fmt.Sprintf("secret %s has been changed on server: your version %d, server version %d. "+
+				"Your update is not saved, review the current data with 'secret get -n %s', merge your "+
+				"changes into it and run 'secret sync' before updating again.",
+				name, version, current.Version, name)
+			cobra.CheckErr(msg)
+		}
+		if err != nil {
+			if update {
+				fmt.Println("error updating secret: ", err)
+			} else {
 				fmt.Println("error adding secret: ", err)
 			}
+			return
+		}
+		if update && !inVault(cmd) {
+			updateLocal(svc, token, key, dbpath, name)
 		}
 	},
 }
 
+// updateLocal replaces local copy of the updated secret with the new version from the server, so that
+// the next update expects it. Secrets which are not kept in local DB are left to sync.
+func updateLocal(svc *grpcclient.Service, token string, key *helpers.SecretKey, dbpath string, name string) {
+	if localVersion(dbpath, name) == 0 {
+		return
+	}
+	store, err := storage.NewSQLiteDB(dbpath)
+	if err == nil {
+		defer func() {
+			if err := store.DB.Close(); err != nil {
+				cobra.CheckErr("failed to close local DB")
+			}
+		}()
+		err = refetchSecret(svc, store, token, key, name)
+	}
+	if err != nil {
+		fmt.Printf("secret is updated on server, but not in local DB: %v, run 'secret sync'\n", err)
+	}
+}
+
+// metaLabel is the label keeping free-form metadata of secrets.
+const metaLabel = "meta"
+
+// uploadFile streams file content to the server without reading the whole file into memory.
+func uploadFile(svc *grpcclient.Service, token string, key *helpers.SecretKey, secret *models.Secret,
+	update bool, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", file, err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			cobra.CheckErr("failed to close file")
+		}
+	}()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat file %s: %w", file, err)
+	}
+
+	err = svc.UploadSecret(token, key, secret, update, f, printProgress("uploaded", info.Size()))
+	fmt.Fprintln(os.Stderr)
+	return err
+}
+
+// labelsFlag merges labels from label flag and deprecated metadata flag, labels are validated.
+func labelsFlag(cmd *cobra.Command) map[string]string {
+	meta, _ := cmd.Flags().GetString("metadata")
+	result := metadataLabels(meta)
+	values, _ := cmd.Flags().GetStringToString("label")
+	for k, v := range values {
+		result[k] = v
+	}
+	if err := labels.Validate(result); err != nil {
+		msg := fmt.Sprintf("invalid secret labels: %v", err)
+		cobra.CheckErr(msg)
+	}
+	return result
+}
+
+// metadataLabels converts value of deprecated metadata flag to labels. Metadata given as key=value pairs
+// with valid keys is split into labels, any other metadata becomes 'meta' label, as the metadata of
+// existing secrets did, e.g. '{"a":"x","b":"y"}' is kept as is.
+func metadataLabels(meta string) map[string]string {
+	result := make(map[string]string)
+	if meta == "" {
+		return result
+	}
+	for _, pair := range strings.Split(meta, ",") {
+		k, v, found := strings.Cut(pair, "=")
+		k = strings.TrimSpace(k)
+		if !found || labels.ValidateKey(k) != nil {
+			return map[string]string{metaLabel: meta}
+		}
+		result[k] = strings.TrimSpace(v)
+	}
+	return result
+}
+
+// cardData builds card secret from JSON in data flag or from card flags, card is validated.
+func cardData(cmd *cobra.Command, dataStr string) []byte {
+	var card models.Card
+	if dataStr != "" {
+		if err := json.Unmarshal([]byte(dataStr), &card); err != nil {
+			msg := fmt.Sprintf("card data must be JSON with number, holder, expiry and cvv: %v", err)
+			cobra.CheckErr(msg)
+		}
+	} else {
+		card.Number, _ = cmd.Flags().GetString("card-number")
+		card.Holder, _ = cmd.Flags().GetString("card-holder")
+		card.Expiry, _ = cmd.Flags().GetString("card-expiry")
+		card.CVV, _ = cmd.Flags().GetString("card-cvv")
+	}
+
+	valid, err := helpers.NewCard(card.Number, card.Holder, card.Expiry, card.CVV, time.Now())
+	if err != nil {
+		msg := fmt.Sprintf("invalid card: %v", err)
+		cobra.CheckErr(msg)
+	}
+
+	data, err := json.Marshal(valid)
+	if err != nil {
+		msg := fmt.Sprintf("failed to encode card: %v", err)
+		cobra.CheckErr(msg)
+	}
+	return data
+}
+
+// credentialsData builds login secret from JSON in data flag or from login flags. Password is
+// generated with --generate, it is asked interactively if not given.
+func credentialsData(cmd *cobra.Command, dataStr string) []byte {
+	var creds models.Credentials
+	if dataStr != "" {
+		if err := json.Unmarshal([]byte(dataStr), &creds); err != nil {
+			msg := fmt.Sprintf("login data must be JSON with username, password, url and notes: %v", err)
+			cobra.CheckErr(msg)
+		}
+	} else {
+		creds.Username, _ = cmd.Flags().GetString("username")
+		creds.Password, _ = cmd.Flags().GetString("password")
+		creds.URL, _ = cmd.Flags().GetString("url")
+		creds.Notes, _ = cmd.Flags().GetString("notes")
+	}
+
+	if creds.Username == "" {
+		cobra.CheckErr("username is required for login type")
+	}
+
+	if generate, _ := cmd.Flags().GetBool("generate"); generate {
+		password, err := helpers.GeneratePassword(passwordPolicy(cmd))
+		if err != nil {
+			msg := fmt.Sprintf("failed to generate password: %v", err)
+			cobra.CheckErr(msg)
+		}
+		creds.Password = password
+		fmt.Println("generated password:", password)
+	}
+	if creds.Password == "" {
+		creds.Password = helpers.GetPassword()
+	}
+
+	data, err := json.Marshal(creds)
+	if err != nil {
+		msg := fmt.Sprintf("failed to encode login: %v", err)
+		cobra.CheckErr(msg)
+	}
+	return data
+}
+
+// passwordPolicy reads password generator policy from flags, defaults are taken from configuration file.
+func passwordPolicy(cmd *cobra.Command) helpers.PasswordPolicy {
+	length, _ := cmd.Flags().GetInt("length")
+	if !cmd.Flags().Changed("length") && viper.GetViper().IsSet(passwordLength) {
+		length = viper.GetViper().GetInt(passwordLength)
+	}
+
+	classes, _ := cmd.Flags().GetString("classes")
+	if !cmd.Flags().Changed("classes") && viper.GetViper().IsSet(passwordClasses) {
+		classes = viper.GetViper().GetString(passwordClasses)
+	}
+
+	parsed, err := helpers.ParsePasswordClasses(classes)
+	if err != nil {
+		msg := fmt.Sprintf("invalid password policy: %v", err)
+		cobra.CheckErr(msg)
+	}
+	return helpers.PasswordPolicy{Length: length, Classes: parsed}
+}
+
+// localVersion returns version of the secret in local DB, 0 if it is not available.
+func localVersion(dbpath string, name string) int64 {
+	if _, err := os.Stat(dbpath); err != nil {
+		return 0
+	}
+	store, err := storage.NewSQLiteDB(dbpath)
+	if err != nil {
+		return 0
+	}
+	defer func() {
+		if err := store.DB.Close(); err != nil {
+			cobra.CheckErr("failed to close local DB")
+		}
+	}()
+
+	secret, err := store.SecretGet(name)
+	if err != nil {
+		return 0
+	}
+	return secret.Version
+}
+
 func init() {
 	AddCmd.Flags().StringP(secretName, "n", "", "Unique secret name.")
 	AddCmd.Flags().StringP("data", "d", "", "Secret data.")
-	AddCmd.Flags().StringP("metadata", "m", "", "JSON string with secret metadata.")
-	AddCmd.Flags().StringP("stype", "t", "text", "Secret type: permitted [text, binary, card].")
-	AddCmd.Flags().StringP("file", "f", "", "File with secret data.")
+	AddCmd.Flags().StringToStringP("label", "l", nil,
+		"Secret labels as key=value pairs, e.g. env=prod,team=payments. Labels are replaced on update.")
+	AddCmd.Flags().StringP("metadata", "m", "",
+		"Secret metadata, it is saved as 'meta' label unless given as key=value pairs.")
+	if err := AddCmd.Flags().MarkDeprecated("metadata", "use --label instead"); err != nil {
+		cobra.CheckErr(err)
+	}
+	AddCmd.Flags().StringP("stype", "t", "text", "Secret type: permitted [text, binary, card, file, login, otp].")
+	AddCmd.Flags().StringP("file", "f", "", "File with secret data, it is streamed to the server by chunks.")
 	AddCmd.Flags().BoolP("update", "u", false, "Update existing secret.")
+	AddCmd.Flags().Int64(secretVersion, 0,
+		"Expected current version of secret for update, version from local DB is used if not set. "+
+			"Update is refused if the version is unknown.")
+	AddCmd.Flags().String("card-number", "", "Card number for card type.")
+	AddCmd.Flags().String("card-holder", "", "Card holder name for card type.")
+	AddCmd.Flags().String("card-expiry", "", "Card expiry date in MM/YY format for card type.")
+	AddCmd.Flags().String("card-cvv", "", "Card CVV for card type.")
+	AddCmd.Flags().String("username", "", "Username for login type.")
+	AddCmd.Flags().String("password", "", "Password for login type, it is asked if not set.")
+	AddCmd.Flags().String("url", "", "Website URL for login type.")
+	AddCmd.Flags().String("notes", "", "Notes for login type.")
+	AddCmd.Flags().Bool("generate", false, "Generate password for login type.")
+	AddCmd.Flags().Int("length", defaultPasswordLength, "Length of generated password.")
+	AddCmd.Flags().String("classes", defaultPasswordClasses,
+		"Comma separated character classes of generated password: lower, upper, digits, symbols.")
 	AddCmd.MarkFlagsMutuallyExclusive("data", "file")
+	AddCmd.MarkFlagsMutuallyExclusive("data", "card-number")
+	AddCmd.MarkFlagsMutuallyExclusive("password", "generate")
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	grpcclient "github.com/vkupriya/gophkeeper/internal/client/grpc"
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
 )
 
 var DeleteCmd = &cobra.Command{
@@ -26,10 +27,12 @@ var DeleteCmd = &cobra.Command{
 
 		svc := grpcclient.NewService()
 
-		if err := grpcclient.NewGRPCClient(svc, server); err != nil {
+		if err := grpcclient.NewGRPCClient(svc, server, helpers.GetTLSConfig()); err != nil {
 			msg = fmt.Sprint(msgErrInitGRPC, err)
 			cobra.CheckErr(msg)
 		}
+		svc.SetRefreshToken(viper.GetViper().GetString(refreshJWT), saveTokens)
+		useVault(cmd, svc, token, nil)
 
 		name, _ := cmd.Flags().GetString(secretName)
 
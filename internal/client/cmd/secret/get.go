@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	grpcclient "github.com/vkupriya/gophkeeper/internal/client/grpc"
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
 	"github.com/vkupriya/gophkeeper/internal/client/models"
 	"github.com/vkupriya/gophkeeper/internal/client/storage"
 )
@@ -31,52 +33,34 @@ var GetCmd = &cobra.Command{
 		if token == "" {
 			cobra.CheckErr(msgErrMissingToken)
 		}
-		key := viper.GetViper().GetString("secretkey")
-		if key == "" {
-			cobra.CheckErr("Missing secretkey, update configuration file")
-		}
+		key := secretKey()
 		svc := grpcclient.NewService()
 
-		if err := grpcclient.NewGRPCClient(svc, server); err != nil {
+		if err := grpcclient.NewGRPCClient(svc, server, helpers.GetTLSConfig()); err != nil {
 			msg = fmt.Sprint(msgErrInitGRPC, err)
 			cobra.CheckErr(msg)
 		}
+		svc.SetRefreshToken(viper.GetViper().GetString(refreshJWT), saveTokens)
+		key = useVault(cmd, svc, token, key)
 
 		name, _ := cmd.Flags().GetString("name")
 		filepath, _ := cmd.Flags().GetString("outfile")
+		version, _ := cmd.Flags().GetInt64(secretVersion)
 
-		secret, err := svc.GetSecret(token, key, name)
+		var secret *models.Secret
+		var err error
+		downloaded := false
+		if filepath != "" {
+			secret, err = downloadFile(svc, token, key, name, version, filepath)
+			downloaded = err == nil
+		} else {
+			secret, err = svc.GetSecretVersion(token, key, name, version)
+		}
 		if err != nil {
 			if errors.Is(err, grpcclient.ErrServerUnavailable) {
-				fmt.Println("server is unavailable, attempting to read secret from local DB.")
-				dbpath, _ := cmd.Flags().GetString("dbpath")
-				if dbpath == "" {
-					cobra.CheckErr(msgErrNoDBPath)
-				}
-
-				if _, err := os.Stat(dbpath); errors.Is(err, os.ErrNotExist) {
-					cobra.CheckErr("local DB does not exists, run 'init' command to create DB")
-				}
-
-				store, err := storage.NewSQLiteDB(dbpath)
-				if err != nil {
-					msg = fmt.Sprintf("Error in setting up DB: %v", err)
-					cobra.CheckErr(msg)
-				}
-				defer func() {
-					if err := store.DB.Close(); err != nil {
-						cobra.CheckErr("failed to close local DB")
-					}
-				}()
-
-				secret, err = store.SecretGet(name)
-				if err != nil {
-					if errors.Is(err, storage.ErrSecretNotFound) {
-						cobra.CheckErr("secret not found in local DB")
-					}
-					msg = fmt.Sprintf("failed to read secrets from local DB: %v", err)
-					cobra.CheckErr(msg)
-
+				secret = localSecret(cmd, name)
+				if version != 0 && secret.Version != version {
+					cobra.CheckErr("only latest version of secret is available in local DB")
 				}
 			} else {
 				msg = fmt.Sprintf("error getting secret: %v", err)
@@ -85,11 +69,11 @@ var GetCmd = &cobra.Command{
 		}
 
 		if filepath != "" {
-			if secret.Type != "text" && secret.Type != "binary" {
-				cobra.CheckErr("exporting to file only supported for 'text' and 'binary' types")
+			if downloaded {
+				return
 			}
 
-			f, err := os.OpenFile(filepath, os.O_WRONLY|os.O_CREATE, FilePermissions)
+			f, err := os.OpenFile(filepath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, FilePermissions)
 			if err != nil {
 				msg = fmt.Sprintf("error opening file %s: %v ", filepath, err)
 				cobra.CheckErr(msg)
@@ -106,37 +90,151 @@ var GetCmd = &cobra.Command{
 			}
 
 		} else {
-			switch secret.Type {
-			case "text":
-				res, _ := json.MarshalIndent(models.SecretPrint{
-					Name:    secret.Name,
-					Type:    secret.Type,
-					Meta:    secret.Meta,
-					Data:    string(secret.Data),
-					Version: secret.Version,
-				}, "", "    ")
-				fmt.Println(string(res))
-			case "card":
-				res, _ := json.MarshalIndent(models.SecretPrint{
-					Name:    secret.Name,
-					Type:    secret.Type,
-					Meta:    secret.Meta,
-					Data:    string(secret.Data),
-					Version: secret.Version,
-				}, "", "    ")
-				fmt.Println(string(res))
-			default:
-				res, _ := json.MarshalIndent(secret, "", "    ")
-				fmt.Println(string(res))
-			}
+			printSecret(cmd, secret)
 		}
-
 	},
 }
 
+// printSecret prints secret as JSON, card numbers, passwords and OTP secrets are masked
+// unless reveal flag is set.
+func printSecret(cmd *cobra.Command, secret *models.Secret) {
+	switch secret.Type {
+	case "text":
+		res, _ := json.MarshalIndent(models.SecretPrint{
+			Name:    secret.Name,
+			Type:    secret.Type,
+			Labels:  secret.Labels,
+			Data:    string(secret.Data),
+			Version: secret.Version,
+		}, "", "    ")
+		fmt.Println(string(res))
+	case "card":
+		var card models.Card
+		if err := json.Unmarshal(secret.Data, &card); err != nil {
+			// card added before structured card type, it is printed as is
+			res, _ := json.MarshalIndent(models.SecretPrint{
+				Name:    secret.Name,
+				Type:    secret.Type,
+				Labels:  secret.Labels,
+				Data:    string(secret.Data),
+				Version: secret.Version,
+			}, "", "    ")
+			fmt.Println(string(res))
+			break
+		}
+		if reveal, _ := cmd.Flags().GetBool("reveal"); !reveal {
+			card = helpers.MaskCard(card)
+		}
+		res, _ := json.MarshalIndent(models.CardPrint{
+			Name:    secret.Name,
+			Type:    secret.Type,
+			Labels:  secret.Labels,
+			Data:    card,
+			Version: secret.Version,
+		}, "", "    ")
+		fmt.Println(string(res))
+	case "login":
+		var creds models.Credentials
+		if err := json.Unmarshal(secret.Data, &creds); err != nil {
+			msg := fmt.Sprintf("failed to decode login: %v", err)
+			cobra.CheckErr(msg)
+		}
+		if reveal, _ := cmd.Flags().GetBool("reveal"); !reveal {
+			creds.Password = strings.Repeat("*", len(creds.Password))
+		}
+		res, _ := json.MarshalIndent(models.CredentialsPrint{
+			Name:    secret.Name,
+			Type:    secret.Type,
+			Labels:  secret.Labels,
+			Data:    creds,
+			Version: secret.Version,
+		}, "", "    ")
+		fmt.Println(string(res))
+	case "otp":
+		data := string(secret.Data)
+		if reveal, _ := cmd.Flags().GetBool("reveal"); !reveal {
+			data = helpers.MaskOTPURI(data)
+		}
+		res, _ := json.MarshalIndent(models.SecretPrint{
+			Name:    secret.Name,
+			Type:    secret.Type,
+			Labels:  secret.Labels,
+			Data:    data,
+			Version: secret.Version,
+		}, "", "    ")
+		fmt.Println(string(res))
+	default:
+		res, _ := json.MarshalIndent(secret, "", "    ")
+		fmt.Println(string(res))
+	}
+}
+
+// localSecret reads secret from local DB when the server is unavailable.
+func localSecret(cmd *cobra.Command, name string) *models.Secret {
+	if inVault(cmd) {
+		cobra.CheckErr(msgErrVaultOffline)
+	}
+	fmt.Println("server is unavailable, attempting to read secret from local DB.")
+	dbpath, _ := cmd.Flags().GetString("dbpath")
+	if dbpath == "" {
+		cobra.CheckErr(msgErrNoDBPath)
+	}
+
+	if _, err := os.Stat(dbpath); errors.Is(err, os.ErrNotExist) {
+		cobra.CheckErr("local DB does not exists, run 'init' command to create DB")
+	}
+
+	store, err := storage.NewSQLiteDB(dbpath)
+	if err != nil {
+		msg := fmt.Sprintf("Error in setting up DB: %v", err)
+		cobra.CheckErr(msg)
+	}
+	defer func() {
+		if err := store.DB.Close(); err != nil {
+			cobra.CheckErr("failed to close local DB")
+		}
+	}()
+
+	secret, err := store.SecretGet(name)
+	if err != nil {
+		if errors.Is(err, storage.ErrSecretNotFound) {
+			cobra.CheckErr("secret not found in local DB")
+		}
+		if errors.Is(err, storage.ErrSecretCorrupted) {
+			cobra.CheckErr("local copy of the secret is corrupted, run 'verify' once the server is available")
+		}
+		msg := fmt.Sprintf("failed to read secrets from local DB: %v", err)
+		cobra.CheckErr(msg)
+	}
+	return secret
+}
+
+// downloadFile streams secret data from the server into the file, file is removed if download fails.
+func downloadFile(svc *grpcclient.Service, token string, key *helpers.SecretKey, name string, version int64,
+	filepath string) (*models.Secret, error) {
+	f, err := os.OpenFile(filepath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, FilePermissions)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file %s: %w", filepath, err)
+	}
+
+	secret, err := svc.DownloadSecret(token, key, name, version, f, printProgress("downloaded", 0))
+	fmt.Fprintln(os.Stderr)
+	if cerr := f.Close(); err == nil && cerr != nil {
+		err = fmt.Errorf("failed to close file %s: %w", filepath, cerr)
+	}
+	if err != nil {
+		_ = os.Remove(filepath)
+		return nil, err
+	}
+	return secret, nil
+}
+
 func init() {
 	GetCmd.Flags().StringP(secretName, "n", "", "Secret name.")
-	GetCmd.Flags().StringP("outfile", "f", "", "Export secret data into file for binary and text types.")
+	GetCmd.Flags().StringP("outfile", "f", "", "Export secret data into file, data is streamed from the server.")
+	GetCmd.Flags().Int64(secretVersion, 0, "Secret version, latest if not set.")
+	GetCmd.Flags().Bool("reveal", false,
+		"Show card number, CVV, login password and OTP secret, they are masked by default.")
 	if err := GetCmd.MarkFlagRequired("name"); err != nil {
 		cobra.CheckErr(err)
 	}
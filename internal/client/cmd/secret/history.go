@@ -0,0 +1,59 @@
+package secret
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	grpcclient "github.com/vkupriya/gophkeeper/internal/client/grpc"
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
+)
+
+var HistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "list versions of secret",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		var msg string
+		server := viper.GetViper().GetString(hostGRPC)
+		if server == "" {
+			cobra.CheckErr(msgErrMissingGRPCServer)
+		}
+
+		token := viper.GetViper().GetString(tokenJWT)
+		if token == "" {
+			cobra.CheckErr(msgErrMissingToken)
+		}
+
+		svc := grpcclient.NewService()
+
+		if err := grpcclient.NewGRPCClient(svc, server, helpers.GetTLSConfig()); err != nil {
+			msg = fmt.Sprint(msgErrInitGRPC, err)
+			cobra.CheckErr(msg)
+		}
+		svc.SetRefreshToken(viper.GetViper().GetString(refreshJWT), saveTokens)
+		useVault(cmd, svc, token, nil)
+
+		name, _ := cmd.Flags().GetString(secretName)
+
+		versions, err := svc.ListSecretVersions(token, name)
+		if err != nil {
+			msg = fmt.Sprintf("error getting secret versions: %v", err)
+			cobra.CheckErr(msg)
+		}
+
+		res, err := json.MarshalIndent(versions, "", "   ")
+		if err != nil {
+			cobra.CheckErr(err)
+		}
+		fmt.Println(string(res))
+	},
+}
+
+func init() {
+	HistoryCmd.Flags().StringP(secretName, "n", "", "Secret name.")
+	if err := HistoryCmd.MarkFlagRequired(secretName); err != nil {
+		cobra.CheckErr(err)
+	}
+}
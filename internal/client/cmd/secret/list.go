@@ -9,8 +9,12 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	grpcclient "github.com/vkupriya/gophkeeper/internal/client/grpc"
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
 	"github.com/vkupriya/gophkeeper/internal/client/models"
 	"github.com/vkupriya/gophkeeper/internal/client/storage"
+	"github.com/vkupriya/gophkeeper/internal/labels"
+	pb "github.com/vkupriya/gophkeeper/internal/proto"
+	"github.com/vkupriya/gophkeeper/internal/secretpath"
 )
 
 var ListCmd = &cobra.Command{
@@ -32,14 +36,49 @@ var ListCmd = &cobra.Command{
 
 		svc := grpcclient.NewService()
 
-		if err := grpcclient.NewGRPCClient(svc, server); err != nil {
+		if err := grpcclient.NewGRPCClient(svc, server, helpers.GetTLSConfig()); err != nil {
 			msg = fmt.Sprint(msgErrInitGRPC, err)
 			cobra.CheckErr(msg)
 		}
+		svc.SetRefreshToken(viper.GetViper().GetString(refreshJWT), saveTokens)
+		useVault(cmd, svc, token, nil)
 
-		secrets, err := svc.ListSecrets(token)
+		labelSelector, _ := cmd.Flags().GetString("label")
+		if _, err := labels.ParseSelector(labelSelector); err != nil {
+			cobra.CheckErr(err)
+		}
+		path, _ := cmd.Flags().GetString("path")
+		folder, err := secretpath.NormalizeFolder(path)
+		if err != nil {
+			cobra.CheckErr(err)
+		}
+		recursive, _ := cmd.Flags().GetBool("recursive")
+		sortOrder, _ := cmd.Flags().GetString("sort")
+		if _, err := grpcclient.SortToProto(sortOrder); err != nil {
+			cobra.CheckErr(err)
+		}
+		types, _ := cmd.Flags().GetStringSlice("type")
+		for _, t := range types {
+			if grpcclient.TypeToProto(t) == pb.SecretType_UNKNOWN {
+				cobra.CheckErr(fmt.Sprintf("unknown secret type %q", t))
+			}
+		}
+		pageSize, _ := cmd.Flags().GetInt("page-size")
+		filter := models.SecretFilter{
+			Labels:    labelSelector,
+			Folder:    folder,
+			Recursive: recursive,
+			Sort:      sortOrder,
+			Types:     types,
+			PageSize:  pageSize,
+		}
+
+		secrets, err = svc.ListSecrets(token, filter)
 		if err != nil {
 			if errors.Is(err, grpcclient.ErrServerUnavailable) {
+				if inVault(cmd) {
+					cobra.CheckErr(msgErrVaultOffline)
+				}
 				fmt.Println("server is unavailable, attempting to read secrets from local DB.")
 				dbpath, _ := cmd.Flags().GetString("dbpath")
 				if dbpath == "" {
@@ -61,7 +100,7 @@ var ListCmd = &cobra.Command{
 					}
 				}()
 
-				secrets, err = store.SecretList()
+				secrets, err = store.SecretList(filter)
 				if err != nil {
 					msg = fmt.Sprintf("failed to read secrets from local DB: %v", err)
 					cobra.CheckErr(msg)
@@ -73,6 +112,12 @@ var ListCmd = &cobra.Command{
 			}
 		}
 
+		// folder is rendered as a tree
+		if cmd.Flags().Changed("path") {
+			fmt.Print(helpers.RenderTree(folder, secrets))
+			return
+		}
+
 		if len(secrets) != 0 {
 			res, err := json.MarshalIndent(secrets, "", "   ")
 			if err != nil {
@@ -82,3 +127,15 @@ var ListCmd = &cobra.Command{
 		}
 	},
 }
+
+func init() {
+	ListCmd.Flags().StringP("label", "l", "",
+		"Label selector, e.g. env=prod,team=payments. Requirements: key=value, key!=value, key, !key. "+
+			"Characters ',', '=' and '\\' of values are escaped with '\\'.")
+	ListCmd.Flags().StringP("path", "p", "", "Folder of path-style secret names, e.g. work/aws/, printed as a tree.")
+	ListCmd.Flags().BoolP("recursive", "r", true, "List secrets of subfolders, otherwise only subfolder names.")
+	ListCmd.Flags().StringP("sort", "s", models.SortName,
+		"Sort order: name, -name, updated, -updated (newest first). Local DB is sorted by name only.")
+	ListCmd.Flags().StringSliceP("type", "t", nil, "Secret types to list, e.g. login,card.")
+	ListCmd.Flags().Int("page-size", 0, "Number of secrets received from server in one response, 0 is server default.")
+}
@@ -0,0 +1,49 @@
+package secret
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	grpcclient "github.com/vkupriya/gophkeeper/internal/client/grpc"
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
+)
+
+var MigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "re-encrypt secrets stored with server-side encryption on the client",
+	Long: `Secrets added before client-side encryption were encrypted by the server.
+Migrate command decrypts them locally and uploads again encrypted with the key
+derived from the master password, so the server never sees plaintext data.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var msg string
+		if inVault(cmd) {
+			cobra.CheckErr("vault secrets were never encrypted by the server, nothing to migrate")
+		}
+		server := viper.GetViper().GetString(hostGRPC)
+		if server == "" {
+			cobra.CheckErr(msgErrMissingGRPCServer)
+		}
+
+		token := viper.GetViper().GetString(tokenJWT)
+		if token == "" {
+			cobra.CheckErr(msgErrMissingToken)
+		}
+
+		key := secretKey()
+		svc := grpcclient.NewService()
+
+		if err := grpcclient.NewGRPCClient(svc, server, helpers.GetTLSConfig()); err != nil {
+			msg = fmt.Sprint(msgErrInitGRPC, err)
+			cobra.CheckErr(msg)
+		}
+		svc.SetRefreshToken(viper.GetViper().GetString(refreshJWT), saveTokens)
+
+		migrated, err := svc.MigrateSecrets(token, key)
+		if err != nil {
+			msg = fmt.Sprintf("failed to migrate secrets: %v", err)
+			cobra.CheckErr(msg)
+		}
+		fmt.Printf("successfully migrated %d secrets.\n", migrated)
+	},
+}
@@ -0,0 +1,66 @@
+package secret
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	grpcclient "github.com/vkupriya/gophkeeper/internal/client/grpc"
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
+	"github.com/vkupriya/gophkeeper/internal/secretpath"
+)
+
+var MoveCmd = &cobra.Command{
+	Use:     "move",
+	Aliases: []string{"rename"},
+	Short:   "move or rename secret or folder",
+	Long: `Move command renames secret, or every secret of the folder if name ends with '/'.
+Secret is moved into the folder keeping its base name if target ends with '/'.
+Version history of moved secrets is preserved on the server.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var msg string
+		server := viper.GetViper().GetString(hostGRPC)
+		if server == "" {
+			cobra.CheckErr(msgErrMissingGRPCServer)
+		}
+
+		token := viper.GetViper().GetString(tokenJWT)
+		if token == "" {
+			cobra.CheckErr(msgErrMissingToken)
+		}
+
+		name, _ := cmd.Flags().GetString(secretName)
+		to, _ := cmd.Flags().GetString("to")
+		target, err := secretpath.MoveTarget(name, to)
+		if err != nil {
+			cobra.CheckErr(err)
+		}
+
+		svc := grpcclient.NewService()
+
+		if err := grpcclient.NewGRPCClient(svc, server, helpers.GetTLSConfig()); err != nil {
+			msg = fmt.Sprint(msgErrInitGRPC, err)
+			cobra.CheckErr(msg)
+		}
+		svc.SetRefreshToken(viper.GetViper().GetString(refreshJWT), saveTokens)
+		useVault(cmd, svc, token, nil)
+
+		moved, err := svc.MoveSecret(token, name, to)
+		if err != nil {
+			msg = fmt.Sprintf("error moving secret: %v", err)
+			cobra.CheckErr(msg)
+		}
+		fmt.Printf("moved %d secret(s) from %s to %s, run 'secret sync' to update local DB\n", moved, name, target)
+	},
+}
+
+func init() {
+	MoveCmd.Flags().StringP(secretName, "n", "", "Secret name or folder ending with '/'.")
+	MoveCmd.Flags().String("to", "", "New secret name or folder ending with '/'.")
+	if err := MoveCmd.MarkFlagRequired(secretName); err != nil {
+		cobra.CheckErr(err)
+	}
+	if err := MoveCmd.MarkFlagRequired("to"); err != nil {
+		cobra.CheckErr(err)
+	}
+}
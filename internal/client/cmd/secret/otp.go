@@ -0,0 +1,76 @@
+package secret
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	grpcclient "github.com/vkupriya/gophkeeper/internal/client/grpc"
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
+)
+
+var OTPCmd = &cobra.Command{
+	Use:   "otp",
+	Short: "Print current one-time password",
+	Long: `Otp prints current TOTP code of otp secret and seconds it remains valid.
+Secret is read from local DB when the server is unavailable.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var msg string
+		server := viper.GetViper().GetString(hostGRPC)
+		if server == "" {
+			cobra.CheckErr(msgErrMissingGRPCServer)
+		}
+
+		token := viper.GetViper().GetString(tokenJWT)
+		if token == "" {
+			cobra.CheckErr(msgErrMissingToken)
+		}
+		key := secretKey()
+		svc := grpcclient.NewService()
+
+		if err := grpcclient.NewGRPCClient(svc, server, helpers.GetTLSConfig()); err != nil {
+			msg = fmt.Sprint(msgErrInitGRPC, err)
+			cobra.CheckErr(msg)
+		}
+		svc.SetRefreshToken(viper.GetViper().GetString(refreshJWT), saveTokens)
+		key = useVault(cmd, svc, token, key)
+
+		name, _ := cmd.Flags().GetString(secretName)
+
+		secret, err := svc.GetSecret(token, key, name)
+		if err != nil {
+			if !errors.Is(err, grpcclient.ErrServerUnavailable) {
+				msg = fmt.Sprintf("error getting secret: %v", err)
+				cobra.CheckErr(msg)
+			}
+			secret = localSecret(cmd, name)
+		}
+
+		if secret.Type != "otp" {
+			msg = fmt.Sprintf("secret %s is not of otp type", name)
+			cobra.CheckErr(msg)
+		}
+
+		otp, err := helpers.ParseOTPURI(string(secret.Data))
+		if err != nil {
+			msg = fmt.Sprintf("failed to parse otp secret: %v", err)
+			cobra.CheckErr(msg)
+		}
+
+		code, remaining, err := helpers.TOTP(otp, time.Now())
+		if err != nil {
+			msg = fmt.Sprintf("failed to generate code: %v", err)
+			cobra.CheckErr(msg)
+		}
+		fmt.Printf("%s (expires in %ds)\n", code, remaining)
+	},
+}
+
+func init() {
+	OTPCmd.Flags().StringP(secretName, "n", "", "Secret name.")
+	if err := OTPCmd.MarkFlagRequired("name"); err != nil {
+		cobra.CheckErr(err)
+	}
+}
@@ -0,0 +1,96 @@
+package secret
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	grpcclient "github.com/vkupriya/gophkeeper/internal/client/grpc"
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
+)
+
+var RollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "rollback secret to previous version",
+	Long: `Rollback command takes data of the given secret version and saves it
+as a new version, so the history of the secret is preserved. Data is copied on server,
+large files are not uploaded again and share storage with the restored version. Rollback is refused if
+the secret is changed on server while it runs, or if the version can not be decrypted
+with the current key, e.g. it was left under an old master password by 'secret rotate-key'.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var msg string
+		server := viper.GetViper().GetString(hostGRPC)
+		if server == "" {
+			cobra.CheckErr(msgErrMissingGRPCServer)
+		}
+
+		token := viper.GetViper().GetString(tokenJWT)
+		if token == "" {
+			cobra.CheckErr(msgErrMissingToken)
+		}
+
+		key := secretKey()
+		svc := grpcclient.NewService()
+
+		if err := grpcclient.NewGRPCClient(svc, server, helpers.GetTLSConfig()); err != nil {
+			msg = fmt.Sprint(msgErrInitGRPC, err)
+			cobra.CheckErr(msg)
+		}
+		svc.SetRefreshToken(viper.GetViper().GetString(refreshJWT), saveTokens)
+		key = useVault(cmd, svc, token, key)
+
+		name, _ := cmd.Flags().GetString(secretName)
+		version, _ := cmd.Flags().GetInt64(secretVersion)
+
+		// current version is taken before the old revision, so that a change made meanwhile is not overwritten
+		versions, err := svc.ListSecretVersions(token, name)
+		if err != nil {
+			msg = fmt.Sprintf("error getting versions of secret: %v", err)
+			cobra.CheckErr(msg)
+		}
+		var current int64
+		for _, v := range versions {
+			current = max(current, v.Version)
+		}
+
+		// data is copied on the server, the version is read to make sure the current key opens it
+		_, err = svc.GetSecretVersion(token, key, name, version)
+		if errors.Is(err, grpcclient.ErrSecretUndecryptable) {
+			msg = fmt.Sprintf("version %d of secret %s can not be decrypted with the current key, "+
+				"it can not be restored.", version, name)
+			cobra.CheckErr(msg)
+		}
+		if err != nil {
+			msg = fmt.Sprintf("error getting version %d of secret: %v", version, err)
+			cobra.CheckErr(msg)
+		}
+
+		_, err = svc.RollbackSecret(token, name, version, current)
+		if errors.Is(err, grpcclient.ErrVersionConflict) {
+			msg = fmt.Sprintf("secret %s has been changed on server after version %d, rollback is not saved. "+
+				"Review the changes with 'secret history' and 'secret get' before rolling back again.", name, current)
+			cobra.CheckErr(msg)
+		}
+		if err != nil {
+			msg = fmt.Sprintf("error rolling back secret: %v", err)
+			cobra.CheckErr(msg)
+		}
+		fmt.Printf("secret %s rolled back to version %d.\n", name, version)
+		if !inVault(cmd) {
+			dbpath, _ := cmd.Flags().GetString("dbpath")
+			updateLocal(svc, token, key, dbpath, name)
+		}
+	},
+}
+
+func init() {
+	RollbackCmd.Flags().StringP(secretName, "n", "", "Secret name.")
+	RollbackCmd.Flags().Int64(secretVersion, 0, "Secret version to rollback to.")
+	if err := RollbackCmd.MarkFlagRequired(secretName); err != nil {
+		cobra.CheckErr(err)
+	}
+	if err := RollbackCmd.MarkFlagRequired(secretVersion); err != nil {
+		cobra.CheckErr(err)
+	}
+}
@@ -0,0 +1,149 @@
+package secret
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	grpcclient "github.com/vkupriya/gophkeeper/internal/client/grpc"
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
+	"github.com/vkupriya/gophkeeper/internal/client/models"
+	"github.com/vkupriya/gophkeeper/internal/client/storage"
+)
+
+// newMasterPassword keeps the new master password in configuration file until key rotation is completed.
+const newMasterPassword string = "new_secretkey"
+
+var RotateKeyCmd = &cobra.Command{
+	Use:   "rotate-key",
+	Short: "re-encrypt all secrets under a new master password",
+	Long: `Rotate-key command asks for a new master password and re-encrypts the latest versions of all
+your secrets with the key derived from it, seals your vault keys with the new public key and
+registers the key. Previous versions of each secret are re-encrypted as well and replaced on server
+in one transaction; previous versions of large files uploaded in chunks can not be re-encrypted in
+place and are deleted. Versions that can not be decrypted with the current master password are
+left as they are and can not be restored with 'secret rollback'. Secrets are re-encrypted one by one
+and recorded in local DB, if the command is interrupted, run it again to resume the rotation.
+The new master password replaces secretkey in configuration file once all secrets are re-encrypted.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var msg string
+		if inVault(cmd) {
+			cobra.CheckErr("vault secrets are encrypted with the vault key, it is not rotated")
+		}
+		server := viper.GetViper().GetString(hostGRPC)
+		if server == "" {
+			cobra.CheckErr(msgErrMissingGRPCServer)
+		}
+		token := viper.GetViper().GetString(tokenJWT)
+		if token == "" {
+			cobra.CheckErr(msgErrMissingToken)
+		}
+		oldKey := secretKey()
+
+		dbpath, _ := cmd.Flags().GetString("dbpath")
+		if dbpath == "" {
+			cobra.CheckErr(msgErrNoDBPath)
+		}
+		if _, err := os.Stat(dbpath); errors.Is(err, os.ErrNotExist) {
+			cobra.CheckErr("local DB does not exists, run 'init' command to create DB")
+		}
+		store, err := storage.NewSQLiteDB(dbpath)
+		if err != nil {
+			msg = fmt.Sprintf("Error in setting up DB: %v", err)
+			cobra.CheckErr(msg)
+		}
+		defer func() {
+			if err := store.DB.Close(); err != nil {
+				cobra.CheckErr("failed to close local DB")
+			}
+		}()
+
+		password := viper.GetViper().GetString(newMasterPassword)
+		if password == "" {
+			password = readNewPassword()
+			if password == viper.GetViper().GetString(masterPassword) {
+				cobra.CheckErr("new master password must differ from the current one")
+			}
+			// interrupted rotation is resumed with the same password
+			viper.Set(newMasterPassword, password)
+			if err = viper.WriteConfig(); err != nil {
+				msg = fmt.Sprintf("error writing configuration file: %v", err)
+				cobra.CheckErr(msg)
+			}
+		} else {
+			fmt.Println("resuming interrupted key rotation")
+		}
+		newKey := helpers.NewSecretKey(password, viper.GetViper().GetString(userName))
+		private, err := newKey.ShareKey()
+		if err != nil {
+			cobra.CheckErr(err)
+		}
+		keyID := helpers.KeyFingerprint(private.PublicKey().Bytes())
+
+		rotated, err := store.RotationBegin(keyID)
+		if err != nil {
+			msg = fmt.Sprintf("failed to start key rotation: %v", err)
+			cobra.CheckErr(msg)
+		}
+
+		svc := grpcclient.NewService()
+		if err := grpcclient.NewGRPCClient(svc, server, helpers.GetTLSConfig()); err != nil {
+			msg = fmt.Sprint(msgErrInitGRPC, err)
+			cobra.CheckErr(msg)
+		}
+		svc.SetRefreshToken(viper.GetViper().GetString(refreshJWT), saveTokens)
+
+		result, err := svc.RotateKey(token, oldKey, newKey, rotated, func(r models.RotatedSecret) error {
+			return store.RotationAdd(keyID, r)
+		})
+		if err != nil {
+			msg = fmt.Sprintf("key rotation is interrupted: %v\nrun 'secret rotate-key' again to resume it", err)
+			cobra.CheckErr(msg)
+		}
+
+		viper.Set(masterPassword, password)
+		viper.Set(newMasterPassword, "")
+		if err = viper.WriteConfig(); err != nil {
+			msg = fmt.Sprintf("error writing configuration file: %v\nset secretkey to the new master password", err)
+			cobra.CheckErr(msg)
+		}
+		fmt.Printf("re-encrypted %d secrets (%d were re-encrypted before) and %d vault keys.\n",
+			result.Rotated, result.Skipped, result.Vaults)
+		fmt.Printf("re-encrypted %d previous versions, deleted %d previous versions of chunked files.\n",
+			result.Versions, result.Deleted)
+		if result.Unreadable != 0 {
+			fmt.Printf("%d previous versions can not be decrypted with the old master password, "+
+				"they are left as they are and can not be restored.\n", result.Unreadable)
+		}
+
+		updated, err := store.RotationFinish(keyID)
+		if err != nil {
+			fmt.Printf("failed to update local DB: %v, run 'secret sync'\n", err)
+		} else {
+			fmt.Printf("updated %d secrets in local DB.\n", updated)
+		}
+
+		// copies of secrets shared with the user are sealed with the old public key
+		shared, err := svc.ListSharedSecrets(token, false)
+		if err == nil && len(shared) != 0 {
+			fmt.Printf("%d secrets shared with you can not be opened with the new key, "+
+				"ask their owners to share them again.\n", len(shared))
+		}
+	},
+}
+
+// readNewPassword asks for the new master password twice.
+func readNewPassword() string {
+	fmt.Println("Enter new master password.")
+	password := helpers.GetPassword()
+	if password == "" {
+		cobra.CheckErr("master password must not be empty")
+	}
+	fmt.Println("Repeat new master password.")
+	if helpers.GetPassword() != password {
+		cobra.CheckErr("passwords do not match")
+	}
+	return password
+}
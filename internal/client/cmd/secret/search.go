@@ -0,0 +1,129 @@
+package secret
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	grpcclient "github.com/vkupriya/gophkeeper/internal/client/grpc"
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
+	"github.com/vkupriya/gophkeeper/internal/client/models"
+	"github.com/vkupriya/gophkeeper/internal/client/storage"
+	"github.com/vkupriya/gophkeeper/internal/search"
+)
+
+var SearchCmd = &cobra.Command{
+	Use:   "search QUERY",
+	Short: "search secrets by name, type and labels",
+	Long: `Search command finds secrets which name, type or labels contain words of the query,
+words match by prefix. Secrets are printed from the most relevant one.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var msg string
+		server := viper.GetViper().GetString(hostGRPC)
+		if server == "" {
+			cobra.CheckErr(msgErrMissingGRPCServer)
+		}
+
+		token := viper.GetViper().GetString(tokenJWT)
+		if token == "" {
+			cobra.CheckErr(msgErrMissingToken)
+		}
+
+		query := strings.Join(args, " ")
+		if _, err := search.Terms(query); err != nil {
+			cobra.CheckErr(err)
+		}
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		svc := grpcclient.NewService()
+
+		if err := grpcclient.NewGRPCClient(svc, server, helpers.GetTLSConfig()); err != nil {
+			msg = fmt.Sprint(msgErrInitGRPC, err)
+			cobra.CheckErr(msg)
+		}
+		svc.SetRefreshToken(viper.GetViper().GetString(refreshJWT), saveTokens)
+		useVault(cmd, svc, token, nil)
+
+		results, err := svc.SearchSecrets(token, query, limit)
+		if err != nil {
+			if !errors.Is(err, grpcclient.ErrServerUnavailable) {
+				msg = fmt.Sprintf("error searching secrets: %v", err)
+				cobra.CheckErr(msg)
+			}
+			fmt.Println("server is unavailable, attempting to search secrets in local DB.")
+			results = localSearch(cmd, query, limit)
+		}
+
+		if len(results) == 0 {
+			fmt.Println("no secrets found")
+			return
+		}
+		printSearchResults(results)
+	},
+}
+
+// localSearch searches secrets in local DB when the server is unavailable.
+func localSearch(cmd *cobra.Command, query string, limit int) []*models.SearchResult {
+	if inVault(cmd) {
+		cobra.CheckErr(msgErrVaultOffline)
+	}
+	dbpath, _ := cmd.Flags().GetString("dbpath")
+	if dbpath == "" {
+		cobra.CheckErr(msgErrNoDBPath)
+	}
+
+	if _, err := os.Stat(dbpath); errors.Is(err, os.ErrNotExist) {
+		cobra.CheckErr("local DB does not exists, run 'init' command to create DB")
+	}
+
+	store, err := storage.NewSQLiteDB(dbpath)
+	if err != nil {
+		msg := fmt.Sprintf("Error in setting up DB: %v", err)
+		cobra.CheckErr(msg)
+	}
+	defer func() {
+		if err := store.DB.Close(); err != nil {
+			cobra.CheckErr("failed to close local DB")
+		}
+	}()
+
+	results, err := store.SecretSearch(query, limit)
+	if err != nil {
+		msg := fmt.Sprintf("failed to search secrets in local DB: %v", err)
+		cobra.CheckErr(msg)
+	}
+	return results
+}
+
+// printSearchResults prints results as a table ordered by rank.
+func printSearchResults(results []*models.SearchResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tNAME\tTYPE\tVERSION\tLABELS\tRANK")
+	for i, r := range results {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\t%.3f\n", i+1, r.Secret.Name, r.Secret.Type, r.Secret.Version,
+			formatLabels(r.Secret.Labels), r.Rank)
+	}
+	if err := w.Flush(); err != nil {
+		cobra.CheckErr(err)
+	}
+}
+
+// formatLabels returns labels as sorted comma separated key=value pairs.
+func formatLabels(secretLabels map[string]string) string {
+	pairs := make([]string, 0, len(secretLabels))
+	for k, v := range secretLabels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func init() {
+	SearchCmd.Flags().Int("limit", search.DefaultLimit, "Maximum number of secrets to show.")
+}
@@ -1,7 +1,14 @@
 package secret
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	grpcclient "github.com/vkupriya/gophkeeper/internal/client/grpc"
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
+	"github.com/vkupriya/gophkeeper/internal/client/models"
 )
 
 const (
@@ -9,11 +16,26 @@ const (
 	msgErrMissingToken      = "missing user token, please, login"
 	msgErrInitGRPC          = "error initializing GRPC client: "
 	msgErrNoDBPath          = "missing local db path"
+	msgErrMissingSecretKey  = "missing secretkey, update configuration file"
+	msgErrMissingUser       = "missing user name, please, login"
+	msgErrVaultOffline      = "vault secrets are not kept in local DB, server is unavailable"
 )
 
 const secretName string = "name"
+const secretVersion string = "version"
 const tokenJWT string = "token"
+const refreshJWT string = "refresh_token"
 const hostGRPC string = "server"
+const masterPassword string = "secretkey"
+const userName string = "user"
+const vaultRef string = "vault"
+const passwordLength string = "password_length"
+const passwordClasses string = "password_classes"
+
+const (
+	defaultPasswordLength  = 20
+	defaultPasswordClasses = "lower,upper,digits,symbols"
+)
 
 var SecretCmd = &cobra.Command{
 	Use:   "secret",
@@ -30,4 +52,78 @@ func init() {
 	SecretCmd.AddCommand(GetCmd)
 	SecretCmd.AddCommand(DeleteCmd)
 	SecretCmd.AddCommand(SyncCmd)
+	SecretCmd.AddCommand(MigrateCmd)
+	SecretCmd.AddCommand(RotateKeyCmd)
+	SecretCmd.AddCommand(HistoryCmd)
+	SecretCmd.AddCommand(RollbackCmd)
+	SecretCmd.AddCommand(OTPCmd)
+	SecretCmd.AddCommand(MoveCmd)
+	SecretCmd.AddCommand(SearchCmd)
+	SecretCmd.AddCommand(ShareCmd)
+	SecretCmd.AddCommand(UnshareCmd)
+	SecretCmd.AddCommand(SharedCmd)
+
+	SecretCmd.PersistentFlags().String(vaultRef, "",
+		"Team vault as org/vault, secret commands act on the vault secrets instead of yours.")
+}
+
+// useVault switches the service to the vault set by flag and returns key of the vault,
+// the user key is returned if the vault is not set.
+func useVault(cmd *cobra.Command, svc *grpcclient.Service, token string, key *helpers.SecretKey) *helpers.SecretKey {
+	ref, _ := cmd.Flags().GetString(vaultRef)
+	if ref == "" {
+		return key
+	}
+	if err := svc.SetVault(ref); err != nil {
+		cobra.CheckErr(err)
+	}
+	if key == nil {
+		return nil
+	}
+	vaultKey, err := svc.VaultKey(token, key)
+	if err != nil {
+		msg := fmt.Sprintf("error getting vault key: %v", err)
+		cobra.CheckErr(msg)
+	}
+	return vaultKey
+}
+
+// inVault reports whether the command acts on the vault secrets, they are not kept in local DB.
+func inVault(cmd *cobra.Command) bool {
+	ref, _ := cmd.Flags().GetString(vaultRef)
+	return ref != ""
+}
+
+// secretKey derives encryption key from the master password in configuration file.
+func secretKey() *helpers.SecretKey {
+	password := viper.GetViper().GetString(masterPassword)
+	if password == "" {
+		cobra.CheckErr(msgErrMissingSecretKey)
+	}
+	user := viper.GetViper().GetString(userName)
+	if user == "" {
+		cobra.CheckErr(msgErrMissingUser)
+	}
+	return helpers.NewSecretKey(password, user)
+}
+
+// saveTokens writes tokens refreshed by grpc client into configuration file.
+func saveTokens(tokens *models.Tokens) error {
+	viper.Set(tokenJWT, tokens.Token)
+	viper.Set(refreshJWT, tokens.RefreshToken)
+	if err := viper.WriteConfig(); err != nil {
+		return fmt.Errorf("error writing configuration file: %w", err)
+	}
+	return nil
+}
+
+// printProgress returns callback printing number of transferred bytes to stderr, total is unknown if 0.
+func printProgress(action string, total int64) func(n int64) {
+	return func(n int64) {
+		if total > 0 {
+			fmt.Fprintf(os.Stderr, "\r%s %d of %d bytes (%d%%)", action, n, total, n*100/total)
+			return
+		}
+		fmt.Fprintf(os.Stderr, "\r%s %d bytes", action, n)
+	}
 }
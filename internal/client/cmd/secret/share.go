@@ -0,0 +1,205 @@
+package secret
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	grpcclient "github.com/vkupriya/gophkeeper/internal/client/grpc"
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
+	"github.com/vkupriya/gophkeeper/internal/client/models"
+)
+
+const shareWith string = "with"
+
+var ShareCmd = &cobra.Command{
+	Use:   "share",
+	Short: "share secret with another user",
+	Long: `Share command seals the secret with public key of the recipient on the client and stores
+the sealed copy on the server. Read-write access allows the recipient to update the secret.
+Share is not updated with the secret, run share again to share the new version.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		token, key, svc := shareService(cmd)
+
+		name, _ := cmd.Flags().GetString(secretName)
+		recipient, _ := cmd.Flags().GetString(shareWith)
+		access, _ := cmd.Flags().GetString("access")
+		if access != models.AccessReadOnly && access != models.AccessReadWrite {
+			cobra.CheckErr(fmt.Sprintf("unknown access %q, use %s or %s", access, models.AccessReadOnly,
+				models.AccessReadWrite))
+		}
+
+		// recipient with read-write access seals updates with the owner public key
+		if err := svc.RegisterPublicKey(token, key); err != nil {
+			msg := fmt.Sprintf("error registering public key: %v", err)
+			cobra.CheckErr(msg)
+		}
+		fingerprint, err := svc.ShareSecret(token, key, name, recipient, access)
+		if err != nil {
+			msg := fmt.Sprintf("error sharing secret: %v", err)
+			cobra.CheckErr(msg)
+		}
+		fmt.Printf("shared secret %s with %s (%s), recipient key fingerprint %s\n", name, recipient, access,
+			fingerprint)
+	},
+}
+
+var UnshareCmd = &cobra.Command{
+	Use:     "unshare",
+	Aliases: []string{"revoke"},
+	Short:   "revoke share of secret",
+	Long: `Unshare command deletes the copy of the secret sealed for the recipient on the server.
+The recipient could have saved the secret before, change it if it must not be known anymore.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		token, _, svc := shareService(cmd)
+
+		name, _ := cmd.Flags().GetString(secretName)
+		recipient, _ := cmd.Flags().GetString(shareWith)
+		if err := svc.RevokeShare(token, name, recipient); err != nil {
+			msg := fmt.Sprintf("error revoking share: %v", err)
+			cobra.CheckErr(msg)
+		}
+		fmt.Printf("revoked share of secret %s with %s\n", name, recipient)
+	},
+}
+
+var SharedCmd = &cobra.Command{
+	Use:   "shared",
+	Short: "list, get or update secrets shared with you",
+	Long: `Shared command lists secrets shared with you, or secrets shared by you with --by-me.
+Secret is printed if its name and owner are set, it is updated with --data if it is shared read-write.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		token, key, svc := shareService(cmd)
+
+		// secrets may be shared with the user only after the public key is registered
+		if err := svc.RegisterPublicKey(token, key); err != nil {
+			msg := fmt.Sprintf("error registering public key: %v", err)
+			cobra.CheckErr(msg)
+		}
+
+		name, _ := cmd.Flags().GetString(secretName)
+		owner, _ := cmd.Flags().GetString("owner")
+		if name == "" && owner == "" {
+			outgoing, _ := cmd.Flags().GetBool("by-me")
+			items, err := svc.ListSharedSecrets(token, outgoing)
+			if err != nil {
+				msg := fmt.Sprintf("error getting list of shared secrets: %v", err)
+				cobra.CheckErr(msg)
+			}
+			printShares(items, outgoing)
+			return
+		}
+		if name == "" || owner == "" {
+			cobra.CheckErr("both secret name and owner must be set")
+		}
+
+		if !cmd.Flags().Changed("data") {
+			secret, access, err := svc.GetSharedSecret(token, key, owner, name)
+			if err != nil {
+				msg := fmt.Sprintf("error getting shared secret: %v", err)
+				cobra.CheckErr(msg)
+			}
+			fmt.Printf("shared by %s (%s)\n", owner, access)
+			printSecret(cmd, secret)
+			return
+		}
+
+		data, _ := cmd.Flags().GetString("data")
+		version, _ := cmd.Flags().GetInt64(secretVersion)
+		if version == 0 {
+			secret, _, err := svc.GetSharedSecret(token, key, owner, name)
+			if err != nil {
+				msg := fmt.Sprintf("error getting shared secret: %v", err)
+				cobra.CheckErr(msg)
+			}
+			version = secret.Version
+		}
+		err := svc.UpdateSharedSecret(token, key, owner, name, []byte(data), version)
+		if errors.Is(err, grpcclient.ErrVersionConflict) {
+			msg := fmt.Sprintf("secret %s has been changed by %s, review it and retry with '--version'", name, owner)
+			cobra.CheckErr(msg)
+		}
+		if err != nil {
+			msg := fmt.Sprintf("error updating shared secret: %v", err)
+			cobra.CheckErr(msg)
+		}
+		fmt.Printf("updated secret %s of %s\n", name, owner)
+	},
+}
+
+// shareService connects to the server, shared secrets are not kept in local DB.
+func shareService(cmd *cobra.Command) (string, *helpers.SecretKey, *grpcclient.Service) {
+	if inVault(cmd) {
+		cobra.CheckErr("vault secrets can not be shared, add the user to the vault instead")
+	}
+	server := viper.GetViper().GetString(hostGRPC)
+	if server == "" {
+		cobra.CheckErr(msgErrMissingGRPCServer)
+	}
+
+	token := viper.GetViper().GetString(tokenJWT)
+	if token == "" {
+		cobra.CheckErr(msgErrMissingToken)
+	}
+	key := secretKey()
+
+	svc := grpcclient.NewService()
+	if err := grpcclient.NewGRPCClient(svc, server, helpers.GetTLSConfig()); err != nil {
+		msg := fmt.Sprint(msgErrInitGRPC, err)
+		cobra.CheckErr(msg)
+	}
+	svc.SetRefreshToken(viper.GetViper().GetString(refreshJWT), saveTokens)
+	return token, key, svc
+}
+
+// printShares prints shared secrets as a table, stale shares are behind the current version of the secret.
+func printShares(items []*models.SharedSecretItem, outgoing bool) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if outgoing {
+		fmt.Fprintln(w, "NAME\tRECIPIENT\tTYPE\tACCESS\tVERSION\tSHARED")
+	} else {
+		fmt.Fprintln(w, "OWNER\tNAME\tTYPE\tACCESS\tVERSION\tSHARED")
+	}
+	for _, item := range items {
+		version := fmt.Sprint(item.Version)
+		if item.Version < item.CurrentVersion {
+			version = fmt.Sprintf("%d (stale, current %d)", item.Version, item.CurrentVersion)
+		}
+		first, second := item.Owner, item.Name
+		if outgoing {
+			first, second = item.Name, item.Recipient
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", first, second, item.Type, item.Access, version,
+			item.SharedAt.Local().Format("2006-01-02 15:04:05"))
+	}
+	if err := w.Flush(); err != nil {
+		cobra.CheckErr(err)
+	}
+}
+
+func init() {
+	ShareCmd.Flags().StringP(secretName, "n", "", "Secret name.")
+	ShareCmd.Flags().String(shareWith, "", "Login of the user to share the secret with.")
+	ShareCmd.Flags().String("access", models.AccessReadOnly, "Access of the recipient: ro (read-only) or rw (read-write).")
+	UnshareCmd.Flags().StringP(secretName, "n", "", "Secret name.")
+	UnshareCmd.Flags().String(shareWith, "", "Login of the user to revoke the share from.")
+	for _, c := range []*cobra.Command{ShareCmd, UnshareCmd} {
+		if err := c.MarkFlagRequired(secretName); err != nil {
+			cobra.CheckErr(err)
+		}
+		if err := c.MarkFlagRequired(shareWith); err != nil {
+			cobra.CheckErr(err)
+		}
+	}
+
+	SharedCmd.Flags().Bool("by-me", false, "List secrets shared by you instead of secrets shared with you.")
+	SharedCmd.Flags().StringP(secretName, "n", "", "Name of the shared secret to print or update.")
+	SharedCmd.Flags().String("owner", "", "Owner of the shared secret.")
+	SharedCmd.Flags().StringP("data", "d", "", "New data of the secret shared read-write, e.g. JSON of card or login.")
+	SharedCmd.Flags().Int64(secretVersion, 0, "Expected current version of the secret on update, latest if not set.")
+	SharedCmd.Flags().Bool("reveal", false,
+		"Show card number, CVV, login password and OTP secret, they are masked by default.")
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	grpcclient "github.com/vkupriya/gophkeeper/internal/client/grpc"
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
+	"github.com/vkupriya/gophkeeper/internal/client/models"
 	"github.com/vkupriya/gophkeeper/internal/client/storage"
 )
 
@@ -17,6 +19,9 @@ var SyncCmd = &cobra.Command{
 	Long:  ``,
 	Run: func(cmd *cobra.Command, args []string) {
 		var msg string
+		if inVault(cmd) {
+			cobra.CheckErr("vault secrets are not synced into local DB")
+		}
 		// map for local secrets for DB Sync
 		secretsMapLocal := map[string]int64{}
 		// map for remote secrets for DB Sync
@@ -30,10 +35,7 @@ var SyncCmd = &cobra.Command{
 			cobra.CheckErr(msgErrMissingToken)
 		}
 
-		key := viper.GetViper().GetString("secretkey")
-		if key == "" {
-			cobra.CheckErr("missing secretkey, update configuration file.")
-		}
+		key := secretKey()
 
 		dbpath, _ := cmd.Flags().GetString("dbpath")
 		if dbpath == "" {
@@ -52,12 +54,13 @@ var SyncCmd = &cobra.Command{
 
 		svc := grpcclient.NewService()
 
-		if err := grpcclient.NewGRPCClient(svc, server); err != nil {
+		if err := grpcclient.NewGRPCClient(svc, server, helpers.GetTLSConfig()); err != nil {
 			msg = fmt.Sprintf("failed initializing GRPC client: %v ", err)
 			cobra.CheckErr(msg)
 		}
+		svc.SetRefreshToken(viper.GetViper().GetString(refreshJWT), saveTokens)
 
-		secretsRemote, err := svc.ListSecrets(token)
+		secretsRemote, err := svc.ListSecrets(token, models.SecretFilter{Recursive: true})
 		if err != nil {
 			msg = fmt.Sprintf("failed to get list of secrets from server: %v", err)
 			cobra.CheckErr(msg)
@@ -69,7 +72,7 @@ var SyncCmd = &cobra.Command{
 			}
 		}
 
-		secretsLocal, err := store.SecretList()
+		secretsLocal, err := store.SecretList(models.SecretFilter{Recursive: true})
 		if err != nil {
 			msg = fmt.Sprintf("failed to get list of secrets from local DB: %v", err)
 			cobra.CheckErr(msg)
@@ -82,19 +85,22 @@ var SyncCmd = &cobra.Command{
 			}
 		}
 
+		// local secrets which data does not match their hashes are re-fetched even if they are up to date
+		failed, err := store.SecretVerify()
+		if err != nil {
+			msg = fmt.Sprintf("failed to verify secrets in local DB: %v", err)
+			cobra.CheckErr(msg)
+		}
+
 		for name, remoteVersion := range secretsMapRemote {
 			if localVersion, ok := secretsMapLocal[name]; ok {
-				if localVersion < remoteVersion {
-					secret, err := svc.GetSecret(token, key, name)
-					if err != nil {
-						msg = fmt.Sprintf("failed to get secret: %v ", err)
-						cobra.CheckErr(msg)
+				if localVersion < remoteVersion || failed[name] != nil {
+					if errors.Is(failed[name], storage.ErrSecretCorrupted) {
+						fmt.Printf("local secret %s is corrupted, re-fetching it.\n", name)
 					}
 					// updating local secret
-					err = store.SecretUpdate(secret)
-					if err != nil {
-						msg = fmt.Sprintf("failed to update secret: %v", err)
-						cobra.CheckErr(msg)
+					if err := refetchSecret(svc, store, token, key, name); err != nil {
+						cobra.CheckErr(err.Error())
 					}
 				}
 			} else {
@@ -124,3 +130,16 @@ var SyncCmd = &cobra.Command{
 		fmt.Println("successfully synchronised secret db.")
 	},
 }
+
+// refetchSecret replaces local copy of the secret with the latest version from the server.
+func refetchSecret(svc *grpcclient.Service, store *storage.SQLiteDB, token string, key *helpers.SecretKey,
+	name string) error {
+	secret, err := svc.GetSecret(token, key, name)
+	if err != nil {
+		return fmt.Errorf("failed to get secret: %w", err)
+	}
+	if err = store.SecretUpdate(secret); err != nil {
+		return fmt.Errorf("failed to update secret: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package secret
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	grpcclient "github.com/vkupriya/gophkeeper/internal/client/grpc"
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
+	"github.com/vkupriya/gophkeeper/internal/client/storage"
+)
+
+var VerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "verify integrity of secrets in local DB",
+	Long: `Verify command checks data of every secret in local DB against the hash stored with it.
+Corrupted secrets are reported and re-fetched from the server, secrets stored before hashes were
+introduced are re-fetched as well, so that they get the hash.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var msg string
+		dbpath, _ := cmd.Flags().GetString("dbpath")
+		if dbpath == "" {
+			cobra.CheckErr(msgErrNoDBPath)
+		}
+
+		if _, err := os.Stat(dbpath); errors.Is(err, os.ErrNotExist) {
+			cobra.CheckErr("local DB does not exists, run 'init' command to create DB")
+		}
+
+		store, err := storage.NewSQLiteDB(dbpath)
+		if err != nil {
+			msg = fmt.Sprintf("Error in setting up DB: %v", err)
+			cobra.CheckErr(msg)
+		}
+		defer func() {
+			if err := store.DB.Close(); err != nil {
+				cobra.CheckErr("failed to close local DB")
+			}
+		}()
+
+		failed, err := store.SecretVerify()
+		if err != nil {
+			msg = fmt.Sprintf("failed to verify secrets in local DB: %v", err)
+			cobra.CheckErr(msg)
+		}
+		if len(failed) == 0 {
+			fmt.Println("local DB is intact")
+			return
+		}
+
+		names := make([]string, 0, len(failed))
+		for name := range failed {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		corrupted := 0
+		for _, name := range names {
+			if errors.Is(failed[name], storage.ErrSecretCorrupted) {
+				corrupted++
+				fmt.Printf("local secret %s is corrupted\n", name)
+			}
+		}
+
+		server := viper.GetViper().GetString(hostGRPC)
+		if server == "" {
+			cobra.CheckErr(msgErrMissingGRPCServer)
+		}
+		token := viper.GetViper().GetString(tokenJWT)
+		if token == "" {
+			cobra.CheckErr(msgErrMissingToken)
+		}
+		key := secretKey()
+
+		svc := grpcclient.NewService()
+		if err := grpcclient.NewGRPCClient(svc, server, helpers.GetTLSConfig()); err != nil {
+			msg = fmt.Sprint(msgErrInitGRPC, err)
+			cobra.CheckErr(msg)
+		}
+		svc.SetRefreshToken(viper.GetViper().GetString(refreshJWT), saveTokens)
+
+		refetched := 0
+		for _, name := range names {
+			if err := refetchSecret(svc, store, token, key, name); err != nil {
+				fmt.Printf("failed to re-fetch secret %s: %v\n", name, err)
+				continue
+			}
+			refetched++
+		}
+
+		fmt.Printf("%d corrupted and %d unverified local secrets, %d re-fetched from the server\n",
+			corrupted, len(names)-corrupted, refetched)
+		if refetched != len(names) {
+			cobra.CheckErr("some secrets were not re-fetched, run 'secret sync' once the server is available")
+		}
+	},
+}
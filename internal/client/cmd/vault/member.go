@@ -0,0 +1,75 @@
+package vault
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/vkupriya/gophkeeper/internal/vault"
+)
+
+const memberLogin string = "user"
+
+var AddMemberCmd = &cobra.Command{
+	Use:   "add-member",
+	Short: "add member to team vault",
+	Long: `Add-member command adds the user to the vault with --vault or to the organization with --org,
+role of existing member is changed. Vault key is sealed with public key of the user on the client,
+the user must have logged in at least once. Members of the organization have their role in every
+vault of it, but get vault keys only when added to the vault.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		token, key, svc := vaultService()
+
+		org, name := memberTarget(cmd)
+		login, _ := cmd.Flags().GetString(memberLogin)
+		role, _ := cmd.Flags().GetString("role")
+		if _, err := vault.ParseRole(role); err != nil {
+			cobra.CheckErr(err)
+		}
+
+		if err := svc.AddVaultMember(token, key, org, name, login, role); err != nil {
+			msg := fmt.Sprintf("error adding vault member: %v", err)
+			cobra.CheckErr(msg)
+		}
+		fmt.Printf("added %s to %s as %s\n", login, target(org, name), role)
+	},
+}
+
+var RemoveMemberCmd = &cobra.Command{
+	Use:   "remove-member",
+	Short: "remove member from team vault",
+	Long: `Remove-member command removes the user from the vault with --vault or from the organization
+with --org. The vault key is not changed, secrets read by the user before could have been saved.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		token, _, svc := vaultService()
+
+		org, name := memberTarget(cmd)
+		login, _ := cmd.Flags().GetString(memberLogin)
+		if err := svc.RemoveVaultMember(token, org, name, login); err != nil {
+			msg := fmt.Sprintf("error removing vault member: %v", err)
+			cobra.CheckErr(msg)
+		}
+		fmt.Printf("removed %s from %s\n", login, target(org, name))
+	},
+}
+
+// target returns printable vault reference, empty vault is the organization.
+func target(org string, name string) string {
+	if name == "" {
+		return "organization " + org
+	}
+	return "vault " + org + "/" + name
+}
+
+func init() {
+	for _, c := range []*cobra.Command{AddMemberCmd, RemoveMemberCmd} {
+		c.Flags().StringP(vaultRef, "v", "", "Vault as org/vault.")
+		c.Flags().String(orgName, "", "Organization, membership gives the role in every vault of it.")
+		c.Flags().StringP(memberLogin, "u", "", "Login of the member.")
+		c.MarkFlagsMutuallyExclusive(vaultRef, orgName)
+		c.MarkFlagsOneRequired(vaultRef, orgName)
+		if err := c.MarkFlagRequired(memberLogin); err != nil {
+			cobra.CheckErr(err)
+		}
+	}
+	AddMemberCmd.Flags().String("role", string(vault.RoleViewer), "Role of the member: owner, editor or viewer.")
+}
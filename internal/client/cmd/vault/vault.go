@@ -0,0 +1,196 @@
+// Package vault implements gkcli commands managing team vaults and their members.
+package vault
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	grpcclient "github.com/vkupriya/gophkeeper/internal/client/grpc"
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
+	"github.com/vkupriya/gophkeeper/internal/client/models"
+	"github.com/vkupriya/gophkeeper/internal/vault"
+)
+
+const (
+	msgErrMissingGRPCServer = "missing grpc server address and port"
+	msgErrMissingToken      = "missing user token, please, login"
+	msgErrInitGRPC          = "error initializing GRPC client: "
+	msgErrMissingSecretKey  = "missing secretkey, update configuration file"
+	msgErrMissingUser       = "missing user name, please, login"
+)
+
+const vaultRef string = "vault"
+const orgName string = "org"
+const tokenJWT string = "token"
+const refreshJWT string = "refresh_token"
+const hostGRPC string = "server"
+const masterPassword string = "secretkey"
+const userName string = "user"
+
+var VaultCmd = &cobra.Command{
+	Use:   "vault",
+	Short: "team vault commands",
+	Long: `Vault commands manage team vaults of organizations and their members.
+Secret commands act on the vault secrets with 'secret --vault org/vault'.
+Viewer may read secrets, editor may also change them, owner may also manage members.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+	},
+}
+
+var CreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "create team vault",
+	Long: `Create command creates vault 'org/vault' with you as its owner. Organization is created with you
+as its owner if it does not exist, otherwise you must be its owner.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		token, key, svc := vaultService()
+
+		ref, _ := cmd.Flags().GetString(vaultRef)
+		org, name, err := vault.Parse(ref)
+		if err != nil {
+			cobra.CheckErr(err)
+		}
+
+		// vault members are added with the vault key sealed with their public keys
+		if err := svc.RegisterPublicKey(token, key); err != nil {
+			msg := fmt.Sprintf("error registering public key: %v", err)
+			cobra.CheckErr(msg)
+		}
+		if err := svc.CreateVault(token, key, org, name); err != nil {
+			msg := fmt.Sprintf("error creating vault: %v", err)
+			cobra.CheckErr(msg)
+		}
+		fmt.Printf("created vault %s\n", ref)
+	},
+}
+
+var ListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list team vaults or their members",
+	Long: `List command lists vaults where you are a member, members of the vault with --vault
+or members of the organization with --org.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		token, _, svc := vaultService()
+
+		if !cmd.Flags().Changed(vaultRef) && !cmd.Flags().Changed(orgName) {
+			vaults, err := svc.ListVaults(token)
+			if err != nil {
+				msg := fmt.Sprintf("error getting list of vaults: %v", err)
+				cobra.CheckErr(msg)
+			}
+			printVaults(vaults)
+			return
+		}
+
+		org, name := memberTarget(cmd)
+		members, err := svc.ListVaultMembers(token, org, name)
+		if err != nil {
+			msg := fmt.Sprintf("error getting list of vault members: %v", err)
+			cobra.CheckErr(msg)
+		}
+		printMembers(members)
+	},
+}
+
+func init() {
+	VaultCmd.AddCommand(CreateCmd)
+	VaultCmd.AddCommand(ListCmd)
+	VaultCmd.AddCommand(AddMemberCmd)
+	VaultCmd.AddCommand(RemoveMemberCmd)
+
+	CreateCmd.Flags().StringP(vaultRef, "v", "", "Vault as org/vault, e.g. acme/payments-oncall.")
+	if err := CreateCmd.MarkFlagRequired(vaultRef); err != nil {
+		cobra.CheckErr(err)
+	}
+	ListCmd.Flags().StringP(vaultRef, "v", "", "Vault as org/vault to list members of.")
+	ListCmd.Flags().String(orgName, "", "Organization to list members of.")
+	ListCmd.MarkFlagsMutuallyExclusive(vaultRef, orgName)
+}
+
+// vaultService connects to the server.
+func vaultService() (string, *helpers.SecretKey, *grpcclient.Service) {
+	server := viper.GetViper().GetString(hostGRPC)
+	if server == "" {
+		cobra.CheckErr(msgErrMissingGRPCServer)
+	}
+
+	token := viper.GetViper().GetString(tokenJWT)
+	if token == "" {
+		cobra.CheckErr(msgErrMissingToken)
+	}
+	password := viper.GetViper().GetString(masterPassword)
+	if password == "" {
+		cobra.CheckErr(msgErrMissingSecretKey)
+	}
+	user := viper.GetViper().GetString(userName)
+	if user == "" {
+		cobra.CheckErr(msgErrMissingUser)
+	}
+	key := helpers.NewSecretKey(password, user)
+
+	svc := grpcclient.NewService()
+	if err := grpcclient.NewGRPCClient(svc, server, helpers.GetTLSConfig()); err != nil {
+		msg := fmt.Sprint(msgErrInitGRPC, err)
+		cobra.CheckErr(msg)
+	}
+	svc.SetRefreshToken(viper.GetViper().GetString(refreshJWT), saveTokens)
+	return token, key, svc
+}
+
+// saveTokens writes tokens refreshed by grpc client into configuration file.
+func saveTokens(tokens *models.Tokens) error {
+	viper.Set(tokenJWT, tokens.Token)
+	viper.Set(refreshJWT, tokens.RefreshToken)
+	if err := viper.WriteConfig(); err != nil {
+		return fmt.Errorf("error writing configuration file: %w", err)
+	}
+	return nil
+}
+
+// memberTarget returns organization and vault of --vault or --org flag, vault is empty for organization.
+func memberTarget(cmd *cobra.Command) (string, string) {
+	if org, _ := cmd.Flags().GetString(orgName); org != "" {
+		if err := vault.ValidateName(org); err != nil {
+			cobra.CheckErr(err)
+		}
+		return org, ""
+	}
+	ref, _ := cmd.Flags().GetString(vaultRef)
+	org, name, err := vault.Parse(ref)
+	if err != nil {
+		cobra.CheckErr(err)
+	}
+	return org, name
+}
+
+// printVaults prints vaults as a table.
+func printVaults(vaults []*models.Vault) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VAULT\tROLE")
+	for _, v := range vaults {
+		fmt.Fprintf(w, "%s/%s\t%s\n", v.Org, v.Name, v.Role)
+	}
+	if err := w.Flush(); err != nil {
+		cobra.CheckErr(err)
+	}
+}
+
+// printMembers prints members as a table, members of the organization have their role in every vault.
+func printMembers(members []*models.VaultMember) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LOGIN\tROLE\tMEMBER OF")
+	for _, m := range members {
+		scope := m.Vault
+		if scope == "" {
+			scope = "organization"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", m.Login, m.Role, scope)
+	}
+	if err := w.Flush(); err != nil {
+		cobra.CheckErr(err)
+	}
+}
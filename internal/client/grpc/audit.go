@@ -0,0 +1,75 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vkupriya/gophkeeper/internal/client/models"
+	pb "github.com/vkupriya/gophkeeper/internal/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ListAuditEvents - function getting audit events of the user from gophkeeper server, newest first.
+func (s *Service) ListAuditEvents(t string, filter models.AuditFilter) ([]*models.AuditEvent, error) {
+	in := &pb.ListAuditEventsRequest{Limit: int32(filter.Limit)}
+	if !filter.From.IsZero() {
+		in.From = timestamppb.New(filter.From)
+	}
+	if !filter.To.IsZero() {
+		in.To = timestamppb.New(filter.To)
+	}
+
+	var resp *pb.ListAuditEventsResponse
+	err := s.withAuth(t, func(ctx context.Context) error {
+		var err error
+		resp, err = s.clientGRPC.ListAuditEvents(ctx, in)
+		return err
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			return nil, ErrServerUnavailable
+		}
+		return nil, fmt.Errorf("error in getting audit events: %w", err)
+	}
+
+	events := make([]*models.AuditEvent, 0, len(resp.GetEvents()))
+	for _, e := range resp.GetEvents() {
+		events = append(events, &models.AuditEvent{
+			ID:        e.GetId(),
+			Method:    e.GetMethod(),
+			Name:      e.GetName(),
+			Owner:     e.GetOwner(),
+			ClientIP:  e.GetClientIp(),
+			Code:      e.GetCode(),
+			CreatedAt: e.GetCreatedAt().AsTime(),
+		})
+	}
+	return events, nil
+}
+
+// VerifyAuditLog - function asking gophkeeper server to verify hash chain of the audit log.
+func (s *Service) VerifyAuditLog(t string) (*models.AuditVerification, error) {
+	var resp *pb.VerifyAuditLogResponse
+	err := s.withAuth(t, func(ctx context.Context) error {
+		var err error
+		resp, err = s.clientGRPC.VerifyAuditLog(ctx, &pb.Empty{})
+		return err
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			return nil, ErrServerUnavailable
+		}
+		return nil, fmt.Errorf("error in verifying audit log: %w", err)
+	}
+
+	return &models.AuditVerification{
+		Intact:      resp.GetIntact(),
+		Verified:    resp.GetVerified(),
+		Unchained:   resp.GetUnchained(),
+		BrokenID:    resp.GetBrokenId(),
+		Reason:      resp.GetReason(),
+		Checkpoints: int(resp.GetCheckpoints()),
+	}, nil
+}
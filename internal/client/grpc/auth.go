@@ -0,0 +1,92 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vkupriya/gophkeeper/internal/client/models"
+	pb "github.com/vkupriya/gophkeeper/internal/proto"
+	"github.com/vkupriya/gophkeeper/internal/vault"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// SetRefreshToken enables transparent refresh of expired access token,
+// onRefresh is called with new tokens so they can be persisted.
+func (s *Service) SetRefreshToken(refreshToken string, onRefresh func(tokens *models.Tokens) error) {
+	s.refreshToken = refreshToken
+	s.onRefresh = onRefresh
+}
+
+// RefreshToken exchanges refresh token for a new pair of access and refresh tokens.
+func (s *Service) RefreshToken(refreshToken string) (*models.Tokens, error) {
+	authToken, err := s.clientGRPC.RefreshToken(context.Background(), &pb.RefreshTokenRequest{
+		RefreshToken: refreshToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh token: %w", err)
+	}
+
+	return &models.Tokens{
+		Token:        authToken.GetToken(),
+		RefreshToken: authToken.GetRefreshToken(),
+	}, nil
+}
+
+// Logout revokes refresh token and access token t on the server.
+func (s *Service) Logout(t string, refreshToken string) error {
+	_, err := s.clientGRPC.Logout(s.authContext(t), &pb.LogoutRequest{
+		RefreshToken: refreshToken,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to logout: %w", err)
+	}
+	return nil
+}
+
+// RevokeSessions revokes all access and refresh tokens of the user on the server.
+func (s *Service) RevokeSessions(t string) error {
+	err := s.withAuth(t, func(ctx context.Context) error {
+		_, err := s.clientGRPC.RevokeSessions(ctx, &pb.Empty{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return nil
+}
+
+// withAuth calls f with access token in context metadata, if the server rejects
+// the token it is refreshed once and the call is retried.
+func (s *Service) withAuth(t string, f func(ctx context.Context) error) error {
+	err := f(s.authContext(t))
+	if status.Code(err) != codes.Unauthenticated || s.refreshToken == "" {
+		return err
+	}
+
+	tokens, rerr := s.RefreshToken(s.refreshToken)
+	if rerr != nil {
+		return fmt.Errorf("%w, %w", err, rerr)
+	}
+	s.token = tokens.Token
+	s.refreshToken = tokens.RefreshToken
+	if s.onRefresh != nil {
+		if err := s.onRefresh(tokens); err != nil {
+			return fmt.Errorf("failed to save refreshed tokens: %w", err)
+		}
+	}
+
+	return f(s.authContext(t))
+}
+
+func (s *Service) authContext(t string) context.Context {
+	if s.token != "" {
+		t = s.token
+	}
+	md := metadata.New(map[string]string{"authorization": t})
+	if s.vault != "" {
+		md.Set(vault.MetadataKey, s.vault)
+	}
+	return metadata.NewOutgoingContext(context.Background(), md)
+}
@@ -1,35 +1,61 @@
 package grpcclient
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
-	"strings"
 
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
 	"github.com/vkupriya/gophkeeper/internal/client/models"
 	pb "github.com/vkupriya/gophkeeper/internal/proto"
+	"github.com/vkupriya/gophkeeper/internal/search"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
 var (
 	ErrServerUnavailable = errors.New("server not available")
+	ErrVersionConflict   = errors.New("secret has been changed on server")
+	// ErrSecretCorrupted is returned when secret data does not match its hash kept by the server.
+	ErrSecretCorrupted = errors.New("secret data is corrupted")
+	// ErrSecretUndecryptable is returned when secret data is not opened by the key, e.g. it was
+	// encrypted under the master password replaced since.
+	ErrSecretUndecryptable = errors.New("failed to decrypt secret")
 )
 
 type Service struct {
 	connGRPC   *grpc.ClientConn
 	clientGRPC pb.GophKeeperClient
+	// token is an access token refreshed by the service, it replaces the token passed to methods.
+	token        string
+	refreshToken string
+	onRefresh    func(tokens *models.Tokens) error
+	// vault is 'org/vault' reference, secret methods act on the vault secrets if it is set.
+	vault string
 }
 
 func NewService() *Service {
 	return &Service{}
 }
 
-func NewGRPCClient(s *Service, grpcHost string) error {
-	conn, err := grpc.NewClient(grpcHost, grpc.WithTransportCredentials((insecure.NewCredentials())))
+// NewGRPCClient connects service to gophkeeper server, connection is not encrypted
+// unless TLS is enabled in tlsCfg.
+func NewGRPCClient(s *Service, grpcHost string, tlsCfg *models.TLSConfig) error {
+	creds := insecure.NewCredentials()
+	if tlsCfg != nil && tlsCfg.Enabled {
+		tlsConfig, err := helpers.NewTLSConfig(tlsCfg)
+		if err != nil {
+			return fmt.Errorf("failed to set up TLS: %w", err)
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	conn, err := grpc.NewClient(grpcHost, grpc.WithTransportCredentials(creds))
 	if err != nil {
 		return fmt.Errorf("failed to create GRPC client: %w", err)
 	}
@@ -39,37 +65,98 @@ func NewGRPCClient(s *Service, grpcHost string) error {
 	return nil
 }
 
-func (s *Service) Register(user string, password string) (string, error) {
+func (s *Service) Register(user string, password string) (*models.Tokens, error) {
 	authToken, err := s.clientGRPC.Register(context.Background(), &pb.User{
 		Login:    user,
 		Password: password,
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to register: %w", err)
+		return nil, fmt.Errorf("failed to register: %w", err)
 	}
 
-	token := strings.Split(authToken.String(), ":")[1]
-	token = strings.ReplaceAll(token, `"`, "")
-	return token, nil
+	return &models.Tokens{
+		Token:        authToken.GetToken(),
+		RefreshToken: authToken.GetRefreshToken(),
+	}, nil
 }
 
-func (s *Service) Login(user string, password string) (string, error) {
+func (s *Service) Login(user string, password string) (*models.Tokens, error) {
 	authToken, err := s.clientGRPC.Login(context.Background(), &pb.User{
 		Login:    user,
 		Password: password,
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to login: %w", err)
+		return nil, fmt.Errorf("failed to login: %w", err)
 	}
-	token := strings.Split(authToken.String(), ":")[1]
-	token = strings.ReplaceAll(token, `"`, "")
-	return token, nil
+
+	return &models.Tokens{
+		Token:        authToken.GetToken(),
+		RefreshToken: authToken.GetRefreshToken(),
+	}, nil
 }
 
-func (s *Service) ListSecrets(t string) ([]*models.SecretItem, error) {
-	md := metadata.New(map[string]string{"authorization": t})
-	ctxWithAuth := metadata.NewOutgoingContext(context.Background(), md)
-	secrets, err := s.clientGRPC.ListSecrets(ctxWithAuth, &pb.Empty{})
+// ListSecrets - function getting list of secrets matching the filter from gophkeeper server,
+// subfolders of non-recursive listing are returned as items of folder type before secrets.
+// Secrets are received in pages of filter.PageSize until the server returns the last page.
+func (s *Service) ListSecrets(t string, filter models.SecretFilter) ([]*models.SecretItem, error) {
+	sortOrder, err := SortToProto(filter.Sort)
+	if err != nil {
+		return nil, err
+	}
+	types := make([]pb.SecretType, 0, len(filter.Types))
+	for _, st := range filter.Types {
+		types = append(types, TypeToProto(st))
+	}
+	in := &pb.ListSecretsRequest{
+		LabelSelector: filter.Labels,
+		Prefix:        filter.Folder,
+		Recursive:     filter.Recursive,
+		PageSize:      int32(filter.PageSize),
+		Sort:          sortOrder,
+		Types:         types,
+	}
+
+	resultItems := make([]*models.SecretItem, 0)
+	for {
+		page, err := s.listSecretsPage(t, in)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, folder := range page.GetFolders() {
+			resultItems = append(resultItems, &models.SecretItem{
+				Name: folder,
+				Type: models.TypeFolder,
+			})
+		}
+		for _, secret := range page.GetItems() {
+			item := &models.SecretItem{
+				Name:    secret.GetName(),
+				Type:    ProtoToType(secret.GetType()),
+				Labels:  secret.GetLabels(),
+				Version: secret.GetVersion(),
+			}
+			if secret.GetUpdatedAt() != nil {
+				updatedAt := secret.GetUpdatedAt().AsTime()
+				item.UpdatedAt = &updatedAt
+			}
+			resultItems = append(resultItems, item)
+		}
+
+		if page.GetNextPageToken() == "" {
+			return resultItems, nil
+		}
+		in.PageToken = page.GetNextPageToken()
+	}
+}
+
+func (s *Service) listSecretsPage(t string, in *pb.ListSecretsRequest) (*pb.ListSecretsResponse, error) {
+	var page *pb.ListSecretsResponse
+	err := s.withAuth(t, func(ctx context.Context) error {
+		var err error
+		page, err = s.clientGRPC.ListSecrets(ctx, in)
+		return err
+	})
 	if err != nil {
 		status, ok := status.FromError(err)
 		if !ok {
@@ -83,36 +170,87 @@ func (s *Service) ListSecrets(t string) ([]*models.SecretItem, error) {
 			return nil, fmt.Errorf("error in getting list of secrets: %w", err)
 		}
 	}
+	return page, nil
+}
 
-	resultItems := make([]*models.SecretItem, 0, len(secrets.Items))
-	for _, secret := range secrets.Items {
-		resultItems = append(resultItems, &models.SecretItem{
-			Name:    secret.GetName(),
-			Type:    ProtoToType(secret.GetType()),
-			Version: secret.GetVersion(),
+// SearchSecrets - function searching secrets by words of the query in their names, types and
+// labels on gophkeeper server, results are ordered by relevance.
+func (s *Service) SearchSecrets(t string, query string, limit int) ([]*models.SearchResult, error) {
+	var resp *pb.SearchSecretsResponse
+	err := s.withAuth(t, func(ctx context.Context) error {
+		var err error
+		resp, err = s.clientGRPC.SearchSecrets(ctx, &pb.SearchSecretsRequest{
+			Query: query,
+			Limit: int32(search.Limit(limit)),
 		})
+		return err
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			return nil, ErrServerUnavailable
+		}
+		return nil, fmt.Errorf("error in searching secrets: %w", err)
+	}
+
+	results := make([]*models.SearchResult, 0, len(resp.GetResults()))
+	for _, r := range resp.GetResults() {
+		results = append(results, &models.SearchResult{
+			Secret: &models.SecretItem{
+				Name:    r.GetSecret().GetName(),
+				Type:    ProtoToType(r.GetSecret().GetType()),
+				Labels:  r.GetSecret().GetLabels(),
+				Version: r.GetSecret().GetVersion(),
+			},
+			Rank: r.GetRank(),
+		})
+	}
+	return results, nil
+}
+
+// MoveSecret - function renaming secret, or every secret of the folder if from ends with '/',
+// on gophkeeper server. Version history is preserved, number of moved secrets is returned.
+func (s *Service) MoveSecret(t string, from string, to string) (int64, error) {
+	var resp *pb.MoveSecretResponse
+	err := s.withAuth(t, func(ctx context.Context) error {
+		var err error
+		resp, err = s.clientGRPC.MoveSecret(ctx, &pb.MoveSecretRequest{
+			From: from,
+			To:   to,
+		})
+		return err
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			return 0, ErrServerUnavailable
+		}
+		return 0, fmt.Errorf("error in moving secret: %w", err)
 	}
-	return resultItems, nil
+	return resp.GetMoved(), nil
 }
 
 // AddSecret - function adding secret to gophkeeper server, it takes
-// login token, encryption key and secret struct.
-func (s *Service) AddSecret(t string, key string, secret *models.Secret) error {
-	md := metadata.New(map[string]string{"authorization": t})
-	md.Append("secretkey", key)
+// login token, encryption key and secret struct. Secret data is encrypted
+// locally, server receives only ciphertext.
+func (s *Service) AddSecret(t string, key *helpers.SecretKey, secret *models.Secret) error {
+	data, err := helpers.Encrypt(key.Key, secret.Data)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt secret: %w", err)
+	}
 	pbSecret := &pb.Secret{
-		Name:    secret.Name,
-		Meta:    secret.Meta,
-		Data:    secret.Data,
-		Type:    TypeToProto(secret.Type),
-		Version: secret.Version,
+		Name:       secret.Name,
+		Labels:     secret.Labels,
+		Data:       data,
+		Type:       TypeToProto(secret.Type),
+		Version:    secret.Version,
+		EncVersion: helpers.EncVersionClient,
 	}
 
-	ctxWithAuth := metadata.NewOutgoingContext(context.Background(), md)
-	_, err := s.clientGRPC.AddSecret(ctxWithAuth, &pb.AddSecretRequest{
-		Secret: pbSecret,
+	err = s.withAuth(t, func(ctx context.Context) error {
+		_, err := s.clientGRPC.AddSecret(ctx, &pb.AddSecretRequest{
+			Secret: pbSecret,
+		})
+		return err
 	})
-
 	if err != nil {
 		return fmt.Errorf("failed to add secret: %w", err)
 	}
@@ -120,33 +258,76 @@ func (s *Service) AddSecret(t string, key string, secret *models.Secret) error {
 }
 
 // UpdateSecret - function uptading named secret on gophkeeper server, it takes
-// login token, encryption key and secret struct.
-func (s *Service) UpdateSecret(t string, key string, secret *models.Secret) error {
-	md := metadata.New(map[string]string{"authorization": t})
-	md.Append("secretkey", key)
-	ctxWithAuth := metadata.NewOutgoingContext(context.Background(), md)
-	_, err := s.clientGRPC.UpdateSecret(ctxWithAuth, &pb.UpdateSecretRequest{
-		Secret: &pb.Secret{
-			Name: secret.Name,
-			Meta: secret.Meta,
-			Data: secret.Data,
-			Type: TypeToProto(secret.Type),
-		},
+// login token, encryption key and secret struct. Secret version is the expected current
+// version of the secret on the server, ErrVersionConflict is returned if it does not match.
+func (s *Service) UpdateSecret(t string, key *helpers.SecretKey, secret *models.Secret) error {
+	data, err := helpers.Encrypt(key.Key, secret.Data)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt secret: %w", err)
+	}
+	err = s.withAuth(t, func(ctx context.Context) error {
+		_, err := s.clientGRPC.UpdateSecret(ctx, &pb.UpdateSecretRequest{
+			Secret: &pb.Secret{
+				Name:       secret.Name,
+				Labels:     secret.Labels,
+				Data:       data,
+				Type:       TypeToProto(secret.Type),
+				Version:    secret.Version,
+				EncVersion: helpers.EncVersionClient,
+			},
+		})
+		return err
 	})
 	if err != nil {
+		if status.Code(err) == codes.Aborted {
+			return fmt.Errorf("%w: %s", ErrVersionConflict, status.Convert(err).Message())
+		}
 		return fmt.Errorf("failed to update secret: %w", err)
 	}
 	return nil
 }
 
+// RollbackSecret - function saving data of the given past version of named secret as its new version,
+// data is copied on the server. current is the expected current version of the secret, ErrVersionConflict
+// is returned if the secret has been changed since then. The new version is returned.
+func (s *Service) RollbackSecret(t string, name string, version int64, current int64) (int64, error) {
+	var resp *pb.RollbackSecretResponse
+	err := s.withAuth(t, func(ctx context.Context) error {
+		var err error
+		resp, err = s.clientGRPC.RollbackSecret(ctx, &pb.RollbackSecretRequest{
+			Name:           name,
+			Version:        version,
+			CurrentVersion: current,
+		})
+		return err
+	})
+	if err != nil {
+		if status.Code(err) == codes.Aborted {
+			return 0, fmt.Errorf("%w: %s", ErrVersionConflict, status.Convert(err).Message())
+		}
+		return 0, fmt.Errorf("failed to roll back secret: %w", err)
+	}
+	return resp.GetVersion(), nil
+}
+
 // GetSecret - function getting named secret from gophkeeper server, it takes
-// login token, encryption key and secret name.
-func (s *Service) GetSecret(t string, key string, name string) (*models.Secret, error) {
-	md := metadata.New(map[string]string{"authorization": t})
-	md.Append("secretkey", key)
-	ctxWithAuth := metadata.NewOutgoingContext(context.Background(), md)
-	resp, err := s.clientGRPC.GetSecret(ctxWithAuth, &pb.GetSecretRequest{
-		Name: name,
+// login token, encryption key and secret name. Secret data is decrypted locally.
+func (s *Service) GetSecret(t string, key *helpers.SecretKey, name string) (*models.Secret, error) {
+	return s.GetSecretVersion(t, key, name, 0)
+}
+
+// GetSecretVersion - function getting given version of named secret from gophkeeper server,
+// latest version is returned when version is 0.
+func (s *Service) GetSecretVersion(t string, key *helpers.SecretKey, name string,
+	version int64) (*models.Secret, error) {
+	var resp *pb.GetSecretResponse
+	err := s.withAuth(t, func(ctx context.Context) error {
+		var err error
+		resp, err = s.clientGRPC.GetSecret(ctx, &pb.GetSecretRequest{
+			Name:    name,
+			Version: version,
+		})
+		return err
 	})
 	if err != nil {
 		status, ok := status.FromError(err)
@@ -157,27 +338,124 @@ func (s *Service) GetSecret(t string, key string, name string) (*models.Secret,
 		switch status.Code() {
 		case codes.Unavailable:
 			return nil, ErrServerUnavailable
+		case codes.DataLoss:
+			return nil, fmt.Errorf("%w on server", ErrSecretCorrupted)
 		default:
 			return nil, fmt.Errorf("error in getting a secret: %w", err)
 		}
 	}
 
+	// data of secrets uploaded by chunks is not returned by GetSecret
+	if resp.Secret.GetEncVersion() == helpers.EncVersionChunked {
+		var buf bytes.Buffer
+		secret, err := s.DownloadSecret(t, key, name, resp.Secret.GetVersion(), &buf, nil)
+		if err != nil {
+			return nil, err
+		}
+		secret.Data = buf.Bytes()
+		return secret, nil
+	}
+
+	sum := sha256.Sum256(resp.Secret.GetData())
+	if err = verifyHash(resp.Secret.GetHash(), sum[:]); err != nil {
+		return nil, err
+	}
+	data, err := helpers.DecryptSecret(key, resp.Secret.GetEncVersion(), resp.Secret.GetData())
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrSecretUndecryptable, name, err)
+	}
+
 	secret := models.Secret{
 		Name:    resp.Secret.GetName(),
-		Type:    ProtoToType(resp.Secret.Type),
-		Meta:    resp.Secret.GetMeta(),
-		Data:    resp.Secret.GetData(),
+		Type:    ProtoToType(resp.Secret.GetType()),
+		Labels:  resp.Secret.GetLabels(),
+		Data:    data,
 		Version: resp.Secret.GetVersion(),
 	}
 
 	return &secret, nil
 }
 
+// verifyHash checks hash of the received data against the hash kept by the server,
+// secrets saved before hashes were introduced have no hash.
+func verifyHash(want []byte, got []byte) error {
+	if len(want) != 0 && !bytes.Equal(want, got) {
+		return fmt.Errorf("%w in transfer", ErrSecretCorrupted)
+	}
+	return nil
+}
+
+// MigrateSecrets - function re-encrypting on the client all secrets which were
+// encrypted by the server before client-side encryption, returns number of migrated secrets.
+func (s *Service) MigrateSecrets(t string, key *helpers.SecretKey) (int, error) {
+	var items []*pb.SecretItem
+	in := &pb.ListSecretsRequest{Recursive: true}
+	for {
+		page, err := s.listSecretsPage(t, in)
+		if status.Code(err) == codes.NotFound {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		items = append(items, page.GetItems()...)
+		if page.GetNextPageToken() == "" {
+			break
+		}
+		in.PageToken = page.GetNextPageToken()
+	}
+
+	var migrated int
+	for _, item := range items {
+		if item.GetEncVersion() != helpers.EncVersionLegacy {
+			continue
+		}
+		secret, err := s.GetSecret(t, key, item.GetName())
+		if err != nil {
+			return migrated, err
+		}
+		if err := s.UpdateSecret(t, key, secret); err != nil {
+			return migrated, err
+		}
+		migrated++
+	}
+	return migrated, nil
+}
+
+// ListSecretVersions - function getting history of named secret from gophkeeper server.
+func (s *Service) ListSecretVersions(t string, name string) ([]*models.SecretVersion, error) {
+	var resp *pb.ListSecretVersionsResponse
+	err := s.withAuth(t, func(ctx context.Context) error {
+		var err error
+		resp, err = s.clientGRPC.ListSecretVersions(ctx, &pb.ListSecretVersionsRequest{
+			Name: name,
+		})
+		return err
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			return nil, ErrServerUnavailable
+		}
+		return nil, fmt.Errorf("error in getting secret versions: %w", err)
+	}
+
+	versions := make([]*models.SecretVersion, 0, len(resp.GetVersions()))
+	for _, v := range resp.GetVersions() {
+		versions = append(versions, &models.SecretVersion{
+			Version:   v.GetVersion(),
+			Type:      ProtoToType(v.GetType()),
+			CreatedAt: v.GetCreatedAt().AsTime(),
+		})
+	}
+	return versions, nil
+}
+
 func (s *Service) DeleteSecret(t string, name string) error {
-	md := metadata.New(map[string]string{"authorization": t})
-	ctxWithAuth := metadata.NewOutgoingContext(context.Background(), md)
-	_, err := s.clientGRPC.DeleteSecret(ctxWithAuth, &pb.DeleteSecretRequest{
-		Name: name,
+	err := s.withAuth(t, func(ctx context.Context) error {
+		_, err := s.clientGRPC.DeleteSecret(ctx, &pb.DeleteSecretRequest{
+			Name: name,
+		})
+		return err
 	})
 	if err != nil {
 		return fmt.Errorf("failed to delete secret: %w", err)
@@ -196,6 +474,10 @@ func TypeToProto(st string) pb.SecretType {
 		return pb.SecretType_CARD
 	case "file":
 		return pb.SecretType_FILE
+	case "login":
+		return pb.SecretType_CREDENTIALS
+	case "otp":
+		return pb.SecretType_OTP
 	case "unknown":
 		return pb.SecretType_UNKNOWN
 	default:
@@ -203,6 +485,23 @@ func TypeToProto(st string) pb.SecretType {
 	}
 }
 
+// SortToProto converts sort order of list command, empty order sorts secrets by name.
+func SortToProto(sort string) (pb.SecretSort, error) {
+	switch sort {
+	case "", models.SortName:
+		return pb.SecretSort_SORT_NAME_ASC, nil
+	case models.SortNameDesc:
+		return pb.SecretSort_SORT_NAME_DESC, nil
+	case models.SortUpdated:
+		return pb.SecretSort_SORT_UPDATED_ASC, nil
+	case models.SortUpdatedDesc:
+		return pb.SecretSort_SORT_UPDATED_DESC, nil
+	default:
+		return 0, fmt.Errorf("unknown sort order %q, use one of %s, %s, %s, %s", sort,
+			models.SortName, models.SortNameDesc, models.SortUpdated, models.SortUpdatedDesc)
+	}
+}
+
 func ProtoToType(st pb.SecretType) string {
 	switch st {
 	case pb.SecretType_TEXT:
@@ -213,6 +512,10 @@ func ProtoToType(st pb.SecretType) string {
 		return "card"
 	case pb.SecretType_FILE:
 		return "file"
+	case pb.SecretType_CREDENTIALS:
+		return "login"
+	case pb.SecretType_OTP:
+		return "otp"
 	case pb.SecretType_UNKNOWN:
 		return "unknown"
 	default:
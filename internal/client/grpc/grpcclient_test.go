@@ -1,16 +1,30 @@
 package grpcclient
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
 	"github.com/vkupriya/gophkeeper/internal/client/models"
 	pb "github.com/vkupriya/gophkeeper/internal/proto"
 
 	mocks "github.com/vkupriya/gophkeeper/internal/proto/mocks"
+	"github.com/vkupriya/gophkeeper/internal/search"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+var testKey = helpers.NewSecretKey("encryptionkey", "user")
+
 func TestRegister(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -19,15 +33,17 @@ func TestRegister(t *testing.T) {
 
 	value := "ksjdfksjldkfjsldkfjsdlkf"
 	m.EXPECT().Register(gomock.Any(), gomock.Any()).Return(&pb.UserAuthToken{
-		Token: value,
+		Token:        value,
+		RefreshToken: "refresh",
 	}, nil)
 
 	svc := NewService()
 	svc.clientGRPC = m
 
-	token, err := svc.Register("user", "password")
+	tokens, err := svc.Register("user", "password")
 	require.NoError(t, err)
-	require.Equal(t, token, value)
+	require.Equal(t, tokens.Token, value)
+	require.Equal(t, tokens.RefreshToken, "refresh")
 }
 
 func TestLogin(t *testing.T) {
@@ -38,15 +54,17 @@ func TestLogin(t *testing.T) {
 
 	value := "ksjdfksjldkfjsldkfjsdlkf"
 	m.EXPECT().Login(gomock.Any(), gomock.Any()).Return(&pb.UserAuthToken{
-		Token: value,
+		Token:        value,
+		RefreshToken: "refresh",
 	}, nil)
 
 	svc := NewService()
 	svc.clientGRPC = m
 
-	token, err := svc.Login("user", "password")
+	tokens, err := svc.Login("user", "password")
 	require.NoError(t, err)
-	require.Equal(t, token, value)
+	require.Equal(t, tokens.Token, value)
+	require.Equal(t, tokens.RefreshToken, "refresh")
 }
 
 func TestListSecrets(t *testing.T) {
@@ -55,50 +73,175 @@ func TestListSecrets(t *testing.T) {
 
 	m := mocks.NewMockGophKeeperClient(ctrl)
 
-	m.EXPECT().ListSecrets(gomock.Any(), gomock.Any()).Return(&pb.ListSecretsResponse{
-		Items: []*pb.SecretItem{
-			{
-				Name:    "secret01",
-				Type:    pb.SecretType_TEXT,
-				Version: 1,
-			},
-		},
-	}, nil)
+	m.EXPECT().ListSecrets(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *pb.ListSecretsRequest, _ ...grpc.CallOption) (*pb.ListSecretsResponse, error) {
+			require.Equal(t, "env=prod", in.GetLabelSelector())
+			require.Equal(t, "work/", in.GetPrefix())
+			require.False(t, in.GetRecursive())
+			return &pb.ListSecretsResponse{
+				Folders: []string{"work/aws/"},
+				Items: []*pb.SecretItem{
+					{
+						Name:    "secret01",
+						Type:    pb.SecretType_TEXT,
+						Labels:  map[string]string{"env": "prod"},
+						Version: 1,
+					},
+				},
+			}, nil
+		})
 
 	secretsExpected := []*models.SecretItem{
+		{
+			Name: "work/aws/",
+			Type: models.TypeFolder,
+		},
 		{
 			Name:    "secret01",
 			Type:    "text",
+			Labels:  map[string]string{"env": "prod"},
 			Version: 1,
 		},
 	}
 	svc := NewService()
 	svc.clientGRPC = m
 
-	secrets, err := svc.ListSecrets("user")
+	secrets, err := svc.ListSecrets("user", models.SecretFilter{Labels: "env=prod", Folder: "work/"})
 	require.NoError(t, err)
 	require.Equal(t, secrets, secretsExpected)
 }
 
+func TestListSecretsPages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mocks.NewMockGophKeeperClient(ctrl)
+
+	updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	pages := map[string]*pb.ListSecretsResponse{
+		"": {
+			Items:         []*pb.SecretItem{{Name: "secret02", Type: pb.SecretType_CREDENTIALS, Version: 1}},
+			NextPageToken: "page2",
+		},
+		"page2": {
+			Items: []*pb.SecretItem{{
+				Name:      "secret01",
+				Type:      pb.SecretType_CREDENTIALS,
+				Version:   3,
+				UpdatedAt: timestamppb.New(updatedAt),
+			}},
+		},
+	}
+	m.EXPECT().ListSecrets(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+		func(_ context.Context, in *pb.ListSecretsRequest, _ ...grpc.CallOption) (*pb.ListSecretsResponse, error) {
+			require.Equal(t, int32(1), in.GetPageSize())
+			require.Equal(t, pb.SecretSort_SORT_NAME_DESC, in.GetSort())
+			require.Equal(t, []pb.SecretType{pb.SecretType_CREDENTIALS}, in.GetTypes())
+			return pages[in.GetPageToken()], nil
+		})
+
+	svc := NewService()
+	svc.clientGRPC = m
+
+	secrets, err := svc.ListSecrets("user", models.SecretFilter{
+		Sort:     models.SortNameDesc,
+		Types:    []string{"login"},
+		PageSize: 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*models.SecretItem{
+		{Name: "secret02", Type: "login", Version: 1},
+		{Name: "secret01", Type: "login", Version: 3, UpdatedAt: &updatedAt},
+	}, secrets)
+
+	_, err = svc.ListSecrets("user", models.SecretFilter{Sort: "size"})
+	require.Error(t, err)
+}
+
+func TestSearchSecrets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mocks.NewMockGophKeeperClient(ctrl)
+
+	m.EXPECT().SearchSecrets(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *pb.SearchSecretsRequest, _ ...grpc.CallOption) (*pb.SearchSecretsResponse, error) {
+			require.Equal(t, "aws prod", in.GetQuery())
+			require.Equal(t, int32(search.DefaultLimit), in.GetLimit())
+			return &pb.SearchSecretsResponse{
+				Results: []*pb.SearchResult{
+					{
+						Secret: &pb.SecretItem{Name: "work/aws/prod", Type: pb.SecretType_TEXT, Version: 2},
+						Rank:   0.75,
+					},
+				},
+			}, nil
+		})
+
+	svc := NewService()
+	svc.clientGRPC = m
+
+	results, err := svc.SearchSecrets("user", "aws prod", 0)
+	require.NoError(t, err)
+	require.Equal(t, []*models.SearchResult{
+		{Secret: &models.SecretItem{Name: "work/aws/prod", Type: "text", Version: 2}, Rank: 0.75},
+	}, results)
+}
+
+func TestMoveSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mocks.NewMockGophKeeperClient(ctrl)
+
+	m.EXPECT().MoveSecret(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *pb.MoveSecretRequest, _ ...grpc.CallOption) (*pb.MoveSecretResponse, error) {
+			require.Equal(t, "work/aws/", in.GetFrom())
+			require.Equal(t, "cloud/aws/", in.GetTo())
+			return &pb.MoveSecretResponse{Moved: 2}, nil
+		})
+	m.EXPECT().MoveSecret(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.AlreadyExists, "exists"))
+
+	svc := NewService()
+	svc.clientGRPC = m
+
+	moved, err := svc.MoveSecret("user", "work/aws/", "cloud/aws/")
+	require.NoError(t, err)
+	require.Equal(t, int64(2), moved)
+
+	_, err = svc.MoveSecret("user", "github", "work/")
+	require.Error(t, err)
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
 func TestAddSecret(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	m := mocks.NewMockGophKeeperClient(ctrl)
 
-	m.EXPECT().AddSecret(gomock.Any(), gomock.Any()).Return(&pb.Empty{}, nil)
 	card := "{'card':'0123456789876','name': 'Super Agent', 'expiry': '02/25', 'cvv':'555'}"
+	m.EXPECT().AddSecret(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *pb.AddSecretRequest, _ ...grpc.CallOption) (*pb.Empty, error) {
+			require.NotEqual(t, []byte(card), in.Secret.GetData())
+			require.Equal(t, helpers.EncVersionClient, in.Secret.GetEncVersion())
+
+			data, err := helpers.Decrypt(testKey.Key, in.Secret.GetData())
+			require.NoError(t, err)
+			require.Equal(t, []byte(card), data)
+			return &pb.Empty{}, nil
+		})
 	secret := &models.Secret{
 		Name:    "card01",
 		Type:    "card",
-		Meta:    "metadata",
+		Labels:  map[string]string{"env": "prod"},
 		Data:    []byte(card),
 		Version: 1,
 	}
 	svc := NewService()
 	svc.clientGRPC = m
 
-	err := svc.AddSecret("token", "encryptionkey", secret)
+	err := svc.AddSecret("token", testKey, secret)
 	require.NoError(t, err)
 }
 
@@ -108,33 +251,172 @@ func TestGetSecret(t *testing.T) {
 
 	card := "{'card':'0123456789876','name': 'Super Agent', 'expiry': '02/25', 'cvv':'555'}"
 
+	data, err := helpers.Encrypt(testKey.Key, []byte(card))
+	require.NoError(t, err)
+
 	m := mocks.NewMockGophKeeperClient(ctrl)
 
 	m.EXPECT().GetSecret(gomock.Any(), gomock.Any()).Return(&pb.GetSecretResponse{
 		Secret: &pb.Secret{
-			Name:    "card01",
-			Type:    pb.SecretType_CARD,
-			Meta:    "metadata",
-			Data:    []byte(card),
-			Version: 1,
+			Name:       "card01",
+			Type:       pb.SecretType_CARD,
+			Labels:     map[string]string{"env": "prod"},
+			Data:       data,
+			Version:    1,
+			EncVersion: helpers.EncVersionClient,
 		},
 	}, nil)
 
 	secretExpected := &models.Secret{
 		Name:    "card01",
 		Type:    "card",
-		Meta:    "metadata",
+		Labels:  map[string]string{"env": "prod"},
 		Data:    []byte(card),
 		Version: 1,
 	}
 	svc := NewService()
 	svc.clientGRPC = m
 
-	secret, err := svc.GetSecret("token", "encryptionkey", "card01")
+	secret, err := svc.GetSecret("token", testKey, "card01")
 	require.NoError(t, err)
 	require.Equal(t, secret, secretExpected)
 }
 
+func TestGetSecretCorrupted(t *testing.T) {
+	data, err := helpers.Encrypt(testKey.Key, []byte("secret"))
+	require.NoError(t, err)
+	hash := sha256.Sum256(data)
+
+	tests := []struct {
+		err  error
+		hash []byte
+		name string
+		code codes.Code
+	}{
+		{name: "Hash", hash: hash[:]},
+		{name: "Fail_Hash", hash: make([]byte, sha256.Size), err: ErrSecretCorrupted},
+		{name: "Fail_Server", code: codes.DataLoss, err: ErrSecretCorrupted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := mocks.NewMockGophKeeperClient(ctrl)
+			if tt.code != codes.OK {
+				m.EXPECT().GetSecret(gomock.Any(), gomock.Any()).Return(nil, status.Error(tt.code, "corrupted"))
+			} else {
+				m.EXPECT().GetSecret(gomock.Any(), gomock.Any()).Return(&pb.GetSecretResponse{
+					Secret: &pb.Secret{
+						Name:       "text01",
+						Type:       pb.SecretType_TEXT,
+						Data:       data,
+						Version:    1,
+						EncVersion: helpers.EncVersionClient,
+						Hash:       tt.hash,
+					},
+				}, nil)
+			}
+
+			svc := NewService()
+			svc.clientGRPC = m
+
+			_, err := svc.GetSecret("token", testKey, "text01")
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestGetSecretLegacy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	data, err := helpers.Encrypt(testKey.Legacy, []byte("secret"))
+	require.NoError(t, err)
+
+	m := mocks.NewMockGophKeeperClient(ctrl)
+
+	m.EXPECT().GetSecret(gomock.Any(), gomock.Any()).Return(&pb.GetSecretResponse{
+		Secret: &pb.Secret{
+			Name:       "text01",
+			Type:       pb.SecretType_TEXT,
+			Data:       data,
+			Version:    1,
+			EncVersion: helpers.EncVersionLegacy,
+		},
+	}, nil)
+
+	svc := NewService()
+	svc.clientGRPC = m
+
+	secret, err := svc.GetSecret("token", testKey, "text01")
+	require.NoError(t, err)
+	require.Equal(t, []byte("secret"), secret.Data)
+}
+
+// legacyMeta is metadata of secrets created before labels, it was documented as JSON string.
+const legacyMeta = `{"env":"prod","team":"payments"}`
+
+func TestMigrateSecrets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	data, err := helpers.Encrypt(testKey.Legacy, []byte("secret"))
+	require.NoError(t, err)
+
+	m := mocks.NewMockGophKeeperClient(ctrl)
+
+	// legacy secrets are listed in two pages
+	m.EXPECT().ListSecrets(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *pb.ListSecretsRequest, _ ...grpc.CallOption) (*pb.ListSecretsResponse, error) {
+			require.True(t, in.GetRecursive())
+			if in.GetPageToken() == "" {
+				return &pb.ListSecretsResponse{
+					Items: []*pb.SecretItem{
+						{Name: "text01", Type: pb.SecretType_TEXT, Version: 1, EncVersion: helpers.EncVersionLegacy},
+						{Name: "text02", Type: pb.SecretType_TEXT, Version: 1, EncVersion: helpers.EncVersionClient},
+					},
+					NextPageToken: "page2",
+				}, nil
+			}
+			require.Equal(t, "page2", in.GetPageToken())
+			return &pb.ListSecretsResponse{
+				Items: []*pb.SecretItem{
+					{Name: "text03", Type: pb.SecretType_TEXT, Version: 1, EncVersion: helpers.EncVersionLegacy},
+				},
+			}, nil
+		}).Times(2)
+	// legacy secrets keep JSON metadata in 'meta' label
+	m.EXPECT().GetSecret(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *pb.GetSecretRequest, _ ...grpc.CallOption) (*pb.GetSecretResponse, error) {
+			return &pb.GetSecretResponse{
+				Secret: &pb.Secret{
+					Name:       in.GetName(),
+					Type:       pb.SecretType_TEXT,
+					Data:       data,
+					Version:    1,
+					EncVersion: helpers.EncVersionLegacy,
+					Labels:     map[string]string{"meta": legacyMeta},
+				},
+			}, nil
+		}).Times(2)
+	var updated []string
+	m.EXPECT().UpdateSecret(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *pb.UpdateSecretRequest, _ ...grpc.CallOption) (*pb.Empty, error) {
+			require.Equal(t, helpers.EncVersionClient, in.Secret.GetEncVersion())
+			require.Equal(t, map[string]string{"meta": legacyMeta}, in.Secret.GetLabels())
+			updated = append(updated, in.Secret.GetName())
+			return &pb.Empty{}, nil
+		}).Times(2)
+
+	svc := NewService()
+	svc.clientGRPC = m
+
+	migrated, err := svc.MigrateSecrets("token", testKey)
+	require.NoError(t, err)
+	require.Equal(t, 2, migrated)
+	require.Equal(t, []string{"text01", "text03"}, updated)
+}
+
 func TestUpdateSecret(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -148,7 +430,7 @@ func TestUpdateSecret(t *testing.T) {
 	secret := &models.Secret{
 		Name:    "card01",
 		Type:    "card",
-		Meta:    "metadata",
+		Labels:  map[string]string{"env": "prod"},
 		Data:    []byte(card),
 		Version: 1,
 	}
@@ -156,7 +438,7 @@ func TestUpdateSecret(t *testing.T) {
 	svc := NewService()
 	svc.clientGRPC = m
 
-	err := svc.UpdateSecret("token", "encryptionkey", secret)
+	err := svc.UpdateSecret("token", testKey, secret)
 	require.NoError(t, err)
 }
 
@@ -174,3 +456,880 @@ func TestDeleteSecret(t *testing.T) {
 	err := svc.DeleteSecret("token", "card01")
 	require.NoError(t, err)
 }
+
+func TestGetSecretVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	data, err := helpers.Encrypt(testKey.Key, []byte("secret v1"))
+	require.NoError(t, err)
+
+	m := mocks.NewMockGophKeeperClient(ctrl)
+
+	m.EXPECT().GetSecret(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *pb.GetSecretRequest, _ ...grpc.CallOption) (*pb.GetSecretResponse, error) {
+			require.Equal(t, int64(1), in.GetVersion())
+			return &pb.GetSecretResponse{
+				Secret: &pb.Secret{
+					Name:       "text01",
+					Type:       pb.SecretType_TEXT,
+					Data:       data,
+					Version:    1,
+					EncVersion: helpers.EncVersionClient,
+				},
+			}, nil
+		})
+
+	svc := NewService()
+	svc.clientGRPC = m
+
+	secret, err := svc.GetSecretVersion("token", testKey, "text01", 1)
+	require.NoError(t, err)
+	require.Equal(t, []byte("secret v1"), secret.Data)
+	require.Equal(t, int64(1), secret.Version)
+}
+
+func TestListSecretVersions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	created := time.Date(2024, 11, 3, 10, 0, 0, 0, time.UTC)
+
+	m := mocks.NewMockGophKeeperClient(ctrl)
+
+	m.EXPECT().ListSecretVersions(gomock.Any(), gomock.Any()).Return(&pb.ListSecretVersionsResponse{
+		Versions: []*pb.SecretVersionItem{
+			{Version: 1, Type: pb.SecretType_TEXT, CreatedAt: timestamppb.New(created)},
+			{Version: 2, Type: pb.SecretType_TEXT, CreatedAt: timestamppb.New(created.Add(time.Hour))},
+		},
+	}, nil)
+
+	versionsExpected := []*models.SecretVersion{
+		{Version: 1, Type: "text", CreatedAt: created},
+		{Version: 2, Type: "text", CreatedAt: created.Add(time.Hour)},
+	}
+
+	svc := NewService()
+	svc.clientGRPC = m
+
+	versions, err := svc.ListSecretVersions("token", "text01")
+	require.NoError(t, err)
+	require.Equal(t, versionsExpected, versions)
+}
+
+func TestUpdateSecretVersionConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mocks.NewMockGophKeeperClient(ctrl)
+
+	m.EXPECT().UpdateSecret(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *pb.UpdateSecretRequest, _ ...grpc.CallOption) (*pb.Empty, error) {
+			require.Equal(t, int64(1), in.Secret.GetVersion())
+			return nil, status.Error(codes.Aborted, "secret version conflict: expected version 1, current version 2")
+		})
+
+	secret := &models.Secret{
+		Name:    "text01",
+		Type:    "text",
+		Data:    []byte("secret"),
+		Version: 1,
+	}
+
+	svc := NewService()
+	svc.clientGRPC = m
+
+	err := svc.UpdateSecret("token", testKey, secret)
+	require.ErrorIs(t, err, ErrVersionConflict)
+}
+
+func TestRollbackSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mocks.NewMockGophKeeperClient(ctrl)
+
+	m.EXPECT().RollbackSecret(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *pb.RollbackSecretRequest,
+			_ ...grpc.CallOption) (*pb.RollbackSecretResponse, error) {
+			require.Equal(t, "text01", in.GetName())
+			require.Equal(t, int64(1), in.GetVersion())
+			if in.GetCurrentVersion() != 3 {
+				return nil, status.Error(codes.Aborted, "secret version conflict: expected version 2, current version 3")
+			}
+			return &pb.RollbackSecretResponse{Version: 4}, nil
+		}).Times(2)
+
+	svc := NewService()
+	svc.clientGRPC = m
+
+	version, err := svc.RollbackSecret("token", "text01", 1, 3)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), version)
+
+	_, err = svc.RollbackSecret("token", "text01", 1, 2)
+	require.ErrorIs(t, err, ErrVersionConflict)
+}
+
+func TestRefreshTokenOnUnauthenticated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mocks.NewMockGophKeeperClient(ctrl)
+
+	tokenFromCtx := func(ctx context.Context) string {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		return md["authorization"][0]
+	}
+
+	gomock.InOrder(
+		m.EXPECT().ListSecrets(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, _ *pb.ListSecretsRequest, _ ...grpc.CallOption) (*pb.ListSecretsResponse, error) {
+				require.Equal(t, "expired", tokenFromCtx(ctx))
+				return nil, status.Error(codes.Unauthenticated, "access token is invalid")
+			}),
+		m.EXPECT().RefreshToken(gomock.Any(), &pb.RefreshTokenRequest{RefreshToken: "refresh"}).Return(
+			&pb.UserAuthToken{Token: "fresh", RefreshToken: "refresh2"}, nil),
+		m.EXPECT().ListSecrets(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, _ *pb.ListSecretsRequest, _ ...grpc.CallOption) (*pb.ListSecretsResponse, error) {
+				require.Equal(t, "fresh", tokenFromCtx(ctx))
+				return &pb.ListSecretsResponse{}, nil
+			}),
+	)
+
+	var saved *models.Tokens
+	svc := NewService()
+	svc.clientGRPC = m
+	svc.SetRefreshToken("refresh", func(tokens *models.Tokens) error {
+		saved = tokens
+		return nil
+	})
+
+	_, err := svc.ListSecrets("expired", models.SecretFilter{})
+	require.NoError(t, err)
+	require.Equal(t, &models.Tokens{Token: "fresh", RefreshToken: "refresh2"}, saved)
+}
+
+func TestLogout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mocks.NewMockGophKeeperClient(ctrl)
+
+	m.EXPECT().Logout(gomock.Any(), &pb.LogoutRequest{RefreshToken: "refresh"}).Return(&pb.Empty{}, nil)
+
+	svc := NewService()
+	svc.clientGRPC = m
+
+	err := svc.Logout("token", "refresh")
+	require.NoError(t, err)
+}
+
+func TestRevokeSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mocks.NewMockGophKeeperClient(ctrl)
+
+	m.EXPECT().RevokeSessions(gomock.Any(), &pb.Empty{}).DoAndReturn(
+		func(ctx context.Context, _ *pb.Empty, _ ...grpc.CallOption) (*pb.Empty, error) {
+			md, ok := metadata.FromOutgoingContext(ctx)
+			require.True(t, ok)
+			require.Equal(t, []string{"token"}, md.Get("authorization"))
+			return &pb.Empty{}, nil
+		})
+
+	svc := NewService()
+	svc.clientGRPC = m
+
+	err := svc.RevokeSessions("token")
+	require.NoError(t, err)
+}
+
+// uploadChunks uploads data with mocked stream and returns encrypted chunks received by the server.
+func uploadChunks(t *testing.T, data []byte) [][]byte {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+
+	m := mocks.NewMockGophKeeperClient(ctrl)
+	stream := mocks.NewMockGophKeeper_UploadSecretClient(ctrl)
+
+	var chunks [][]byte
+	m.EXPECT().UploadSecret(gomock.Any()).Return(stream, nil)
+	stream.EXPECT().Send(gomock.Any()).DoAndReturn(func(req *pb.UploadSecretRequest) error {
+		if header := req.GetHeader(); header != nil {
+			require.Empty(t, chunks)
+			require.Equal(t, "file01", header.Secret.GetName())
+			require.Equal(t, helpers.EncVersionChunked, header.Secret.GetEncVersion())
+			require.Empty(t, header.Secret.GetData())
+			return nil
+		}
+		chunks = append(chunks, req.GetChunk())
+		return nil
+	}).AnyTimes()
+	stream.EXPECT().CloseAndRecv().Return(&pb.UploadSecretResponse{Version: 1}, nil)
+
+	svc := NewService()
+	svc.clientGRPC = m
+
+	var sent int64
+	err := svc.UploadSecret("token", testKey, &models.Secret{Name: "file01", Type: "file"}, false,
+		bytes.NewReader(data), func(n int64) { sent = n })
+	require.NoError(t, err)
+	require.Equal(t, int64(len(data)), sent)
+	return chunks
+}
+
+// downloadChunks downloads chunks with mocked stream, hash is reported by the server in secret header.
+func downloadChunks(t *testing.T, chunks [][]byte, hash []byte) ([]byte, error) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+
+	m := mocks.NewMockGophKeeperClient(ctrl)
+	stream := mocks.NewMockGophKeeper_DownloadSecretClient(ctrl)
+
+	m.EXPECT().DownloadSecret(gomock.Any(), &pb.DownloadSecretRequest{Name: "file01"}).Return(stream, nil)
+	calls := []*gomock.Call{
+		stream.EXPECT().Recv().Return(&pb.DownloadSecretResponse{
+			Payload: &pb.DownloadSecretResponse_Secret{Secret: &pb.Secret{
+				Name:       "file01",
+				Type:       pb.SecretType_FILE,
+				Version:    1,
+				EncVersion: helpers.EncVersionChunked,
+				Hash:       hash,
+			}},
+		}, nil),
+	}
+	for _, chunk := range chunks {
+		calls = append(calls, stream.EXPECT().Recv().Return(&pb.DownloadSecretResponse{
+			Payload: &pb.DownloadSecretResponse_Chunk{Chunk: chunk},
+		}, nil).MaxTimes(1))
+	}
+	calls = append(calls, stream.EXPECT().Recv().Return(nil, io.EOF).MaxTimes(1))
+	gomock.InOrder(calls...)
+
+	svc := NewService()
+	svc.clientGRPC = m
+
+	var buf bytes.Buffer
+	secret, err := svc.DownloadSecret("token", testKey, "file01", 0, &buf, nil)
+	if err != nil {
+		return nil, err
+	}
+	require.Equal(t, "file", secret.Type)
+	require.Equal(t, int64(1), secret.Version)
+	return buf.Bytes(), nil
+}
+
+func TestUploadDownloadSecret(t *testing.T) {
+	data := make([]byte, 2*helpers.ChunkSize+100)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+
+	chunks := uploadChunks(t, data)
+	require.Len(t, chunks, 3)
+
+	got, err := downloadChunks(t, chunks, nil)
+	require.NoError(t, err)
+	require.Equal(t, data, got)
+
+	t.Run("Empty", func(t *testing.T) {
+		chunks := uploadChunks(t, nil)
+		require.Len(t, chunks, 1)
+
+		got, err := downloadChunks(t, chunks, nil)
+		require.NoError(t, err)
+		require.Empty(t, got)
+	})
+
+	t.Run("Fail_Truncated", func(t *testing.T) {
+		_, err := downloadChunks(t, chunks[:2], nil)
+		require.Error(t, err)
+	})
+
+	t.Run("Fail_Reordered", func(t *testing.T) {
+		_, err := downloadChunks(t, [][]byte{chunks[1], chunks[0], chunks[2]}, nil)
+		require.Error(t, err)
+	})
+
+	t.Run("Fail_NoChunks", func(t *testing.T) {
+		_, err := downloadChunks(t, nil, nil)
+		require.ErrorIs(t, err, ErrSecretTruncated)
+	})
+
+	hash := sha256.New()
+	for _, chunk := range chunks {
+		hash.Write(chunk)
+	}
+
+	t.Run("Hash", func(t *testing.T) {
+		got, err := downloadChunks(t, chunks, hash.Sum(nil))
+		require.NoError(t, err)
+		require.Equal(t, data, got)
+	})
+
+	t.Run("Fail_Hash", func(t *testing.T) {
+		_, err := downloadChunks(t, chunks, make([]byte, sha256.Size))
+		require.ErrorIs(t, err, ErrSecretCorrupted)
+	})
+}
+
+func TestUploadSecretVersionConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mocks.NewMockGophKeeperClient(ctrl)
+	stream := mocks.NewMockGophKeeper_UploadSecretClient(ctrl)
+
+	m.EXPECT().UploadSecret(gomock.Any()).Return(stream, nil)
+	stream.EXPECT().Send(gomock.Any()).Return(nil)
+	stream.EXPECT().Send(gomock.Any()).Return(io.EOF)
+	stream.EXPECT().CloseAndRecv().Return(nil, status.Error(codes.Aborted, "expected version 1, current version 2"))
+
+	svc := NewService()
+	svc.clientGRPC = m
+
+	err := svc.UploadSecret("token", testKey, &models.Secret{Name: "file01", Type: "file", Version: 1}, true,
+		bytes.NewReader([]byte("data")), nil)
+	require.ErrorIs(t, err, ErrVersionConflict)
+}
+
+func TestGetSecretChunked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	data, err := helpers.EncryptChunk(testKey.Key, 0, true, []byte("file content"))
+	require.NoError(t, err)
+
+	m := mocks.NewMockGophKeeperClient(ctrl)
+	stream := mocks.NewMockGophKeeper_DownloadSecretClient(ctrl)
+
+	m.EXPECT().GetSecret(gomock.Any(), gomock.Any()).Return(&pb.GetSecretResponse{
+		Secret: &pb.Secret{
+			Name:       "file01",
+			Type:       pb.SecretType_FILE,
+			Version:    3,
+			EncVersion: helpers.EncVersionChunked,
+		},
+	}, nil)
+	m.EXPECT().DownloadSecret(gomock.Any(), &pb.DownloadSecretRequest{Name: "file01", Version: 3}).Return(stream, nil)
+	gomock.InOrder(
+		stream.EXPECT().Recv().Return(&pb.DownloadSecretResponse{
+			Payload: &pb.DownloadSecretResponse_Secret{Secret: &pb.Secret{
+				Name:       "file01",
+				Type:       pb.SecretType_FILE,
+				Version:    3,
+				EncVersion: helpers.EncVersionChunked,
+			}},
+		}, nil),
+		stream.EXPECT().Recv().Return(&pb.DownloadSecretResponse{
+			Payload: &pb.DownloadSecretResponse_Chunk{Chunk: data},
+		}, nil),
+		stream.EXPECT().Recv().Return(nil, io.EOF),
+	)
+
+	svc := NewService()
+	svc.clientGRPC = m
+
+	secret, err := svc.GetSecret("token", testKey, "file01")
+	require.NoError(t, err)
+	require.Equal(t, []byte("file content"), secret.Data)
+	require.Equal(t, int64(3), secret.Version)
+}
+
+func TestSecretTypes(t *testing.T) {
+	for _, st := range []string{"text", "binary", "card", "file", "login"} {
+		require.Equal(t, st, ProtoToType(TypeToProto(st)))
+	}
+	require.Equal(t, pb.SecretType_CREDENTIALS, TypeToProto("login"))
+	require.Equal(t, pb.SecretType_UNKNOWN, TypeToProto("bogus"))
+}
+
+func TestShareSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	recipient := helpers.NewSecretKey("recipient-password", "recipient")
+	recipientKey, err := recipient.ShareKey()
+	require.NoError(t, err)
+
+	data, err := helpers.Encrypt(testKey.Key, []byte("secret"))
+	require.NoError(t, err)
+
+	m := mocks.NewMockGophKeeperClient(ctrl)
+
+	m.EXPECT().GetPublicKey(gomock.Any(), gomock.Any()).Return(&pb.GetPublicKeyResponse{
+		PublicKey: recipientKey.PublicKey().Bytes(),
+	}, nil)
+	m.EXPECT().GetSecret(gomock.Any(), gomock.Any()).Return(&pb.GetSecretResponse{
+		Secret: &pb.Secret{
+			Name:       "text01",
+			Type:       pb.SecretType_TEXT,
+			Data:       data,
+			Version:    3,
+			EncVersion: helpers.EncVersionClient,
+		},
+	}, nil)
+	m.EXPECT().ShareSecret(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *pb.ShareSecretRequest, _ ...grpc.CallOption) (*pb.Empty, error) {
+			require.Equal(t, "text01", in.GetName())
+			require.Equal(t, "recipient", in.GetRecipient())
+			require.Equal(t, pb.ShareAccess_READ_WRITE, in.GetAccess())
+			require.Equal(t, int64(3), in.GetVersion())
+			require.Equal(t, helpers.EncVersionShared, in.GetEncVersion())
+
+			// only the recipient can open the shared copy
+			shared, err := helpers.DecryptSecret(recipient, in.GetEncVersion(), in.GetData())
+			require.NoError(t, err)
+			require.Equal(t, []byte("secret"), shared)
+			_, err = helpers.DecryptSecret(testKey, in.GetEncVersion(), in.GetData())
+			require.Error(t, err)
+			return &pb.Empty{}, nil
+		})
+
+	svc := NewService()
+	svc.clientGRPC = m
+
+	fingerprint, err := svc.ShareSecret("token", testKey, "text01", "recipient", models.AccessReadWrite)
+	require.NoError(t, err)
+	require.Equal(t, helpers.KeyFingerprint(recipientKey.PublicKey().Bytes()), fingerprint)
+}
+
+func TestUpdateSharedSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	owner := helpers.NewSecretKey("owner-password", "owner")
+	ownerKey, err := owner.ShareKey()
+	require.NoError(t, err)
+	recipientKey, err := testKey.ShareKey()
+	require.NoError(t, err)
+
+	data, err := helpers.SealShared(recipientKey.PublicKey().Bytes(), []byte("secret"))
+	require.NoError(t, err)
+
+	m := mocks.NewMockGophKeeperClient(ctrl)
+
+	m.EXPECT().GetSharedSecret(gomock.Any(), gomock.Any()).Return(&pb.GetSharedSecretResponse{
+		Secret: &pb.Secret{
+			Name:       "text01",
+			Type:       pb.SecretType_TEXT,
+			Data:       data,
+			Version:    2,
+			EncVersion: helpers.EncVersionShared,
+		},
+		Access: pb.ShareAccess_READ_WRITE,
+	}, nil)
+	m.EXPECT().GetPublicKey(gomock.Any(), gomock.Any()).Return(&pb.GetPublicKeyResponse{
+		PublicKey: ownerKey.PublicKey().Bytes(),
+	}, nil)
+	m.EXPECT().UpdateSharedSecret(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *pb.UpdateSharedSecretRequest, _ ...grpc.CallOption) (*pb.Empty, error) {
+			require.Equal(t, "owner", in.GetOwner())
+			require.Equal(t, int64(2), in.GetVersion())
+
+			ownerData, err := helpers.DecryptSecret(owner, in.GetEncVersion(), in.GetOwnerData())
+			require.NoError(t, err)
+			require.Equal(t, []byte("updated"), ownerData)
+			ownData, err := helpers.DecryptSecret(testKey, in.GetEncVersion(), in.GetData())
+			require.NoError(t, err)
+			require.Equal(t, []byte("updated"), ownData)
+			return &pb.Empty{}, nil
+		})
+	m.EXPECT().UpdateSharedSecret(gomock.Any(), gomock.Any()).Return(nil,
+		status.Error(codes.PermissionDenied, "secret is shared read-only"))
+	m.EXPECT().GetPublicKey(gomock.Any(), gomock.Any()).Return(&pb.GetPublicKeyResponse{
+		PublicKey: ownerKey.PublicKey().Bytes(),
+	}, nil)
+
+	svc := NewService()
+	svc.clientGRPC = m
+
+	secret, access, err := svc.GetSharedSecret("token", testKey, "owner", "text01")
+	require.NoError(t, err)
+	require.Equal(t, models.AccessReadWrite, access)
+	require.Equal(t, []byte("secret"), secret.Data)
+
+	require.NoError(t, svc.UpdateSharedSecret("token", testKey, "owner", "text01", []byte("updated"), 2))
+
+	err = svc.UpdateSharedSecret("token", testKey, "owner", "text01", []byte("updated"), 3)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestVaultMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	member := helpers.NewSecretKey("member-password", "member")
+	memberKey, err := member.ShareKey()
+	require.NoError(t, err)
+
+	m := mocks.NewMockGophKeeperClient(ctrl)
+
+	var sealedKey []byte
+	m.EXPECT().CreateVault(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, in *pb.CreateVaultRequest, _ ...grpc.CallOption) (*pb.Empty, error) {
+			require.Equal(t, "acme", in.GetOrg())
+			require.Equal(t, "oncall", in.GetName())
+			sealedKey = in.GetSealedKey()
+			return &pb.Empty{}, nil
+		})
+	m.EXPECT().GetPublicKey(gomock.Any(), gomock.Any()).Return(&pb.GetPublicKeyResponse{
+		PublicKey: memberKey.PublicKey().Bytes(),
+	}, nil)
+	m.EXPECT().GetVaultKey(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ *pb.Empty, _ ...grpc.CallOption) (*pb.GetVaultKeyResponse, error) {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			require.Equal(t, []string{"acme/oncall"}, md.Get("vault"))
+			return &pb.GetVaultKeyResponse{SealedKey: sealedKey}, nil
+		})
+	m.EXPECT().AddVaultMember(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, in *pb.VaultMemberRequest, _ ...grpc.CallOption) (*pb.Empty, error) {
+			// vault of the service is restored after getting the vault key
+			md, _ := metadata.FromOutgoingContext(ctx)
+			require.Empty(t, md.Get("vault"))
+			require.Equal(t, "member", in.GetLogin())
+			require.Equal(t, pb.VaultRole_EDITOR, in.GetRole())
+
+			// the member opens the same vault key as the creator
+			private, err := testKey.ShareKey()
+			require.NoError(t, err)
+			ownerVaultKey, err := helpers.OpenShared(private, sealedKey)
+			require.NoError(t, err)
+			memberVaultKey, err := helpers.OpenShared(memberKey, in.GetSealedKey())
+			require.NoError(t, err)
+			require.Equal(t, ownerVaultKey, memberVaultKey)
+			return &pb.Empty{}, nil
+		})
+	m.EXPECT().ListVaultMembers(gomock.Any(), gomock.Any()).Return(&pb.ListVaultMembersResponse{
+		Members: []*pb.VaultMember{
+			{Login: "user", Role: pb.VaultRole_OWNER},
+			{Login: "member", Vault: "oncall", Role: pb.VaultRole_EDITOR},
+		},
+	}, nil)
+
+	svc := NewService()
+	svc.clientGRPC = m
+
+	require.NoError(t, svc.CreateVault("token", testKey, "acme", "oncall"))
+	require.NoError(t, svc.AddVaultMember("token", testKey, "acme", "oncall", "member", "editor"))
+
+	members, err := svc.ListVaultMembers("token", "acme", "oncall")
+	require.NoError(t, err)
+	require.Equal(t, []*models.VaultMember{
+		{Login: "user", Role: "owner"},
+		{Login: "member", Vault: "oncall", Role: "editor"},
+	}, members)
+}
+
+func TestVaultSecrets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	vaultKey, err := helpers.NewVaultKey()
+	require.NoError(t, err)
+	private, err := testKey.ShareKey()
+	require.NoError(t, err)
+	sealedKey, err := helpers.SealShared(private.PublicKey().Bytes(), vaultKey.Key)
+	require.NoError(t, err)
+	data, err := helpers.Encrypt(vaultKey.Key, []byte("vault secret"))
+	require.NoError(t, err)
+
+	m := mocks.NewMockGophKeeperClient(ctrl)
+
+	m.EXPECT().GetVaultKey(gomock.Any(), gomock.Any()).Return(&pb.GetVaultKeyResponse{SealedKey: sealedKey}, nil)
+	m.EXPECT().GetSecret(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ *pb.GetSecretRequest, _ ...grpc.CallOption) (*pb.GetSecretResponse, error) {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			require.Equal(t, []string{"acme/oncall"}, md.Get("vault"))
+			return &pb.GetSecretResponse{Secret: &pb.Secret{
+				Name:       "db",
+				Type:       pb.SecretType_TEXT,
+				Data:       data,
+				Version:    1,
+				EncVersion: helpers.EncVersionClient,
+			}}, nil
+		})
+
+	svc := NewService()
+	svc.clientGRPC = m
+
+	require.Error(t, svc.SetVault("acme"))
+	require.NoError(t, svc.SetVault("acme/oncall"))
+
+	key, err := svc.VaultKey("token", testKey)
+	require.NoError(t, err)
+	secret, err := svc.GetSecret("token", key, "db")
+	require.NoError(t, err)
+	require.Equal(t, []byte("vault secret"), secret.Data)
+}
+
+func TestListAuditEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	created := time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC)
+
+	m := mocks.NewMockGophKeeperClient(ctrl)
+
+	m.EXPECT().ListAuditEvents(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *pb.ListAuditEventsRequest, _ ...grpc.CallOption) (*pb.ListAuditEventsResponse,
+			error) {
+			require.True(t, from.Equal(in.GetFrom().AsTime()))
+			require.Nil(t, in.GetTo())
+			require.Equal(t, int32(10), in.GetLimit())
+			return &pb.ListAuditEventsResponse{Events: []*pb.AuditEvent{{
+				Id:        7,
+				Method:    "GetSecret",
+				Name:      "work/db",
+				Owner:     "user",
+				ClientIp:  "192.0.2.10",
+				Code:      "OK",
+				CreatedAt: timestamppb.New(created),
+			}}}, nil
+		})
+
+	svc := NewService()
+	svc.clientGRPC = m
+
+	events, err := svc.ListAuditEvents("token", models.AuditFilter{From: from, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, []*models.AuditEvent{{
+		ID:        7,
+		Method:    "GetSecret",
+		Name:      "work/db",
+		Owner:     "user",
+		ClientIP:  "192.0.2.10",
+		Code:      "OK",
+		CreatedAt: created,
+	}}, events)
+}
+
+func TestVerifyAuditLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mocks.NewMockGophKeeperClient(ctrl)
+
+	gomock.InOrder(
+		m.EXPECT().VerifyAuditLog(gomock.Any(), gomock.Any()).Return(&pb.VerifyAuditLogResponse{
+			Verified:    41,
+			Unchained:   3,
+			BrokenId:    42,
+			Reason:      "event hash mismatch, event was changed",
+			Checkpoints: 2,
+		}, nil),
+		m.EXPECT().VerifyAuditLog(gomock.Any(), gomock.Any()).Return(nil,
+			status.Error(codes.Unavailable, "server is down")),
+	)
+
+	svc := NewService()
+	svc.clientGRPC = m
+
+	result, err := svc.VerifyAuditLog("token")
+	require.NoError(t, err)
+	require.Equal(t, &models.AuditVerification{
+		Verified:    41,
+		Unchained:   3,
+		BrokenID:    42,
+		Reason:      "event hash mismatch, event was changed",
+		Checkpoints: 2,
+	}, result)
+
+	_, err = svc.VerifyAuditLog("token")
+	require.ErrorIs(t, err, ErrServerUnavailable)
+}
+
+func TestRotateKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newKey := helpers.NewSecretKey("new-encryptionkey", "user")
+	oldPrivate, err := testKey.ShareKey()
+	require.NoError(t, err)
+	newPrivate, err := newKey.ShareKey()
+	require.NoError(t, err)
+
+	stored := map[string]*pb.Secret{}
+	for name, key := range map[string]*helpers.SecretKey{"text01": testKey, "text03": newKey} {
+		data, err := helpers.Encrypt(key.Key, []byte(name))
+		require.NoError(t, err)
+		stored[name] = &pb.Secret{Name: name, Type: pb.SecretType_TEXT, Data: data, Version: 2,
+			EncVersion: helpers.EncVersionClient}
+	}
+	stored["text01"].Labels = map[string]string{"meta": legacyMeta}
+	// past versions of text01 are under the old key and under a key replaced before, text03 history is
+	// already re-encrypted, the past version of file01 is kept in chunks
+	history := map[string]map[int64]*pb.Secret{"text01": {}, "text03": {}, "file01": {
+		1: {Name: "file01", Type: pb.SecretType_FILE, Version: 1, EncVersion: helpers.EncVersionChunked},
+	}}
+	for name, keys := range map[string][]*helpers.SecretKey{
+		"text01": {helpers.NewSecretKey("previous-encryptionkey", "user"), testKey},
+		"text03": {newKey},
+	} {
+		for i, key := range keys {
+			data, err := helpers.Encrypt(key.Key, []byte(name))
+			require.NoError(t, err)
+			history[name][int64(i+1)] = &pb.Secret{Name: name, Type: pb.SecretType_TEXT, Data: data,
+				Version: int64(i + 1), EncVersion: helpers.EncVersionClient}
+		}
+	}
+	chunk, err := helpers.EncryptChunk(testKey.Key, 0, true, []byte("file content"))
+	require.NoError(t, err)
+	vaultKey, err := helpers.NewVaultKey()
+	require.NoError(t, err)
+	sealedKey, err := helpers.SealShared(oldPrivate.PublicKey().Bytes(), vaultKey.Key)
+	require.NoError(t, err)
+
+	m := mocks.NewMockGophKeeperClient(ctrl)
+	download := mocks.NewMockGophKeeper_DownloadSecretClient(ctrl)
+	upload := mocks.NewMockGophKeeper_UploadSecretClient(ctrl)
+
+	m.EXPECT().ListSecrets(gomock.Any(), gomock.Any()).Return(&pb.ListSecretsResponse{
+		Items: []*pb.SecretItem{
+			{Name: "text01", Type: pb.SecretType_TEXT, Version: 2, EncVersion: helpers.EncVersionClient},
+			{Name: "text02", Type: pb.SecretType_TEXT, Version: 3, EncVersion: helpers.EncVersionClient},
+			{Name: "text03", Type: pb.SecretType_TEXT, Version: 2, EncVersion: helpers.EncVersionClient},
+			{Name: "file01", Type: pb.SecretType_FILE, Version: 1, EncVersion: helpers.EncVersionChunked},
+		},
+	}, nil)
+	// text03 is re-encrypted, but not journaled by the interrupted rotation, so it is read with both keys
+	m.EXPECT().GetSecret(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *pb.GetSecretRequest, _ ...grpc.CallOption) (*pb.GetSecretResponse, error) {
+			if in.GetVersion() != 0 {
+				return &pb.GetSecretResponse{Secret: history[in.GetName()][in.GetVersion()]}, nil
+			}
+			return &pb.GetSecretResponse{Secret: stored[in.GetName()]}, nil
+		}).Times(7)
+	m.EXPECT().ListSecretVersions(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *pb.ListSecretVersionsRequest,
+			_ ...grpc.CallOption) (*pb.ListSecretVersionsResponse, error) {
+			resp := &pb.ListSecretVersionsResponse{}
+			for version := int64(1); version <= int64(len(history[in.GetName()]))+1; version++ {
+				resp.Versions = append(resp.Versions, &pb.SecretVersionItem{Version: version})
+			}
+			return resp, nil
+		}).Times(3)
+	m.EXPECT().ReplaceSecretVersions(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *pb.ReplaceSecretVersionsRequest, _ ...grpc.CallOption) (*pb.Empty, error) {
+			if in.GetName() == "file01" {
+				// past versions of chunked secrets can not be re-encrypted in place
+				require.Equal(t, []int64{1}, in.GetDelete())
+				require.Empty(t, in.GetVersions())
+				return &pb.Empty{}, nil
+			}
+			require.Equal(t, "text01", in.GetName())
+			require.Empty(t, in.GetDelete())
+			require.Len(t, in.GetVersions(), 1)
+			require.Equal(t, int64(2), in.GetVersions()[0].GetVersion())
+			data, err := helpers.Decrypt(newKey.Key, in.GetVersions()[0].GetData())
+			require.NoError(t, err)
+			require.Equal(t, []byte("text01"), data)
+			return &pb.Empty{}, nil
+		}).Times(2)
+	m.EXPECT().UpdateSecret(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *pb.UpdateSecretRequest, _ ...grpc.CallOption) (*pb.Empty, error) {
+			require.Equal(t, "text01", in.Secret.GetName())
+			require.Equal(t, int64(2), in.Secret.GetVersion())
+			require.Equal(t, map[string]string{"meta": legacyMeta}, in.Secret.GetLabels())
+			data, err := helpers.Decrypt(newKey.Key, in.Secret.GetData())
+			require.NoError(t, err)
+			require.Equal(t, []byte("text01"), data)
+			return &pb.Empty{}, nil
+		})
+
+	m.EXPECT().DownloadSecret(gomock.Any(), &pb.DownloadSecretRequest{Name: "file01"}).Return(download, nil)
+	gomock.InOrder(
+		download.EXPECT().Recv().Return(&pb.DownloadSecretResponse{
+			Payload: &pb.DownloadSecretResponse_Secret{Secret: &pb.Secret{
+				Name: "file01", Type: pb.SecretType_FILE, Version: 1, EncVersion: helpers.EncVersionChunked}},
+		}, nil),
+		download.EXPECT().Recv().Return(&pb.DownloadSecretResponse{
+			Payload: &pb.DownloadSecretResponse_Chunk{Chunk: chunk},
+		}, nil),
+		download.EXPECT().Recv().Return(nil, io.EOF),
+	)
+	m.EXPECT().UploadSecret(gomock.Any()).Return(upload, nil)
+	upload.EXPECT().Send(gomock.Any()).DoAndReturn(func(req *pb.UploadSecretRequest) error {
+		if header := req.GetHeader(); header != nil {
+			require.True(t, header.GetUpdate())
+			require.Equal(t, int64(1), header.Secret.GetVersion())
+			return nil
+		}
+		data, err := helpers.DecryptChunk(newKey.Key, 0, true, req.GetChunk())
+		require.NoError(t, err)
+		require.Equal(t, []byte("file content"), data)
+		return nil
+	}).Times(2)
+	upload.EXPECT().CloseAndRecv().Return(&pb.UploadSecretResponse{Version: 2}, nil)
+
+	m.EXPECT().ListVaults(gomock.Any(), gomock.Any()).Return(&pb.ListVaultsResponse{
+		Vaults: []*pb.Vault{{Org: "acme", Name: "oncall"}, {Org: "acme", Name: "payments"}},
+	}, nil)
+	m.EXPECT().GetVaultKey(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ *pb.Empty, _ ...grpc.CallOption) (*pb.GetVaultKeyResponse, error) {
+			// the user is a member of acme/payments by organization only
+			md, _ := metadata.FromOutgoingContext(ctx)
+			if md.Get("vault")[0] == "acme/payments" {
+				return nil, status.Error(codes.NotFound, "vault key not found")
+			}
+			return &pb.GetVaultKeyResponse{SealedKey: sealedKey}, nil
+		}).Times(2)
+	m.EXPECT().SetVaultKey(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, in *pb.SetVaultKeyRequest, _ ...grpc.CallOption) (*pb.Empty, error) {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			require.Equal(t, []string{"acme/oncall"}, md.Get("vault"))
+			key, err := helpers.OpenShared(newPrivate, in.GetSealedKey())
+			require.NoError(t, err)
+			require.Equal(t, vaultKey.Key, key)
+			return &pb.Empty{}, nil
+		})
+	m.EXPECT().SetPublicKey(gomock.Any(), &pb.SetPublicKeyRequest{
+		PublicKey: newPrivate.PublicKey().Bytes(),
+	}).Return(&pb.Empty{}, nil)
+
+	svc := NewService()
+	svc.clientGRPC = m
+
+	var journaled []models.RotatedSecret
+	result, err := svc.RotateKey("token", testKey, newKey,
+		map[string]models.RotatedSecret{"text02": {Name: "text02", From: 2, To: 3}},
+		func(r models.RotatedSecret) error {
+			journaled = append(journaled, r)
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, &models.KeyRotation{Rotated: 2, Skipped: 2, Vaults: 1, Versions: 1, Deleted: 1,
+		Unreadable: 1}, result)
+	require.Equal(t, []models.RotatedSecret{
+		{Name: "text01", From: 2, To: 3},
+		{Name: "text03", From: 2, To: 2},
+		{Name: "file01", From: 1, To: 2},
+	}, journaled)
+
+	t.Run("Fail_VersionConflict", func(t *testing.T) {
+		m.EXPECT().ListSecrets(gomock.Any(), gomock.Any()).Return(&pb.ListSecretsResponse{
+			Items: []*pb.SecretItem{{Name: "text01", Type: pb.SecretType_TEXT, Version: 2}},
+		}, nil)
+		m.EXPECT().GetSecret(gomock.Any(), gomock.Any()).Return(&pb.GetSecretResponse{Secret: stored["text01"]}, nil)
+		m.EXPECT().UpdateSecret(gomock.Any(), gomock.Any()).Return(nil,
+			status.Error(codes.Aborted, "expected version 2, current version 3"))
+
+		_, err := svc.RotateKey("token", testKey, newKey, nil, func(r models.RotatedSecret) error {
+			t.Errorf("secret %s must not be journaled", r.Name)
+			return nil
+		})
+		require.ErrorIs(t, err, ErrVersionConflict)
+	})
+
+	t.Run("Fail_Vault", func(t *testing.T) {
+		require.NoError(t, svc.SetVault("acme/oncall"))
+		defer func() {
+			require.NoError(t, svc.SetVault(""))
+		}()
+		_, err := svc.RotateKey("token", testKey, newKey, nil, nil)
+		require.Error(t, err)
+	})
+}
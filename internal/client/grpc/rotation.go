@@ -0,0 +1,254 @@
+package grpcclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
+	"github.com/vkupriya/gophkeeper/internal/client/models"
+	pb "github.com/vkupriya/gophkeeper/internal/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RotateKey - function re-encrypting all secrets of the user under newKey. Every secret is replaced by a
+// new version with the check of its current version, so a concurrent change fails the rotation rather
+// than being lost, then its past versions are re-encrypted. Secrets in rotated, re-encrypted by an
+// interrupted rotation and not changed since, are skipped, journal is called for every re-encrypted
+// secret, so that the rotation can be resumed. Then vault keys of the user are sealed with the new
+// public key and the key is registered.
+func (s *Service) RotateKey(t string, oldKey *helpers.SecretKey, newKey *helpers.SecretKey,
+	rotated map[string]models.RotatedSecret, journal func(rotated models.RotatedSecret) error) (*models.KeyRotation,
+	error) {
+	if s.vault != "" {
+		return nil, errors.New("vault secrets are encrypted with the vault key, it is not rotated")
+	}
+
+	var items []*pb.SecretItem
+	in := &pb.ListSecretsRequest{Recursive: true}
+	for {
+		page, err := s.listSecretsPage(t, in)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page.GetItems()...)
+		if page.GetNextPageToken() == "" {
+			break
+		}
+		in.PageToken = page.GetNextPageToken()
+	}
+
+	result := &models.KeyRotation{}
+	for _, item := range items {
+		if r, ok := rotated[item.GetName()]; ok && r.To == item.GetVersion() {
+			result.Skipped++
+			continue
+		}
+		r, err := s.rotateSecret(t, oldKey, newKey, item)
+		if err != nil {
+			return result, fmt.Errorf("failed to re-encrypt secret %s: %w", item.GetName(), err)
+		}
+		if err = s.rotateHistory(t, oldKey, newKey, r.Name, r.To, result); err != nil {
+			return result, fmt.Errorf("failed to re-encrypt history of secret %s: %w", item.GetName(), err)
+		}
+		if err = journal(*r); err != nil {
+			return result, err
+		}
+		if r.From == r.To {
+			result.Skipped++
+		} else {
+			result.Rotated++
+		}
+	}
+
+	var err error
+	if result.Vaults, err = s.resealVaultKeys(t, oldKey, newKey); err != nil {
+		return result, err
+	}
+	if err = s.RegisterPublicKey(t, newKey); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// rotateSecret re-encrypts the secret under newKey. Secret which is not opened by oldKey is checked
+// with newKey, as rotation may be interrupted after the secret was updated, but before it was journaled.
+func (s *Service) rotateSecret(t string, oldKey *helpers.SecretKey, newKey *helpers.SecretKey,
+	item *pb.SecretItem) (*models.RotatedSecret, error) {
+	if item.GetEncVersion() == helpers.EncVersionChunked {
+		return s.rotateChunked(t, oldKey, newKey, item.GetName())
+	}
+
+	secret, err := s.GetSecret(t, oldKey, item.GetName())
+	if err != nil {
+		if secret, errNew := s.GetSecret(t, newKey, item.GetName()); errNew == nil {
+			return &models.RotatedSecret{Name: secret.Name, From: secret.Version, To: secret.Version}, nil
+		}
+		return nil, err
+	}
+	// secret version is the expected current version on the server
+	if err = s.UpdateSecret(t, newKey, secret); err != nil {
+		return nil, err
+	}
+	return &models.RotatedSecret{Name: secret.Name, From: secret.Version, To: secret.Version + 1}, nil
+}
+
+// rotateHistory re-encrypts versions of the secret before current under newKey. The server replaces them
+// in one transaction, so the history is never left half rotated. Past versions kept in chunks are deleted
+// instead, versions opened by newKey are already rotated, versions opened by neither key are left.
+func (s *Service) rotateHistory(t string, oldKey *helpers.SecretKey, newKey *helpers.SecretKey, name string,
+	current int64, result *models.KeyRotation) error {
+	versions, err := s.ListSecretVersions(t, name)
+	if err != nil {
+		return err
+	}
+
+	in := &pb.ReplaceSecretVersionsRequest{Name: name}
+	var unreadable int
+	for _, v := range versions {
+		if v.Version >= current {
+			continue
+		}
+		var resp *pb.GetSecretResponse
+		err = s.withAuth(t, func(ctx context.Context) error {
+			var err error
+			resp, err = s.clientGRPC.GetSecret(ctx, &pb.GetSecretRequest{Name: name, Version: v.Version})
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("error in getting version %d: %w", v.Version, err)
+		}
+
+		secret := resp.GetSecret()
+		if secret.GetEncVersion() == helpers.EncVersionChunked {
+			in.Delete = append(in.Delete, v.Version)
+			continue
+		}
+		sum := sha256.Sum256(secret.GetData())
+		if err = verifyHash(secret.GetHash(), sum[:]); err != nil {
+			return fmt.Errorf("version %d: %w", v.Version, err)
+		}
+		data, err := helpers.DecryptSecret(oldKey, secret.GetEncVersion(), secret.GetData())
+		if err != nil {
+			if _, errNew := helpers.DecryptSecret(newKey, secret.GetEncVersion(), secret.GetData()); errNew != nil {
+				unreadable++
+			}
+			continue
+		}
+		if data, err = helpers.Encrypt(newKey.Key, data); err != nil {
+			return fmt.Errorf("failed to encrypt version %d: %w", v.Version, err)
+		}
+		in.Versions = append(in.Versions, &pb.SecretVersionData{
+			Version:    v.Version,
+			Data:       data,
+			EncVersion: helpers.EncVersionClient,
+		})
+	}
+
+	if len(in.GetVersions()) != 0 || len(in.GetDelete()) != 0 {
+		err = s.withAuth(t, func(ctx context.Context) error {
+			_, err := s.clientGRPC.ReplaceSecretVersions(ctx, in)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("error in replacing versions: %w", err)
+		}
+	}
+	result.Versions += len(in.GetVersions())
+	result.Deleted += len(in.GetDelete())
+	result.Unreadable += unreadable
+	return nil
+}
+
+// rotateChunked re-encrypts the secret uploaded by chunks. Decrypted data is kept in a temporary file
+// readable by the user only, it is removed once the secret is uploaded.
+func (s *Service) rotateChunked(t string, oldKey *helpers.SecretKey, newKey *helpers.SecretKey,
+	name string) (*models.RotatedSecret, error) {
+	f, err := os.CreateTemp("", "gophkeeper-rotate-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+
+	secret, err := s.DownloadSecret(t, oldKey, name, 0, f, nil)
+	if err != nil {
+		if secret, errNew := s.DownloadSecret(t, newKey, name, 0, io.Discard, nil); errNew == nil {
+			return &models.RotatedSecret{Name: secret.Name, From: secret.Version, To: secret.Version}, nil
+		}
+		return nil, err
+	}
+	if err = s.UploadSecret(t, newKey, secret, true, f, nil); err != nil {
+		return nil, err
+	}
+	return &models.RotatedSecret{Name: secret.Name, From: secret.Version, To: secret.Version + 1}, nil
+}
+
+// resealVaultKeys seals vault keys of the user with the public key of newKey and returns their number.
+// Keys already sealed with it are skipped, vaults where the user is a member by organization only have
+// no key of the user.
+func (s *Service) resealVaultKeys(t string, oldKey *helpers.SecretKey, newKey *helpers.SecretKey) (int, error) {
+	oldPrivate, err := oldKey.ShareKey()
+	if err != nil {
+		return 0, err
+	}
+	newPrivate, err := newKey.ShareKey()
+	if err != nil {
+		return 0, err
+	}
+	vaults, err := s.ListVaults(t)
+	if err != nil {
+		return 0, err
+	}
+
+	current := s.vault
+	defer func() {
+		s.vault = current
+	}()
+
+	var resealed int
+	for _, v := range vaults {
+		s.vault = v.Org + "/" + v.Name
+
+		var resp *pb.GetVaultKeyResponse
+		err = s.withAuth(t, func(ctx context.Context) error {
+			var err error
+			resp, err = s.clientGRPC.GetVaultKey(ctx, &pb.Empty{})
+			return err
+		})
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				continue
+			}
+			return resealed, fmt.Errorf("error in getting key of vault %s: %w", s.vault, err)
+		}
+
+		vaultKey, err := helpers.OpenShared(oldPrivate, resp.GetSealedKey())
+		if err != nil {
+			if _, errNew := helpers.OpenShared(newPrivate, resp.GetSealedKey()); errNew == nil {
+				continue
+			}
+			return resealed, fmt.Errorf("failed to open key of vault %s: %w", s.vault, err)
+		}
+		sealedKey, err := helpers.SealShared(newPrivate.PublicKey().Bytes(), vaultKey)
+		if err != nil {
+			return resealed, fmt.Errorf("failed to seal key of vault %s: %w", s.vault, err)
+		}
+
+		err = s.withAuth(t, func(ctx context.Context) error {
+			_, err := s.clientGRPC.SetVaultKey(ctx, &pb.SetVaultKeyRequest{SealedKey: sealedKey})
+			return err
+		})
+		if err != nil {
+			return resealed, fmt.Errorf("error in setting key of vault %s: %w", s.vault, err)
+		}
+		resealed++
+	}
+	return resealed, nil
+}
@@ -0,0 +1,233 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
+	"github.com/vkupriya/gophkeeper/internal/client/models"
+	pb "github.com/vkupriya/gophkeeper/internal/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RegisterPublicKey - function registering public share key of the user on gophkeeper server,
+// secrets shared with the user are sealed with it. Key is derived from the secret key, so
+// registration is idempotent.
+func (s *Service) RegisterPublicKey(t string, key *helpers.SecretKey) error {
+	private, err := key.ShareKey()
+	if err != nil {
+		return err
+	}
+	err = s.withAuth(t, func(ctx context.Context) error {
+		_, err := s.clientGRPC.SetPublicKey(ctx, &pb.SetPublicKeyRequest{
+			PublicKey: private.PublicKey().Bytes(),
+		})
+		return err
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			return ErrServerUnavailable
+		}
+		return fmt.Errorf("error in registering public key: %w", err)
+	}
+	return nil
+}
+
+// GetPublicKey - function getting public share key of the user from gophkeeper server.
+func (s *Service) GetPublicKey(t string, user string) ([]byte, error) {
+	var resp *pb.GetPublicKeyResponse
+	err := s.withAuth(t, func(ctx context.Context) error {
+		var err error
+		resp, err = s.clientGRPC.GetPublicKey(ctx, &pb.GetPublicKeyRequest{Login: user})
+		return err
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			return nil, ErrServerUnavailable
+		}
+		return nil, fmt.Errorf("error in getting public key of %s: %w", user, err)
+	}
+	return resp.GetPublicKey(), nil
+}
+
+// ShareSecret - function sharing named secret with the recipient, secret data is decrypted and
+// sealed with public key of the recipient locally. Fingerprint of the recipient key is returned
+// to be verified by users out of band.
+func (s *Service) ShareSecret(t string, key *helpers.SecretKey, name string, recipient string,
+	access string) (string, error) {
+	publicKey, err := s.GetPublicKey(t, recipient)
+	if err != nil {
+		return "", err
+	}
+	secret, err := s.GetSecret(t, key, name)
+	if err != nil {
+		return "", err
+	}
+	data, err := helpers.SealShared(publicKey, secret.Data)
+	if err != nil {
+		return "", fmt.Errorf("failed to seal secret for %s: %w", recipient, err)
+	}
+
+	err = s.withAuth(t, func(ctx context.Context) error {
+		_, err := s.clientGRPC.ShareSecret(ctx, &pb.ShareSecretRequest{
+			Name:       name,
+			Recipient:  recipient,
+			Access:     AccessToProto(access),
+			Data:       data,
+			EncVersion: helpers.EncVersionShared,
+			Version:    secret.Version,
+		})
+		return err
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			return "", ErrServerUnavailable
+		}
+		return "", fmt.Errorf("error in sharing secret: %w", err)
+	}
+	return helpers.KeyFingerprint(publicKey), nil
+}
+
+// RevokeShare - function revoking share of named secret with the recipient.
+func (s *Service) RevokeShare(t string, name string, recipient string) error {
+	err := s.withAuth(t, func(ctx context.Context) error {
+		_, err := s.clientGRPC.RevokeShare(ctx, &pb.RevokeShareRequest{
+			Name:      name,
+			Recipient: recipient,
+		})
+		return err
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			return ErrServerUnavailable
+		}
+		return fmt.Errorf("error in revoking share: %w", err)
+	}
+	return nil
+}
+
+// ListSharedSecrets - function getting list of secrets shared with the user, or secrets shared
+// by the user if outgoing is set.
+func (s *Service) ListSharedSecrets(t string, outgoing bool) ([]*models.SharedSecretItem, error) {
+	var resp *pb.ListSharedSecretsResponse
+	err := s.withAuth(t, func(ctx context.Context) error {
+		var err error
+		resp, err = s.clientGRPC.ListSharedSecrets(ctx, &pb.ListSharedSecretsRequest{Outgoing: outgoing})
+		return err
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			return nil, ErrServerUnavailable
+		}
+		return nil, fmt.Errorf("error in getting list of shared secrets: %w", err)
+	}
+
+	items := make([]*models.SharedSecretItem, 0, len(resp.GetItems()))
+	for _, item := range resp.GetItems() {
+		items = append(items, &models.SharedSecretItem{
+			SharedAt:       item.GetSharedAt().AsTime(),
+			Owner:          item.GetOwner(),
+			Name:           item.GetName(),
+			Recipient:      item.GetRecipient(),
+			Access:         ProtoToAccess(item.GetAccess()),
+			Type:           ProtoToType(item.GetType()),
+			Version:        item.GetVersion(),
+			CurrentVersion: item.GetCurrentVersion(),
+		})
+	}
+	return items, nil
+}
+
+// GetSharedSecret - function getting secret shared by the owner with the user, secret data is
+// opened with share key of the user locally. Access level of the share is returned too.
+func (s *Service) GetSharedSecret(t string, key *helpers.SecretKey, owner string,
+	name string) (*models.Secret, string, error) {
+	var resp *pb.GetSharedSecretResponse
+	err := s.withAuth(t, func(ctx context.Context) error {
+		var err error
+		resp, err = s.clientGRPC.GetSharedSecret(ctx, &pb.GetSharedSecretRequest{
+			Owner: owner,
+			Name:  name,
+		})
+		return err
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			return nil, "", ErrServerUnavailable
+		}
+		return nil, "", fmt.Errorf("error in getting shared secret: %w", err)
+	}
+
+	data, err := helpers.DecryptSecret(key, resp.GetSecret().GetEncVersion(), resp.GetSecret().GetData())
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decrypt shared secret %s: %w", name, err)
+	}
+	secret := &models.Secret{
+		Name:    resp.GetSecret().GetName(),
+		Type:    ProtoToType(resp.GetSecret().GetType()),
+		Labels:  resp.GetSecret().GetLabels(),
+		Data:    data,
+		Version: resp.GetSecret().GetVersion(),
+	}
+	return secret, ProtoToAccess(resp.GetAccess()), nil
+}
+
+// UpdateSharedSecret - function updating secret shared read-write by the owner with the user.
+// New data is sealed for both the owner and the user, version is the expected current version
+// of the secret, ErrVersionConflict is returned if it does not match.
+func (s *Service) UpdateSharedSecret(t string, key *helpers.SecretKey, owner string, name string,
+	data []byte, version int64) error {
+	ownerKey, err := s.GetPublicKey(t, owner)
+	if err != nil {
+		return err
+	}
+	ownerData, err := helpers.SealShared(ownerKey, data)
+	if err != nil {
+		return fmt.Errorf("failed to seal secret for %s: %w", owner, err)
+	}
+	private, err := key.ShareKey()
+	if err != nil {
+		return err
+	}
+	ownData, err := helpers.SealShared(private.PublicKey().Bytes(), data)
+	if err != nil {
+		return fmt.Errorf("failed to seal secret: %w", err)
+	}
+
+	err = s.withAuth(t, func(ctx context.Context) error {
+		_, err := s.clientGRPC.UpdateSharedSecret(ctx, &pb.UpdateSharedSecretRequest{
+			Owner:      owner,
+			Name:       name,
+			Version:    version,
+			OwnerData:  ownerData,
+			Data:       ownData,
+			EncVersion: helpers.EncVersionShared,
+		})
+		return err
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unavailable:
+			return ErrServerUnavailable
+		case codes.Aborted:
+			return fmt.Errorf("%w: %s", ErrVersionConflict, status.Convert(err).Message())
+		}
+		return fmt.Errorf("failed to update shared secret: %w", err)
+	}
+	return nil
+}
+
+func AccessToProto(access string) pb.ShareAccess {
+	if access == models.AccessReadWrite {
+		return pb.ShareAccess_READ_WRITE
+	}
+	return pb.ShareAccess_READ_ONLY
+}
+
+func ProtoToAccess(access pb.ShareAccess) string {
+	if access == pb.ShareAccess_READ_WRITE {
+		return models.AccessReadWrite
+	}
+	return models.AccessReadOnly
+}
@@ -0,0 +1,235 @@
+package grpcclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
+	"github.com/vkupriya/gophkeeper/internal/client/models"
+	pb "github.com/vkupriya/gophkeeper/internal/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrSecretHeaderMissing = errors.New("secret header is missing in server response")
+	ErrSecretTruncated     = errors.New("secret data is truncated")
+)
+
+// UploadSecret streams secret data read from r to gophkeeper server by encrypted chunks, so
+// the whole secret is never kept in memory. Secret is updated if update is set, secret.Version is
+// the expected current version then. Reader is rewound when the call is retried after token refresh,
+// progress is called with number of bytes sent, it may be nil.
+func (s *Service) UploadSecret(t string, key *helpers.SecretKey, secret *models.Secret, update bool,
+	r io.ReadSeeker, progress func(n int64)) error {
+	header := &pb.UploadSecretHeader{
+		Secret: &pb.Secret{
+			Name:       secret.Name,
+			Labels:     secret.Labels,
+			Type:       TypeToProto(secret.Type),
+			Version:    secret.Version,
+			EncVersion: helpers.EncVersionChunked,
+		},
+		Update: update,
+	}
+
+	err := s.withAuth(t, func(ctx context.Context) error {
+		if _, err := r.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to rewind secret data: %w", err)
+		}
+		return s.upload(ctx, key, header, r, progress)
+	})
+	if err != nil {
+		if status.Code(err) == codes.Aborted {
+			return fmt.Errorf("%w: %s", ErrVersionConflict, status.Convert(err).Message())
+		}
+		return fmt.Errorf("failed to upload secret: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) upload(ctx context.Context, key *helpers.SecretKey, header *pb.UploadSecretHeader,
+	r io.Reader, progress func(n int64)) error {
+	stream, err := s.clientGRPC.UploadSecret(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Send returns io.EOF if the server has closed the stream, actual status is returned by CloseAndRecv.
+	send := func(req *pb.UploadSecretRequest) error {
+		if err := stream.Send(req); err != nil {
+			if errors.Is(err, io.EOF) {
+				_, err = stream.CloseAndRecv()
+			}
+			return err
+		}
+		return nil
+	}
+
+	if err := send(&pb.UploadSecretRequest{
+		Payload: &pb.UploadSecretRequest_Header{Header: header},
+	}); err != nil {
+		return err
+	}
+
+	// next chunk is read ahead to know whether the current one is the last
+	chunk, err := readChunk(r)
+	if err != nil {
+		return err
+	}
+	var sent int64
+	for index := uint64(0); ; index++ {
+		next, err := readChunk(r)
+		if err != nil {
+			return err
+		}
+		last := len(next) == 0
+
+		data, err := helpers.EncryptChunk(key.Key, index, last, chunk)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt chunk: %w", err)
+		}
+		if err := send(&pb.UploadSecretRequest{
+			Payload: &pb.UploadSecretRequest_Chunk{Chunk: data},
+		}); err != nil {
+			return err
+		}
+
+		sent += int64(len(chunk))
+		if progress != nil {
+			progress(sent)
+		}
+		if last {
+			break
+		}
+		chunk = next
+	}
+
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+// readChunk reads up to ChunkSize bytes, empty chunk is returned at the end of data.
+func readChunk(r io.Reader) ([]byte, error) {
+	buf := make([]byte, helpers.ChunkSize)
+	n, err := io.ReadFull(r, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("failed to read secret data: %w", err)
+	}
+	return buf[:n], nil
+}
+
+// DownloadSecret streams decrypted data of given secret version into w, latest version is downloaded
+// when version is 0. Returned secret holds everything but data, progress is called with number of bytes
+// written, it may be nil.
+func (s *Service) DownloadSecret(t string, key *helpers.SecretKey, name string, version int64,
+	w io.Writer, progress func(n int64)) (*models.Secret, error) {
+	var secret *models.Secret
+	err := s.withAuth(t, func(ctx context.Context) error {
+		var err error
+		secret, err = s.download(ctx, key, &pb.DownloadSecretRequest{
+			Name:    name,
+			Version: version,
+		}, w, progress)
+		return err
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unavailable:
+			return nil, ErrServerUnavailable
+		case codes.DataLoss:
+			return nil, fmt.Errorf("%w on server", ErrSecretCorrupted)
+		}
+		return nil, fmt.Errorf("error in downloading a secret: %w", err)
+	}
+	return secret, nil
+}
+
+func (s *Service) download(ctx context.Context, key *helpers.SecretKey, req *pb.DownloadSecretRequest,
+	w io.Writer, progress func(n int64)) (*models.Secret, error) {
+	stream, err := s.clientGRPC.DownloadSecret(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	header := resp.GetSecret()
+	if header == nil {
+		return nil, ErrSecretHeaderMissing
+	}
+	secret := &models.Secret{
+		Name:    header.GetName(),
+		Type:    ProtoToType(header.GetType()),
+		Labels:  header.GetLabels(),
+		Version: header.GetVersion(),
+	}
+
+	var written int64
+	write := func(data []byte) error {
+		if _, err := w.Write(data); err != nil {
+			return fmt.Errorf("failed to write secret data: %w", err)
+		}
+		written += int64(len(data))
+		if progress != nil {
+			progress(written)
+		}
+		return nil
+	}
+
+	// secret was not uploaded by chunks, its data is in the header
+	if header.GetEncVersion() != helpers.EncVersionChunked {
+		sum := sha256.Sum256(header.GetData())
+		if err := verifyHash(header.GetHash(), sum[:]); err != nil {
+			return nil, err
+		}
+		data, err := helpers.DecryptSecret(key, header.GetEncVersion(), header.GetData())
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: %w", ErrSecretUndecryptable, secret.Name, err)
+		}
+		return secret, write(data)
+	}
+
+	// chunk is decrypted when the next one arrives, as only then it is known whether it is the last
+	var chunk []byte
+	var index uint64
+	hash := sha256.New()
+	for received := false; ; received = true {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			if !received {
+				return nil, ErrSecretTruncated
+			}
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if received {
+			if err := decryptChunk(key, index, false, chunk, write); err != nil {
+				return nil, err
+			}
+			index++
+		}
+		chunk = resp.GetChunk()
+		hash.Write(chunk)
+	}
+
+	if err := verifyHash(header.GetHash(), hash.Sum(nil)); err != nil {
+		return nil, err
+	}
+	return secret, decryptChunk(key, index, true, chunk, write)
+}
+
+func decryptChunk(key *helpers.SecretKey, index uint64, last bool, chunk []byte, write func([]byte) error) error {
+	data, err := helpers.DecryptChunk(key.Key, index, last, chunk)
+	if err != nil {
+		return fmt.Errorf("%w, chunk %d: %w", ErrSecretUndecryptable, index, err)
+	}
+	return write(data)
+}
@@ -0,0 +1,235 @@
+package grpcclient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vkupriya/gophkeeper/internal/client/models"
+	shelpers "github.com/vkupriya/gophkeeper/internal/server/helpers"
+	smodels "github.com/vkupriya/gophkeeper/internal/server/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	// certFile and keyFile are PEM files in test temp dir.
+	certFile string
+	keyFile  string
+}
+
+// newTestCert issues certificate signed by parent, self-signed CA certificate is issued if parent is nil.
+func newTestCert(t *testing.T, name string, parent *testCert, tmpl *x509.Certificate) *testCert {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	tmpl.SerialNumber = serial
+	tmpl.Subject = pkix.Name{CommonName: name}
+	tmpl.NotBefore = time.Now().Add(-time.Hour)
+	tmpl.NotAfter = time.Now().Add(time.Hour)
+
+	signer, signerKey := tmpl, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	tc := &testCert{
+		cert:     cert,
+		key:      key,
+		certFile: filepath.Join(dir, name+".crt"),
+		keyFile:  filepath.Join(dir, name+".key"),
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	require.NoError(t, os.WriteFile(tc.certFile, certPEM, 0o600))
+	require.NoError(t, os.WriteFile(tc.keyFile, keyPEM, 0o600))
+	return tc
+}
+
+func newTestCA(t *testing.T, name string) *testCert {
+	t.Helper()
+	return newTestCert(t, name, nil, &x509.Certificate{
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	})
+}
+
+// startTLSServer runs gRPC server with health service only, GophKeeper service
+// is not needed to check transport security.
+func startTLSServer(t *testing.T, c *smodels.Config) string {
+	t.Helper()
+
+	tlsConfig, err := shelpers.NewTLSConfig(c)
+	require.NoError(t, err)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String()
+}
+
+func TestTLSConnection(t *testing.T) {
+	ca := newTestCA(t, "ca")
+	otherCA := newTestCA(t, "other-ca")
+	serverCert := newTestCert(t, "server", ca, &x509.Certificate{
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		DNSNames:    []string{"localhost"},
+	})
+	clientCert := newTestCert(t, "client", ca, &x509.Certificate{
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	foreignClientCert := newTestCert(t, "foreign-client", otherCA, &x509.Certificate{
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+
+	tlsAddr := startTLSServer(t, &smodels.Config{
+		TLSCertFile: serverCert.certFile,
+		TLSKeyFile:  serverCert.keyFile,
+	})
+	mtlsAddr := startTLSServer(t, &smodels.Config{
+		TLSCertFile:     serverCert.certFile,
+		TLSKeyFile:      serverCert.keyFile,
+		TLSClientCAFile: ca.certFile,
+	})
+
+	tests := []struct {
+		name    string
+		addr    string
+		cfg     *models.TLSConfig
+		wantErr bool
+	}{
+		{
+			name: "TLS_Success",
+			addr: tlsAddr,
+			cfg:  &models.TLSConfig{Enabled: true, CAFile: ca.certFile},
+		},
+		{
+			name:    "TLS_Fail_UnknownCA",
+			addr:    tlsAddr,
+			cfg:     &models.TLSConfig{Enabled: true, CAFile: otherCA.certFile},
+			wantErr: true,
+		},
+		{
+			name:    "TLS_Fail_Insecure",
+			addr:    tlsAddr,
+			cfg:     &models.TLSConfig{},
+			wantErr: true,
+		},
+		{
+			name: "MTLS_Success",
+			addr: mtlsAddr,
+			cfg: &models.TLSConfig{
+				Enabled:  true,
+				CAFile:   ca.certFile,
+				CertFile: clientCert.certFile,
+				KeyFile:  clientCert.keyFile,
+			},
+		},
+		{
+			name:    "MTLS_Fail_NoClientCert",
+			addr:    mtlsAddr,
+			cfg:     &models.TLSConfig{Enabled: true, CAFile: ca.certFile},
+			wantErr: true,
+		},
+		{
+			name: "MTLS_Fail_UnknownClientCert",
+			addr: mtlsAddr,
+			cfg: &models.TLSConfig{
+				Enabled:  true,
+				CAFile:   ca.certFile,
+				CertFile: foreignClientCert.certFile,
+				KeyFile:  foreignClientCert.keyFile,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewService()
+			require.NoError(t, NewGRPCClient(svc, tt.addr, tt.cfg))
+			defer func() {
+				_ = svc.connGRPC.Close()
+			}()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			_, err := healthpb.NewHealthClient(svc.connGRPC).Check(ctx, &healthpb.HealthCheckRequest{})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNewGRPCClientTLSConfigErrors(t *testing.T) {
+	ca := newTestCA(t, "ca")
+	notPEM := filepath.Join(t.TempDir(), "ca.txt")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0o600))
+
+	tests := []struct {
+		name string
+		cfg  *models.TLSConfig
+	}{
+		{
+			name: "MissingCAFile",
+			cfg:  &models.TLSConfig{Enabled: true, CAFile: filepath.Join(t.TempDir(), "missing.crt")},
+		},
+		{
+			name: "NotPEMCAFile",
+			cfg:  &models.TLSConfig{Enabled: true, CAFile: notPEM},
+		},
+		{
+			name: "CertWithoutKey",
+			cfg:  &models.TLSConfig{Enabled: true, CAFile: ca.certFile, CertFile: ca.certFile},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Error(t, NewGRPCClient(NewService(), "127.0.0.1:0", tt.cfg))
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/vkupriya/gophkeeper/internal/client/helpers"
+	"github.com/vkupriya/gophkeeper/internal/client/models"
+	pb "github.com/vkupriya/gophkeeper/internal/proto"
+	"github.com/vkupriya/gophkeeper/internal/vault"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SetVault switches secret methods of the service to secrets of the vault 'org/vault',
+// empty reference switches them back to secrets of the user.
+func (s *Service) SetVault(ref string) error {
+	if ref != "" {
+		if _, _, err := vault.Parse(ref); err != nil {
+			return fmt.Errorf("invalid vault: %w", err)
+		}
+	}
+	s.vault = ref
+	return nil
+}
+
+// CreateVault - function creating vault of the organization on gophkeeper server, organization is
+// created with the user as its owner if it does not exist. Vault key is generated locally and
+// sealed with public key of the user.
+func (s *Service) CreateVault(t string, key *helpers.SecretKey, org string, name string) error {
+	private, err := key.ShareKey()
+	if err != nil {
+		return err
+	}
+	vaultKey, err := helpers.NewVaultKey()
+	if err != nil {
+		return err
+	}
+	sealedKey, err := helpers.SealShared(private.PublicKey().Bytes(), vaultKey.Key)
+	if err != nil {
+		return fmt.Errorf("failed to seal vault key: %w", err)
+	}
+
+	err = s.withAuth(t, func(ctx context.Context) error {
+		_, err := s.clientGRPC.CreateVault(ctx, &pb.CreateVaultRequest{
+			Org:       org,
+			Name:      name,
+			SealedKey: sealedKey,
+		})
+		return err
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			return ErrServerUnavailable
+		}
+		return fmt.Errorf("error in creating vault %s/%s: %w", org, name, err)
+	}
+	return nil
+}
+
+// ListVaults - function getting vaults where the user is a member from gophkeeper server.
+func (s *Service) ListVaults(t string) ([]*models.Vault, error) {
+	var resp *pb.ListVaultsResponse
+	err := s.withAuth(t, func(ctx context.Context) error {
+		var err error
+		resp, err = s.clientGRPC.ListVaults(ctx, &pb.Empty{})
+		return err
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			return nil, ErrServerUnavailable
+		}
+		return nil, fmt.Errorf("error in getting list of vaults: %w", err)
+	}
+
+	vaults := make([]*models.Vault, 0, len(resp.GetVaults()))
+	for _, v := range resp.GetVaults() {
+		vaults = append(vaults, &models.Vault{
+			Org:  v.GetOrg(),
+			Name: v.GetName(),
+			Role: ProtoToRole(v.GetRole()),
+		})
+	}
+	return vaults, nil
+}
+
+// VaultKey - function getting key of the vault set by SetVault, the key is sealed for the user
+// on gophkeeper server and opened locally.
+func (s *Service) VaultKey(t string, key *helpers.SecretKey) (*helpers.SecretKey, error) {
+	if s.vault == "" {
+		return nil, errors.New("vault is not set")
+	}
+	var resp *pb.GetVaultKeyResponse
+	err := s.withAuth(t, func(ctx context.Context) error {
+		var err error
+		resp, err = s.clientGRPC.GetVaultKey(ctx, &pb.Empty{})
+		return err
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			return nil, ErrServerUnavailable
+		}
+		return nil, fmt.Errorf("error in getting key of vault %s: %w", s.vault, err)
+	}
+
+	private, err := key.ShareKey()
+	if err != nil {
+		return nil, err
+	}
+	vaultKey, err := helpers.OpenShared(private, resp.GetSealedKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open key of vault %s: %w", s.vault, err)
+	}
+	return &helpers.SecretKey{Key: vaultKey}, nil
+}
+
+// AddVaultMember - function adding member to the vault or changing the member role, empty vault
+// is the organization. Vault key of the user is sealed locally with public key of the member.
+func (s *Service) AddVaultMember(t string, key *helpers.SecretKey, org string, name string, login string,
+	role string) error {
+	var sealedKey []byte
+	if name != "" {
+		publicKey, err := s.GetPublicKey(t, login)
+		if err != nil {
+			return err
+		}
+		vaultKey, err := s.vaultKeyOf(t, key, org+"/"+name)
+		if err != nil {
+			return err
+		}
+		sealedKey, err = helpers.SealShared(publicKey, vaultKey.Key)
+		if err != nil {
+			return fmt.Errorf("failed to seal vault key for %s: %w", login, err)
+		}
+	}
+
+	err := s.withAuth(t, func(ctx context.Context) error {
+		_, err := s.clientGRPC.AddVaultMember(ctx, &pb.VaultMemberRequest{
+			Org:       org,
+			Vault:     name,
+			Login:     login,
+			Role:      RoleToProto(role),
+			SealedKey: sealedKey,
+		})
+		return err
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			return ErrServerUnavailable
+		}
+		return fmt.Errorf("error in adding member %s to vault %s/%s: %w", login, org, name, err)
+	}
+	return nil
+}
+
+// RemoveVaultMember - function removing member from the vault, empty vault is the organization.
+func (s *Service) RemoveVaultMember(t string, org string, name string, login string) error {
+	err := s.withAuth(t, func(ctx context.Context) error {
+		_, err := s.clientGRPC.RemoveVaultMember(ctx, &pb.VaultMemberRequest{
+			Org:   org,
+			Vault: name,
+			Login: login,
+		})
+		return err
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			return ErrServerUnavailable
+		}
+		return fmt.Errorf("error in removing member %s from vault %s/%s: %w", login, org, name, err)
+	}
+	return nil
+}
+
+// ListVaultMembers - function getting members of the vault and its organization, empty vault
+// is the organization.
+func (s *Service) ListVaultMembers(t string, org string, name string) ([]*models.VaultMember, error) {
+	var resp *pb.ListVaultMembersResponse
+	err := s.withAuth(t, func(ctx context.Context) error {
+		var err error
+		resp, err = s.clientGRPC.ListVaultMembers(ctx, &pb.ListVaultMembersRequest{Org: org, Vault: name})
+		return err
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			return nil, ErrServerUnavailable
+		}
+		return nil, fmt.Errorf("error in getting members of vault %s/%s: %w", org, name, err)
+	}
+
+	members := make([]*models.VaultMember, 0, len(resp.GetMembers()))
+	for _, m := range resp.GetMembers() {
+		members = append(members, &models.VaultMember{
+			Login: m.GetLogin(),
+			Vault: m.GetVault(),
+			Role:  ProtoToRole(m.GetRole()),
+		})
+	}
+	return members, nil
+}
+
+// vaultKeyOf returns key of the vault ref, vault of the service is kept.
+func (s *Service) vaultKeyOf(t string, key *helpers.SecretKey, ref string) (*helpers.SecretKey, error) {
+	current := s.vault
+	defer func() {
+		s.vault = current
+	}()
+	s.vault = ref
+	return s.VaultKey(t, key)
+}
+
+// RoleToProto converts vault role to protobuf enum.
+func RoleToProto(role string) pb.VaultRole {
+	switch vault.Role(role) {
+	case vault.RoleOwner:
+		return pb.VaultRole_OWNER
+	case vault.RoleEditor:
+		return pb.VaultRole_EDITOR
+	default:
+		return pb.VaultRole_VIEWER
+	}
+}
+
+// ProtoToRole converts protobuf enum to vault role.
+func ProtoToRole(role pb.VaultRole) string {
+	switch role {
+	case pb.VaultRole_OWNER:
+		return string(vault.RoleOwner)
+	case pb.VaultRole_EDITOR:
+		return string(vault.RoleEditor)
+	default:
+		return string(vault.RoleViewer)
+	}
+}
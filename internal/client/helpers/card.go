@@ -0,0 +1,118 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vkupriya/gophkeeper/internal/client/models"
+)
+
+const (
+	cardNumberMinLen = 12
+	cardNumberMaxLen = 19
+	cardMaskVisible  = 4
+)
+
+var (
+	ErrCardNumber  = errors.New("invalid card number")
+	ErrCardHolder  = errors.New("card holder is missing")
+	ErrCardExpiry  = errors.New("invalid card expiry, expected MM/YY")
+	ErrCardExpired = errors.New("card has expired")
+	ErrCardCVV     = errors.New("invalid card CVV, expected 3 or 4 digits")
+)
+
+// NewCard normalizes card fields and validates them, spaces and dashes in the number are removed,
+// expiry is converted to MM/YY.
+func NewCard(number, holder, expiry, cvv string, now time.Time) (*models.Card, error) {
+	card := &models.Card{
+		Number: strings.NewReplacer(" ", "", "-", "").Replace(number),
+		Holder: strings.TrimSpace(holder),
+		CVV:    strings.TrimSpace(cvv),
+	}
+
+	if !isDigits(card.Number) || len(card.Number) < cardNumberMinLen || len(card.Number) > cardNumberMaxLen ||
+		!LuhnValid(card.Number) {
+		return nil, ErrCardNumber
+	}
+
+	if card.Holder == "" {
+		return nil, ErrCardHolder
+	}
+
+	month, year, err := parseExpiry(strings.TrimSpace(expiry))
+	if err != nil {
+		return nil, err
+	}
+	// card is valid through the last day of expiry month
+	if !now.Before(time.Date(year, time.Month(month)+1, 1, 0, 0, 0, 0, now.Location())) {
+		return nil, ErrCardExpired
+	}
+	card.Expiry = fmt.Sprintf("%02d/%02d", month, year%100)
+
+	if !isDigits(card.CVV) || (len(card.CVV) != 3 && len(card.CVV) != 4) {
+		return nil, ErrCardCVV
+	}
+
+	return card, nil
+}
+
+// LuhnValid checks card number checksum with Luhn algorithm.
+func LuhnValid(number string) bool {
+	if number == "" || !isDigits(number) {
+		return false
+	}
+
+	var sum int
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		d := int(number[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// MaskCard hides card number except the last digits and CVV.
+func MaskCard(card models.Card) models.Card {
+	if len(card.Number) > cardMaskVisible {
+		card.Number = strings.Repeat("*", len(card.Number)-cardMaskVisible) + card.Number[len(card.Number)-cardMaskVisible:]
+	}
+	card.CVV = strings.Repeat("*", len(card.CVV))
+	return card
+}
+
+// parseExpiry parses MM/YY or MM/YYYY expiry date.
+func parseExpiry(expiry string) (int, int, error) {
+	m, y, ok := strings.Cut(expiry, "/")
+	if !ok || len(m) != 2 || (len(y) != 2 && len(y) != 4) || !isDigits(m) || !isDigits(y) {
+		return 0, 0, ErrCardExpiry
+	}
+
+	month, _ := strconv.Atoi(m)
+	year, _ := strconv.Atoi(y)
+	if month < 1 || month > 12 {
+		return 0, 0, ErrCardExpiry
+	}
+	if len(y) == 2 {
+		year += 2000
+	}
+	return month, year, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vkupriya/gophkeeper/internal/client/models"
+)
+
+func TestLuhnValid(t *testing.T) {
+	tests := map[string]bool{
+		"4111111111111111": true,
+		"5555555555554444": true,
+		"378282246310005":  true,
+		"4111111111111112": false,
+		"0123456789876":    false,
+		"41111a1111111111": false,
+		"":                 false,
+	}
+
+	for number, want := range tests {
+		t.Run(number, func(t *testing.T) {
+			require.Equal(t, want, LuhnValid(number))
+		})
+	}
+}
+
+func TestNewCard(t *testing.T) {
+	now := time.Date(2025, time.March, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		number  string
+		holder  string
+		expiry  string
+		cvv     string
+		want    *models.Card
+		wantErr error
+	}{
+		{
+			name:   "Success",
+			number: "4111 1111-1111 1111",
+			holder: " Super Agent ",
+			expiry: "03/25",
+			cvv:    "555",
+			want:   &models.Card{Number: "4111111111111111", Holder: "Super Agent", Expiry: "03/25", CVV: "555"},
+		},
+		{
+			name:   "Success_LongYear",
+			number: "378282246310005",
+			holder: "Super Agent",
+			expiry: "12/2030",
+			cvv:    "1234",
+			want:   &models.Card{Number: "378282246310005", Holder: "Super Agent", Expiry: "12/30", CVV: "1234"},
+		},
+		{name: "Fail_Luhn", number: "4111111111111112", holder: "A", expiry: "03/25", cvv: "555", wantErr: ErrCardNumber},
+		{name: "Fail_Short", number: "42", holder: "A", expiry: "03/25", cvv: "555", wantErr: ErrCardNumber},
+		{name: "Fail_Holder", number: "4111111111111111", expiry: "03/25", cvv: "555", wantErr: ErrCardHolder},
+		{name: "Fail_Expiry", number: "4111111111111111", holder: "A", expiry: "13/25", cvv: "555", wantErr: ErrCardExpiry},
+		{name: "Fail_Expired", number: "4111111111111111", holder: "A", expiry: "02/25", cvv: "555", wantErr: ErrCardExpired},
+		{name: "Fail_CVV", number: "4111111111111111", holder: "A", expiry: "03/25", cvv: "55", wantErr: ErrCardCVV},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card, err := NewCard(tt.number, tt.holder, tt.expiry, tt.cvv, now)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, card)
+		})
+	}
+}
+
+func TestMaskCard(t *testing.T) {
+	card := models.Card{Number: "4111111111111111", Holder: "Super Agent", Expiry: "03/25", CVV: "555"}
+
+	require.Equal(t, models.Card{
+		Number: "************1111",
+		Holder: "Super Agent",
+		Expiry: "03/25",
+		CVV:    "***",
+	}, MaskCard(card))
+}
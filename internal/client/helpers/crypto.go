@@ -0,0 +1,236 @@
+package helpers
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	// EncVersionLegacy marks secrets encrypted by the server with sha256 of the secret key.
+	EncVersionLegacy int32 = 0
+	// EncVersionClient marks secrets encrypted on the client with the key derived from the master password.
+	EncVersionClient int32 = 1
+	// EncVersionChunked marks secrets encrypted on the client by chunks and transferred by streaming calls.
+	EncVersionChunked int32 = 2
+	// EncVersionShared marks secrets sealed on the client with X25519 public key of the user they are shared with.
+	EncVersionShared int32 = 3
+)
+
+// ChunkSize is the size of plaintext chunk of streamed secrets.
+const ChunkSize = 1024 * 1024
+
+const (
+	kdfSaltPrefix = "gophkeeper:"
+	kdfTime       = 1
+	kdfMemory     = 64 * 1024
+	kdfThreads    = 4
+	kdfKeyLen     = 32
+)
+
+// shareKeyInfo separates X25519 share key from other keys derived from the secret key.
+const shareKeyInfo = "gophkeeper:x25519"
+
+var ErrCiphertextTooShort = errors.New("ciphertext too short")
+
+// SecretKey holds encryption keys derived from the user master password.
+type SecretKey struct {
+	// Key is argon2id key used for client-side encryption.
+	Key []byte
+	// Legacy is sha256 key used by server for secrets added before client-side encryption.
+	Legacy []byte
+}
+
+// NewSecretKey derives encryption keys from the master password, user login is used as a salt,
+// so the same key is derived on every client of the user.
+func NewSecretKey(password string, user string) *SecretKey {
+	legacy := sha256.Sum256([]byte(password))
+
+	return &SecretKey{
+		Key:    argon2.IDKey([]byte(password), []byte(kdfSaltPrefix+user), kdfTime, kdfMemory, kdfThreads, kdfKeyLen),
+		Legacy: legacy[:],
+	}
+}
+
+// NewVaultKey generates random key of a team vault, it is shared by vault members sealed with their
+// public keys. Vault has no legacy key.
+func NewVaultKey() (*SecretKey, error) {
+	key := make([]byte, kdfKeyLen)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate vault key: %w", err)
+	}
+	return &SecretKey{Key: key}, nil
+}
+
+// ShareKey returns X25519 key of the user derived from the secret key, secrets shared with the user
+// are sealed with its public key.
+func (k *SecretKey) ShareKey() (*ecdh.PrivateKey, error) {
+	mac := hmac.New(sha256.New, k.Key)
+	mac.Write([]byte(shareKeyInfo))
+	private, err := ecdh.X25519().NewPrivateKey(mac.Sum(nil))
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive share key: %w", err)
+	}
+	return private, nil
+}
+
+// SealShared seals data for the owner of X25519 public key: AES-GCM key is derived from ECDH of
+// ephemeral key and the public key, ephemeral public key is prepended to the ciphertext.
+func SealShared(publicKey []byte, data []byte) ([]byte, error) {
+	recipient, err := ecdh.X25519().NewPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("error generating ephemeral key: %w", err)
+	}
+	shared, err := ephemeral.ECDH(recipient)
+	if err != nil {
+		return nil, fmt.Errorf("error computing shared key: %w", err)
+	}
+
+	ephemeralPublic := ephemeral.PublicKey().Bytes()
+	sealed, err := seal(sharedKey(shared, ephemeralPublic, publicKey), data, nil)
+	if err != nil {
+		return nil, err
+	}
+	return append(ephemeralPublic, sealed...), nil
+}
+
+// OpenShared opens data sealed by SealShared with the public key of private.
+func OpenShared(private *ecdh.PrivateKey, data []byte) ([]byte, error) {
+	size := len(private.PublicKey().Bytes())
+	if len(data) < size {
+		return nil, ErrCiphertextTooShort
+	}
+	ephemeral, err := ecdh.X25519().NewPublicKey(data[:size])
+	if err != nil {
+		return nil, fmt.Errorf("invalid ephemeral key: %w", err)
+	}
+	shared, err := private.ECDH(ephemeral)
+	if err != nil {
+		return nil, fmt.Errorf("error computing shared key: %w", err)
+	}
+	return open(sharedKey(shared, data[:size], private.PublicKey().Bytes()), data[size:], nil)
+}
+
+// sharedKey binds AES key to both public keys of the key exchange.
+func sharedKey(shared []byte, ephemeralPublic []byte, publicKey []byte) []byte {
+	h := sha256.New()
+	h.Write(shared)
+	h.Write(ephemeralPublic)
+	h.Write(publicKey)
+	return h.Sum(nil)
+}
+
+// KeyFingerprint returns short fingerprint of public key to be compared by users out of band.
+func KeyFingerprint(publicKey []byte) string {
+	sum := sha256.Sum256(publicKey)
+	groups := make([]string, 0, 8)
+	for i := 0; i < 16; i += 2 {
+		groups = append(groups, hex.EncodeToString(sum[i:i+2]))
+	}
+	return strings.Join(groups, ":")
+}
+
+// Encrypt seals data with AES-GCM, random nonce is prepended to the ciphertext.
+func Encrypt(key []byte, data []byte) ([]byte, error) {
+	return seal(key, data, nil)
+}
+
+// Decrypt opens data sealed by Encrypt.
+func Decrypt(key []byte, data []byte) ([]byte, error) {
+	return open(key, data, nil)
+}
+
+// EncryptChunk seals chunk of streamed secret. Chunk index and last chunk flag are authenticated,
+// so reordered, dropped or truncated chunks fail decryption.
+func EncryptChunk(key []byte, index uint64, last bool, data []byte) ([]byte, error) {
+	return seal(key, data, chunkAAD(index, last))
+}
+
+// DecryptChunk opens chunk sealed by EncryptChunk.
+func DecryptChunk(key []byte, index uint64, last bool, data []byte) ([]byte, error) {
+	return open(key, data, chunkAAD(index, last))
+}
+
+func chunkAAD(index uint64, last bool) []byte {
+	aad := binary.BigEndian.AppendUint64(nil, index)
+	if last {
+		return append(aad, 1)
+	}
+	return append(aad, 0)
+}
+
+func seal(key []byte, data []byte, aad []byte) ([]byte, error) {
+	aesgcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aesgcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("error generating random nonce: %w", err)
+	}
+
+	return aesgcm.Seal(nonce, nonce, data, aad), nil
+}
+
+func open(key []byte, data []byte, aad []byte) ([]byte, error) {
+	aesgcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < aesgcm.NonceSize() {
+		return nil, ErrCiphertextTooShort
+	}
+	nonce, bytesEncrypted := data[:aesgcm.NonceSize()], data[aesgcm.NonceSize():]
+
+	bytesDecrypted, err := aesgcm.Open(nil, nonce, bytesEncrypted, aad)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting data: %w", err)
+	}
+
+	return bytesDecrypted, nil
+}
+
+// DecryptSecret opens secret data according to its encryption version.
+func DecryptSecret(key *SecretKey, encVersion int32, data []byte) ([]byte, error) {
+	switch encVersion {
+	case EncVersionLegacy:
+		return Decrypt(key.Legacy, data)
+	case EncVersionShared:
+		private, err := key.ShareKey()
+		if err != nil {
+			return nil, err
+		}
+		return OpenShared(private, data)
+	default:
+		return Decrypt(key.Key, data)
+	}
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	aesblock, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating new block cipher: %w", err)
+	}
+
+	aesgcm, err := cipher.NewGCM(aesblock)
+	if err != nil {
+		return nil, fmt.Errorf("error creating new GCM for block cipher: %w", err)
+	}
+	return aesgcm, nil
+}
@@ -0,0 +1,64 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSealShared(t *testing.T) {
+	alice := NewSecretKey("alice-password", "alice")
+	bob := NewSecretKey("bob-password", "bob")
+
+	bobKey, err := bob.ShareKey()
+	require.NoError(t, err)
+
+	// share key is derived from the secret key, so it is the same on every client
+	again, err := NewSecretKey("bob-password", "bob").ShareKey()
+	require.NoError(t, err)
+	require.Equal(t, bobKey.PublicKey().Bytes(), again.PublicKey().Bytes())
+
+	sealed, err := SealShared(bobKey.PublicKey().Bytes(), []byte("secret data"))
+	require.NoError(t, err)
+
+	data, err := DecryptSecret(bob, EncVersionShared, sealed)
+	require.NoError(t, err)
+	require.Equal(t, []byte("secret data"), data)
+
+	_, err = DecryptSecret(alice, EncVersionShared, sealed)
+	require.Error(t, err)
+
+	sealed[len(sealed)-1] ^= 1
+	_, err = DecryptSecret(bob, EncVersionShared, sealed)
+	require.Error(t, err)
+
+	_, err = OpenShared(bobKey, sealed[:10])
+	require.ErrorIs(t, err, ErrCiphertextTooShort)
+
+	_, err = SealShared([]byte("short"), []byte("secret data"))
+	require.Error(t, err)
+}
+
+func TestKeyFingerprint(t *testing.T) {
+	fingerprint := KeyFingerprint([]byte("public key"))
+	require.Len(t, fingerprint, 39)
+	require.Equal(t, fingerprint, KeyFingerprint([]byte("public key")))
+	require.NotEqual(t, fingerprint, KeyFingerprint([]byte("other key")))
+}
+
+func TestNewVaultKey(t *testing.T) {
+	key, err := NewVaultKey()
+	require.NoError(t, err)
+	require.Len(t, key.Key, 32)
+	require.Nil(t, key.Legacy)
+
+	other, err := NewVaultKey()
+	require.NoError(t, err)
+	require.NotEqual(t, key.Key, other.Key)
+
+	sealed, err := Encrypt(key.Key, []byte("vault data"))
+	require.NoError(t, err)
+	data, err := DecryptSecret(&SecretKey{Key: key.Key}, EncVersionClient, sealed)
+	require.NoError(t, err)
+	require.Equal(t, []byte("vault data"), data)
+}
@@ -0,0 +1,156 @@
+package helpers
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vkupriya/gophkeeper/internal/client/models"
+)
+
+const (
+	otpScheme         = "otpauth"
+	otpTypeTOTP       = "totp"
+	otpDefaultAlgo    = "SHA1"
+	otpDefaultDigits  = 6
+	otpDefaultPeriod  = 30
+	otpMaskedSecret   = "********"
+	otpMaxPeriod      = 3600
+	otpModuloDecimals = 10
+)
+
+var (
+	ErrOTPURI       = errors.New("invalid otpauth URI")
+	ErrOTPType      = errors.New("only totp type of otpauth URI is supported")
+	ErrOTPSecret    = errors.New("invalid OTP secret, expected base32")
+	ErrOTPAlgorithm = errors.New("unsupported OTP algorithm, expected SHA1, SHA256 or SHA512")
+	ErrOTPDigits    = errors.New("unsupported OTP digits, expected 6, 7 or 8")
+	ErrOTPPeriod    = errors.New("invalid OTP period")
+)
+
+// ParseOTPURI parses otpauth://totp/ISSUER:ACCOUNT?secret=...&issuer=...&algorithm=...&digits=...&period=...
+// URI, missing parameters get defaults of RFC 6238.
+func ParseOTPURI(uri string) (*models.OTP, error) {
+	u, err := url.Parse(strings.TrimSpace(uri))
+	if err != nil || u.Scheme != otpScheme {
+		return nil, ErrOTPURI
+	}
+	if !strings.EqualFold(u.Host, otpTypeTOTP) {
+		return nil, ErrOTPType
+	}
+
+	otp := &models.OTP{
+		Algorithm: otpDefaultAlgo,
+		Digits:    otpDefaultDigits,
+		Period:    otpDefaultPeriod,
+	}
+
+	label := strings.TrimPrefix(u.Path, "/")
+	if issuer, account, ok := strings.Cut(label, ":"); ok {
+		otp.Issuer, otp.Account = strings.TrimSpace(issuer), strings.TrimSpace(account)
+	} else {
+		otp.Account = label
+	}
+
+	q := u.Query()
+	if issuer := q.Get("issuer"); issuer != "" {
+		otp.Issuer = issuer
+	}
+
+	secret := strings.ToUpper(strings.ReplaceAll(q.Get("secret"), " ", ""))
+	otp.Secret, err = base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(secret, "="))
+	if err != nil || len(otp.Secret) == 0 {
+		return nil, ErrOTPSecret
+	}
+
+	if algo := q.Get("algorithm"); algo != "" {
+		otp.Algorithm = strings.ToUpper(algo)
+		if _, err := otpHash(otp.Algorithm); err != nil {
+			return nil, err
+		}
+	}
+
+	if digits := q.Get("digits"); digits != "" {
+		otp.Digits, err = strconv.Atoi(digits)
+		if err != nil || otp.Digits < 6 || otp.Digits > 8 {
+			return nil, ErrOTPDigits
+		}
+	}
+
+	if period := q.Get("period"); period != "" {
+		otp.Period, err = strconv.Atoi(period)
+		if err != nil || otp.Period <= 0 || otp.Period > otpMaxPeriod {
+			return nil, ErrOTPPeriod
+		}
+	}
+
+	return otp, nil
+}
+
+// TOTP returns RFC 6238 code for the given time and number of seconds the code remains valid.
+func TOTP(otp *models.OTP, now time.Time) (string, int, error) {
+	newHash, err := otpHash(otp.Algorithm)
+	if err != nil {
+		return "", 0, err
+	}
+	period := int64(otp.Period)
+	unix := now.Unix()
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(unix/period))
+
+	mac := hmac.New(newHash, otp.Secret)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation of RFC 4226
+	offset := sum[len(sum)-1] & 0x0f
+	value := int64(binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff)
+
+	modulo := int64(1)
+	for range otp.Digits {
+		modulo *= otpModuloDecimals
+	}
+
+	code := fmt.Sprintf("%0*d", otp.Digits, value%modulo)
+	return code, int(period - unix%period), nil
+}
+
+// MaskOTPURI hides secret parameter of otpauth URI.
+func MaskOTPURI(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return otpMaskedSecret
+	}
+	params := strings.Split(u.RawQuery, "&")
+	for i, param := range params {
+		if strings.HasPrefix(param, "secret=") {
+			params[i] = "secret=" + otpMaskedSecret
+		}
+	}
+	u.RawQuery = strings.Join(params, "&")
+	return u.String()
+}
+
+func otpHash(algorithm string) (func() hash.Hash, error) {
+	switch algorithm {
+	case "SHA1":
+		return sha1.New, nil
+	case "SHA256":
+		return sha256.New, nil
+	case "SHA512":
+		return sha512.New, nil
+	default:
+		return nil, ErrOTPAlgorithm
+	}
+}
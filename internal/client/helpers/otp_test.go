@@ -0,0 +1,90 @@
+package helpers
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vkupriya/gophkeeper/internal/client/models"
+)
+
+func TestTOTP(t *testing.T) {
+	// test vectors of RFC 6238 appendix B
+	seeds := map[string]string{
+		"SHA1":   "12345678901234567890",
+		"SHA256": "12345678901234567890123456789012",
+		"SHA512": "1234567890123456789012345678901234567890123456789012345678901234",
+	}
+	tests := []struct {
+		unix int64
+		algo string
+		code string
+	}{
+		{unix: 59, algo: "SHA1", code: "94287082"},
+		{unix: 59, algo: "SHA256", code: "46119246"},
+		{unix: 59, algo: "SHA512", code: "90693936"},
+		{unix: 1111111109, algo: "SHA1", code: "07081804"},
+		{unix: 1234567890, algo: "SHA256", code: "91819424"},
+		{unix: 20000000000, algo: "SHA512", code: "47863826"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.algo+"_"+tt.code, func(t *testing.T) {
+			otp := &models.OTP{Secret: []byte(seeds[tt.algo]), Algorithm: tt.algo, Digits: 8, Period: 30}
+			code, remaining, err := TOTP(otp, time.Unix(tt.unix, 0))
+			require.NoError(t, err)
+			require.Equal(t, tt.code, code)
+			require.Equal(t, int(30-tt.unix%30), remaining)
+		})
+	}
+}
+
+func TestParseOTPURI(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+	tests := []struct {
+		name    string
+		uri     string
+		want    *models.OTP
+		wantErr error
+	}{
+		{
+			name: "Defaults",
+			uri:  "otpauth://totp/GitHub:agent?secret=" + secret,
+			want: &models.OTP{Issuer: "GitHub", Account: "agent", Secret: []byte("12345678901234567890"),
+				Algorithm: "SHA1", Digits: 6, Period: 30},
+		},
+		{
+			name: "AllParams",
+			uri: "otpauth://totp/agent%40example.com?secret=" + secret +
+				"&issuer=Example&algorithm=sha256&digits=8&period=60",
+			want: &models.OTP{Issuer: "Example", Account: "agent@example.com", Secret: []byte("12345678901234567890"),
+				Algorithm: "SHA256", Digits: 8, Period: 60},
+		},
+		{name: "Fail_Scheme", uri: "https://totp/agent?secret=" + secret, wantErr: ErrOTPURI},
+		{name: "Fail_HOTP", uri: "otpauth://hotp/agent?secret=" + secret, wantErr: ErrOTPType},
+		{name: "Fail_Secret", uri: "otpauth://totp/agent?secret=1!", wantErr: ErrOTPSecret},
+		{name: "Fail_NoSecret", uri: "otpauth://totp/agent", wantErr: ErrOTPSecret},
+		{name: "Fail_Algorithm", uri: "otpauth://totp/agent?algorithm=MD5&secret=" + secret, wantErr: ErrOTPAlgorithm},
+		{name: "Fail_Digits", uri: "otpauth://totp/agent?digits=10&secret=" + secret, wantErr: ErrOTPDigits},
+		{name: "Fail_Period", uri: "otpauth://totp/agent?period=0&secret=" + secret, wantErr: ErrOTPPeriod},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			otp, err := ParseOTPURI(tt.uri)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, otp)
+		})
+	}
+}
+
+func TestMaskOTPURI(t *testing.T) {
+	require.Equal(t, "otpauth://totp/agent?secret=********&issuer=GitHub",
+		MaskOTPURI("otpauth://totp/agent?secret=GEZDGNBV&issuer=GitHub"))
+}
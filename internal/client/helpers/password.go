@@ -0,0 +1,112 @@
+package helpers
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Character classes of generated passwords.
+const (
+	ClassLower   = "lower"
+	ClassUpper   = "upper"
+	ClassDigits  = "digits"
+	ClassSymbols = "symbols"
+)
+
+const passwordMaxLen = 1024
+
+var passwordClasses = map[string]string{
+	ClassLower:   "abcdefghijklmnopqrstuvwxyz",
+	ClassUpper:   "ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+	ClassDigits:  "0123456789",
+	ClassSymbols: "!#$%&()*+,-./:;<=>?@[]^_{|}~",
+}
+
+var (
+	ErrPasswordLength = errors.New("password is too short to contain every character class")
+	ErrPasswordClass  = errors.New("unknown character class")
+)
+
+// PasswordPolicy defines generated password length and character classes,
+// generated password contains at least one character of every class.
+type PasswordPolicy struct {
+	Length  int
+	Classes []string
+}
+
+// ParsePasswordClasses parses comma separated list of character classes.
+func ParsePasswordClasses(classes string) ([]string, error) {
+	result := make([]string, 0, len(passwordClasses))
+	for _, class := range strings.Split(classes, ",") {
+		class = strings.TrimSpace(class)
+		if _, ok := passwordClasses[class]; !ok {
+			return nil, fmt.Errorf("%w: %q", ErrPasswordClass, class)
+		}
+		result = append(result, class)
+	}
+	return result, nil
+}
+
+// GeneratePassword returns random password satisfying the policy.
+func GeneratePassword(policy PasswordPolicy) (string, error) {
+	if len(policy.Classes) == 0 {
+		return "", fmt.Errorf("%w: no classes", ErrPasswordClass)
+	}
+	if policy.Length < len(policy.Classes) || policy.Length > passwordMaxLen {
+		return "", ErrPasswordLength
+	}
+
+	var alphabet strings.Builder
+	password := make([]byte, 0, policy.Length)
+	for _, class := range policy.Classes {
+		chars, ok := passwordClasses[class]
+		if !ok {
+			return "", fmt.Errorf("%w: %q", ErrPasswordClass, class)
+		}
+		alphabet.WriteString(chars)
+
+		c, err := randomChar(chars)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+
+	all := alphabet.String()
+	for len(password) < policy.Length {
+		c, err := randomChar(all)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+
+	// characters of required classes are moved to random positions
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := randomInt(i + 1)
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j] = password[j], password[i]
+	}
+	return string(password), nil
+}
+
+func randomChar(chars string) (byte, error) {
+	i, err := randomInt(len(chars))
+	if err != nil {
+		return 0, err
+	}
+	return chars[i], nil
+}
+
+func randomInt(n int) (int, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate random number: %w", err)
+	}
+	return int(i.Int64()), nil
+}
@@ -0,0 +1,75 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratePassword(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  PasswordPolicy
+		wantErr error
+	}{
+		{
+			name:   "AllClasses",
+			policy: PasswordPolicy{Length: 20, Classes: []string{ClassLower, ClassUpper, ClassDigits, ClassSymbols}},
+		},
+		{
+			name:   "DigitsOnly",
+			policy: PasswordPolicy{Length: 6, Classes: []string{ClassDigits}},
+		},
+		{
+			name:   "MinLength",
+			policy: PasswordPolicy{Length: 2, Classes: []string{ClassLower, ClassUpper}},
+		},
+		{
+			name:    "Fail_TooShort",
+			policy:  PasswordPolicy{Length: 3, Classes: []string{ClassLower, ClassUpper, ClassDigits, ClassSymbols}},
+			wantErr: ErrPasswordLength,
+		},
+		{
+			name:    "Fail_NoClasses",
+			policy:  PasswordPolicy{Length: 10},
+			wantErr: ErrPasswordClass,
+		},
+		{
+			name:    "Fail_UnknownClass",
+			policy:  PasswordPolicy{Length: 10, Classes: []string{"emoji"}},
+			wantErr: ErrPasswordClass,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			password, err := GeneratePassword(tt.policy)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, password, tt.policy.Length)
+
+			allowed := ""
+			for _, class := range tt.policy.Classes {
+				require.True(t, strings.ContainsAny(password, passwordClasses[class]),
+					"password %q has no %s characters", password, class)
+				allowed += passwordClasses[class]
+			}
+			for _, c := range password {
+				require.True(t, strings.ContainsRune(allowed, c), "unexpected character %q", c)
+			}
+		})
+	}
+}
+
+func TestParsePasswordClasses(t *testing.T) {
+	classes, err := ParsePasswordClasses("lower, digits")
+	require.NoError(t, err)
+	require.Equal(t, []string{ClassLower, ClassDigits}, classes)
+
+	_, err = ParsePasswordClasses("lower,emoji")
+	require.ErrorIs(t, err, ErrPasswordClass)
+}
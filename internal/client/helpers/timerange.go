@@ -0,0 +1,37 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidTime = errors.New("invalid time")
+
+// timeLayouts are accepted absolute times, times without zone are local.
+var timeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+// ParseTime parses absolute time, e.g. '2024-05-01' or '2024-05-01T10:00:00Z', or time relative
+// to now, e.g. '90m', '24h' or '7d' ago.
+func ParseTime(s string, now time.Time) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return time.Time{}, fmt.Errorf("%w %q", ErrInvalidTime, s)
+		}
+		return now.AddDate(0, 0, -n), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return time.Time{}, fmt.Errorf("%w %q, use date, RFC 3339 time or duration like 24h or 7d", ErrInvalidTime, s)
+	}
+	return now.Add(-d), nil
+}
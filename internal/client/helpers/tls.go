@@ -0,0 +1,70 @@
+package helpers
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/viper"
+	"github.com/vkupriya/gophkeeper/internal/client/models"
+)
+
+// Configuration file keys of TLS settings.
+const (
+	TLSEnabled  = "tls"
+	TLSCAFile   = "tls_ca"
+	TLSCertFile = "tls_cert"
+	TLSKeyFile  = "tls_key"
+)
+
+var (
+	ErrNoCertificates = errors.New("no certificates found in CA bundle")
+	ErrTLSKeyPair     = errors.New("both client certificate and key must be specified")
+)
+
+// GetTLSConfig reads TLS settings from configuration file and flags,
+// TLS is enabled implicitly when CA bundle or client certificate is set.
+func GetTLSConfig() *models.TLSConfig {
+	v := viper.GetViper()
+	cfg := &models.TLSConfig{
+		CAFile:   v.GetString(TLSCAFile),
+		CertFile: v.GetString(TLSCertFile),
+		KeyFile:  v.GetString(TLSKeyFile),
+	}
+	cfg.Enabled = v.GetBool(TLSEnabled) || cfg.CAFile != "" || cfg.CertFile != ""
+	return cfg
+}
+
+// NewTLSConfig builds TLS configuration for connection to gophkeeper server.
+func NewTLSConfig(cfg *models.TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: %s", ErrNoCertificates, cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return nil, ErrTLSKeyPair
+	}
+	if cfg.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
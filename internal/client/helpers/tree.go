@@ -0,0 +1,80 @@
+package helpers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vkupriya/gophkeeper/internal/client/models"
+	"github.com/vkupriya/gophkeeper/internal/secretpath"
+)
+
+type treeNode struct {
+	item     *models.SecretItem
+	children map[string]*treeNode
+}
+
+func (n *treeNode) child(name string) *treeNode {
+	if n.children == nil {
+		n.children = make(map[string]*treeNode)
+	}
+	c, ok := n.children[name]
+	if !ok {
+		c = &treeNode{}
+		n.children[name] = c
+	}
+	return c
+}
+
+// RenderTree renders secrets of the folder as a tree, item names must start with the folder.
+// Subfolders are printed before secrets, secrets are followed by their type and version.
+func RenderTree(folder string, items []*models.SecretItem) string {
+	root := &treeNode{}
+	for _, item := range items {
+		rest := strings.TrimSuffix(strings.TrimPrefix(item.Name, folder), secretpath.Separator)
+		node := root
+		for _, segment := range strings.Split(rest, secretpath.Separator) {
+			node = node.child(segment)
+		}
+		if item.Type != models.TypeFolder {
+			node.item = item
+		}
+	}
+
+	var b strings.Builder
+	if folder == "" {
+		b.WriteString(".\n")
+	} else {
+		b.WriteString(folder + "\n")
+	}
+	renderNodes(&b, root, "")
+	return b.String()
+}
+
+func renderNodes(b *strings.Builder, node *treeNode, indent string) {
+	names := make([]string, 0, len(node.children))
+	for name := range node.children {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, c := node.children[names[i]], node.children[names[j]]
+		if (a.item == nil) != (c.item == nil) {
+			return a.item == nil
+		}
+		return names[i] < names[j]
+	})
+
+	for i, name := range names {
+		c := node.children[name]
+		branch, next := "├── ", "│   "
+		if i == len(names)-1 {
+			branch, next = "└── ", "    "
+		}
+		if c.item == nil {
+			fmt.Fprintf(b, "%s%s%s/\n", indent, branch, name)
+		} else {
+			fmt.Fprintf(b, "%s%s%s [%s v%d]\n", indent, branch, name, c.item.Type, c.item.Version)
+		}
+		renderNodes(b, c, indent+next)
+	}
+}
@@ -0,0 +1,28 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vkupriya/gophkeeper/internal/client/models"
+)
+
+func TestRenderTree(t *testing.T) {
+	items := []*models.SecretItem{
+		{Name: "work/github", Type: "login", Version: 2},
+		{Name: "work/aws/prod", Type: "text", Version: 3},
+		{Name: "work/aws/staging", Type: "text", Version: 1},
+		{Name: "work/gcp/", Type: models.TypeFolder},
+	}
+
+	want := "work/\n" +
+		"├── aws/\n" +
+		"│   ├── prod [text v3]\n" +
+		"│   └── staging [text v1]\n" +
+		"├── gcp/\n" +
+		"└── github [login v2]\n"
+	require.Equal(t, want, RenderTree("work/", items))
+
+	require.Equal(t, ".\n└── github [login v1]\n",
+		RenderTree("", []*models.SecretItem{{Name: "github", Type: "login", Version: 1}}))
+}
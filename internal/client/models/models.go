@@ -1,25 +1,211 @@
 package models
 
+import "time"
+
+// TLSConfig holds client transport security settings.
+type TLSConfig struct {
+	// Enabled turns on TLS, server certificate is verified with system roots unless CAFile is set.
+	Enabled bool
+	CAFile  string
+	// CertFile and KeyFile are client certificate and key for mTLS.
+	CertFile string
+	KeyFile  string
+}
+
+type Tokens struct {
+	Token        string
+	RefreshToken string
+}
+
 type Secret struct {
 	Name    string
 	Type    string
-	Meta    string
+	Labels  map[string]string
 	Data    []byte
 	Version int64
 }
 
 type SecretPrint struct {
-	Name    string `json:"name"`
-	Type    string `json:"type"`
-	Meta    string `json:"meta"`
-	Data    string `json:"data"`
-	Version int64  `json:"version"`
+	Name    string            `json:"name"`
+	Type    string            `json:"type"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Data    string            `json:"data"`
+	Version int64             `json:"version"`
+}
+
+// Card is data of card secret, it is stored encrypted as JSON.
+type Card struct {
+	Number string `json:"number"`
+	Holder string `json:"holder"`
+	// Expiry is expiration date in MM/YY format.
+	Expiry string `json:"expiry"`
+	CVV    string `json:"cvv"`
+}
+
+type CardPrint struct {
+	Name    string            `json:"name"`
+	Type    string            `json:"type"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Data    Card              `json:"data"`
+	Version int64             `json:"version"`
+}
+
+// Credentials is data of login secret, it is stored encrypted as JSON.
+type Credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	URL      string `json:"url,omitempty"`
+	Notes    string `json:"notes,omitempty"`
+}
+
+type CredentialsPrint struct {
+	Name    string            `json:"name"`
+	Type    string            `json:"type"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Data    Credentials       `json:"data"`
+	Version int64             `json:"version"`
+}
+
+// OTP is TOTP generator parameters parsed from otpauth:// URI, the URI is stored as secret data.
+type OTP struct {
+	Issuer    string
+	Account   string
+	Secret    []byte
+	Algorithm string
+	Digits    int
+	Period    int
 }
 
 type SecretList []SecretItem
 
 type SecretItem struct {
-	Name    string `json:"name"`
-	Type    string `json:"type"`
-	Version int64  `json:"version"`
+	// UpdatedAt is known only for secrets listed by server.
+	UpdatedAt *time.Time        `json:"updated_at,omitempty"`
+	Name      string            `json:"name"`
+	Type      string            `json:"type"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Version   int64             `json:"version"`
+}
+
+// SearchResult is a secret found by search, results with higher rank are more relevant.
+type SearchResult struct {
+	Secret *SecretItem `json:"secret"`
+	Rank   float64     `json:"rank"`
+}
+
+// TypeFolder is type of list items representing folders of path-style secret names.
+const TypeFolder = "folder"
+
+// SecretFilter selects secrets in list command.
+type SecretFilter struct {
+	// Labels is label selector, e.g. 'env=prod,team=payments'.
+	Labels string
+	// Folder is a name prefix, e.g. 'work/aws/', empty folder is the root one.
+	Folder string
+	// Sort is one of Sort* orders, secrets are sorted by name if it is empty.
+	Sort string
+	// Types limits secret types, secrets of any type are listed if it is empty.
+	Types []string
+	// PageSize is a number of secrets received from server in one response, 0 is server default.
+	PageSize int
+	// Recursive includes secrets of subfolders, otherwise subfolders are listed as folder items.
+	Recursive bool
+}
+
+// Sort orders of list command, updated orders are supported by server only.
+const (
+	SortName        = "name"
+	SortNameDesc    = "-name"
+	SortUpdated     = "updated"
+	SortUpdatedDesc = "-updated"
+)
+
+// Access levels of shared secrets.
+const (
+	AccessReadOnly  = "ro"
+	AccessReadWrite = "rw"
+)
+
+// SharedSecretItem is a secret shared by its owner with the recipient. Share is stale when the owner
+// has updated the secret after sharing it.
+type SharedSecretItem struct {
+	SharedAt       time.Time `json:"shared_at"`
+	Owner          string    `json:"owner"`
+	Name           string    `json:"name"`
+	Recipient      string    `json:"recipient"`
+	Access         string    `json:"access"`
+	Type           string    `json:"type"`
+	Version        int64     `json:"version"`
+	CurrentVersion int64     `json:"current_version"`
+}
+
+// Vault is a team vault, referenced as 'org/vault', with the role of the user in it.
+type Vault struct {
+	Org  string `json:"org"`
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// VaultMember is a member of the vault, member of the organization has empty vault.
+type VaultMember struct {
+	Login string `json:"login"`
+	Vault string `json:"vault,omitempty"`
+	Role  string `json:"role"`
+}
+
+// AuditEvent is a secret access or mutation by the user recorded by the server.
+type AuditEvent struct {
+	CreatedAt time.Time `json:"created_at"`
+	Method    string    `json:"method"`
+	Name      string    `json:"name,omitempty"`
+	Owner     string    `json:"owner"`
+	ClientIP  string    `json:"client_ip"`
+	Code      string    `json:"code"`
+	ID        int64     `json:"id"`
+}
+
+// AuditFilter selects audit events in [From, To), zero time is not limited.
+type AuditFilter struct {
+	From  time.Time
+	To    time.Time
+	Limit int
+}
+
+// AuditVerification is a result of the audit log hash chain check made by the server.
+type AuditVerification struct {
+	Reason      string `json:"reason,omitempty"`
+	Verified    int64  `json:"verified"`
+	Unchained   int64  `json:"unchained"`
+	BrokenID    int64  `json:"broken_id,omitempty"`
+	Checkpoints int    `json:"checkpoints"`
+	Intact      bool   `json:"intact"`
+}
+
+type SecretVersion struct {
+	CreatedAt time.Time `json:"created_at"`
+	Type      string    `json:"type"`
+	Version   int64     `json:"version"`
+}
+
+// RotatedSecret is a secret re-encrypted under the new key by key rotation, From and To are its
+// versions on the server before and after re-encryption. They are equal if the secret was found
+// already re-encrypted, as it happens when rotation is resumed.
+type RotatedSecret struct {
+	Name string
+	From int64
+	To   int64
+}
+
+// KeyRotation is a result of master key rotation.
+type KeyRotation struct {
+	// Rotated is the number of secrets re-encrypted by this run, Skipped were re-encrypted before.
+	Rotated int
+	Skipped int
+	// Vaults is the number of vault keys sealed with the new public key.
+	Vaults int
+	// Versions is the number of re-encrypted past versions, Deleted is the number of deleted past
+	// versions kept in chunks. Unreadable past versions are opened by neither key and left as they are.
+	Versions   int
+	Deleted    int
+	Unreadable int
 }
@@ -0,0 +1,11 @@
+CREATE TABLE secret_labels(
+    name VARCHAR(200) NOT NULL,
+    key VARCHAR(63) NOT NULL,
+    value VARCHAR(255) NOT NULL,
+    PRIMARY KEY (name, key)
+);
+
+INSERT INTO secret_labels (name, key, value)
+    SELECT name, 'meta', meta FROM secrets WHERE meta IS NOT NULL AND meta <> '';
+
+ALTER TABLE secrets DROP COLUMN meta;
@@ -0,0 +1,2 @@
+-- SHA-256 hash of secret data is verified on read, secrets stored before have no hash until re-fetched
+ALTER TABLE secrets ADD COLUMN hash BYTEA;
@@ -0,0 +1,8 @@
+-- journal of master key rotation, secrets re-encrypted under the new key are skipped when it is resumed
+CREATE TABLE IF NOT EXISTS key_rotation(
+    key_id VARCHAR(64) NOT NULL,
+    name VARCHAR(200) NOT NULL,
+    from_version BIGINT NOT NULL,
+    to_version BIGINT NOT NULL,
+    PRIMARY KEY (key_id, name)
+);
@@ -0,0 +1,8 @@
+-- FTS5 index secrets_fts is created by the client built with sqlite_fts5 tag. Client built without it
+-- marks the index stale when secrets are changed, stale index is rebuilt when DB is opened.
+CREATE TABLE search_index(
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    stale BOOLEAN NOT NULL
+);
+
+INSERT INTO search_index (id, stale) VALUES (1, TRUE);
@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vkupriya/gophkeeper/internal/client/models"
+)
+
+// RotationBegin starts or resumes rotation to the key identified by keyID and returns secrets
+// already re-encrypted under the key by name. Journal of the rotation to another key, which was
+// abandoned, is dropped.
+func (s *SQLiteDB) RotationBegin(keyID string) (map[string]models.RotatedSecret, error) {
+	db := s.DB
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeoutDefault)
+	defer cancel()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM key_rotation WHERE key_id<>?", keyID); err != nil {
+		return nil, fmt.Errorf("error deleting journal of abandoned key rotation: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, "SELECT name, from_version, to_version FROM key_rotation WHERE key_id=?",
+		keyID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying key rotation journal: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Printf("failed to close rows")
+		}
+	}()
+
+	rotated := make(map[string]models.RotatedSecret)
+	for rows.Next() {
+		var r models.RotatedSecret
+		if err = rows.Scan(&r.Name, &r.From, &r.To); err != nil {
+			return nil, fmt.Errorf("failed to scan row in key rotation journal: %w", err)
+		}
+		rotated[r.Name] = r
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan rows in key rotation journal: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit key rotation start: %w", err)
+	}
+	return rotated, nil
+}
+
+// RotationAdd records in the journal the secret re-encrypted under the key identified by keyID.
+func (s *SQLiteDB) RotationAdd(keyID string, rotated models.RotatedSecret) error {
+	db := s.DB
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeoutDefault)
+	defer cancel()
+
+	querySQL := "INSERT INTO key_rotation (key_id, name, from_version, to_version) VALUES(?,?,?,?) " +
+		"ON CONFLICT (key_id, name) DO UPDATE SET from_version=excluded.from_version, to_version=excluded.to_version"
+	if _, err := db.ExecContext(ctx, querySQL, keyID, rotated.Name, rotated.From, rotated.To); err != nil {
+		return fmt.Errorf("failed to record rotation of secret %s: %w", rotated.Name, err)
+	}
+	return nil
+}
+
+// RotationFinish completes rotation to the key identified by keyID. Local secrets keep decrypted
+// data, so only versions of secrets which were up to date before re-encryption are advanced, other
+// ones are left to sync. The journal is cleared in the same transaction, number of updated local
+// secrets is returned.
+func (s *SQLiteDB) RotationFinish(keyID string) (int64, error) {
+	db := s.DB
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeoutDefault)
+	defer cancel()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	querySQL := "UPDATE secrets SET version = r.to_version FROM key_rotation r " +
+		"WHERE r.key_id=? AND r.name = secrets.name AND secrets.version = r.from_version"
+	res, err := tx.ExecContext(ctx, querySQL, keyID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update versions of rotated secrets: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get number of updated secrets: %w", err)
+	}
+	if _, err = tx.ExecContext(ctx, "DELETE FROM key_rotation"); err != nil {
+		return 0, fmt.Errorf("error clearing key rotation journal: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit key rotation: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vkupriya/gophkeeper/internal/client/models"
+)
+
+func TestRotation(t *testing.T) {
+	store, err := NewSQLiteDB(filepath.Join(t.TempDir(), "rotation.db"))
+	require.NoError(t, err)
+	defer func() {
+		if err := store.DB.Close(); err != nil {
+			t.Error("failed to close local DB")
+		}
+	}()
+	require.NoError(t, RunMigrations(store))
+
+	for _, name := range []string{"current", "stale", "pending"} {
+		require.NoError(t, store.SecretAdd(&models.Secret{Name: name, Type: "text", Data: []byte(name), Version: 2}))
+	}
+
+	// journal of the abandoned rotation is dropped
+	require.NoError(t, store.RotationAdd("old", models.RotatedSecret{Name: "pending", From: 2, To: 3}))
+	rotated, err := store.RotationBegin("new")
+	require.NoError(t, err)
+	require.Empty(t, rotated)
+
+	require.NoError(t, store.RotationAdd("new", models.RotatedSecret{Name: "current", From: 2, To: 3}))
+	require.NoError(t, store.RotationAdd("new", models.RotatedSecret{Name: "stale", From: 1, To: 2}))
+	require.NoError(t, store.RotationAdd("new", models.RotatedSecret{Name: "stale", From: 5, To: 6}))
+
+	// rotation is resumed
+	rotated, err = store.RotationBegin("new")
+	require.NoError(t, err)
+	require.Equal(t, map[string]models.RotatedSecret{
+		"current": {Name: "current", From: 2, To: 3},
+		"stale":   {Name: "stale", From: 5, To: 6},
+	}, rotated)
+
+	// only the local secret which was up to date gets the new version, data is kept
+	updated, err := store.RotationFinish("new")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), updated)
+	for name, version := range map[string]int64{"current": 3, "stale": 2, "pending": 2} {
+		secret, err := store.SecretGet(name)
+		require.NoError(t, err)
+		require.Equal(t, version, secret.Version, name)
+		require.Equal(t, []byte(name), secret.Data)
+	}
+
+	rotated, err = store.RotationBegin("new")
+	require.NoError(t, err)
+	require.Empty(t, rotated)
+}
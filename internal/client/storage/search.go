@@ -0,0 +1,205 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vkupriya/gophkeeper/internal/client/models"
+	"github.com/vkupriya/gophkeeper/internal/search"
+)
+
+// errNoFTS5 is returned when sqlite3 driver is built without sqlite_fts5 tag.
+var errNoFTS5 = errors.New("sqlite FTS5 module is not available")
+
+// SecretSearch returns local secrets which name, type or labels contain words of the query,
+// words match by prefix. Results are ordered by rank. FTS5 index kept with secrets is used if
+// sqlite3 driver is built with sqlite_fts5 tag, otherwise secrets are ranked by scanning them.
+func (s *SQLiteDB) SecretSearch(query string, limit int) ([]*models.SearchResult, error) {
+	terms, err := search.Terms(query)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.SecretList(models.SecretFilter{Recursive: true})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeoutDefault)
+	defer cancel()
+
+	results, err := s.searchFTS(ctx, items, terms)
+	if errors.Is(err, errNoFTS5) {
+		results = searchScan(items, terms)
+	} else if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Rank != results[j].Rank {
+			return results[i].Rank > results[j].Rank
+		}
+		return results[i].Secret.Name < results[j].Secret.Name
+	})
+	if limit = search.Limit(limit); len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// openSearchIndex creates FTS5 index of secrets if sqlite3 driver has FTS5 module and rebuilds the index
+// if it is marked stale.
+func (s *SQLiteDB) openSearchIndex() error {
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeoutDefault)
+	defer cancel()
+
+	if err := s.DB.QueryRowContext(ctx, "SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&s.fts); err != nil {
+		return fmt.Errorf("failed to check sqlite FTS5 module: %w", err)
+	}
+	if !s.fts {
+		return nil
+	}
+
+	var stale bool
+	if err := s.DB.QueryRowContext(ctx, "SELECT stale FROM search_index").Scan(&stale); err != nil {
+		return fmt.Errorf("failed to get search index state: %w", err)
+	}
+	if !stale {
+		return nil
+	}
+
+	items, err := s.SecretList(models.SecretFilter{Recursive: true})
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.ExecContext(ctx, "CREATE VIRTUAL TABLE IF NOT EXISTS secrets_fts USING fts5(name, type, labels)")
+	if err != nil {
+		return fmt.Errorf("failed to create search index: %w", err)
+	}
+	if err = s.unindexSecret(ctx, tx, ""); err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err = s.indexSecret(ctx, tx, item.Name, item.Type, item.Labels); err != nil {
+			return err
+		}
+	}
+	if _, err = tx.ExecContext(ctx, "UPDATE search_index SET stale=FALSE"); err != nil {
+		return fmt.Errorf("failed to update search index state: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit search index rebuild: %w", err)
+	}
+	return nil
+}
+
+// indexSecret replaces the secret in the search index within transaction. Without FTS5 module the index
+// is marked stale, so that it is rebuilt by the client which has the module.
+func (s *SQLiteDB) indexSecret(ctx context.Context, tx *sql.Tx, name string, typ string,
+	secretLabels map[string]string) error {
+	if err := s.unindexSecret(ctx, tx, name); err != nil {
+		return err
+	}
+	if !s.fts {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, "INSERT INTO secrets_fts (name, type, labels) VALUES(?,?,?)",
+		name, typ, search.LabelsText(secretLabels))
+	if err != nil {
+		return fmt.Errorf("failed to index secret %s: %w", name, err)
+	}
+	return nil
+}
+
+// unindexSecret removes the secret from the search index within transaction, all secrets are removed
+// if name is empty.
+func (s *SQLiteDB) unindexSecret(ctx context.Context, tx *sql.Tx, name string) error {
+	if !s.fts {
+		if _, err := tx.ExecContext(ctx, "UPDATE search_index SET stale=TRUE"); err != nil {
+			return fmt.Errorf("failed to mark search index stale: %w", err)
+		}
+		return nil
+	}
+	querySQL, args := "DELETE FROM secrets_fts WHERE name=?", []any{name}
+	if name == "" {
+		querySQL, args = "DELETE FROM secrets_fts", nil
+	}
+	if _, err := tx.ExecContext(ctx, querySQL, args...); err != nil {
+		return fmt.Errorf("failed to remove secret %s from search index: %w", name, err)
+	}
+	return nil
+}
+
+// searchFTS ranks items found by terms in FTS5 index of secrets.
+func (s *SQLiteDB) searchFTS(ctx context.Context, items []*models.SecretItem,
+	terms []string) ([]*models.SearchResult, error) {
+	if !s.fts {
+		return nil, errNoFTS5
+	}
+
+	byName := make(map[string]*models.SecretItem, len(items))
+	for _, item := range items {
+		byName[item.Name] = item
+	}
+
+	// terms contain only letters and digits, so they are safe in FTS5 query syntax
+	prefixes := make([]string, 0, len(terms))
+	for _, term := range terms {
+		prefixes = append(prefixes, `"`+term+`"*`)
+	}
+
+	querySQL := "SELECT name, -bm25(secrets_fts, ?, ?, ?) FROM secrets_fts WHERE secrets_fts MATCH ?"
+	rows, err := s.DB.QueryContext(ctx, querySQL, search.WeightName, search.WeightType, search.WeightLabels,
+		strings.Join(prefixes, " "))
+	if err != nil {
+		return nil, fmt.Errorf("error searching secrets: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Printf("failed to close rows")
+		}
+	}()
+
+	results := make([]*models.SearchResult, 0)
+	for rows.Next() {
+		var name string
+		var rank float64
+		if err = rows.Scan(&name, &rank); err != nil {
+			return nil, fmt.Errorf("failed to scan row of secret search: %w", err)
+		}
+		if item, ok := byName[name]; ok {
+			results = append(results, &models.SearchResult{Secret: item, Rank: rank})
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan rows of secret search: %w", err)
+	}
+	return results, nil
+}
+
+// searchScan ranks items by fields where every term is a prefix of some word,
+// items missing any term are skipped.
+func searchScan(items []*models.SecretItem, terms []string) []*models.SearchResult {
+	results := make([]*models.SearchResult, 0)
+	for _, item := range items {
+		if rank := search.Rank(terms, item.Name, item.Type, item.Labels); rank > 0 {
+			results = append(results, &models.SearchResult{Secret: item, Rank: rank})
+		}
+	}
+	return results
+}
@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vkupriya/gophkeeper/internal/client/models"
+	"github.com/vkupriya/gophkeeper/internal/search"
+)
+
+func TestSecretSearch(t *testing.T) {
+	store, err := NewSQLiteDB(filepath.Join(t.TempDir(), "search.db"))
+	require.NoError(t, err)
+	defer func() {
+		if err := store.DB.Close(); err != nil {
+			t.Error("failed to close local DB")
+		}
+	}()
+	require.NoError(t, RunMigrations(store))
+
+	secrets := []*models.Secret{
+		{Name: "work/aws/prod", Type: "text", Labels: map[string]string{"team": "payments"}},
+		{Name: "work/github", Type: "login", Labels: map[string]string{"env": "prod"}},
+		{Name: "home/wifi", Type: "text"},
+	}
+	for _, secret := range secrets {
+		secret.Data = []byte("data")
+		secret.Version = 1
+		require.NoError(t, store.SecretAdd(secret))
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "Name", query: "aws", want: []string{"work/aws/prod"}},
+		{name: "Prefix", query: "wor git", want: []string{"work/github"}},
+		{name: "Type", query: "login", want: []string{"work/github"}},
+		{name: "Labels", query: "payments", want: []string{"work/aws/prod"}},
+		{name: "Ranked", query: "prod", want: []string{"work/aws/prod", "work/github"}},
+		{name: "NotFound", query: "gitlab", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := store.SecretSearch(tt.query, 0)
+			require.NoError(t, err)
+			names := make([]string, 0, len(results))
+			for _, r := range results {
+				names = append(names, r.Secret.Name)
+			}
+			require.Equal(t, tt.want, names)
+		})
+	}
+
+	results, err := store.SecretSearch("work", 1)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	_, err = store.SecretSearch(" / ", 0)
+	require.ErrorIs(t, err, search.ErrEmptyQuery)
+}
+
+func TestSearchIndex(t *testing.T) {
+	dbpath := filepath.Join(t.TempDir(), "index.db")
+	store, err := NewSQLiteDB(dbpath)
+	require.NoError(t, err)
+
+	names := func(query string) []string {
+		results, err := store.SecretSearch(query, 0)
+		require.NoError(t, err)
+		found := make([]string, 0, len(results))
+		for _, r := range results {
+			found = append(found, r.Secret.Name)
+		}
+		return found
+	}
+
+	secret := &models.Secret{Name: "work/aws", Type: "text", Data: []byte("data"), Version: 1,
+		Labels: map[string]string{"env": "prod"}}
+	require.NoError(t, store.SecretAdd(secret))
+	require.Equal(t, []string{"work/aws"}, names("prod"))
+
+	// index follows updated labels and deleted secrets
+	secret.Labels = map[string]string{"env": "dev"}
+	require.NoError(t, store.SecretUpdate(secret))
+	require.Empty(t, names("prod"))
+	require.Equal(t, []string{"work/aws"}, names("dev"))
+	require.NoError(t, store.SecretDelete(secret.Name))
+	require.Empty(t, names("dev"))
+
+	// secret added without FTS5 module is indexed when DB is opened again
+	fts := store.fts
+	store.fts = false
+	require.NoError(t, store.SecretAdd(secret))
+	require.NoError(t, store.DB.Close())
+
+	store, err = NewSQLiteDB(dbpath)
+	require.NoError(t, err)
+	defer func() {
+		if err := store.DB.Close(); err != nil {
+			t.Error("failed to close local DB")
+		}
+	}()
+	require.Equal(t, fts, store.fts)
+	require.Equal(t, []string{"work/aws"}, names("dev"))
+
+	if store.fts {
+		var stale bool
+		require.NoError(t, store.DB.QueryRow("SELECT stale FROM search_index").Scan(&stale))
+		require.False(t, stale)
+	}
+}
+
+func TestSearchScan(t *testing.T) {
+	items := []*models.SecretItem{
+		{Name: "work/aws/prod", Type: "text", Labels: map[string]string{"team": "payments"}},
+		{Name: "work/github", Type: "login", Labels: map[string]string{"env": "prod"}},
+	}
+
+	results := searchScan(items, []string{"prod"})
+	require.Len(t, results, 2)
+	require.Equal(t, search.WeightName, results[0].Rank)
+	require.Equal(t, search.WeightLabels, results[1].Rank)
+
+	require.Empty(t, searchScan(items, []string{"work", "payments", "login"}))
+}
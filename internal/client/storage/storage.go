@@ -1,10 +1,13 @@
 package storage
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"embed"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-migrate/migrate/v4"
@@ -17,6 +20,8 @@ import (
 	_ "github.com/mattn/go-sqlite3"
 
 	"github.com/vkupriya/gophkeeper/internal/client/models"
+	"github.com/vkupriya/gophkeeper/internal/labels"
+	"github.com/vkupriya/gophkeeper/internal/secretpath"
 )
 
 const ctxTimeoutDefault time.Duration = time.Second * 3
@@ -27,18 +32,33 @@ var (
 	ErrSecretAlreadyExists = errors.New("secret already exists")
 	ErrSecretNotFound      = errors.New("secret not found")
 	ErrNoSecrets           = errors.New("no secrets")
+	ErrSecretCorrupted     = errors.New("secret data does not match its hash")
+	ErrSecretNoHash        = errors.New("secret has no hash")
 )
 
 type SQLiteDB struct {
 	DB *sql.DB
+	// fts is set if sqlite3 driver is built with FTS5 module
+	fts bool
 }
 
+// NewSQLiteDB opens local DB and applies pending migrations, so DB created by an older client
+// is upgraded when it is opened.
 func NewSQLiteDB(dbpath string) (*SQLiteDB, error) {
 	db, err := sql.Open("sqlite3", dbpath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open sqlite db: %w", err)
 	}
-	return &SQLiteDB{DB: db}, nil
+	s := &SQLiteDB{DB: db}
+	if err = RunMigrations(s); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	if err = s.openSearchIndex(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return s, nil
 }
 
 //go:embed migrations/*.sql
@@ -65,15 +85,39 @@ func RunMigrations(s *SQLiteDB) error {
 	return nil
 }
 
-func (s *SQLiteDB) SecretList() ([]*models.SecretItem, error) {
+// SecretList returns local secrets matching the filter, subfolders of non-recursive listing are
+// returned as items of folder type before secrets.
+func (s *SQLiteDB) SecretList(filter models.SecretFilter) ([]*models.SecretItem, error) {
+	selector, err := labels.ParseSelector(filter.Labels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse label selector: %w", err)
+	}
+	folder, err := secretpath.NormalizeFolder(filter.Folder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse folder: %w", err)
+	}
+
 	db := s.DB
 	secrets := make([]*models.SecretItem, 0)
 	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeoutDefault)
 	defer cancel()
 
+	// local DB does not keep update time, so secrets are sorted by name in updated orders too
 	querySQL := "SELECT name, type, version FROM secrets"
+	args := make([]any, 0, len(filter.Types))
+	if len(filter.Types) > 0 {
+		querySQL += " WHERE type IN (?" + strings.Repeat(", ?", len(filter.Types)-1) + ")"
+		for _, t := range filter.Types {
+			args = append(args, t)
+		}
+	}
+	if filter.Sort == models.SortNameDesc {
+		querySQL += " ORDER BY name DESC"
+	} else {
+		querySQL += " ORDER BY name"
+	}
 
-	rows, err := db.QueryContext(ctx, querySQL)
+	rows, err := db.QueryContext(ctx, querySQL, args...)
 	if err != nil {
 		return nil, fmt.Errorf("error querying secrets db: %w", err)
 	}
@@ -99,7 +143,73 @@ func (s *SQLiteDB) SecretList() ([]*models.SecretItem, error) {
 		return nil, fmt.Errorf("failed to scan rows in secrets table: %w", err)
 	}
 
-	return secrets, nil
+	all, err := queryLabels(ctx, db, "SELECT name, key, value FROM secret_labels")
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.SecretItem, 0, len(secrets))
+	folders := make([]*models.SecretItem, 0)
+	seen := make(map[string]bool)
+	for _, secret := range secrets {
+		secret.Labels = all[secret.Name]
+		if !selector.Matches(secret.Labels) {
+			continue
+		}
+		if secretpath.InFolder(secret.Name, folder, filter.Recursive) {
+			result = append(result, secret)
+			continue
+		}
+		if sub, ok := secretpath.Subfolder(secret.Name, folder); ok && !filter.Recursive && !seen[sub] {
+			seen[sub] = true
+			folders = append(folders, &models.SecretItem{Name: sub, Type: models.TypeFolder})
+		}
+	}
+	return append(folders, result...), nil
+}
+
+// queryLabels returns labels grouped by secret name, query must select name, key and value.
+func queryLabels(ctx context.Context, db *sql.DB, querySQL string, args ...any) (map[string]map[string]string, error) {
+	rows, err := db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying secret labels: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Printf("failed to close rows")
+		}
+	}()
+
+	result := make(map[string]map[string]string)
+	for rows.Next() {
+		var name, key, value string
+		if err = rows.Scan(&name, &key, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan row in secret_labels table: %w", err)
+		}
+		if result[name] == nil {
+			result[name] = make(map[string]string)
+		}
+		result[name][key] = value
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan rows in secret_labels table: %w", err)
+	}
+	return result, nil
+}
+
+// replaceLabels replaces all labels of the secret within transaction.
+func replaceLabels(ctx context.Context, tx *sql.Tx, name string, secretLabels map[string]string) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM secret_labels WHERE name=?", name); err != nil {
+		return fmt.Errorf("failed to delete labels of secret %s: %w", name, err)
+	}
+	for k, v := range secretLabels {
+		_, err := tx.ExecContext(ctx, "INSERT INTO secret_labels (name, key, value) VALUES(?,?,?)", name, k, v)
+		if err != nil {
+			return fmt.Errorf("failed to insert label %s of secret %s: %w", k, name, err)
+		}
+	}
+	return nil
 }
 
 func (s *SQLiteDB) SecretDelete(name string) error {
@@ -107,13 +217,27 @@ func (s *SQLiteDB) SecretDelete(name string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeoutDefault)
 	defer cancel()
 
-	querySQL := "DELETE FROM secrets WHERE name=?"
-
-	_, err := db.ExecContext(ctx, querySQL, name)
+	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM secrets WHERE name=?", name); err != nil {
 		return fmt.Errorf("error deleting secret %s: %w", name, err)
 	}
+	if _, err = tx.ExecContext(ctx, "DELETE FROM secret_labels WHERE name=?", name); err != nil {
+		return fmt.Errorf("error deleting labels of secret %s: %w", name, err)
+	}
+	if err = s.unindexSecret(ctx, tx, name); err != nil {
+		return err
+	}
 
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit deletion of secret %s: %w", name, err)
+	}
 	return nil
 }
 
@@ -122,13 +246,27 @@ func (s *SQLiteDB) SecretDeleteAll() error {
 	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeoutDefault)
 	defer cancel()
 
-	querySQL := "DELETE FROM secrets"
-
-	_, err := db.ExecContext(ctx, querySQL)
+	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM secrets"); err != nil {
 		return fmt.Errorf("error deleting all secrets: %w", err)
 	}
+	if _, err = tx.ExecContext(ctx, "DELETE FROM secret_labels"); err != nil {
+		return fmt.Errorf("error deleting all secret labels: %w", err)
+	}
+	if err = s.unindexSecret(ctx, tx, ""); err != nil {
+		return err
+	}
 
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit deletion of all secrets: %w", err)
+	}
 	return nil
 }
 
@@ -137,12 +275,30 @@ func (s *SQLiteDB) SecretAdd(secret *models.Secret) error {
 	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeoutDefault)
 	defer cancel()
 
-	querySQL := "INSERT INTO secrets (name, type, meta, data, version) VALUES(?,?,?,?,?)"
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	querySQL := "INSERT INTO secrets (name, type, data, version, hash) VALUES(?,?,?,?,?)"
 
-	_, err := db.ExecContext(ctx, querySQL, secret.Name, secret.Type, secret.Meta, secret.Data, secret.Version)
+	_, err = tx.ExecContext(ctx, querySQL, secret.Name, secret.Type, secret.Data, secret.Version, dataHash(secret.Data))
 	if err != nil {
 		return fmt.Errorf("failed to insert secret %s into SQLiteDB: %w", secret.Name, err)
 	}
+	if err = replaceLabels(ctx, tx, secret.Name, secret.Labels); err != nil {
+		return err
+	}
+	if err = s.indexSecret(ctx, tx, secret.Name, secret.Type, secret.Labels); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit secret %s into SQLiteDB: %w", secret.Name, err)
+	}
 	return nil
 }
 
@@ -151,30 +307,105 @@ func (s *SQLiteDB) SecretUpdate(secret *models.Secret) error {
 	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeoutDefault)
 	defer cancel()
 
-	querySQL := "UPDATE secrets SET type=?, meta=?, data=?, version=? WHERE name=?"
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
 
-	_, err := db.ExecContext(ctx, querySQL, secret.Type, secret.Meta, secret.Data, secret.Version, secret.Name)
+	querySQL := "UPDATE secrets SET type=?, data=?, version=?, hash=? WHERE name=?"
+
+	_, err = tx.ExecContext(ctx, querySQL, secret.Type, secret.Data, secret.Version, dataHash(secret.Data),
+		secret.Name)
 	if err != nil {
 		return fmt.Errorf("failed to update secret %s in SQLiteDB: %w", secret.Name, err)
 	}
+	if err = replaceLabels(ctx, tx, secret.Name, secret.Labels); err != nil {
+		return err
+	}
+	if err = s.indexSecret(ctx, tx, secret.Name, secret.Type, secret.Labels); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit secret %s update in SQLiteDB: %w", secret.Name, err)
+	}
 	return nil
 }
 
+// SecretGet returns local secret, ErrSecretCorrupted is returned if its data does not match its hash.
 func (s *SQLiteDB) SecretGet(name string) (*models.Secret, error) {
 	db := s.DB
 	var secret models.Secret
+	var hash []byte
 	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeoutDefault)
 	defer cancel()
 
-	querySQL := "SELECT * FROM secrets WHERE name=?"
+	querySQL := "SELECT name, type, data, version, hash FROM secrets WHERE name=?"
 
 	row := db.QueryRowContext(ctx, querySQL, name)
-	err := row.Scan(&secret.Name, &secret.Type, &secret.Meta, &secret.Data, &secret.Version)
+	err := row.Scan(&secret.Name, &secret.Type, &secret.Data, &secret.Version, &hash)
 	switch {
 	case errors.Is(err, sql.ErrNoRows):
 		return &models.Secret{}, ErrSecretNotFound
 	case err != nil:
 		return &models.Secret{}, fmt.Errorf("failed to query secret: %w", err)
 	}
+	// secrets stored before hashes were introduced are not verified
+	if hash != nil && !bytes.Equal(hash, dataHash(secret.Data)) {
+		return &models.Secret{}, fmt.Errorf("local secret %s: %w", name, ErrSecretCorrupted)
+	}
+
+	secretLabels, err := queryLabels(ctx, db, "SELECT name, key, value FROM secret_labels WHERE name=?", name)
+	if err != nil {
+		return &models.Secret{}, err
+	}
+	secret.Labels = secretLabels[name]
 	return &secret, nil
 }
+
+// SecretVerify checks data of all local secrets against their hashes and returns errors of the failed
+// ones by secret name: ErrSecretCorrupted or ErrSecretNoHash for secrets stored before hashes were introduced.
+func (s *SQLiteDB) SecretVerify() (map[string]error, error) {
+	db := s.DB
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeoutDefault)
+	defer cancel()
+
+	rows, err := db.QueryContext(ctx, "SELECT name, data, hash FROM secrets ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("error querying secrets db: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Printf("failed to close rows")
+		}
+	}()
+
+	failed := make(map[string]error)
+	for rows.Next() {
+		var name string
+		var data, hash []byte
+		if err = rows.Scan(&name, &data, &hash); err != nil {
+			return nil, fmt.Errorf("failed to scan row in secrets table: %w", err)
+		}
+		switch {
+		case hash == nil:
+			failed[name] = ErrSecretNoHash
+		case !bytes.Equal(hash, dataHash(data)):
+			failed[name] = ErrSecretCorrupted
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan rows in secrets table: %w", err)
+	}
+	return failed, nil
+}
+
+// dataHash returns SHA-256 hash of local secret data.
+func dataHash(data []byte) []byte {
+	hash := sha256.Sum256(data)
+	return hash[:]
+}
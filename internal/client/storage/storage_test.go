@@ -1,10 +1,14 @@
 package storage
 
 import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"github.com/vkupriya/gophkeeper/internal/client/models"
+	"github.com/vkupriya/gophkeeper/internal/labels"
 )
 
 var dbpath = "/tmp/test.db"
@@ -38,7 +42,7 @@ func TestSecretAdd(t *testing.T) {
 	secret := &models.Secret{
 		Name:    "card01",
 		Type:    "card",
-		Meta:    "metadata",
+		Labels:  map[string]string{"env": "prod", "team": "payments"},
 		Data:    []byte("hello world"),
 		Version: 1,
 	}
@@ -61,7 +65,7 @@ func TestSecretGet(t *testing.T) {
 	secret := &models.Secret{
 		Name:    "card01",
 		Type:    "card",
-		Meta:    "metadata",
+		Labels:  map[string]string{"env": "prod", "team": "payments"},
 		Data:    []byte("hello world"),
 		Version: 1,
 	}
@@ -82,11 +86,32 @@ func TestSecretList(t *testing.T) {
 		}
 	}()
 
-	secretList, err := store.SecretList()
+	secretList, err := store.SecretList(models.SecretFilter{Recursive: true})
 	require.NoError(t, err)
 	if len(secretList) == 0 {
 		t.Error("expected non-empty list of secrets")
 	}
+
+	tests := map[string]int{
+		"env=prod":                1,
+		"env=prod,team=payments":  1,
+		"env=dev":                 0,
+		"env!=prod":               0,
+		"team,!archived":          1,
+		"env=prod,team=security":  0,
+		"archived":                0,
+		"env=prod,team!=security": 1,
+	}
+	for selector, want := range tests {
+		t.Run(selector, func(t *testing.T) {
+			secretList, err := store.SecretList(models.SecretFilter{Labels: selector, Recursive: true})
+			require.NoError(t, err)
+			require.Len(t, secretList, want)
+			if want > 0 {
+				require.Equal(t, map[string]string{"env": "prod", "team": "payments"}, secretList[0].Labels)
+			}
+		})
+	}
 }
 
 func TestSecretDeleteAll(t *testing.T) {
@@ -103,3 +128,194 @@ func TestSecretDeleteAll(t *testing.T) {
 	err = store.SecretDeleteAll()
 	require.NoError(t, err)
 }
+
+func TestSecretListFolders(t *testing.T) {
+	store, err := NewSQLiteDB(filepath.Join(t.TempDir(), "folders.db"))
+	require.NoError(t, err)
+	defer func() {
+		if err := store.DB.Close(); err != nil {
+			t.Error("failed to close local DB")
+		}
+	}()
+	require.NoError(t, RunMigrations(store))
+
+	for _, name := range []string{"github", "work/github", "work/aws/prod", "work/aws/staging"} {
+		require.NoError(t, store.SecretAdd(&models.Secret{Name: name, Type: "text", Data: []byte(name), Version: 1}))
+	}
+
+	names := func(items []*models.SecretItem) []string {
+		result := make([]string, 0, len(items))
+		for _, item := range items {
+			result = append(result, item.Name)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name   string
+		filter models.SecretFilter
+		want   []string
+	}{
+		{name: "Root", filter: models.SecretFilter{}, want: []string{"work/", "github"}},
+		{name: "Folder", filter: models.SecretFilter{Folder: "work"}, want: []string{"work/aws/", "work/github"}},
+		{
+			name:   "Recursive",
+			filter: models.SecretFilter{Folder: "work/", Recursive: true},
+			want:   []string{"work/aws/prod", "work/aws/staging", "work/github"},
+		},
+		{name: "Missing", filter: models.SecretFilter{Folder: "home/"}, want: []string{}},
+		{
+			name:   "NameDesc",
+			filter: models.SecretFilter{Sort: models.SortNameDesc, Recursive: true},
+			want:   []string{"work/github", "work/aws/staging", "work/aws/prod", "github"},
+		},
+		{name: "Types", filter: models.SecretFilter{Types: []string{"text"}}, want: []string{"work/", "github"}},
+		{name: "Types_Missing", filter: models.SecretFilter{Types: []string{"login", "card"}}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := store.SecretList(tt.filter)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, names(items))
+		})
+	}
+
+	_, err = store.SecretList(models.SecretFilter{Folder: "work//"})
+	require.Error(t, err)
+}
+
+func TestSecretVerify(t *testing.T) {
+	store, err := NewSQLiteDB(filepath.Join(t.TempDir(), "verify.db"))
+	require.NoError(t, err)
+	defer func() {
+		if err := store.DB.Close(); err != nil {
+			t.Error("failed to close local DB")
+		}
+	}()
+	require.NoError(t, RunMigrations(store))
+
+	for _, name := range []string{"intact", "corrupted", "legacy"} {
+		require.NoError(t, store.SecretAdd(&models.Secret{Name: name, Type: "text", Data: []byte(name), Version: 1}))
+	}
+	_, err = store.DB.Exec("UPDATE secrets SET data=? WHERE name=?", []byte("changed"), "corrupted")
+	require.NoError(t, err)
+	// secret stored before hashes were introduced
+	_, err = store.DB.Exec("UPDATE secrets SET hash=NULL WHERE name=?", "legacy")
+	require.NoError(t, err)
+
+	failed, err := store.SecretVerify()
+	require.NoError(t, err)
+	require.Equal(t, map[string]error{"corrupted": ErrSecretCorrupted, "legacy": ErrSecretNoHash}, failed)
+
+	_, err = store.SecretGet("corrupted")
+	require.ErrorIs(t, err, ErrSecretCorrupted)
+	secret, err := store.SecretGet("legacy")
+	require.NoError(t, err)
+
+	// re-fetched secret gets the hash
+	require.NoError(t, store.SecretUpdate(secret))
+	failed, err = store.SecretVerify()
+	require.NoError(t, err)
+	require.Equal(t, map[string]error{"corrupted": ErrSecretCorrupted}, failed)
+}
+
+// createOldDB creates DB of a client which knew only the given migrations with one secret "old".
+func createOldDB(t *testing.T, path string, migrations ...string) {
+	t.Helper()
+	old, err := sql.Open("sqlite3", path)
+	require.NoError(t, err)
+	for _, name := range migrations {
+		schema, err := migrationsDir.ReadFile("migrations/" + name)
+		require.NoError(t, err)
+		_, err = old.Exec(string(schema))
+		require.NoError(t, err)
+	}
+	for _, query := range []string{
+		"CREATE TABLE schema_migrations (version uint64, dirty bool)",
+		fmt.Sprintf("INSERT INTO schema_migrations (version, dirty) VALUES (%d, false)", len(migrations)),
+		"INSERT INTO secrets (name, type, data, version) VALUES ('old', 'text', 'data', 1)",
+	} {
+		_, err = old.Exec(query)
+		require.NoError(t, err)
+	}
+	require.NoError(t, old.Close())
+}
+
+func TestUpgradeOnOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.db")
+	createOldDB(t, path, "00001_init.up.sql")
+
+	store, err := NewSQLiteDB(path)
+	require.NoError(t, err)
+	defer func() {
+		if err := store.DB.Close(); err != nil {
+			t.Error("failed to close local DB")
+		}
+	}()
+
+	failed, err := store.SecretVerify()
+	require.NoError(t, err)
+	require.Equal(t, map[string]error{"old": ErrSecretNoHash}, failed)
+	require.NoError(t, store.SecretAdd(&models.Secret{Name: "new", Type: "text", Data: []byte("data"), Version: 1,
+		Labels: map[string]string{"env": "prod"}}))
+	items, err := store.SecretList(models.SecretFilter{Labels: "env=prod", Recursive: true})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+}
+
+func TestUpgradeMeta(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.db")
+	createOldDB(t, path, "00001_init.up.sql")
+	// metadata was documented as JSON string
+	meta := `{"a":"x=1","b":"y"}`
+	old, err := sql.Open("sqlite3", path)
+	require.NoError(t, err)
+	_, err = old.Exec("UPDATE secrets SET meta = ? WHERE name = 'old'", meta)
+	require.NoError(t, err)
+	require.NoError(t, old.Close())
+
+	store, err := NewSQLiteDB(path)
+	require.NoError(t, err)
+	defer func() {
+		if err := store.DB.Close(); err != nil {
+			t.Error("failed to close local DB")
+		}
+	}()
+
+	secret, err := store.SecretGet("old")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"meta": meta}, secret.Labels)
+	require.NoError(t, labels.Validate(secret.Labels))
+	require.NoError(t, store.SecretUpdate(secret))
+	items, err := store.SecretList(models.SecretFilter{Labels: "meta=" + labels.EscapeValue(meta), Recursive: true})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+}
+
+func TestVerifyBeforeHashes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.db")
+	createOldDB(t, path, "00001_init.up.sql", "00002_secret_labels.up.sql")
+
+	// verify and sync open DB created before hashes, which is migrated first
+	store, err := NewSQLiteDB(path)
+	require.NoError(t, err)
+	defer func() {
+		if err := store.DB.Close(); err != nil {
+			t.Error("failed to close local DB")
+		}
+	}()
+
+	failed, err := store.SecretVerify()
+	require.NoError(t, err)
+	require.Equal(t, map[string]error{"old": ErrSecretNoHash}, failed)
+	secret, err := store.SecretGet("old")
+	require.NoError(t, err)
+	require.Equal(t, []byte("data"), secret.Data)
+
+	// re-fetched secret gets the hash
+	require.NoError(t, store.SecretUpdate(secret))
+	failed, err = store.SecretVerify()
+	require.NoError(t, err)
+	require.Empty(t, failed)
+}
@@ -0,0 +1,210 @@
+// Package labels implements secret labels validation and label selectors shared by
+// gophkeeper server and client.
+package labels
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	keyMaxLen   = 63
+	valueMaxLen = 255
+	maxLabels   = 64
+)
+
+var keyRegexp = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$`)
+
+var valueEscaper = strings.NewReplacer(`\`, `\\`, `,`, `\,`, `=`, `\=`)
+
+var (
+	ErrInvalidKey      = errors.New("invalid label key")
+	ErrInvalidValue    = errors.New("invalid label value")
+	ErrTooManyLabels   = errors.New("too many labels")
+	ErrInvalidSelector = errors.New("invalid label selector")
+)
+
+// Operator is a label selector requirement operator.
+type Operator int
+
+const (
+	// Equals requires label to be set to the value.
+	Equals Operator = iota
+	// NotEquals requires label to be missing or set to another value.
+	NotEquals
+	// Exists requires label to be set.
+	Exists
+	// NotExists requires label to be missing.
+	NotExists
+)
+
+// Requirement is a single condition of label selector.
+type Requirement struct {
+	Key      string
+	Value    string
+	Operator Operator
+}
+
+// Selector is a list of requirements, all of them must be satisfied.
+type Selector []Requirement
+
+// ValidateKey checks label key: up to 63 letters, digits, '.', '_', '/' or '-',
+// starting and ending with letter or digit.
+func ValidateKey(key string) error {
+	if len(key) > keyMaxLen || !keyRegexp.MatchString(key) {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return nil
+}
+
+// ValidateValue checks label value: up to 255 characters. Any characters are allowed,
+// ',', '=' and '\' are escaped with '\' in label selector.
+func ValidateValue(value string) error {
+	if len(value) > valueMaxLen {
+		return fmt.Errorf("%w: %q", ErrInvalidValue, value)
+	}
+	return nil
+}
+
+// Validate checks every label of the secret.
+func Validate(labels map[string]string) error {
+	if len(labels) > maxLabels {
+		return fmt.Errorf("%w: %d, at most %d allowed", ErrTooManyLabels, len(labels), maxLabels)
+	}
+	for k, v := range labels {
+		if err := ValidateKey(k); err != nil {
+			return err
+		}
+		if err := ValidateValue(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseSelector parses comma separated requirements: 'key=value' (or 'key==value'),
+// 'key!=value', 'key' and '!key'. Empty selector matches everything. Characters ',', '='
+// and '\' of values are escaped with '\', e.g. 'meta={"a":"x"\,"b":"y"}'.
+func ParseSelector(selector string) (Selector, error) {
+	result := Selector{}
+	if strings.TrimSpace(selector) == "" {
+		return result, nil
+	}
+
+	for _, part := range splitSelector(selector) {
+		part = strings.TrimSpace(part)
+		var r Requirement
+		// keys have no escaped characters, so the first '=' separates key and value
+		k, v, found := strings.Cut(part, "=")
+		switch {
+		case found && strings.HasSuffix(k, "!"):
+			r = Requirement{Key: strings.TrimSuffix(k, "!"), Value: v, Operator: NotEquals}
+		case found:
+			r = Requirement{Key: k, Value: strings.TrimPrefix(v, "="), Operator: Equals}
+		case strings.HasPrefix(part, "!"):
+			r = Requirement{Key: strings.TrimPrefix(part, "!"), Operator: NotExists}
+		default:
+			r = Requirement{Key: part, Operator: Exists}
+		}
+		r.Key = strings.TrimSpace(r.Key)
+
+		if err := ValidateKey(r.Key); err != nil {
+			return nil, fmt.Errorf("%w %q: %w", ErrInvalidSelector, part, err)
+		}
+		value, err := unescapeValue(strings.TrimSpace(r.Value))
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w", ErrInvalidSelector, part, err)
+		}
+		if err := ValidateValue(value); err != nil {
+			return nil, fmt.Errorf("%w %q: %w", ErrInvalidSelector, part, err)
+		}
+		r.Value = value
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// splitSelector splits selector by commas, which are not escaped with '\'.
+func splitSelector(selector string) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(selector); i++ {
+		switch selector[i] {
+		case '\\':
+			i++
+		case ',':
+			parts = append(parts, selector[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, selector[start:])
+}
+
+// unescapeValue removes escaping '\' from value of selector requirement.
+func unescapeValue(value string) (string, error) {
+	if !strings.Contains(value, "\\") {
+		return value, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' {
+			i++
+			if i == len(value) {
+				return "", fmt.Errorf("%w: %q ends with '\\'", ErrInvalidValue, value)
+			}
+		}
+		b.WriteByte(value[i])
+	}
+	return b.String(), nil
+}
+
+// EscapeValue escapes label value to be used in label selector.
+func EscapeValue(value string) string {
+	return valueEscaper.Replace(value)
+}
+
+// Matches reports whether labels satisfy every requirement of the selector.
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, r := range s {
+		v, ok := labels[r.Key]
+		switch r.Operator {
+		case Equals:
+			if !ok || v != r.Value {
+				return false
+			}
+		case NotEquals:
+			if ok && v == r.Value {
+				return false
+			}
+		case Exists:
+			if !ok {
+				return false
+			}
+		case NotExists:
+			if ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// String formats selector back to its textual form.
+func (s Selector) String() string {
+	parts := make([]string, 0, len(s))
+	for _, r := range s {
+		switch r.Operator {
+		case Equals:
+			parts = append(parts, r.Key+"="+EscapeValue(r.Value))
+		case NotEquals:
+			parts = append(parts, r.Key+"!="+EscapeValue(r.Value))
+		case Exists:
+			parts = append(parts, r.Key)
+		case NotExists:
+			parts = append(parts, "!"+r.Key)
+		}
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,126 @@
+package labels
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  map[string]string
+		wantErr error
+	}{
+		{name: "Success", labels: map[string]string{"env": "prod", "team.name/x-y_z": "payments team"}},
+		{name: "Success_Empty", labels: nil},
+		{name: "Success_EmptyValue", labels: map[string]string{"archived": ""}},
+		{name: "Fail_EmptyKey", labels: map[string]string{"": "prod"}, wantErr: ErrInvalidKey},
+		{name: "Fail_KeyChars", labels: map[string]string{"env!": "prod"}, wantErr: ErrInvalidKey},
+		{name: "Fail_KeyEdge", labels: map[string]string{"-env": "prod"}, wantErr: ErrInvalidKey},
+		{name: "Success_ValueJSON", labels: map[string]string{"meta": `{"a":"x=1","b":"y"}`}},
+		{name: "Fail_ValueLength", labels: map[string]string{"env": strings.Repeat("a", 256)}, wantErr: ErrInvalidValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.labels)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     Selector
+		wantErr  bool
+	}{
+		{name: "Empty", selector: " ", want: Selector{}},
+		{
+			name:     "Equals",
+			selector: "env=prod, team==payments",
+			want: Selector{
+				{Key: "env", Value: "prod", Operator: Equals},
+				{Key: "team", Value: "payments", Operator: Equals},
+			},
+		},
+		{
+			name:     "Mixed",
+			selector: "env!=dev,owner,!archived",
+			want: Selector{
+				{Key: "env", Value: "dev", Operator: NotEquals},
+				{Key: "owner", Operator: Exists},
+				{Key: "archived", Operator: NotExists},
+			},
+		},
+		{name: "Fail_EmptyRequirement", selector: "env=prod,", wantErr: true},
+		{name: "Fail_Key", selector: "=prod", wantErr: true},
+		{
+			name:     "Escaped",
+			selector: `meta={"a":"x\=1"\,"b":"y"},path!=c:\\tmp,op==a=b`,
+			want: Selector{
+				{Key: "meta", Value: `{"a":"x=1","b":"y"}`, Operator: Equals},
+				{Key: "path", Value: `c:\tmp`, Operator: NotEquals},
+				{Key: "op", Value: "a=b", Operator: Equals},
+			},
+		},
+		{name: "Fail_Escape", selector: `env=prod\`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSelector(tt.selector)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidSelector)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	labels := map[string]string{"env": "prod", "team": "payments"}
+
+	tests := map[string]bool{
+		"":                        true,
+		"env=prod":                true,
+		"env=prod,team=payments":  true,
+		"env=prod,team=security":  false,
+		"env!=dev":                true,
+		"env!=prod":               false,
+		"owner!=me":               true,
+		"team":                    true,
+		"owner":                   false,
+		"!owner":                  true,
+		"!env":                    false,
+		"env=prod,team,!archived": true,
+	}
+
+	for selector, want := range tests {
+		t.Run(selector, func(t *testing.T) {
+			s, err := ParseSelector(selector)
+			require.NoError(t, err)
+			require.Equal(t, want, s.Matches(labels))
+		})
+	}
+}
+
+func TestSelectorString(t *testing.T) {
+	s, err := ParseSelector("env==prod, team!=dev,owner,!archived")
+	require.NoError(t, err)
+	require.Equal(t, "env=prod,team!=dev,owner,!archived", s.String())
+
+	s = Selector{{Key: "meta", Value: `{"a":"x=1","b":"y\z"}`, Operator: Equals}}
+	parsed, err := ParseSelector(s.String())
+	require.NoError(t, err)
+	require.Equal(t, s, parsed)
+}
@@ -11,6 +11,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	proto "github.com/vkupriya/gophkeeper/internal/proto"
 	grpc "google.golang.org/grpc"
+	metadata "google.golang.org/grpc/metadata"
 )
 
 // MockGophKeeperClient is a mock of GophKeeperClient interface.
@@ -56,6 +57,46 @@ func (mr *MockGophKeeperClientMockRecorder) AddSecret(ctx, in interface{}, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSecret", reflect.TypeOf((*MockGophKeeperClient)(nil).AddSecret), varargs...)
 }
 
+// AddVaultMember mocks base method.
+func (m *MockGophKeeperClient) AddVaultMember(ctx context.Context, in *proto.VaultMemberRequest, opts ...grpc.CallOption) (*proto.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddVaultMember", varargs...)
+	ret0, _ := ret[0].(*proto.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddVaultMember indicates an expected call of AddVaultMember.
+func (mr *MockGophKeeperClientMockRecorder) AddVaultMember(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVaultMember", reflect.TypeOf((*MockGophKeeperClient)(nil).AddVaultMember), varargs...)
+}
+
+// CreateVault mocks base method.
+func (m *MockGophKeeperClient) CreateVault(ctx context.Context, in *proto.CreateVaultRequest, opts ...grpc.CallOption) (*proto.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateVault", varargs...)
+	ret0, _ := ret[0].(*proto.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVault indicates an expected call of CreateVault.
+func (mr *MockGophKeeperClientMockRecorder) CreateVault(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVault", reflect.TypeOf((*MockGophKeeperClient)(nil).CreateVault), varargs...)
+}
+
 // DeleteSecret mocks base method.
 func (m *MockGophKeeperClient) DeleteSecret(ctx context.Context, in *proto.DeleteSecretRequest, opts ...grpc.CallOption) (*proto.Empty, error) {
 	m.ctrl.T.Helper()
@@ -76,6 +117,46 @@ func (mr *MockGophKeeperClientMockRecorder) DeleteSecret(ctx, in interface{}, op
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MockGophKeeperClient)(nil).DeleteSecret), varargs...)
 }
 
+// DownloadSecret mocks base method.
+func (m *MockGophKeeperClient) DownloadSecret(ctx context.Context, in *proto.DownloadSecretRequest, opts ...grpc.CallOption) (proto.GophKeeper_DownloadSecretClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DownloadSecret", varargs...)
+	ret0, _ := ret[0].(proto.GophKeeper_DownloadSecretClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadSecret indicates an expected call of DownloadSecret.
+func (mr *MockGophKeeperClientMockRecorder) DownloadSecret(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadSecret", reflect.TypeOf((*MockGophKeeperClient)(nil).DownloadSecret), varargs...)
+}
+
+// GetPublicKey mocks base method.
+func (m *MockGophKeeperClient) GetPublicKey(ctx context.Context, in *proto.GetPublicKeyRequest, opts ...grpc.CallOption) (*proto.GetPublicKeyResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetPublicKey", varargs...)
+	ret0, _ := ret[0].(*proto.GetPublicKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicKey indicates an expected call of GetPublicKey.
+func (mr *MockGophKeeperClientMockRecorder) GetPublicKey(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicKey", reflect.TypeOf((*MockGophKeeperClient)(nil).GetPublicKey), varargs...)
+}
+
 // GetSecret mocks base method.
 func (m *MockGophKeeperClient) GetSecret(ctx context.Context, in *proto.GetSecretRequest, opts ...grpc.CallOption) (*proto.GetSecretResponse, error) {
 	m.ctrl.T.Helper()
@@ -96,239 +177,1327 @@ func (mr *MockGophKeeperClientMockRecorder) GetSecret(ctx, in interface{}, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockGophKeeperClient)(nil).GetSecret), varargs...)
 }
 
-// ListSecrets mocks base method.
-func (m *MockGophKeeperClient) ListSecrets(ctx context.Context, in *proto.Empty, opts ...grpc.CallOption) (*proto.ListSecretsResponse, error) {
+// GetSharedSecret mocks base method.
+func (m *MockGophKeeperClient) GetSharedSecret(ctx context.Context, in *proto.GetSharedSecretRequest, opts ...grpc.CallOption) (*proto.GetSharedSecretResponse, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{ctx, in}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "ListSecrets", varargs...)
-	ret0, _ := ret[0].(*proto.ListSecretsResponse)
+	ret := m.ctrl.Call(m, "GetSharedSecret", varargs...)
+	ret0, _ := ret[0].(*proto.GetSharedSecretResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListSecrets indicates an expected call of ListSecrets.
-func (mr *MockGophKeeperClientMockRecorder) ListSecrets(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+// GetSharedSecret indicates an expected call of GetSharedSecret.
+func (mr *MockGophKeeperClientMockRecorder) GetSharedSecret(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]interface{}{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecrets", reflect.TypeOf((*MockGophKeeperClient)(nil).ListSecrets), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedSecret", reflect.TypeOf((*MockGophKeeperClient)(nil).GetSharedSecret), varargs...)
 }
 
-// Login mocks base method.
-func (m *MockGophKeeperClient) Login(ctx context.Context, in *proto.User, opts ...grpc.CallOption) (*proto.UserAuthToken, error) {
+// GetVaultKey mocks base method.
+func (m *MockGophKeeperClient) GetVaultKey(ctx context.Context, in *proto.Empty, opts ...grpc.CallOption) (*proto.GetVaultKeyResponse, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{ctx, in}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "Login", varargs...)
-	ret0, _ := ret[0].(*proto.UserAuthToken)
+	ret := m.ctrl.Call(m, "GetVaultKey", varargs...)
+	ret0, _ := ret[0].(*proto.GetVaultKeyResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Login indicates an expected call of Login.
-func (mr *MockGophKeeperClientMockRecorder) Login(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+// GetVaultKey indicates an expected call of GetVaultKey.
+func (mr *MockGophKeeperClientMockRecorder) GetVaultKey(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]interface{}{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockGophKeeperClient)(nil).Login), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVaultKey", reflect.TypeOf((*MockGophKeeperClient)(nil).GetVaultKey), varargs...)
 }
 
-// Register mocks base method.
-func (m *MockGophKeeperClient) Register(ctx context.Context, in *proto.User, opts ...grpc.CallOption) (*proto.UserAuthToken, error) {
+// ListAuditEvents mocks base method.
+func (m *MockGophKeeperClient) ListAuditEvents(ctx context.Context, in *proto.ListAuditEventsRequest, opts ...grpc.CallOption) (*proto.ListAuditEventsResponse, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{ctx, in}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "Register", varargs...)
-	ret0, _ := ret[0].(*proto.UserAuthToken)
+	ret := m.ctrl.Call(m, "ListAuditEvents", varargs...)
+	ret0, _ := ret[0].(*proto.ListAuditEventsResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Register indicates an expected call of Register.
-func (mr *MockGophKeeperClientMockRecorder) Register(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+// ListAuditEvents indicates an expected call of ListAuditEvents.
+func (mr *MockGophKeeperClientMockRecorder) ListAuditEvents(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]interface{}{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockGophKeeperClient)(nil).Register), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditEvents", reflect.TypeOf((*MockGophKeeperClient)(nil).ListAuditEvents), varargs...)
 }
 
-// UpdateSecret mocks base method.
-func (m *MockGophKeeperClient) UpdateSecret(ctx context.Context, in *proto.UpdateSecretRequest, opts ...grpc.CallOption) (*proto.Empty, error) {
+// ListSecretVersions mocks base method.
+func (m *MockGophKeeperClient) ListSecretVersions(ctx context.Context, in *proto.ListSecretVersionsRequest, opts ...grpc.CallOption) (*proto.ListSecretVersionsResponse, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{ctx, in}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "UpdateSecret", varargs...)
-	ret0, _ := ret[0].(*proto.Empty)
+	ret := m.ctrl.Call(m, "ListSecretVersions", varargs...)
+	ret0, _ := ret[0].(*proto.ListSecretVersionsResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateSecret indicates an expected call of UpdateSecret.
-func (mr *MockGophKeeperClientMockRecorder) UpdateSecret(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+// ListSecretVersions indicates an expected call of ListSecretVersions.
+func (mr *MockGophKeeperClientMockRecorder) ListSecretVersions(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]interface{}{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecret", reflect.TypeOf((*MockGophKeeperClient)(nil).UpdateSecret), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecretVersions", reflect.TypeOf((*MockGophKeeperClient)(nil).ListSecretVersions), varargs...)
 }
 
-// MockGophKeeperServer is a mock of GophKeeperServer interface.
-type MockGophKeeperServer struct {
-	ctrl     *gomock.Controller
-	recorder *MockGophKeeperServerMockRecorder
+// ListSecrets mocks base method.
+func (m *MockGophKeeperClient) ListSecrets(ctx context.Context, in *proto.ListSecretsRequest, opts ...grpc.CallOption) (*proto.ListSecretsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListSecrets", varargs...)
+	ret0, _ := ret[0].(*proto.ListSecretsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MockGophKeeperServerMockRecorder is the mock recorder for MockGophKeeperServer.
-type MockGophKeeperServerMockRecorder struct {
-	mock *MockGophKeeperServer
+// ListSecrets indicates an expected call of ListSecrets.
+func (mr *MockGophKeeperClientMockRecorder) ListSecrets(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecrets", reflect.TypeOf((*MockGophKeeperClient)(nil).ListSecrets), varargs...)
 }
 
-// NewMockGophKeeperServer creates a new mock instance.
-func NewMockGophKeeperServer(ctrl *gomock.Controller) *MockGophKeeperServer {
-	mock := &MockGophKeeperServer{ctrl: ctrl}
-	mock.recorder = &MockGophKeeperServerMockRecorder{mock}
-	return mock
+// ListSharedSecrets mocks base method.
+func (m *MockGophKeeperClient) ListSharedSecrets(ctx context.Context, in *proto.ListSharedSecretsRequest, opts ...grpc.CallOption) (*proto.ListSharedSecretsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListSharedSecrets", varargs...)
+	ret0, _ := ret[0].(*proto.ListSharedSecretsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockGophKeeperServer) EXPECT() *MockGophKeeperServerMockRecorder {
-	return m.recorder
+// ListSharedSecrets indicates an expected call of ListSharedSecrets.
+func (mr *MockGophKeeperClientMockRecorder) ListSharedSecrets(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSharedSecrets", reflect.TypeOf((*MockGophKeeperClient)(nil).ListSharedSecrets), varargs...)
 }
 
-// AddSecret mocks base method.
-func (m *MockGophKeeperServer) AddSecret(arg0 context.Context, arg1 *proto.AddSecretRequest) (*proto.Empty, error) {
+// ListVaultMembers mocks base method.
+func (m *MockGophKeeperClient) ListVaultMembers(ctx context.Context, in *proto.ListVaultMembersRequest, opts ...grpc.CallOption) (*proto.ListVaultMembersResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddSecret", arg0, arg1)
-	ret0, _ := ret[0].(*proto.Empty)
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListVaultMembers", varargs...)
+	ret0, _ := ret[0].(*proto.ListVaultMembersResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// AddSecret indicates an expected call of AddSecret.
-func (mr *MockGophKeeperServerMockRecorder) AddSecret(arg0, arg1 interface{}) *gomock.Call {
+// ListVaultMembers indicates an expected call of ListVaultMembers.
+func (mr *MockGophKeeperClientMockRecorder) ListVaultMembers(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSecret", reflect.TypeOf((*MockGophKeeperServer)(nil).AddSecret), arg0, arg1)
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVaultMembers", reflect.TypeOf((*MockGophKeeperClient)(nil).ListVaultMembers), varargs...)
 }
 
-// DeleteSecret mocks base method.
-func (m *MockGophKeeperServer) DeleteSecret(arg0 context.Context, arg1 *proto.DeleteSecretRequest) (*proto.Empty, error) {
+// ListVaults mocks base method.
+func (m *MockGophKeeperClient) ListVaults(ctx context.Context, in *proto.Empty, opts ...grpc.CallOption) (*proto.ListVaultsResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSecret", arg0, arg1)
-	ret0, _ := ret[0].(*proto.Empty)
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListVaults", varargs...)
+	ret0, _ := ret[0].(*proto.ListVaultsResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeleteSecret indicates an expected call of DeleteSecret.
-func (mr *MockGophKeeperServerMockRecorder) DeleteSecret(arg0, arg1 interface{}) *gomock.Call {
+// ListVaults indicates an expected call of ListVaults.
+func (mr *MockGophKeeperClientMockRecorder) ListVaults(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MockGophKeeperServer)(nil).DeleteSecret), arg0, arg1)
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVaults", reflect.TypeOf((*MockGophKeeperClient)(nil).ListVaults), varargs...)
 }
 
-// GetSecret mocks base method.
-func (m *MockGophKeeperServer) GetSecret(arg0 context.Context, arg1 *proto.GetSecretRequest) (*proto.GetSecretResponse, error) {
+// Login mocks base method.
+func (m *MockGophKeeperClient) Login(ctx context.Context, in *proto.User, opts ...grpc.CallOption) (*proto.UserAuthToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSecret", arg0, arg1)
-	ret0, _ := ret[0].(*proto.GetSecretResponse)
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Login", varargs...)
+	ret0, _ := ret[0].(*proto.UserAuthToken)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSecret indicates an expected call of GetSecret.
-func (mr *MockGophKeeperServerMockRecorder) GetSecret(arg0, arg1 interface{}) *gomock.Call {
+// Login indicates an expected call of Login.
+func (mr *MockGophKeeperClientMockRecorder) Login(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockGophKeeperServer)(nil).GetSecret), arg0, arg1)
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockGophKeeperClient)(nil).Login), varargs...)
 }
 
-// ListSecrets mocks base method.
-func (m *MockGophKeeperServer) ListSecrets(arg0 context.Context, arg1 *proto.Empty) (*proto.ListSecretsResponse, error) {
+// Logout mocks base method.
+func (m *MockGophKeeperClient) Logout(ctx context.Context, in *proto.LogoutRequest, opts ...grpc.CallOption) (*proto.Empty, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListSecrets", arg0, arg1)
-	ret0, _ := ret[0].(*proto.ListSecretsResponse)
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Logout", varargs...)
+	ret0, _ := ret[0].(*proto.Empty)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListSecrets indicates an expected call of ListSecrets.
-func (mr *MockGophKeeperServerMockRecorder) ListSecrets(arg0, arg1 interface{}) *gomock.Call {
+// Logout indicates an expected call of Logout.
+func (mr *MockGophKeeperClientMockRecorder) Logout(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecrets", reflect.TypeOf((*MockGophKeeperServer)(nil).ListSecrets), arg0, arg1)
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockGophKeeperClient)(nil).Logout), varargs...)
 }
 
-// Login mocks base method.
-func (m *MockGophKeeperServer) Login(arg0 context.Context, arg1 *proto.User) (*proto.UserAuthToken, error) {
+// MoveSecret mocks base method.
+func (m *MockGophKeeperClient) MoveSecret(ctx context.Context, in *proto.MoveSecretRequest, opts ...grpc.CallOption) (*proto.MoveSecretResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", arg0, arg1)
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MoveSecret", varargs...)
+	ret0, _ := ret[0].(*proto.MoveSecretResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveSecret indicates an expected call of MoveSecret.
+func (mr *MockGophKeeperClientMockRecorder) MoveSecret(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveSecret", reflect.TypeOf((*MockGophKeeperClient)(nil).MoveSecret), varargs...)
+}
+
+// RefreshToken mocks base method.
+func (m *MockGophKeeperClient) RefreshToken(ctx context.Context, in *proto.RefreshTokenRequest, opts ...grpc.CallOption) (*proto.UserAuthToken, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RefreshToken", varargs...)
 	ret0, _ := ret[0].(*proto.UserAuthToken)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Login indicates an expected call of Login.
-func (mr *MockGophKeeperServerMockRecorder) Login(arg0, arg1 interface{}) *gomock.Call {
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockGophKeeperClientMockRecorder) RefreshToken(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockGophKeeperServer)(nil).Login), arg0, arg1)
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockGophKeeperClient)(nil).RefreshToken), varargs...)
 }
 
 // Register mocks base method.
-func (m *MockGophKeeperServer) Register(arg0 context.Context, arg1 *proto.User) (*proto.UserAuthToken, error) {
+func (m *MockGophKeeperClient) Register(ctx context.Context, in *proto.User, opts ...grpc.CallOption) (*proto.UserAuthToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Register", arg0, arg1)
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Register", varargs...)
 	ret0, _ := ret[0].(*proto.UserAuthToken)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Register indicates an expected call of Register.
-func (mr *MockGophKeeperServerMockRecorder) Register(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockGophKeeperClientMockRecorder) Register(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockGophKeeperServer)(nil).Register), arg0, arg1)
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockGophKeeperClient)(nil).Register), varargs...)
 }
 
-// UpdateSecret mocks base method.
-func (m *MockGophKeeperServer) UpdateSecret(arg0 context.Context, arg1 *proto.UpdateSecretRequest) (*proto.Empty, error) {
+// RemoveVaultMember mocks base method.
+func (m *MockGophKeeperClient) RemoveVaultMember(ctx context.Context, in *proto.VaultMemberRequest, opts ...grpc.CallOption) (*proto.Empty, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateSecret", arg0, arg1)
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveVaultMember", varargs...)
 	ret0, _ := ret[0].(*proto.Empty)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateSecret indicates an expected call of UpdateSecret.
-func (mr *MockGophKeeperServerMockRecorder) UpdateSecret(arg0, arg1 interface{}) *gomock.Call {
+// RemoveVaultMember indicates an expected call of RemoveVaultMember.
+func (mr *MockGophKeeperClientMockRecorder) RemoveVaultMember(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecret", reflect.TypeOf((*MockGophKeeperServer)(nil).UpdateSecret), arg0, arg1)
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveVaultMember", reflect.TypeOf((*MockGophKeeperClient)(nil).RemoveVaultMember), varargs...)
 }
 
-// mustEmbedUnimplementedGophKeeperServer mocks base method.
-func (m *MockGophKeeperServer) mustEmbedUnimplementedGophKeeperServer() {
+// ReplaceSecretVersions mocks base method.
+func (m *MockGophKeeperClient) ReplaceSecretVersions(ctx context.Context, in *proto.ReplaceSecretVersionsRequest, opts ...grpc.CallOption) (*proto.Empty, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "mustEmbedUnimplementedGophKeeperServer")
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReplaceSecretVersions", varargs...)
+	ret0, _ := ret[0].(*proto.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// mustEmbedUnimplementedGophKeeperServer indicates an expected call of mustEmbedUnimplementedGophKeeperServer.
-func (mr *MockGophKeeperServerMockRecorder) mustEmbedUnimplementedGophKeeperServer() *gomock.Call {
+// ReplaceSecretVersions indicates an expected call of ReplaceSecretVersions.
+func (mr *MockGophKeeperClientMockRecorder) ReplaceSecretVersions(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedGophKeeperServer", reflect.TypeOf((*MockGophKeeperServer)(nil).mustEmbedUnimplementedGophKeeperServer))
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceSecretVersions", reflect.TypeOf((*MockGophKeeperClient)(nil).ReplaceSecretVersions), varargs...)
 }
 
-// MockUnsafeGophKeeperServer is a mock of UnsafeGophKeeperServer interface.
-type MockUnsafeGophKeeperServer struct {
-	ctrl     *gomock.Controller
-	recorder *MockUnsafeGophKeeperServerMockRecorder
+// RevokeSessions mocks base method.
+func (m *MockGophKeeperClient) RevokeSessions(ctx context.Context, in *proto.Empty, opts ...grpc.CallOption) (*proto.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeSessions", varargs...)
+	ret0, _ := ret[0].(*proto.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MockUnsafeGophKeeperServerMockRecorder is the mock recorder for MockUnsafeGophKeeperServer.
-type MockUnsafeGophKeeperServerMockRecorder struct {
-	mock *MockUnsafeGophKeeperServer
+// RevokeSessions indicates an expected call of RevokeSessions.
+func (mr *MockGophKeeperClientMockRecorder) RevokeSessions(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessions", reflect.TypeOf((*MockGophKeeperClient)(nil).RevokeSessions), varargs...)
 }
 
-// NewMockUnsafeGophKeeperServer creates a new mock instance.
-func NewMockUnsafeGophKeeperServer(ctrl *gomock.Controller) *MockUnsafeGophKeeperServer {
+// RevokeShare mocks base method.
+func (m *MockGophKeeperClient) RevokeShare(ctx context.Context, in *proto.RevokeShareRequest, opts ...grpc.CallOption) (*proto.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeShare", varargs...)
+	ret0, _ := ret[0].(*proto.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeShare indicates an expected call of RevokeShare.
+func (mr *MockGophKeeperClientMockRecorder) RevokeShare(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeShare", reflect.TypeOf((*MockGophKeeperClient)(nil).RevokeShare), varargs...)
+}
+
+// RollbackSecret mocks base method.
+func (m *MockGophKeeperClient) RollbackSecret(ctx context.Context, in *proto.RollbackSecretRequest, opts ...grpc.CallOption) (*proto.RollbackSecretResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RollbackSecret", varargs...)
+	ret0, _ := ret[0].(*proto.RollbackSecretResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RollbackSecret indicates an expected call of RollbackSecret.
+func (mr *MockGophKeeperClientMockRecorder) RollbackSecret(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackSecret", reflect.TypeOf((*MockGophKeeperClient)(nil).RollbackSecret), varargs...)
+}
+
+// SearchSecrets mocks base method.
+func (m *MockGophKeeperClient) SearchSecrets(ctx context.Context, in *proto.SearchSecretsRequest, opts ...grpc.CallOption) (*proto.SearchSecretsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SearchSecrets", varargs...)
+	ret0, _ := ret[0].(*proto.SearchSecretsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchSecrets indicates an expected call of SearchSecrets.
+func (mr *MockGophKeeperClientMockRecorder) SearchSecrets(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchSecrets", reflect.TypeOf((*MockGophKeeperClient)(nil).SearchSecrets), varargs...)
+}
+
+// SetPublicKey mocks base method.
+func (m *MockGophKeeperClient) SetPublicKey(ctx context.Context, in *proto.SetPublicKeyRequest, opts ...grpc.CallOption) (*proto.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetPublicKey", varargs...)
+	ret0, _ := ret[0].(*proto.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPublicKey indicates an expected call of SetPublicKey.
+func (mr *MockGophKeeperClientMockRecorder) SetPublicKey(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPublicKey", reflect.TypeOf((*MockGophKeeperClient)(nil).SetPublicKey), varargs...)
+}
+
+// SetVaultKey mocks base method.
+func (m *MockGophKeeperClient) SetVaultKey(ctx context.Context, in *proto.SetVaultKeyRequest, opts ...grpc.CallOption) (*proto.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetVaultKey", varargs...)
+	ret0, _ := ret[0].(*proto.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetVaultKey indicates an expected call of SetVaultKey.
+func (mr *MockGophKeeperClientMockRecorder) SetVaultKey(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVaultKey", reflect.TypeOf((*MockGophKeeperClient)(nil).SetVaultKey), varargs...)
+}
+
+// ShareSecret mocks base method.
+func (m *MockGophKeeperClient) ShareSecret(ctx context.Context, in *proto.ShareSecretRequest, opts ...grpc.CallOption) (*proto.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ShareSecret", varargs...)
+	ret0, _ := ret[0].(*proto.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShareSecret indicates an expected call of ShareSecret.
+func (mr *MockGophKeeperClientMockRecorder) ShareSecret(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareSecret", reflect.TypeOf((*MockGophKeeperClient)(nil).ShareSecret), varargs...)
+}
+
+// UpdateSecret mocks base method.
+func (m *MockGophKeeperClient) UpdateSecret(ctx context.Context, in *proto.UpdateSecretRequest, opts ...grpc.CallOption) (*proto.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateSecret", varargs...)
+	ret0, _ := ret[0].(*proto.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSecret indicates an expected call of UpdateSecret.
+func (mr *MockGophKeeperClientMockRecorder) UpdateSecret(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecret", reflect.TypeOf((*MockGophKeeperClient)(nil).UpdateSecret), varargs...)
+}
+
+// UpdateSharedSecret mocks base method.
+func (m *MockGophKeeperClient) UpdateSharedSecret(ctx context.Context, in *proto.UpdateSharedSecretRequest, opts ...grpc.CallOption) (*proto.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateSharedSecret", varargs...)
+	ret0, _ := ret[0].(*proto.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSharedSecret indicates an expected call of UpdateSharedSecret.
+func (mr *MockGophKeeperClientMockRecorder) UpdateSharedSecret(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSharedSecret", reflect.TypeOf((*MockGophKeeperClient)(nil).UpdateSharedSecret), varargs...)
+}
+
+// UploadSecret mocks base method.
+func (m *MockGophKeeperClient) UploadSecret(ctx context.Context, opts ...grpc.CallOption) (proto.GophKeeper_UploadSecretClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UploadSecret", varargs...)
+	ret0, _ := ret[0].(proto.GophKeeper_UploadSecretClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadSecret indicates an expected call of UploadSecret.
+func (mr *MockGophKeeperClientMockRecorder) UploadSecret(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadSecret", reflect.TypeOf((*MockGophKeeperClient)(nil).UploadSecret), varargs...)
+}
+
+// VerifyAuditLog mocks base method.
+func (m *MockGophKeeperClient) VerifyAuditLog(ctx context.Context, in *proto.Empty, opts ...grpc.CallOption) (*proto.VerifyAuditLogResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "VerifyAuditLog", varargs...)
+	ret0, _ := ret[0].(*proto.VerifyAuditLogResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyAuditLog indicates an expected call of VerifyAuditLog.
+func (mr *MockGophKeeperClientMockRecorder) VerifyAuditLog(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAuditLog", reflect.TypeOf((*MockGophKeeperClient)(nil).VerifyAuditLog), varargs...)
+}
+
+// MockGophKeeper_UploadSecretClient is a mock of GophKeeper_UploadSecretClient interface.
+type MockGophKeeper_UploadSecretClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockGophKeeper_UploadSecretClientMockRecorder
+}
+
+// MockGophKeeper_UploadSecretClientMockRecorder is the mock recorder for MockGophKeeper_UploadSecretClient.
+type MockGophKeeper_UploadSecretClientMockRecorder struct {
+	mock *MockGophKeeper_UploadSecretClient
+}
+
+// NewMockGophKeeper_UploadSecretClient creates a new mock instance.
+func NewMockGophKeeper_UploadSecretClient(ctrl *gomock.Controller) *MockGophKeeper_UploadSecretClient {
+	mock := &MockGophKeeper_UploadSecretClient{ctrl: ctrl}
+	mock.recorder = &MockGophKeeper_UploadSecretClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGophKeeper_UploadSecretClient) EXPECT() *MockGophKeeper_UploadSecretClientMockRecorder {
+	return m.recorder
+}
+
+// CloseAndRecv mocks base method.
+func (m *MockGophKeeper_UploadSecretClient) CloseAndRecv() (*proto.UploadSecretResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseAndRecv")
+	ret0, _ := ret[0].(*proto.UploadSecretResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseAndRecv indicates an expected call of CloseAndRecv.
+func (mr *MockGophKeeper_UploadSecretClientMockRecorder) CloseAndRecv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseAndRecv", reflect.TypeOf((*MockGophKeeper_UploadSecretClient)(nil).CloseAndRecv))
+}
+
+// CloseSend mocks base method.
+func (m *MockGophKeeper_UploadSecretClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockGophKeeper_UploadSecretClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockGophKeeper_UploadSecretClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockGophKeeper_UploadSecretClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockGophKeeper_UploadSecretClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockGophKeeper_UploadSecretClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockGophKeeper_UploadSecretClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockGophKeeper_UploadSecretClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockGophKeeper_UploadSecretClient)(nil).Header))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockGophKeeper_UploadSecretClient) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockGophKeeper_UploadSecretClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockGophKeeper_UploadSecretClient)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockGophKeeper_UploadSecretClient) Send(arg0 *proto.UploadSecretRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockGophKeeper_UploadSecretClientMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockGophKeeper_UploadSecretClient)(nil).Send), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockGophKeeper_UploadSecretClient) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockGophKeeper_UploadSecretClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockGophKeeper_UploadSecretClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockGophKeeper_UploadSecretClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockGophKeeper_UploadSecretClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockGophKeeper_UploadSecretClient)(nil).Trailer))
+}
+
+// MockGophKeeper_DownloadSecretClient is a mock of GophKeeper_DownloadSecretClient interface.
+type MockGophKeeper_DownloadSecretClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockGophKeeper_DownloadSecretClientMockRecorder
+}
+
+// MockGophKeeper_DownloadSecretClientMockRecorder is the mock recorder for MockGophKeeper_DownloadSecretClient.
+type MockGophKeeper_DownloadSecretClientMockRecorder struct {
+	mock *MockGophKeeper_DownloadSecretClient
+}
+
+// NewMockGophKeeper_DownloadSecretClient creates a new mock instance.
+func NewMockGophKeeper_DownloadSecretClient(ctrl *gomock.Controller) *MockGophKeeper_DownloadSecretClient {
+	mock := &MockGophKeeper_DownloadSecretClient{ctrl: ctrl}
+	mock.recorder = &MockGophKeeper_DownloadSecretClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGophKeeper_DownloadSecretClient) EXPECT() *MockGophKeeper_DownloadSecretClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockGophKeeper_DownloadSecretClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockGophKeeper_DownloadSecretClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockGophKeeper_DownloadSecretClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockGophKeeper_DownloadSecretClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockGophKeeper_DownloadSecretClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockGophKeeper_DownloadSecretClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockGophKeeper_DownloadSecretClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockGophKeeper_DownloadSecretClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockGophKeeper_DownloadSecretClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockGophKeeper_DownloadSecretClient) Recv() (*proto.DownloadSecretResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*proto.DownloadSecretResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockGophKeeper_DownloadSecretClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockGophKeeper_DownloadSecretClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockGophKeeper_DownloadSecretClient) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockGophKeeper_DownloadSecretClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockGophKeeper_DownloadSecretClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockGophKeeper_DownloadSecretClient) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockGophKeeper_DownloadSecretClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockGophKeeper_DownloadSecretClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockGophKeeper_DownloadSecretClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockGophKeeper_DownloadSecretClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockGophKeeper_DownloadSecretClient)(nil).Trailer))
+}
+
+// MockGophKeeperServer is a mock of GophKeeperServer interface.
+type MockGophKeeperServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockGophKeeperServerMockRecorder
+}
+
+// MockGophKeeperServerMockRecorder is the mock recorder for MockGophKeeperServer.
+type MockGophKeeperServerMockRecorder struct {
+	mock *MockGophKeeperServer
+}
+
+// NewMockGophKeeperServer creates a new mock instance.
+func NewMockGophKeeperServer(ctrl *gomock.Controller) *MockGophKeeperServer {
+	mock := &MockGophKeeperServer{ctrl: ctrl}
+	mock.recorder = &MockGophKeeperServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGophKeeperServer) EXPECT() *MockGophKeeperServerMockRecorder {
+	return m.recorder
+}
+
+// AddSecret mocks base method.
+func (m *MockGophKeeperServer) AddSecret(arg0 context.Context, arg1 *proto.AddSecretRequest) (*proto.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSecret", arg0, arg1)
+	ret0, _ := ret[0].(*proto.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSecret indicates an expected call of AddSecret.
+func (mr *MockGophKeeperServerMockRecorder) AddSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSecret", reflect.TypeOf((*MockGophKeeperServer)(nil).AddSecret), arg0, arg1)
+}
+
+// AddVaultMember mocks base method.
+func (m *MockGophKeeperServer) AddVaultMember(arg0 context.Context, arg1 *proto.VaultMemberRequest) (*proto.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddVaultMember", arg0, arg1)
+	ret0, _ := ret[0].(*proto.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddVaultMember indicates an expected call of AddVaultMember.
+func (mr *MockGophKeeperServerMockRecorder) AddVaultMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVaultMember", reflect.TypeOf((*MockGophKeeperServer)(nil).AddVaultMember), arg0, arg1)
+}
+
+// CreateVault mocks base method.
+func (m *MockGophKeeperServer) CreateVault(arg0 context.Context, arg1 *proto.CreateVaultRequest) (*proto.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVault", arg0, arg1)
+	ret0, _ := ret[0].(*proto.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVault indicates an expected call of CreateVault.
+func (mr *MockGophKeeperServerMockRecorder) CreateVault(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVault", reflect.TypeOf((*MockGophKeeperServer)(nil).CreateVault), arg0, arg1)
+}
+
+// DeleteSecret mocks base method.
+func (m *MockGophKeeperServer) DeleteSecret(arg0 context.Context, arg1 *proto.DeleteSecretRequest) (*proto.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecret", arg0, arg1)
+	ret0, _ := ret[0].(*proto.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSecret indicates an expected call of DeleteSecret.
+func (mr *MockGophKeeperServerMockRecorder) DeleteSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MockGophKeeperServer)(nil).DeleteSecret), arg0, arg1)
+}
+
+// DownloadSecret mocks base method.
+func (m *MockGophKeeperServer) DownloadSecret(arg0 *proto.DownloadSecretRequest, arg1 proto.GophKeeper_DownloadSecretServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadSecret", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DownloadSecret indicates an expected call of DownloadSecret.
+func (mr *MockGophKeeperServerMockRecorder) DownloadSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadSecret", reflect.TypeOf((*MockGophKeeperServer)(nil).DownloadSecret), arg0, arg1)
+}
+
+// GetPublicKey mocks base method.
+func (m *MockGophKeeperServer) GetPublicKey(arg0 context.Context, arg1 *proto.GetPublicKeyRequest) (*proto.GetPublicKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicKey", arg0, arg1)
+	ret0, _ := ret[0].(*proto.GetPublicKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicKey indicates an expected call of GetPublicKey.
+func (mr *MockGophKeeperServerMockRecorder) GetPublicKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicKey", reflect.TypeOf((*MockGophKeeperServer)(nil).GetPublicKey), arg0, arg1)
+}
+
+// GetSecret mocks base method.
+func (m *MockGophKeeperServer) GetSecret(arg0 context.Context, arg1 *proto.GetSecretRequest) (*proto.GetSecretResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecret", arg0, arg1)
+	ret0, _ := ret[0].(*proto.GetSecretResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecret indicates an expected call of GetSecret.
+func (mr *MockGophKeeperServerMockRecorder) GetSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockGophKeeperServer)(nil).GetSecret), arg0, arg1)
+}
+
+// GetSharedSecret mocks base method.
+func (m *MockGophKeeperServer) GetSharedSecret(arg0 context.Context, arg1 *proto.GetSharedSecretRequest) (*proto.GetSharedSecretResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedSecret", arg0, arg1)
+	ret0, _ := ret[0].(*proto.GetSharedSecretResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedSecret indicates an expected call of GetSharedSecret.
+func (mr *MockGophKeeperServerMockRecorder) GetSharedSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedSecret", reflect.TypeOf((*MockGophKeeperServer)(nil).GetSharedSecret), arg0, arg1)
+}
+
+// GetVaultKey mocks base method.
+func (m *MockGophKeeperServer) GetVaultKey(arg0 context.Context, arg1 *proto.Empty) (*proto.GetVaultKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVaultKey", arg0, arg1)
+	ret0, _ := ret[0].(*proto.GetVaultKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVaultKey indicates an expected call of GetVaultKey.
+func (mr *MockGophKeeperServerMockRecorder) GetVaultKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVaultKey", reflect.TypeOf((*MockGophKeeperServer)(nil).GetVaultKey), arg0, arg1)
+}
+
+// ListAuditEvents mocks base method.
+func (m *MockGophKeeperServer) ListAuditEvents(arg0 context.Context, arg1 *proto.ListAuditEventsRequest) (*proto.ListAuditEventsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditEvents", arg0, arg1)
+	ret0, _ := ret[0].(*proto.ListAuditEventsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditEvents indicates an expected call of ListAuditEvents.
+func (mr *MockGophKeeperServerMockRecorder) ListAuditEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditEvents", reflect.TypeOf((*MockGophKeeperServer)(nil).ListAuditEvents), arg0, arg1)
+}
+
+// ListSecretVersions mocks base method.
+func (m *MockGophKeeperServer) ListSecretVersions(arg0 context.Context, arg1 *proto.ListSecretVersionsRequest) (*proto.ListSecretVersionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecretVersions", arg0, arg1)
+	ret0, _ := ret[0].(*proto.ListSecretVersionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecretVersions indicates an expected call of ListSecretVersions.
+func (mr *MockGophKeeperServerMockRecorder) ListSecretVersions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecretVersions", reflect.TypeOf((*MockGophKeeperServer)(nil).ListSecretVersions), arg0, arg1)
+}
+
+// ListSecrets mocks base method.
+func (m *MockGophKeeperServer) ListSecrets(arg0 context.Context, arg1 *proto.ListSecretsRequest) (*proto.ListSecretsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecrets", arg0, arg1)
+	ret0, _ := ret[0].(*proto.ListSecretsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecrets indicates an expected call of ListSecrets.
+func (mr *MockGophKeeperServerMockRecorder) ListSecrets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecrets", reflect.TypeOf((*MockGophKeeperServer)(nil).ListSecrets), arg0, arg1)
+}
+
+// ListSharedSecrets mocks base method.
+func (m *MockGophKeeperServer) ListSharedSecrets(arg0 context.Context, arg1 *proto.ListSharedSecretsRequest) (*proto.ListSharedSecretsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSharedSecrets", arg0, arg1)
+	ret0, _ := ret[0].(*proto.ListSharedSecretsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSharedSecrets indicates an expected call of ListSharedSecrets.
+func (mr *MockGophKeeperServerMockRecorder) ListSharedSecrets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSharedSecrets", reflect.TypeOf((*MockGophKeeperServer)(nil).ListSharedSecrets), arg0, arg1)
+}
+
+// ListVaultMembers mocks base method.
+func (m *MockGophKeeperServer) ListVaultMembers(arg0 context.Context, arg1 *proto.ListVaultMembersRequest) (*proto.ListVaultMembersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVaultMembers", arg0, arg1)
+	ret0, _ := ret[0].(*proto.ListVaultMembersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVaultMembers indicates an expected call of ListVaultMembers.
+func (mr *MockGophKeeperServerMockRecorder) ListVaultMembers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVaultMembers", reflect.TypeOf((*MockGophKeeperServer)(nil).ListVaultMembers), arg0, arg1)
+}
+
+// ListVaults mocks base method.
+func (m *MockGophKeeperServer) ListVaults(arg0 context.Context, arg1 *proto.Empty) (*proto.ListVaultsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVaults", arg0, arg1)
+	ret0, _ := ret[0].(*proto.ListVaultsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVaults indicates an expected call of ListVaults.
+func (mr *MockGophKeeperServerMockRecorder) ListVaults(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVaults", reflect.TypeOf((*MockGophKeeperServer)(nil).ListVaults), arg0, arg1)
+}
+
+// Login mocks base method.
+func (m *MockGophKeeperServer) Login(arg0 context.Context, arg1 *proto.User) (*proto.UserAuthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", arg0, arg1)
+	ret0, _ := ret[0].(*proto.UserAuthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockGophKeeperServerMockRecorder) Login(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockGophKeeperServer)(nil).Login), arg0, arg1)
+}
+
+// Logout mocks base method.
+func (m *MockGophKeeperServer) Logout(arg0 context.Context, arg1 *proto.LogoutRequest) (*proto.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", arg0, arg1)
+	ret0, _ := ret[0].(*proto.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockGophKeeperServerMockRecorder) Logout(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockGophKeeperServer)(nil).Logout), arg0, arg1)
+}
+
+// MoveSecret mocks base method.
+func (m *MockGophKeeperServer) MoveSecret(arg0 context.Context, arg1 *proto.MoveSecretRequest) (*proto.MoveSecretResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveSecret", arg0, arg1)
+	ret0, _ := ret[0].(*proto.MoveSecretResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveSecret indicates an expected call of MoveSecret.
+func (mr *MockGophKeeperServerMockRecorder) MoveSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveSecret", reflect.TypeOf((*MockGophKeeperServer)(nil).MoveSecret), arg0, arg1)
+}
+
+// RefreshToken mocks base method.
+func (m *MockGophKeeperServer) RefreshToken(arg0 context.Context, arg1 *proto.RefreshTokenRequest) (*proto.UserAuthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshToken", arg0, arg1)
+	ret0, _ := ret[0].(*proto.UserAuthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockGophKeeperServerMockRecorder) RefreshToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockGophKeeperServer)(nil).RefreshToken), arg0, arg1)
+}
+
+// Register mocks base method.
+func (m *MockGophKeeperServer) Register(arg0 context.Context, arg1 *proto.User) (*proto.UserAuthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", arg0, arg1)
+	ret0, _ := ret[0].(*proto.UserAuthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockGophKeeperServerMockRecorder) Register(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockGophKeeperServer)(nil).Register), arg0, arg1)
+}
+
+// RemoveVaultMember mocks base method.
+func (m *MockGophKeeperServer) RemoveVaultMember(arg0 context.Context, arg1 *proto.VaultMemberRequest) (*proto.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveVaultMember", arg0, arg1)
+	ret0, _ := ret[0].(*proto.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveVaultMember indicates an expected call of RemoveVaultMember.
+func (mr *MockGophKeeperServerMockRecorder) RemoveVaultMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveVaultMember", reflect.TypeOf((*MockGophKeeperServer)(nil).RemoveVaultMember), arg0, arg1)
+}
+
+// ReplaceSecretVersions mocks base method.
+func (m *MockGophKeeperServer) ReplaceSecretVersions(arg0 context.Context, arg1 *proto.ReplaceSecretVersionsRequest) (*proto.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceSecretVersions", arg0, arg1)
+	ret0, _ := ret[0].(*proto.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceSecretVersions indicates an expected call of ReplaceSecretVersions.
+func (mr *MockGophKeeperServerMockRecorder) ReplaceSecretVersions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceSecretVersions", reflect.TypeOf((*MockGophKeeperServer)(nil).ReplaceSecretVersions), arg0, arg1)
+}
+
+// RevokeSessions mocks base method.
+func (m *MockGophKeeperServer) RevokeSessions(arg0 context.Context, arg1 *proto.Empty) (*proto.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessions", arg0, arg1)
+	ret0, _ := ret[0].(*proto.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSessions indicates an expected call of RevokeSessions.
+func (mr *MockGophKeeperServerMockRecorder) RevokeSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessions", reflect.TypeOf((*MockGophKeeperServer)(nil).RevokeSessions), arg0, arg1)
+}
+
+// RevokeShare mocks base method.
+func (m *MockGophKeeperServer) RevokeShare(arg0 context.Context, arg1 *proto.RevokeShareRequest) (*proto.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeShare", arg0, arg1)
+	ret0, _ := ret[0].(*proto.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeShare indicates an expected call of RevokeShare.
+func (mr *MockGophKeeperServerMockRecorder) RevokeShare(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeShare", reflect.TypeOf((*MockGophKeeperServer)(nil).RevokeShare), arg0, arg1)
+}
+
+// RollbackSecret mocks base method.
+func (m *MockGophKeeperServer) RollbackSecret(arg0 context.Context, arg1 *proto.RollbackSecretRequest) (*proto.RollbackSecretResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackSecret", arg0, arg1)
+	ret0, _ := ret[0].(*proto.RollbackSecretResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RollbackSecret indicates an expected call of RollbackSecret.
+func (mr *MockGophKeeperServerMockRecorder) RollbackSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackSecret", reflect.TypeOf((*MockGophKeeperServer)(nil).RollbackSecret), arg0, arg1)
+}
+
+// SearchSecrets mocks base method.
+func (m *MockGophKeeperServer) SearchSecrets(arg0 context.Context, arg1 *proto.SearchSecretsRequest) (*proto.SearchSecretsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchSecrets", arg0, arg1)
+	ret0, _ := ret[0].(*proto.SearchSecretsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchSecrets indicates an expected call of SearchSecrets.
+func (mr *MockGophKeeperServerMockRecorder) SearchSecrets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchSecrets", reflect.TypeOf((*MockGophKeeperServer)(nil).SearchSecrets), arg0, arg1)
+}
+
+// SetPublicKey mocks base method.
+func (m *MockGophKeeperServer) SetPublicKey(arg0 context.Context, arg1 *proto.SetPublicKeyRequest) (*proto.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPublicKey", arg0, arg1)
+	ret0, _ := ret[0].(*proto.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPublicKey indicates an expected call of SetPublicKey.
+func (mr *MockGophKeeperServerMockRecorder) SetPublicKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPublicKey", reflect.TypeOf((*MockGophKeeperServer)(nil).SetPublicKey), arg0, arg1)
+}
+
+// SetVaultKey mocks base method.
+func (m *MockGophKeeperServer) SetVaultKey(arg0 context.Context, arg1 *proto.SetVaultKeyRequest) (*proto.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVaultKey", arg0, arg1)
+	ret0, _ := ret[0].(*proto.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetVaultKey indicates an expected call of SetVaultKey.
+func (mr *MockGophKeeperServerMockRecorder) SetVaultKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVaultKey", reflect.TypeOf((*MockGophKeeperServer)(nil).SetVaultKey), arg0, arg1)
+}
+
+// ShareSecret mocks base method.
+func (m *MockGophKeeperServer) ShareSecret(arg0 context.Context, arg1 *proto.ShareSecretRequest) (*proto.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareSecret", arg0, arg1)
+	ret0, _ := ret[0].(*proto.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShareSecret indicates an expected call of ShareSecret.
+func (mr *MockGophKeeperServerMockRecorder) ShareSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareSecret", reflect.TypeOf((*MockGophKeeperServer)(nil).ShareSecret), arg0, arg1)
+}
+
+// UpdateSecret mocks base method.
+func (m *MockGophKeeperServer) UpdateSecret(arg0 context.Context, arg1 *proto.UpdateSecretRequest) (*proto.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecret", arg0, arg1)
+	ret0, _ := ret[0].(*proto.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSecret indicates an expected call of UpdateSecret.
+func (mr *MockGophKeeperServerMockRecorder) UpdateSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecret", reflect.TypeOf((*MockGophKeeperServer)(nil).UpdateSecret), arg0, arg1)
+}
+
+// UpdateSharedSecret mocks base method.
+func (m *MockGophKeeperServer) UpdateSharedSecret(arg0 context.Context, arg1 *proto.UpdateSharedSecretRequest) (*proto.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSharedSecret", arg0, arg1)
+	ret0, _ := ret[0].(*proto.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSharedSecret indicates an expected call of UpdateSharedSecret.
+func (mr *MockGophKeeperServerMockRecorder) UpdateSharedSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSharedSecret", reflect.TypeOf((*MockGophKeeperServer)(nil).UpdateSharedSecret), arg0, arg1)
+}
+
+// UploadSecret mocks base method.
+func (m *MockGophKeeperServer) UploadSecret(arg0 proto.GophKeeper_UploadSecretServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadSecret", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadSecret indicates an expected call of UploadSecret.
+func (mr *MockGophKeeperServerMockRecorder) UploadSecret(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadSecret", reflect.TypeOf((*MockGophKeeperServer)(nil).UploadSecret), arg0)
+}
+
+// VerifyAuditLog mocks base method.
+func (m *MockGophKeeperServer) VerifyAuditLog(arg0 context.Context, arg1 *proto.Empty) (*proto.VerifyAuditLogResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyAuditLog", arg0, arg1)
+	ret0, _ := ret[0].(*proto.VerifyAuditLogResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyAuditLog indicates an expected call of VerifyAuditLog.
+func (mr *MockGophKeeperServerMockRecorder) VerifyAuditLog(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAuditLog", reflect.TypeOf((*MockGophKeeperServer)(nil).VerifyAuditLog), arg0, arg1)
+}
+
+// mustEmbedUnimplementedGophKeeperServer mocks base method.
+func (m *MockGophKeeperServer) mustEmbedUnimplementedGophKeeperServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedGophKeeperServer")
+}
+
+// mustEmbedUnimplementedGophKeeperServer indicates an expected call of mustEmbedUnimplementedGophKeeperServer.
+func (mr *MockGophKeeperServerMockRecorder) mustEmbedUnimplementedGophKeeperServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedGophKeeperServer", reflect.TypeOf((*MockGophKeeperServer)(nil).mustEmbedUnimplementedGophKeeperServer))
+}
+
+// MockUnsafeGophKeeperServer is a mock of UnsafeGophKeeperServer interface.
+type MockUnsafeGophKeeperServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafeGophKeeperServerMockRecorder
+}
+
+// MockUnsafeGophKeeperServerMockRecorder is the mock recorder for MockUnsafeGophKeeperServer.
+type MockUnsafeGophKeeperServerMockRecorder struct {
+	mock *MockUnsafeGophKeeperServer
+}
+
+// NewMockUnsafeGophKeeperServer creates a new mock instance.
+func NewMockUnsafeGophKeeperServer(ctrl *gomock.Controller) *MockUnsafeGophKeeperServer {
 	mock := &MockUnsafeGophKeeperServer{ctrl: ctrl}
 	mock.recorder = &MockUnsafeGophKeeperServerMockRecorder{mock}
 	return mock
@@ -350,3 +1519,256 @@ func (mr *MockUnsafeGophKeeperServerMockRecorder) mustEmbedUnimplementedGophKeep
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedGophKeeperServer", reflect.TypeOf((*MockUnsafeGophKeeperServer)(nil).mustEmbedUnimplementedGophKeeperServer))
 }
+
+// MockGophKeeper_UploadSecretServer is a mock of GophKeeper_UploadSecretServer interface.
+type MockGophKeeper_UploadSecretServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockGophKeeper_UploadSecretServerMockRecorder
+}
+
+// MockGophKeeper_UploadSecretServerMockRecorder is the mock recorder for MockGophKeeper_UploadSecretServer.
+type MockGophKeeper_UploadSecretServerMockRecorder struct {
+	mock *MockGophKeeper_UploadSecretServer
+}
+
+// NewMockGophKeeper_UploadSecretServer creates a new mock instance.
+func NewMockGophKeeper_UploadSecretServer(ctrl *gomock.Controller) *MockGophKeeper_UploadSecretServer {
+	mock := &MockGophKeeper_UploadSecretServer{ctrl: ctrl}
+	mock.recorder = &MockGophKeeper_UploadSecretServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGophKeeper_UploadSecretServer) EXPECT() *MockGophKeeper_UploadSecretServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockGophKeeper_UploadSecretServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockGophKeeper_UploadSecretServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockGophKeeper_UploadSecretServer)(nil).Context))
+}
+
+// Recv mocks base method.
+func (m *MockGophKeeper_UploadSecretServer) Recv() (*proto.UploadSecretRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*proto.UploadSecretRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockGophKeeper_UploadSecretServerMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockGophKeeper_UploadSecretServer)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockGophKeeper_UploadSecretServer) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockGophKeeper_UploadSecretServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockGophKeeper_UploadSecretServer)(nil).RecvMsg), m)
+}
+
+// SendAndClose mocks base method.
+func (m *MockGophKeeper_UploadSecretServer) SendAndClose(arg0 *proto.UploadSecretResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAndClose", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendAndClose indicates an expected call of SendAndClose.
+func (mr *MockGophKeeper_UploadSecretServerMockRecorder) SendAndClose(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAndClose", reflect.TypeOf((*MockGophKeeper_UploadSecretServer)(nil).SendAndClose), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockGophKeeper_UploadSecretServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockGophKeeper_UploadSecretServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockGophKeeper_UploadSecretServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockGophKeeper_UploadSecretServer) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockGophKeeper_UploadSecretServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockGophKeeper_UploadSecretServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockGophKeeper_UploadSecretServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockGophKeeper_UploadSecretServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockGophKeeper_UploadSecretServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockGophKeeper_UploadSecretServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockGophKeeper_UploadSecretServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockGophKeeper_UploadSecretServer)(nil).SetTrailer), arg0)
+}
+
+// MockGophKeeper_DownloadSecretServer is a mock of GophKeeper_DownloadSecretServer interface.
+type MockGophKeeper_DownloadSecretServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockGophKeeper_DownloadSecretServerMockRecorder
+}
+
+// MockGophKeeper_DownloadSecretServerMockRecorder is the mock recorder for MockGophKeeper_DownloadSecretServer.
+type MockGophKeeper_DownloadSecretServerMockRecorder struct {
+	mock *MockGophKeeper_DownloadSecretServer
+}
+
+// NewMockGophKeeper_DownloadSecretServer creates a new mock instance.
+func NewMockGophKeeper_DownloadSecretServer(ctrl *gomock.Controller) *MockGophKeeper_DownloadSecretServer {
+	mock := &MockGophKeeper_DownloadSecretServer{ctrl: ctrl}
+	mock.recorder = &MockGophKeeper_DownloadSecretServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGophKeeper_DownloadSecretServer) EXPECT() *MockGophKeeper_DownloadSecretServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockGophKeeper_DownloadSecretServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockGophKeeper_DownloadSecretServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockGophKeeper_DownloadSecretServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockGophKeeper_DownloadSecretServer) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockGophKeeper_DownloadSecretServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockGophKeeper_DownloadSecretServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockGophKeeper_DownloadSecretServer) Send(arg0 *proto.DownloadSecretResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockGophKeeper_DownloadSecretServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockGophKeeper_DownloadSecretServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockGophKeeper_DownloadSecretServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockGophKeeper_DownloadSecretServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockGophKeeper_DownloadSecretServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockGophKeeper_DownloadSecretServer) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockGophKeeper_DownloadSecretServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockGophKeeper_DownloadSecretServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockGophKeeper_DownloadSecretServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockGophKeeper_DownloadSecretServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockGophKeeper_DownloadSecretServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockGophKeeper_DownloadSecretServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockGophKeeper_DownloadSecretServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockGophKeeper_DownloadSecretServer)(nil).SetTrailer), arg0)
+}
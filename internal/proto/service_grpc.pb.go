@@ -15,17 +15,43 @@ import (
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
-// Requires gRPC-Go v1.64.0 or later.
-const _ = grpc.SupportPackageIsVersion9
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
 
 const (
-	GophKeeper_Register_FullMethodName     = "/proto.GophKeeper/Register"
-	GophKeeper_Login_FullMethodName        = "/proto.GophKeeper/Login"
-	GophKeeper_AddSecret_FullMethodName    = "/proto.GophKeeper/AddSecret"
-	GophKeeper_UpdateSecret_FullMethodName = "/proto.GophKeeper/UpdateSecret"
-	GophKeeper_GetSecret_FullMethodName    = "/proto.GophKeeper/GetSecret"
-	GophKeeper_DeleteSecret_FullMethodName = "/proto.GophKeeper/DeleteSecret"
-	GophKeeper_ListSecrets_FullMethodName  = "/proto.GophKeeper/ListSecrets"
+	GophKeeper_Register_FullMethodName              = "/proto.GophKeeper/Register"
+	GophKeeper_Login_FullMethodName                 = "/proto.GophKeeper/Login"
+	GophKeeper_RefreshToken_FullMethodName          = "/proto.GophKeeper/RefreshToken"
+	GophKeeper_Logout_FullMethodName                = "/proto.GophKeeper/Logout"
+	GophKeeper_RevokeSessions_FullMethodName        = "/proto.GophKeeper/RevokeSessions"
+	GophKeeper_AddSecret_FullMethodName             = "/proto.GophKeeper/AddSecret"
+	GophKeeper_UpdateSecret_FullMethodName          = "/proto.GophKeeper/UpdateSecret"
+	GophKeeper_GetSecret_FullMethodName             = "/proto.GophKeeper/GetSecret"
+	GophKeeper_DeleteSecret_FullMethodName          = "/proto.GophKeeper/DeleteSecret"
+	GophKeeper_ListSecrets_FullMethodName           = "/proto.GophKeeper/ListSecrets"
+	GophKeeper_SearchSecrets_FullMethodName         = "/proto.GophKeeper/SearchSecrets"
+	GophKeeper_MoveSecret_FullMethodName            = "/proto.GophKeeper/MoveSecret"
+	GophKeeper_ListSecretVersions_FullMethodName    = "/proto.GophKeeper/ListSecretVersions"
+	GophKeeper_ReplaceSecretVersions_FullMethodName = "/proto.GophKeeper/ReplaceSecretVersions"
+	GophKeeper_RollbackSecret_FullMethodName        = "/proto.GophKeeper/RollbackSecret"
+	GophKeeper_UploadSecret_FullMethodName          = "/proto.GophKeeper/UploadSecret"
+	GophKeeper_DownloadSecret_FullMethodName        = "/proto.GophKeeper/DownloadSecret"
+	GophKeeper_SetPublicKey_FullMethodName          = "/proto.GophKeeper/SetPublicKey"
+	GophKeeper_GetPublicKey_FullMethodName          = "/proto.GophKeeper/GetPublicKey"
+	GophKeeper_ShareSecret_FullMethodName           = "/proto.GophKeeper/ShareSecret"
+	GophKeeper_RevokeShare_FullMethodName           = "/proto.GophKeeper/RevokeShare"
+	GophKeeper_ListSharedSecrets_FullMethodName     = "/proto.GophKeeper/ListSharedSecrets"
+	GophKeeper_GetSharedSecret_FullMethodName       = "/proto.GophKeeper/GetSharedSecret"
+	GophKeeper_UpdateSharedSecret_FullMethodName    = "/proto.GophKeeper/UpdateSharedSecret"
+	GophKeeper_CreateVault_FullMethodName           = "/proto.GophKeeper/CreateVault"
+	GophKeeper_ListVaults_FullMethodName            = "/proto.GophKeeper/ListVaults"
+	GophKeeper_AddVaultMember_FullMethodName        = "/proto.GophKeeper/AddVaultMember"
+	GophKeeper_RemoveVaultMember_FullMethodName     = "/proto.GophKeeper/RemoveVaultMember"
+	GophKeeper_ListVaultMembers_FullMethodName      = "/proto.GophKeeper/ListVaultMembers"
+	GophKeeper_GetVaultKey_FullMethodName           = "/proto.GophKeeper/GetVaultKey"
+	GophKeeper_SetVaultKey_FullMethodName           = "/proto.GophKeeper/SetVaultKey"
+	GophKeeper_ListAuditEvents_FullMethodName       = "/proto.GophKeeper/ListAuditEvents"
+	GophKeeper_VerifyAuditLog_FullMethodName        = "/proto.GophKeeper/VerifyAuditLog"
 )
 
 // GophKeeperClient is the client API for GophKeeper service.
@@ -35,11 +61,40 @@ type GophKeeperClient interface {
 	// User
 	Register(ctx context.Context, in *User, opts ...grpc.CallOption) (*UserAuthToken, error)
 	Login(ctx context.Context, in *User, opts ...grpc.CallOption) (*UserAuthToken, error)
+	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*UserAuthToken, error)
+	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*Empty, error)
+	RevokeSessions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
 	AddSecret(ctx context.Context, in *AddSecretRequest, opts ...grpc.CallOption) (*Empty, error)
 	UpdateSecret(ctx context.Context, in *UpdateSecretRequest, opts ...grpc.CallOption) (*Empty, error)
 	GetSecret(ctx context.Context, in *GetSecretRequest, opts ...grpc.CallOption) (*GetSecretResponse, error)
 	DeleteSecret(ctx context.Context, in *DeleteSecretRequest, opts ...grpc.CallOption) (*Empty, error)
-	ListSecrets(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListSecretsResponse, error)
+	ListSecrets(ctx context.Context, in *ListSecretsRequest, opts ...grpc.CallOption) (*ListSecretsResponse, error)
+	SearchSecrets(ctx context.Context, in *SearchSecretsRequest, opts ...grpc.CallOption) (*SearchSecretsResponse, error)
+	MoveSecret(ctx context.Context, in *MoveSecretRequest, opts ...grpc.CallOption) (*MoveSecretResponse, error)
+	ListSecretVersions(ctx context.Context, in *ListSecretVersionsRequest, opts ...grpc.CallOption) (*ListSecretVersionsResponse, error)
+	ReplaceSecretVersions(ctx context.Context, in *ReplaceSecretVersionsRequest, opts ...grpc.CallOption) (*Empty, error)
+	RollbackSecret(ctx context.Context, in *RollbackSecretRequest, opts ...grpc.CallOption) (*RollbackSecretResponse, error)
+	UploadSecret(ctx context.Context, opts ...grpc.CallOption) (GophKeeper_UploadSecretClient, error)
+	DownloadSecret(ctx context.Context, in *DownloadSecretRequest, opts ...grpc.CallOption) (GophKeeper_DownloadSecretClient, error)
+	// Sharing
+	SetPublicKey(ctx context.Context, in *SetPublicKeyRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetPublicKey(ctx context.Context, in *GetPublicKeyRequest, opts ...grpc.CallOption) (*GetPublicKeyResponse, error)
+	ShareSecret(ctx context.Context, in *ShareSecretRequest, opts ...grpc.CallOption) (*Empty, error)
+	RevokeShare(ctx context.Context, in *RevokeShareRequest, opts ...grpc.CallOption) (*Empty, error)
+	ListSharedSecrets(ctx context.Context, in *ListSharedSecretsRequest, opts ...grpc.CallOption) (*ListSharedSecretsResponse, error)
+	GetSharedSecret(ctx context.Context, in *GetSharedSecretRequest, opts ...grpc.CallOption) (*GetSharedSecretResponse, error)
+	UpdateSharedSecret(ctx context.Context, in *UpdateSharedSecretRequest, opts ...grpc.CallOption) (*Empty, error)
+	// Vaults
+	CreateVault(ctx context.Context, in *CreateVaultRequest, opts ...grpc.CallOption) (*Empty, error)
+	ListVaults(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListVaultsResponse, error)
+	AddVaultMember(ctx context.Context, in *VaultMemberRequest, opts ...grpc.CallOption) (*Empty, error)
+	RemoveVaultMember(ctx context.Context, in *VaultMemberRequest, opts ...grpc.CallOption) (*Empty, error)
+	ListVaultMembers(ctx context.Context, in *ListVaultMembersRequest, opts ...grpc.CallOption) (*ListVaultMembersResponse, error)
+	GetVaultKey(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetVaultKeyResponse, error)
+	SetVaultKey(ctx context.Context, in *SetVaultKeyRequest, opts ...grpc.CallOption) (*Empty, error)
+	// Audit
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+	VerifyAuditLog(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*VerifyAuditLogResponse, error)
 }
 
 type gophKeeperClient struct {
@@ -70,6 +125,36 @@ func (c *gophKeeperClient) Login(ctx context.Context, in *User, opts ...grpc.Cal
 	return out, nil
 }
 
+func (c *gophKeeperClient) RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*UserAuthToken, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserAuthToken)
+	err := c.cc.Invoke(ctx, GophKeeper_RefreshToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, GophKeeper_Logout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) RevokeSessions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, GophKeeper_RevokeSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gophKeeperClient) AddSecret(ctx context.Context, in *AddSecretRequest, opts ...grpc.CallOption) (*Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Empty)
@@ -110,7 +195,7 @@ func (c *gophKeeperClient) DeleteSecret(ctx context.Context, in *DeleteSecretReq
 	return out, nil
 }
 
-func (c *gophKeeperClient) ListSecrets(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListSecretsResponse, error) {
+func (c *gophKeeperClient) ListSecrets(ctx context.Context, in *ListSecretsRequest, opts ...grpc.CallOption) (*ListSecretsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListSecretsResponse)
 	err := c.cc.Invoke(ctx, GophKeeper_ListSecrets_FullMethodName, in, out, cOpts...)
@@ -120,6 +205,284 @@ func (c *gophKeeperClient) ListSecrets(ctx context.Context, in *Empty, opts ...g
 	return out, nil
 }
 
+func (c *gophKeeperClient) SearchSecrets(ctx context.Context, in *SearchSecretsRequest, opts ...grpc.CallOption) (*SearchSecretsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchSecretsResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_SearchSecrets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) MoveSecret(ctx context.Context, in *MoveSecretRequest, opts ...grpc.CallOption) (*MoveSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveSecretResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_MoveSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ListSecretVersions(ctx context.Context, in *ListSecretVersionsRequest, opts ...grpc.CallOption) (*ListSecretVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSecretVersionsResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ListSecretVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ReplaceSecretVersions(ctx context.Context, in *ReplaceSecretVersionsRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, GophKeeper_ReplaceSecretVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) RollbackSecret(ctx context.Context, in *RollbackSecretRequest, opts ...grpc.CallOption) (*RollbackSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RollbackSecretResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_RollbackSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) UploadSecret(ctx context.Context, opts ...grpc.CallOption) (GophKeeper_UploadSecretClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GophKeeper_ServiceDesc.Streams[0], GophKeeper_UploadSecret_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gophKeeperUploadSecretClient{ClientStream: stream}
+	return x, nil
+}
+
+type GophKeeper_UploadSecretClient interface {
+	Send(*UploadSecretRequest) error
+	CloseAndRecv() (*UploadSecretResponse, error)
+	grpc.ClientStream
+}
+
+type gophKeeperUploadSecretClient struct {
+	grpc.ClientStream
+}
+
+func (x *gophKeeperUploadSecretClient) Send(m *UploadSecretRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *gophKeeperUploadSecretClient) CloseAndRecv() (*UploadSecretResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadSecretResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gophKeeperClient) DownloadSecret(ctx context.Context, in *DownloadSecretRequest, opts ...grpc.CallOption) (GophKeeper_DownloadSecretClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GophKeeper_ServiceDesc.Streams[1], GophKeeper_DownloadSecret_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gophKeeperDownloadSecretClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GophKeeper_DownloadSecretClient interface {
+	Recv() (*DownloadSecretResponse, error)
+	grpc.ClientStream
+}
+
+type gophKeeperDownloadSecretClient struct {
+	grpc.ClientStream
+}
+
+func (x *gophKeeperDownloadSecretClient) Recv() (*DownloadSecretResponse, error) {
+	m := new(DownloadSecretResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gophKeeperClient) SetPublicKey(ctx context.Context, in *SetPublicKeyRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, GophKeeper_SetPublicKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) GetPublicKey(ctx context.Context, in *GetPublicKeyRequest, opts ...grpc.CallOption) (*GetPublicKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPublicKeyResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_GetPublicKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ShareSecret(ctx context.Context, in *ShareSecretRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, GophKeeper_ShareSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) RevokeShare(ctx context.Context, in *RevokeShareRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, GophKeeper_RevokeShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ListSharedSecrets(ctx context.Context, in *ListSharedSecretsRequest, opts ...grpc.CallOption) (*ListSharedSecretsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSharedSecretsResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ListSharedSecrets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) GetSharedSecret(ctx context.Context, in *GetSharedSecretRequest, opts ...grpc.CallOption) (*GetSharedSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSharedSecretResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_GetSharedSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) UpdateSharedSecret(ctx context.Context, in *UpdateSharedSecretRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, GophKeeper_UpdateSharedSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) CreateVault(ctx context.Context, in *CreateVaultRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, GophKeeper_CreateVault_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ListVaults(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListVaultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVaultsResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ListVaults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) AddVaultMember(ctx context.Context, in *VaultMemberRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, GophKeeper_AddVaultMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) RemoveVaultMember(ctx context.Context, in *VaultMemberRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, GophKeeper_RemoveVaultMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ListVaultMembers(ctx context.Context, in *ListVaultMembersRequest, opts ...grpc.CallOption) (*ListVaultMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVaultMembersResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ListVaultMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) GetVaultKey(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetVaultKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVaultKeyResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_GetVaultKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) SetVaultKey(ctx context.Context, in *SetVaultKeyRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, GophKeeper_SetVaultKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ListAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) VerifyAuditLog(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*VerifyAuditLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyAuditLogResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_VerifyAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GophKeeperServer is the server API for GophKeeper service.
 // All implementations must embed UnimplementedGophKeeperServer
 // for forward compatibility.
@@ -127,11 +490,40 @@ type GophKeeperServer interface {
 	// User
 	Register(context.Context, *User) (*UserAuthToken, error)
 	Login(context.Context, *User) (*UserAuthToken, error)
+	RefreshToken(context.Context, *RefreshTokenRequest) (*UserAuthToken, error)
+	Logout(context.Context, *LogoutRequest) (*Empty, error)
+	RevokeSessions(context.Context, *Empty) (*Empty, error)
 	AddSecret(context.Context, *AddSecretRequest) (*Empty, error)
 	UpdateSecret(context.Context, *UpdateSecretRequest) (*Empty, error)
 	GetSecret(context.Context, *GetSecretRequest) (*GetSecretResponse, error)
 	DeleteSecret(context.Context, *DeleteSecretRequest) (*Empty, error)
-	ListSecrets(context.Context, *Empty) (*ListSecretsResponse, error)
+	ListSecrets(context.Context, *ListSecretsRequest) (*ListSecretsResponse, error)
+	SearchSecrets(context.Context, *SearchSecretsRequest) (*SearchSecretsResponse, error)
+	MoveSecret(context.Context, *MoveSecretRequest) (*MoveSecretResponse, error)
+	ListSecretVersions(context.Context, *ListSecretVersionsRequest) (*ListSecretVersionsResponse, error)
+	ReplaceSecretVersions(context.Context, *ReplaceSecretVersionsRequest) (*Empty, error)
+	RollbackSecret(context.Context, *RollbackSecretRequest) (*RollbackSecretResponse, error)
+	UploadSecret(GophKeeper_UploadSecretServer) error
+	DownloadSecret(*DownloadSecretRequest, GophKeeper_DownloadSecretServer) error
+	// Sharing
+	SetPublicKey(context.Context, *SetPublicKeyRequest) (*Empty, error)
+	GetPublicKey(context.Context, *GetPublicKeyRequest) (*GetPublicKeyResponse, error)
+	ShareSecret(context.Context, *ShareSecretRequest) (*Empty, error)
+	RevokeShare(context.Context, *RevokeShareRequest) (*Empty, error)
+	ListSharedSecrets(context.Context, *ListSharedSecretsRequest) (*ListSharedSecretsResponse, error)
+	GetSharedSecret(context.Context, *GetSharedSecretRequest) (*GetSharedSecretResponse, error)
+	UpdateSharedSecret(context.Context, *UpdateSharedSecretRequest) (*Empty, error)
+	// Vaults
+	CreateVault(context.Context, *CreateVaultRequest) (*Empty, error)
+	ListVaults(context.Context, *Empty) (*ListVaultsResponse, error)
+	AddVaultMember(context.Context, *VaultMemberRequest) (*Empty, error)
+	RemoveVaultMember(context.Context, *VaultMemberRequest) (*Empty, error)
+	ListVaultMembers(context.Context, *ListVaultMembersRequest) (*ListVaultMembersResponse, error)
+	GetVaultKey(context.Context, *Empty) (*GetVaultKeyResponse, error)
+	SetVaultKey(context.Context, *SetVaultKeyRequest) (*Empty, error)
+	// Audit
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	VerifyAuditLog(context.Context, *Empty) (*VerifyAuditLogResponse, error)
 	mustEmbedUnimplementedGophKeeperServer()
 }
 
@@ -148,6 +540,15 @@ func (UnimplementedGophKeeperServer) Register(context.Context, *User) (*UserAuth
 func (UnimplementedGophKeeperServer) Login(context.Context, *User) (*UserAuthToken, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
 }
+func (UnimplementedGophKeeperServer) RefreshToken(context.Context, *RefreshTokenRequest) (*UserAuthToken, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+}
+func (UnimplementedGophKeeperServer) Logout(context.Context, *LogoutRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedGophKeeperServer) RevokeSessions(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSessions not implemented")
+}
 func (UnimplementedGophKeeperServer) AddSecret(context.Context, *AddSecretRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddSecret not implemented")
 }
@@ -160,9 +561,78 @@ func (UnimplementedGophKeeperServer) GetSecret(context.Context, *GetSecretReques
 func (UnimplementedGophKeeperServer) DeleteSecret(context.Context, *DeleteSecretRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteSecret not implemented")
 }
-func (UnimplementedGophKeeperServer) ListSecrets(context.Context, *Empty) (*ListSecretsResponse, error) {
+func (UnimplementedGophKeeperServer) ListSecrets(context.Context, *ListSecretsRequest) (*ListSecretsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListSecrets not implemented")
 }
+func (UnimplementedGophKeeperServer) SearchSecrets(context.Context, *SearchSecretsRequest) (*SearchSecretsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchSecrets not implemented")
+}
+func (UnimplementedGophKeeperServer) MoveSecret(context.Context, *MoveSecretRequest) (*MoveSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveSecret not implemented")
+}
+func (UnimplementedGophKeeperServer) ListSecretVersions(context.Context, *ListSecretVersionsRequest) (*ListSecretVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSecretVersions not implemented")
+}
+func (UnimplementedGophKeeperServer) ReplaceSecretVersions(context.Context, *ReplaceSecretVersionsRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplaceSecretVersions not implemented")
+}
+func (UnimplementedGophKeeperServer) RollbackSecret(context.Context, *RollbackSecretRequest) (*RollbackSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackSecret not implemented")
+}
+func (UnimplementedGophKeeperServer) UploadSecret(GophKeeper_UploadSecretServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadSecret not implemented")
+}
+func (UnimplementedGophKeeperServer) DownloadSecret(*DownloadSecretRequest, GophKeeper_DownloadSecretServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadSecret not implemented")
+}
+func (UnimplementedGophKeeperServer) SetPublicKey(context.Context, *SetPublicKeyRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPublicKey not implemented")
+}
+func (UnimplementedGophKeeperServer) GetPublicKey(context.Context, *GetPublicKeyRequest) (*GetPublicKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPublicKey not implemented")
+}
+func (UnimplementedGophKeeperServer) ShareSecret(context.Context, *ShareSecretRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareSecret not implemented")
+}
+func (UnimplementedGophKeeperServer) RevokeShare(context.Context, *RevokeShareRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeShare not implemented")
+}
+func (UnimplementedGophKeeperServer) ListSharedSecrets(context.Context, *ListSharedSecretsRequest) (*ListSharedSecretsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSharedSecrets not implemented")
+}
+func (UnimplementedGophKeeperServer) GetSharedSecret(context.Context, *GetSharedSecretRequest) (*GetSharedSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSharedSecret not implemented")
+}
+func (UnimplementedGophKeeperServer) UpdateSharedSecret(context.Context, *UpdateSharedSecretRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSharedSecret not implemented")
+}
+func (UnimplementedGophKeeperServer) CreateVault(context.Context, *CreateVaultRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateVault not implemented")
+}
+func (UnimplementedGophKeeperServer) ListVaults(context.Context, *Empty) (*ListVaultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVaults not implemented")
+}
+func (UnimplementedGophKeeperServer) AddVaultMember(context.Context, *VaultMemberRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddVaultMember not implemented")
+}
+func (UnimplementedGophKeeperServer) RemoveVaultMember(context.Context, *VaultMemberRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveVaultMember not implemented")
+}
+func (UnimplementedGophKeeperServer) ListVaultMembers(context.Context, *ListVaultMembersRequest) (*ListVaultMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVaultMembers not implemented")
+}
+func (UnimplementedGophKeeperServer) GetVaultKey(context.Context, *Empty) (*GetVaultKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVaultKey not implemented")
+}
+func (UnimplementedGophKeeperServer) SetVaultKey(context.Context, *SetVaultKeyRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetVaultKey not implemented")
+}
+func (UnimplementedGophKeeperServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedGophKeeperServer) VerifyAuditLog(context.Context, *Empty) (*VerifyAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyAuditLog not implemented")
+}
 func (UnimplementedGophKeeperServer) mustEmbedUnimplementedGophKeeperServer() {}
 func (UnimplementedGophKeeperServer) testEmbeddedByValue()                    {}
 
@@ -220,49 +690,103 @@ func _GophKeeper_Login_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GophKeeper_AddSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddSecretRequest)
+func _GophKeeper_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GophKeeperServer).AddSecret(ctx, in)
+		return srv.(GophKeeperServer).RefreshToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GophKeeper_AddSecret_FullMethodName,
+		FullMethod: GophKeeper_RefreshToken_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GophKeeperServer).AddSecret(ctx, req.(*AddSecretRequest))
+		return srv.(GophKeeperServer).RefreshToken(ctx, req.(*RefreshTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GophKeeper_UpdateSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateSecretRequest)
+func _GophKeeper_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GophKeeperServer).UpdateSecret(ctx, in)
+		return srv.(GophKeeperServer).Logout(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GophKeeper_UpdateSecret_FullMethodName,
+		FullMethod: GophKeeper_Logout_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GophKeeperServer).UpdateSecret(ctx, req.(*UpdateSecretRequest))
+		return srv.(GophKeeperServer).Logout(ctx, req.(*LogoutRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GophKeeper_GetSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetSecretRequest)
+func _GophKeeper_RevokeSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GophKeeperServer).GetSecret(ctx, in)
+		return srv.(GophKeeperServer).RevokeSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_RevokeSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).RevokeSessions(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_AddSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).AddSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_AddSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).AddSecret(ctx, req.(*AddSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_UpdateSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).UpdateSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_UpdateSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).UpdateSecret(ctx, req.(*UpdateSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_GetSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).GetSecret(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
@@ -293,7 +817,7 @@ func _GophKeeper_DeleteSecret_Handler(srv interface{}, ctx context.Context, dec
 }
 
 func _GophKeeper_ListSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+	in := new(ListSecretsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -305,7 +829,432 @@ func _GophKeeper_ListSecrets_Handler(srv interface{}, ctx context.Context, dec f
 		FullMethod: GophKeeper_ListSecrets_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GophKeeperServer).ListSecrets(ctx, req.(*Empty))
+		return srv.(GophKeeperServer).ListSecrets(ctx, req.(*ListSecretsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_SearchSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchSecretsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).SearchSecrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_SearchSecrets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).SearchSecrets(ctx, req.(*SearchSecretsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_MoveSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).MoveSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_MoveSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).MoveSecret(ctx, req.(*MoveSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ListSecretVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSecretVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListSecretVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListSecretVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListSecretVersions(ctx, req.(*ListSecretVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ReplaceSecretVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplaceSecretVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ReplaceSecretVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ReplaceSecretVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ReplaceSecretVersions(ctx, req.(*ReplaceSecretVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_RollbackSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).RollbackSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_RollbackSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).RollbackSecret(ctx, req.(*RollbackSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_UploadSecret_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GophKeeperServer).UploadSecret(&gophKeeperUploadSecretServer{ServerStream: stream})
+}
+
+type GophKeeper_UploadSecretServer interface {
+	SendAndClose(*UploadSecretResponse) error
+	Recv() (*UploadSecretRequest, error)
+	grpc.ServerStream
+}
+
+type gophKeeperUploadSecretServer struct {
+	grpc.ServerStream
+}
+
+func (x *gophKeeperUploadSecretServer) SendAndClose(m *UploadSecretResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *gophKeeperUploadSecretServer) Recv() (*UploadSecretRequest, error) {
+	m := new(UploadSecretRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _GophKeeper_DownloadSecret_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadSecretRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GophKeeperServer).DownloadSecret(m, &gophKeeperDownloadSecretServer{ServerStream: stream})
+}
+
+type GophKeeper_DownloadSecretServer interface {
+	Send(*DownloadSecretResponse) error
+	grpc.ServerStream
+}
+
+type gophKeeperDownloadSecretServer struct {
+	grpc.ServerStream
+}
+
+func (x *gophKeeperDownloadSecretServer) Send(m *DownloadSecretResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GophKeeper_SetPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).SetPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_SetPublicKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).SetPublicKey(ctx, req.(*SetPublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_GetPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).GetPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_GetPublicKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).GetPublicKey(ctx, req.(*GetPublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ShareSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ShareSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ShareSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ShareSecret(ctx, req.(*ShareSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_RevokeShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).RevokeShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_RevokeShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).RevokeShare(ctx, req.(*RevokeShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ListSharedSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSharedSecretsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListSharedSecrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListSharedSecrets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListSharedSecrets(ctx, req.(*ListSharedSecretsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_GetSharedSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSharedSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).GetSharedSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_GetSharedSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).GetSharedSecret(ctx, req.(*GetSharedSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_UpdateSharedSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSharedSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).UpdateSharedSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_UpdateSharedSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).UpdateSharedSecret(ctx, req.(*UpdateSharedSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_CreateVault_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVaultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).CreateVault(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_CreateVault_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).CreateVault(ctx, req.(*CreateVaultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ListVaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListVaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListVaults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListVaults(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_AddVaultMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VaultMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).AddVaultMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_AddVaultMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).AddVaultMember(ctx, req.(*VaultMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_RemoveVaultMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VaultMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).RemoveVaultMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_RemoveVaultMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).RemoveVaultMember(ctx, req.(*VaultMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ListVaultMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVaultMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListVaultMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListVaultMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListVaultMembers(ctx, req.(*ListVaultMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_GetVaultKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).GetVaultKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_GetVaultKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).GetVaultKey(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_SetVaultKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetVaultKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).SetVaultKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_SetVaultKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).SetVaultKey(ctx, req.(*SetVaultKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_VerifyAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).VerifyAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_VerifyAuditLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).VerifyAuditLog(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -325,6 +1274,18 @@ var GophKeeper_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Login",
 			Handler:    _GophKeeper_Login_Handler,
 		},
+		{
+			MethodName: "RefreshToken",
+			Handler:    _GophKeeper_RefreshToken_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _GophKeeper_Logout_Handler,
+		},
+		{
+			MethodName: "RevokeSessions",
+			Handler:    _GophKeeper_RevokeSessions_Handler,
+		},
 		{
 			MethodName: "AddSecret",
 			Handler:    _GophKeeper_AddSecret_Handler,
@@ -345,7 +1306,102 @@ var GophKeeper_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListSecrets",
 			Handler:    _GophKeeper_ListSecrets_Handler,
 		},
+		{
+			MethodName: "SearchSecrets",
+			Handler:    _GophKeeper_SearchSecrets_Handler,
+		},
+		{
+			MethodName: "MoveSecret",
+			Handler:    _GophKeeper_MoveSecret_Handler,
+		},
+		{
+			MethodName: "ListSecretVersions",
+			Handler:    _GophKeeper_ListSecretVersions_Handler,
+		},
+		{
+			MethodName: "ReplaceSecretVersions",
+			Handler:    _GophKeeper_ReplaceSecretVersions_Handler,
+		},
+		{
+			MethodName: "RollbackSecret",
+			Handler:    _GophKeeper_RollbackSecret_Handler,
+		},
+		{
+			MethodName: "SetPublicKey",
+			Handler:    _GophKeeper_SetPublicKey_Handler,
+		},
+		{
+			MethodName: "GetPublicKey",
+			Handler:    _GophKeeper_GetPublicKey_Handler,
+		},
+		{
+			MethodName: "ShareSecret",
+			Handler:    _GophKeeper_ShareSecret_Handler,
+		},
+		{
+			MethodName: "RevokeShare",
+			Handler:    _GophKeeper_RevokeShare_Handler,
+		},
+		{
+			MethodName: "ListSharedSecrets",
+			Handler:    _GophKeeper_ListSharedSecrets_Handler,
+		},
+		{
+			MethodName: "GetSharedSecret",
+			Handler:    _GophKeeper_GetSharedSecret_Handler,
+		},
+		{
+			MethodName: "UpdateSharedSecret",
+			Handler:    _GophKeeper_UpdateSharedSecret_Handler,
+		},
+		{
+			MethodName: "CreateVault",
+			Handler:    _GophKeeper_CreateVault_Handler,
+		},
+		{
+			MethodName: "ListVaults",
+			Handler:    _GophKeeper_ListVaults_Handler,
+		},
+		{
+			MethodName: "AddVaultMember",
+			Handler:    _GophKeeper_AddVaultMember_Handler,
+		},
+		{
+			MethodName: "RemoveVaultMember",
+			Handler:    _GophKeeper_RemoveVaultMember_Handler,
+		},
+		{
+			MethodName: "ListVaultMembers",
+			Handler:    _GophKeeper_ListVaultMembers_Handler,
+		},
+		{
+			MethodName: "GetVaultKey",
+			Handler:    _GophKeeper_GetVaultKey_Handler,
+		},
+		{
+			MethodName: "SetVaultKey",
+			Handler:    _GophKeeper_SetVaultKey_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _GophKeeper_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "VerifyAuditLog",
+			Handler:    _GophKeeper_VerifyAuditLog_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadSecret",
+			Handler:       _GophKeeper_UploadSecret_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadSecret",
+			Handler:       _GophKeeper_DownloadSecret_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/proto/service.proto",
 }
@@ -0,0 +1,828 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.28.2
+// source: internal/proto/vault.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Secret RPCs act on vault secrets if 'vault' metadata is set to 'org/vault', other RPCs ignore it.
+// Viewer may read secrets, editor may also change them, owner may also manage members.
+// Vault secrets are encrypted with the vault key, which is sealed for every vault member
+// with the member public key.
+type VaultRole int32
+
+const (
+	VaultRole_VIEWER VaultRole = 0
+	VaultRole_EDITOR VaultRole = 1
+	VaultRole_OWNER  VaultRole = 2
+)
+
+// Enum value maps for VaultRole.
+var (
+	VaultRole_name = map[int32]string{
+		0: "VIEWER",
+		1: "EDITOR",
+		2: "OWNER",
+	}
+	VaultRole_value = map[string]int32{
+		"VIEWER": 0,
+		"EDITOR": 1,
+		"OWNER":  2,
+	}
+)
+
+func (x VaultRole) Enum() *VaultRole {
+	p := new(VaultRole)
+	*p = x
+	return p
+}
+
+func (x VaultRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VaultRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_proto_vault_proto_enumTypes[0].Descriptor()
+}
+
+func (VaultRole) Type() protoreflect.EnumType {
+	return &file_internal_proto_vault_proto_enumTypes[0]
+}
+
+func (x VaultRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VaultRole.Descriptor instead.
+func (VaultRole) EnumDescriptor() ([]byte, []int) {
+	return file_internal_proto_vault_proto_rawDescGZIP(), []int{0}
+}
+
+type CreateVaultRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Organization is created with the caller as its owner if it does not exist.
+	Org  string `protobuf:"bytes,1,opt,name=org,proto3" json:"org,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Vault key sealed for the caller.
+	SealedKey []byte `protobuf:"bytes,3,opt,name=sealed_key,json=sealedKey,proto3" json:"sealed_key,omitempty"`
+}
+
+func (x *CreateVaultRequest) Reset() {
+	*x = CreateVaultRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_vault_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateVaultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVaultRequest) ProtoMessage() {}
+
+func (x *CreateVaultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_vault_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVaultRequest.ProtoReflect.Descriptor instead.
+func (*CreateVaultRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_vault_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateVaultRequest) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+func (x *CreateVaultRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateVaultRequest) GetSealedKey() []byte {
+	if x != nil {
+		return x.SealedKey
+	}
+	return nil
+}
+
+type Vault struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Org  string `protobuf:"bytes,1,opt,name=org,proto3" json:"org,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Role of the caller in the vault.
+	Role VaultRole `protobuf:"varint,3,opt,name=role,proto3,enum=proto.VaultRole" json:"role,omitempty"`
+}
+
+func (x *Vault) Reset() {
+	*x = Vault{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_vault_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Vault) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vault) ProtoMessage() {}
+
+func (x *Vault) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_vault_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vault.ProtoReflect.Descriptor instead.
+func (*Vault) Descriptor() ([]byte, []int) {
+	return file_internal_proto_vault_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Vault) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+func (x *Vault) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Vault) GetRole() VaultRole {
+	if x != nil {
+		return x.Role
+	}
+	return VaultRole_VIEWER
+}
+
+type ListVaultsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vaults []*Vault `protobuf:"bytes,1,rep,name=vaults,proto3" json:"vaults,omitempty"`
+}
+
+func (x *ListVaultsResponse) Reset() {
+	*x = ListVaultsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_vault_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListVaultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVaultsResponse) ProtoMessage() {}
+
+func (x *ListVaultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_vault_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVaultsResponse.ProtoReflect.Descriptor instead.
+func (*ListVaultsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_vault_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListVaultsResponse) GetVaults() []*Vault {
+	if x != nil {
+		return x.Vaults
+	}
+	return nil
+}
+
+type VaultMember struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Login string `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
+	// Empty vault is the organization, its members have their role in every vault of the organization.
+	Vault string    `protobuf:"bytes,2,opt,name=vault,proto3" json:"vault,omitempty"`
+	Role  VaultRole `protobuf:"varint,3,opt,name=role,proto3,enum=proto.VaultRole" json:"role,omitempty"`
+}
+
+func (x *VaultMember) Reset() {
+	*x = VaultMember{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_vault_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VaultMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VaultMember) ProtoMessage() {}
+
+func (x *VaultMember) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_vault_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VaultMember.ProtoReflect.Descriptor instead.
+func (*VaultMember) Descriptor() ([]byte, []int) {
+	return file_internal_proto_vault_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *VaultMember) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *VaultMember) GetVault() string {
+	if x != nil {
+		return x.Vault
+	}
+	return ""
+}
+
+func (x *VaultMember) GetRole() VaultRole {
+	if x != nil {
+		return x.Role
+	}
+	return VaultRole_VIEWER
+}
+
+type VaultMemberRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Org string `protobuf:"bytes,1,opt,name=org,proto3" json:"org,omitempty"`
+	// Empty vault is the organization.
+	Vault string `protobuf:"bytes,2,opt,name=vault,proto3" json:"vault,omitempty"`
+	Login string `protobuf:"bytes,3,opt,name=login,proto3" json:"login,omitempty"`
+	// Role is ignored by RemoveVaultMember.
+	Role VaultRole `protobuf:"varint,4,opt,name=role,proto3,enum=proto.VaultRole" json:"role,omitempty"`
+	// Vault key sealed for the member, required for vault members and ignored by RemoveVaultMember.
+	SealedKey []byte `protobuf:"bytes,5,opt,name=sealed_key,json=sealedKey,proto3" json:"sealed_key,omitempty"`
+}
+
+func (x *VaultMemberRequest) Reset() {
+	*x = VaultMemberRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_vault_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VaultMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VaultMemberRequest) ProtoMessage() {}
+
+func (x *VaultMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_vault_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VaultMemberRequest.ProtoReflect.Descriptor instead.
+func (*VaultMemberRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_vault_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *VaultMemberRequest) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+func (x *VaultMemberRequest) GetVault() string {
+	if x != nil {
+		return x.Vault
+	}
+	return ""
+}
+
+func (x *VaultMemberRequest) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *VaultMemberRequest) GetRole() VaultRole {
+	if x != nil {
+		return x.Role
+	}
+	return VaultRole_VIEWER
+}
+
+func (x *VaultMemberRequest) GetSealedKey() []byte {
+	if x != nil {
+		return x.SealedKey
+	}
+	return nil
+}
+
+type ListVaultMembersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Org   string `protobuf:"bytes,1,opt,name=org,proto3" json:"org,omitempty"`
+	Vault string `protobuf:"bytes,2,opt,name=vault,proto3" json:"vault,omitempty"`
+}
+
+func (x *ListVaultMembersRequest) Reset() {
+	*x = ListVaultMembersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_vault_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListVaultMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVaultMembersRequest) ProtoMessage() {}
+
+func (x *ListVaultMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_vault_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVaultMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListVaultMembersRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_vault_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListVaultMembersRequest) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+func (x *ListVaultMembersRequest) GetVault() string {
+	if x != nil {
+		return x.Vault
+	}
+	return ""
+}
+
+type ListVaultMembersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Members []*VaultMember `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+}
+
+func (x *ListVaultMembersResponse) Reset() {
+	*x = ListVaultMembersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_vault_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListVaultMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVaultMembersResponse) ProtoMessage() {}
+
+func (x *ListVaultMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_vault_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVaultMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListVaultMembersResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_vault_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListVaultMembersResponse) GetMembers() []*VaultMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// Vault key sealed for the caller, vault is set in metadata.
+type GetVaultKeyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SealedKey []byte `protobuf:"bytes,1,opt,name=sealed_key,json=sealedKey,proto3" json:"sealed_key,omitempty"`
+}
+
+func (x *GetVaultKeyResponse) Reset() {
+	*x = GetVaultKeyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_vault_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVaultKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVaultKeyResponse) ProtoMessage() {}
+
+func (x *GetVaultKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_vault_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVaultKeyResponse.ProtoReflect.Descriptor instead.
+func (*GetVaultKeyResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_vault_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetVaultKeyResponse) GetSealedKey() []byte {
+	if x != nil {
+		return x.SealedKey
+	}
+	return nil
+}
+
+// Vault key sealed for the caller with a new public key, replaces the key the caller has.
+// Vault is set in metadata.
+type SetVaultKeyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SealedKey []byte `protobuf:"bytes,1,opt,name=sealed_key,json=sealedKey,proto3" json:"sealed_key,omitempty"`
+}
+
+func (x *SetVaultKeyRequest) Reset() {
+	*x = SetVaultKeyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_vault_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetVaultKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetVaultKeyRequest) ProtoMessage() {}
+
+func (x *SetVaultKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_vault_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetVaultKeyRequest.ProtoReflect.Descriptor instead.
+func (*SetVaultKeyRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_vault_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SetVaultKeyRequest) GetSealedKey() []byte {
+	if x != nil {
+		return x.SealedKey
+	}
+	return nil
+}
+
+var File_internal_proto_vault_proto protoreflect.FileDescriptor
+
+var file_internal_proto_vault_proto_rawDesc = []byte{
+	0x0a, 0x1a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x76, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x59, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x61, 0x75,
+	0x6c, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x72, 0x67,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x72, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x22, 0x53,
+	0x0a, 0x05, 0x56, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x72, 0x67, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x72, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x24, 0x0a,
+	0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x61, 0x75, 0x6c, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72,
+	0x6f, 0x6c, 0x65, 0x22, 0x3a, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x75, 0x6c, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x06, 0x76, 0x61, 0x75,
+	0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x56, 0x61, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x76, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x22,
+	0x5f, 0x0a, 0x0b, 0x56, 0x61, 0x75, 0x6c, 0x74, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c,
+	0x6f, 0x67, 0x69, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x24, 0x0a, 0x04, 0x72, 0x6f,
+	0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x56, 0x61, 0x75, 0x6c, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65,
+	0x22, 0x97, 0x01, 0x0a, 0x12, 0x56, 0x61, 0x75, 0x6c, 0x74, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x72, 0x67, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x72, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x75,
+	0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x75, 0x6c, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x12, 0x24, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x61, 0x75, 0x6c,
+	0x74, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73,
+	0x65, 0x61, 0x6c, 0x65, 0x64, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x09, 0x73, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x22, 0x41, 0x0a, 0x17, 0x4c, 0x69,
+	0x73, 0x74, 0x56, 0x61, 0x75, 0x6c, 0x74, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x72, 0x67, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6f, 0x72, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x75, 0x6c, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x75, 0x6c, 0x74, 0x22, 0x48, 0x0a,
+	0x18, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x75, 0x6c, 0x74, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x6d, 0x65, 0x6d,
+	0x62, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x56, 0x61, 0x75, 0x6c, 0x74, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x07,
+	0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x22, 0x34, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x56, 0x61,
+	0x75, 0x6c, 0x74, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x73, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x09, 0x73, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x22, 0x33, 0x0a,
+	0x12, 0x53, 0x65, 0x74, 0x56, 0x61, 0x75, 0x6c, 0x74, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x5f, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x4b,
+	0x65, 0x79, 0x2a, 0x2e, 0x0a, 0x09, 0x56, 0x61, 0x75, 0x6c, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x12,
+	0x0a, 0x0a, 0x06, 0x56, 0x49, 0x45, 0x57, 0x45, 0x52, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x45,
+	0x44, 0x49, 0x54, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x4f, 0x57, 0x4e, 0x45, 0x52,
+	0x10, 0x02, 0x42, 0x10, 0x5a, 0x0e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_internal_proto_vault_proto_rawDescOnce sync.Once
+	file_internal_proto_vault_proto_rawDescData = file_internal_proto_vault_proto_rawDesc
+)
+
+func file_internal_proto_vault_proto_rawDescGZIP() []byte {
+	file_internal_proto_vault_proto_rawDescOnce.Do(func() {
+		file_internal_proto_vault_proto_rawDescData = protoimpl.X.CompressGZIP(file_internal_proto_vault_proto_rawDescData)
+	})
+	return file_internal_proto_vault_proto_rawDescData
+}
+
+var file_internal_proto_vault_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_internal_proto_vault_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_internal_proto_vault_proto_goTypes = []any{
+	(VaultRole)(0),                   // 0: proto.VaultRole
+	(*CreateVaultRequest)(nil),       // 1: proto.CreateVaultRequest
+	(*Vault)(nil),                    // 2: proto.Vault
+	(*ListVaultsResponse)(nil),       // 3: proto.ListVaultsResponse
+	(*VaultMember)(nil),              // 4: proto.VaultMember
+	(*VaultMemberRequest)(nil),       // 5: proto.VaultMemberRequest
+	(*ListVaultMembersRequest)(nil),  // 6: proto.ListVaultMembersRequest
+	(*ListVaultMembersResponse)(nil), // 7: proto.ListVaultMembersResponse
+	(*GetVaultKeyResponse)(nil),      // 8: proto.GetVaultKeyResponse
+	(*SetVaultKeyRequest)(nil),       // 9: proto.SetVaultKeyRequest
+}
+var file_internal_proto_vault_proto_depIdxs = []int32{
+	0, // 0: proto.Vault.role:type_name -> proto.VaultRole
+	2, // 1: proto.ListVaultsResponse.vaults:type_name -> proto.Vault
+	0, // 2: proto.VaultMember.role:type_name -> proto.VaultRole
+	0, // 3: proto.VaultMemberRequest.role:type_name -> proto.VaultRole
+	4, // 4: proto.ListVaultMembersResponse.members:type_name -> proto.VaultMember
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_internal_proto_vault_proto_init() }
+func file_internal_proto_vault_proto_init() {
+	if File_internal_proto_vault_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_internal_proto_vault_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateVaultRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_vault_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*Vault); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_vault_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*ListVaultsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_vault_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*VaultMember); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_vault_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*VaultMemberRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_vault_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*ListVaultMembersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_vault_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*ListVaultMembersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_vault_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*GetVaultKeyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_vault_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*SetVaultKeyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_internal_proto_vault_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_internal_proto_vault_proto_goTypes,
+		DependencyIndexes: file_internal_proto_vault_proto_depIdxs,
+		EnumInfos:         file_internal_proto_vault_proto_enumTypes,
+		MessageInfos:      file_internal_proto_vault_proto_msgTypes,
+	}.Build()
+	File_internal_proto_vault_proto = out.File
+	file_internal_proto_vault_proto_rawDesc = nil
+	file_internal_proto_vault_proto_goTypes = nil
+	file_internal_proto_vault_proto_depIdxs = nil
+}
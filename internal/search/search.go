@@ -0,0 +1,97 @@
+// Package search implements parsing of secret search queries shared by gophkeeper server and client.
+package search
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+const (
+	// DefaultLimit is the number of search results returned if limit is not set.
+	DefaultLimit = 20
+	// MaxLimit is the maximum number of search results.
+	MaxLimit = 100
+)
+
+// Weights of secret fields in search rank, name matches are the most relevant.
+const (
+	WeightName   = 3.0
+	WeightType   = 2.0
+	WeightLabels = 1.0
+)
+
+var ErrEmptyQuery = errors.New("search query has no words")
+
+// Terms splits query into lower case words, ErrEmptyQuery is returned if there are none.
+func Terms(query string) ([]string, error) {
+	terms := Tokens(query)
+	if len(terms) == 0 {
+		return nil, ErrEmptyQuery
+	}
+	return terms, nil
+}
+
+// Tokens splits text into lower case words, everything but letters and digits separates words.
+func Tokens(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Limit returns the number of results to return for requested limit.
+func Limit(limit int) int {
+	switch {
+	case limit <= 0:
+		return DefaultLimit
+	case limit > MaxLimit:
+		return MaxLimit
+	}
+	return limit
+}
+
+// Rank ranks the secret by fields where every term is a prefix of some word, it is used where full
+// text index is not available. 0 is returned if any term is missing.
+func Rank(terms []string, name string, typ string, labels map[string]string) float64 {
+	fields := []struct {
+		tokens []string
+		weight float64
+	}{
+		{tokens: Tokens(name), weight: WeightName},
+		{tokens: Tokens(typ), weight: WeightType},
+		{tokens: Tokens(LabelsText(labels)), weight: WeightLabels},
+	}
+
+	var rank float64
+	for _, term := range terms {
+		var best float64
+		for _, field := range fields {
+			if field.weight > best && hasPrefix(field.tokens, term) {
+				best = field.weight
+			}
+		}
+		if best == 0 {
+			return 0
+		}
+		rank += best
+	}
+	return rank
+}
+
+func hasPrefix(tokens []string, prefix string) bool {
+	for _, token := range tokens {
+		if strings.HasPrefix(token, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// LabelsText joins label keys and values into searchable text.
+func LabelsText(labels map[string]string) string {
+	words := make([]string, 0, 2*len(labels))
+	for k, v := range labels {
+		words = append(words, k, v)
+	}
+	return strings.Join(words, " ")
+}
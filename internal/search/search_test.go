@@ -0,0 +1,30 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerms(t *testing.T) {
+	terms, err := Terms(" Work/AWS-prod  env=payments_team ")
+	require.NoError(t, err)
+	require.Equal(t, []string{"work", "aws", "prod", "env", "payments", "team"}, terms)
+
+	_, err = Terms(" /*& ")
+	require.ErrorIs(t, err, ErrEmptyQuery)
+}
+
+func TestLimit(t *testing.T) {
+	require.Equal(t, DefaultLimit, Limit(0))
+	require.Equal(t, 5, Limit(5))
+	require.Equal(t, MaxLimit, Limit(MaxLimit+1))
+}
+
+func TestRank(t *testing.T) {
+	secretLabels := map[string]string{"team": "payments"}
+	require.Equal(t, WeightName, Rank([]string{"aws"}, "work/aws/prod", "text", secretLabels))
+	require.Equal(t, WeightName+WeightLabels, Rank([]string{"wor", "pay"}, "work/aws/prod", "text", secretLabels))
+	require.Equal(t, WeightType, Rank([]string{"tex"}, "work/aws/prod", "text", secretLabels))
+	require.Zero(t, Rank([]string{"aws", "login"}, "work/aws/prod", "text", secretLabels))
+}
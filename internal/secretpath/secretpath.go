@@ -0,0 +1,120 @@
+// Package secretpath implements path-style secret names shared by gophkeeper server and client.
+// Secret name is a list of segments separated by '/', e.g. 'work/aws/prod', folder is a prefix
+// of secret names ending with '/', e.g. 'work/aws/'.
+package secretpath
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Separator separates segments of secret name.
+const Separator = "/"
+
+var (
+	ErrInvalidName   = errors.New("invalid secret name")
+	ErrInvalidFolder = errors.New("invalid folder")
+	ErrMoveIntoSelf  = errors.New("folder can not be moved into itself")
+)
+
+// ValidateName checks that secret name consists of non-empty segments other than '.' and '..'.
+func ValidateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: empty name", ErrInvalidName)
+	}
+	for _, segment := range strings.Split(name, Separator) {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("%w: %q", ErrInvalidName, name)
+		}
+	}
+	return nil
+}
+
+// IsFolder reports whether name is a folder, i.e. it ends with '/'.
+func IsFolder(name string) bool {
+	return strings.HasSuffix(name, Separator)
+}
+
+// NormalizeFolder validates folder and adds trailing '/' if it is missing, leading '/' is
+// removed. Empty folder is the root one.
+func NormalizeFolder(folder string) (string, error) {
+	folder = strings.TrimPrefix(folder, Separator)
+	if folder == "" {
+		return "", nil
+	}
+	if !IsFolder(folder) {
+		folder += Separator
+	}
+	if err := ValidateName(strings.TrimSuffix(folder, Separator)); err != nil {
+		return "", fmt.Errorf("%w: %q", ErrInvalidFolder, folder)
+	}
+	return folder, nil
+}
+
+// InFolder reports whether secret name is in the folder, secrets of subfolders are
+// included if recursive is set.
+func InFolder(name, folder string, recursive bool) bool {
+	rest, ok := strings.CutPrefix(name, folder)
+	if !ok || rest == "" {
+		return false
+	}
+	return recursive || !strings.Contains(rest, Separator)
+}
+
+// Subfolder returns immediate subfolder of the folder containing secret name, false is
+// returned if the secret is not in a subfolder.
+func Subfolder(name, folder string) (string, bool) {
+	rest, ok := strings.CutPrefix(name, folder)
+	if !ok {
+		return "", false
+	}
+	segment, _, found := strings.Cut(rest, Separator)
+	if !found {
+		return "", false
+	}
+	return folder + segment + Separator, true
+}
+
+// Base returns the last segment of secret name.
+func Base(name string) string {
+	return name[strings.LastIndex(name, Separator)+1:]
+}
+
+// MoveTarget validates move of a secret or a folder and returns the new name. Folder is renamed,
+// i.e. its prefix is replaced with 'to' in names of all its secrets. Secret is renamed to 'to',
+// or it is moved into 'to' keeping its base name if 'to' ends with '/'.
+func MoveTarget(from, to string) (string, error) {
+	if IsFolder(from) {
+		folder, err := NormalizeFolder(from)
+		if err != nil {
+			return "", err
+		}
+		target, err := NormalizeFolder(to)
+		if err != nil {
+			return "", err
+		}
+		if target == "" {
+			return "", fmt.Errorf("%w: empty target folder", ErrInvalidFolder)
+		}
+		if strings.HasPrefix(target, folder) {
+			return "", fmt.Errorf("%w: %q into %q", ErrMoveIntoSelf, folder, target)
+		}
+		return target, nil
+	}
+
+	if err := ValidateName(from); err != nil {
+		return "", err
+	}
+	if IsFolder(to) {
+		folder, err := NormalizeFolder(to)
+		if err != nil {
+			return "", err
+		}
+		to = folder + Base(from)
+	}
+	if err := ValidateName(to); err != nil {
+		return "", err
+	}
+	return to, nil
+}
@@ -0,0 +1,128 @@
+package secretpath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateName(t *testing.T) {
+	tests := map[string]bool{
+		"secret01":      true,
+		"work/aws/prod": true,
+		"":              false,
+		"/work":         false,
+		"work/":         false,
+		"work//prod":    false,
+		"work/../prod":  false,
+		"./prod":        false,
+	}
+
+	for name, valid := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateName(name)
+			if valid {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidName)
+		})
+	}
+}
+
+func TestNormalizeFolder(t *testing.T) {
+	tests := []struct {
+		folder  string
+		want    string
+		wantErr bool
+	}{
+		{folder: "", want: ""},
+		{folder: "/", want: ""},
+		{folder: "work", want: "work/"},
+		{folder: "/work/aws/", want: "work/aws/"},
+		{folder: "work//", wantErr: true},
+		{folder: "work/../", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.folder, func(t *testing.T) {
+			got, err := NormalizeFolder(tt.folder)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidFolder)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestInFolder(t *testing.T) {
+	tests := []struct {
+		name      string
+		folder    string
+		recursive bool
+		want      bool
+	}{
+		{name: "secret01", folder: "", want: true},
+		{name: "work/aws/prod", folder: "", want: false},
+		{name: "work/aws/prod", folder: "", recursive: true, want: true},
+		{name: "work/aws/prod", folder: "work/aws/", want: true},
+		{name: "work/aws/prod", folder: "work/", want: false},
+		{name: "work/aws/prod", folder: "work/", recursive: true, want: true},
+		{name: "workshop/prod", folder: "work/", recursive: true, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"_"+tt.folder, func(t *testing.T) {
+			require.Equal(t, tt.want, InFolder(tt.name, tt.folder, tt.recursive))
+		})
+	}
+}
+
+func TestSubfolder(t *testing.T) {
+	folder, ok := Subfolder("work/aws/prod", "work/")
+	require.True(t, ok)
+	require.Equal(t, "work/aws/", folder)
+
+	folder, ok = Subfolder("work/aws/prod", "")
+	require.True(t, ok)
+	require.Equal(t, "work/", folder)
+
+	_, ok = Subfolder("work/github", "work/")
+	require.False(t, ok)
+
+	_, ok = Subfolder("home/github", "work/")
+	require.False(t, ok)
+}
+
+func TestMoveTarget(t *testing.T) {
+	tests := []struct {
+		from    string
+		to      string
+		want    string
+		wantErr error
+	}{
+		{from: "aws", to: "work/aws/prod", want: "work/aws/prod"},
+		{from: "work/aws/prod", to: "personal/", want: "personal/prod"},
+		{from: "work/aws/prod", to: "/", want: "prod"},
+		{from: "work/aws/", to: "personal/aws", want: "personal/aws/"},
+		{from: "work/", to: "work/old/", wantErr: ErrMoveIntoSelf},
+		{from: "work/", to: "/", wantErr: ErrInvalidFolder},
+		{from: "work//", to: "personal/", wantErr: ErrInvalidFolder},
+		{from: "aws", to: "work//aws", wantErr: ErrInvalidName},
+		{from: "", to: "aws", wantErr: ErrInvalidName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+"_"+tt.to, func(t *testing.T) {
+			got, err := MoveTarget(tt.from, tt.to)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
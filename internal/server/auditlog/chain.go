@@ -0,0 +1,127 @@
+// Package auditlog makes the audit log tamper-evident: every event is chained to the previous one
+// by SHA-256 hash and the head of the chain is periodically exported as a signed checkpoint.
+package auditlog
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+)
+
+// verifyBatch is the number of events read from storage at once by Verify.
+const verifyBatch = 1000
+
+// Genesis is the previous hash of the first chained event.
+var Genesis = make([]byte, sha256.Size)
+
+// Storage reads the audit log in the order of event IDs.
+type Storage interface {
+	AuditChain(ctx context.Context, afterID int64, limit int) ([]models.AuditRecord, error)
+	AuditChainStart(ctx context.Context) (int64, error)
+	AuditHead(ctx context.Context) (*models.AuditRecord, error)
+}
+
+// Hash returns hash of the event chained to the previous hash. Fields are length-prefixed, so
+// that moving bytes between them changes the hash, creation time is taken with microseconds
+// precision as stored in DB.
+func Hash(prev []byte, e *models.AuditEvent) []byte {
+	h := sha256.New()
+	var buf [8]byte
+
+	h.Write(prev)
+	binary.BigEndian.PutUint64(buf[:], uint64(e.CreatedAt.UnixMicro()))
+	h.Write(buf[:])
+	for _, field := range []string{e.UserID, e.Owner, e.Method, e.Name, e.ClientIP, e.Code} {
+		binary.BigEndian.PutUint64(buf[:], uint64(len(field)))
+		h.Write(buf[:])
+		h.Write([]byte(field))
+	}
+	return h.Sum(nil)
+}
+
+// Verify walks the audit log from the first event and reports the first broken link: an event
+// changed or deleted after it was written, or an event which hash differs from a checkpoint.
+// Events written before the chain was introduced are only counted, an event without hash from
+// the start of the chain is a broken link, so that the chain can not be removed along with hashes.
+func Verify(ctx context.Context, s Storage, checkpoints []Checkpoint) (*models.AuditVerification, error) {
+	result := &models.AuditVerification{Checkpoints: len(checkpoints)}
+	expected := make(map[int64][]byte, len(checkpoints))
+	for _, cp := range checkpoints {
+		expected[cp.ID] = cp.Hash
+	}
+	start, err := s.AuditChainStart(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read start of audit chain: %w", err)
+	}
+
+	// prev is nil until the first chained event
+	var prev []byte
+	var afterID int64
+	for {
+		records, err := s.AuditChain(ctx, afterID, verifyBatch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read audit log after event %d: %w", afterID, err)
+		}
+
+		for i := range records {
+			r := &records[i]
+			if reason := check(r, prev, start, expected); reason != "" {
+				result.BrokenID = r.ID
+				result.Reason = reason
+				return result, nil
+			}
+			if r.Hash == nil {
+				result.Unchained++
+				continue
+			}
+			prev = r.Hash
+			result.Verified++
+		}
+
+		if len(records) < verifyBatch {
+			break
+		}
+		afterID = records[len(records)-1].ID
+	}
+
+	// the tail of the log covered by a checkpoint has been removed
+	for id := range expected {
+		if result.BrokenID == 0 || id < result.BrokenID {
+			result.BrokenID = id
+			result.Reason = "event of checkpoint is missing"
+		}
+	}
+	return result, nil
+}
+
+// check returns reason why the event is not a valid link of the chain, or empty string. Events
+// before start and before the first chained event may have no hash.
+func check(r *models.AuditRecord, prev []byte, start int64, expected map[int64][]byte) string {
+	if want, ok := expected[r.ID]; ok {
+		if !bytes.Equal(r.Hash, want) {
+			return "event hash differs from checkpoint"
+		}
+		delete(expected, r.ID)
+	}
+
+	if r.Hash == nil {
+		if prev != nil || r.ID >= start {
+			return "event is not chained"
+		}
+		return ""
+	}
+	if prev == nil {
+		prev = Genesis
+	}
+	if !bytes.Equal(r.PrevHash, prev) {
+		return "previous hash mismatch, preceding events were changed or deleted"
+	}
+	if !bytes.Equal(r.Hash, Hash(r.PrevHash, &r.AuditEvent)) {
+		return "event hash mismatch, event was changed"
+	}
+	return ""
+}
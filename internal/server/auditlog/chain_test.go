@@ -0,0 +1,217 @@
+package auditlog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+)
+
+// memoryLog is the audit log kept in memory in the order of IDs.
+type memoryLog struct {
+	records []models.AuditRecord
+	// start is ID of the first event written after the chain was introduced
+	start int64
+}
+
+func (m *memoryLog) AuditChain(_ context.Context, afterID int64, limit int) ([]models.AuditRecord, error) {
+	var records []models.AuditRecord
+	for _, r := range m.records {
+		if r.ID > afterID && len(records) < limit {
+			records = append(records, r)
+		}
+	}
+	return records, nil
+}
+
+func (m *memoryLog) AuditChainStart(_ context.Context) (int64, error) {
+	return m.start, nil
+}
+
+func (m *memoryLog) AuditHead(_ context.Context) (*models.AuditRecord, error) {
+	if len(m.records) == 0 {
+		return nil, nil
+	}
+	head := m.records[len(m.records)-1]
+	return &head, nil
+}
+
+// add appends the event the same way as storage does.
+func (m *memoryLog) add(e models.AuditEvent, chained bool) {
+	r := models.AuditRecord{AuditEvent: e}
+	r.ID = int64(len(m.records) + 1)
+	if chained {
+		r.PrevHash = Genesis
+		if len(m.records) != 0 && m.records[len(m.records)-1].Hash != nil {
+			r.PrevHash = m.records[len(m.records)-1].Hash
+		}
+		r.Hash = Hash(r.PrevHash, &r.AuditEvent)
+	}
+	m.records = append(m.records, r)
+}
+
+// newLog returns log of n chained events preceded by unchained ones.
+func newLog(unchained int, n int) *memoryLog {
+	m := &memoryLog{start: int64(unchained) + 1}
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	for i := range unchained + n {
+		m.add(models.AuditEvent{
+			CreatedAt: created.Add(time.Duration(i) * time.Second),
+			UserID:    "alice",
+			Owner:     "alice",
+			Method:    "GetSecret",
+			Name:      "work/db",
+			ClientIP:  "192.0.2.10",
+			Code:      "OK",
+		}, i >= unchained)
+	}
+	return m
+}
+
+func TestHash(t *testing.T) {
+	e := models.AuditEvent{UserID: "alice", Owner: "alice", Method: "GetSecret", Name: "db"}
+	moved := models.AuditEvent{UserID: "alic", Owner: "ealice", Method: "GetSecret", Name: "db"}
+	if string(Hash(Genesis, &e)) == string(Hash(Genesis, &moved)) {
+		t.Error("moving bytes between fields must change the hash")
+	}
+	if string(Hash(Genesis, &e)) == string(Hash(Hash(Genesis, &e), &e)) {
+		t.Error("previous hash must change the hash")
+	}
+}
+
+func TestVerify(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		log         func() *memoryLog
+		checkpoints func() []Checkpoint
+		brokenID    int64
+		verified    int64
+		unchained   int64
+	}{
+		{
+			name:     "Intact",
+			log:      func() *memoryLog { return newLog(0, 2*verifyBatch+5) },
+			verified: 2*verifyBatch + 5,
+		},
+		{
+			name:      "UnchainedPrefix",
+			log:       func() *memoryLog { return newLog(3, 5) },
+			verified:  5,
+			unchained: 3,
+		},
+		{
+			name: "Changed",
+			log: func() *memoryLog {
+				m := newLog(0, 5)
+				m.records[2].Code = "PermissionDenied"
+				return m
+			},
+			brokenID: 3,
+			verified: 2,
+		},
+		{
+			name: "Deleted",
+			log: func() *memoryLog {
+				m := newLog(0, 5)
+				m.records = append(m.records[:2], m.records[3:]...)
+				return m
+			},
+			brokenID: 4,
+			verified: 2,
+		},
+		{
+			name: "HashRemoved",
+			log: func() *memoryLog {
+				m := newLog(0, 5)
+				m.records[3].Hash = nil
+				return m
+			},
+			brokenID: 4,
+			verified: 3,
+		},
+		{
+			name: "ChainRemoved",
+			log: func() *memoryLog {
+				m := newLog(0, 5)
+				// hashes are removed from every event to edit them freely
+				for i := range m.records {
+					m.records[i].PrevHash = nil
+					m.records[i].Hash = nil
+				}
+				m.records[2].Code = "PermissionDenied"
+				return m
+			},
+			brokenID: 1,
+		},
+		{
+			name: "ChainRemovedAfterPrefix",
+			log: func() *memoryLog {
+				m := newLog(3, 5)
+				for i := 3; i < len(m.records); i++ {
+					m.records[i].PrevHash = nil
+					m.records[i].Hash = nil
+				}
+				return m
+			},
+			brokenID:  4,
+			unchained: 3,
+		},
+		{
+			name: "Rewritten",
+			log: func() *memoryLog {
+				m := newLog(0, 5)
+				m.records[1].Code = "PermissionDenied"
+				// chain recomputed by the attacker
+				for i := 1; i < len(m.records); i++ {
+					m.records[i].PrevHash = m.records[i-1].Hash
+					m.records[i].Hash = Hash(m.records[i].PrevHash, &m.records[i].AuditEvent)
+				}
+				return m
+			},
+			checkpoints: func() []Checkpoint {
+				original := newLog(0, 5)
+				return []Checkpoint{{ID: 4, Hash: original.records[3].Hash}}
+			},
+			brokenID: 4,
+			verified: 3,
+		},
+		{
+			name: "Truncated",
+			log: func() *memoryLog {
+				m := newLog(0, 5)
+				m.records = m.records[:3]
+				return m
+			},
+			checkpoints: func() []Checkpoint {
+				original := newLog(0, 5)
+				return []Checkpoint{{ID: 5, Hash: original.records[4].Hash}, {ID: 2, Hash: original.records[1].Hash}}
+			},
+			brokenID: 5,
+			verified: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.log()
+			var checkpoints []Checkpoint
+			if tt.checkpoints != nil {
+				checkpoints = tt.checkpoints()
+			}
+
+			result, err := Verify(context.Background(), m, checkpoints)
+			if err != nil {
+				t.Fatalf("failed to verify: %v", err)
+			}
+			if result.BrokenID != tt.brokenID || result.Verified != tt.verified || result.Unchained != tt.unchained {
+				t.Errorf("Out -> \nWant: broken %d verified %d unchained %d\nGot : %+v",
+					tt.brokenID, tt.verified, tt.unchained, result)
+			}
+			if (result.BrokenID == 0) != (result.Reason == "") {
+				t.Errorf("reason must be set for broken link only, got %+v", result)
+			}
+		})
+	}
+}
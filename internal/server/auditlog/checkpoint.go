@@ -0,0 +1,164 @@
+package auditlog
+
+import (
+	"bufio"
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+)
+
+var (
+	ErrInvalidKey       = errors.New("invalid ed25519 private key")
+	ErrInvalidSignature = errors.New("invalid checkpoint signature")
+)
+
+// Checkpoint is the head of the audit chain at CreatedAt signed by the server. Checkpoints are
+// stored outside of DB, so that rewriting the whole chain is detected as well.
+type Checkpoint struct {
+	CreatedAt time.Time `json:"created_at"`
+	Hash      []byte    `json:"hash"`
+	Signature []byte    `json:"signature"`
+	ID        int64     `json:"id"`
+}
+
+// message returns the signed content of the checkpoint.
+func (cp *Checkpoint) message() []byte {
+	return fmt.Appendf(nil, "gophkeeper audit checkpoint\n%d\n%x\n%d", cp.ID, cp.Hash, cp.CreatedAt.UnixNano())
+}
+
+// Sign sets signature of the checkpoint.
+func (cp *Checkpoint) Sign(key ed25519.PrivateKey) {
+	cp.Signature = ed25519.Sign(key, cp.message())
+}
+
+// Verify reports whether the checkpoint is signed with the key.
+func (cp *Checkpoint) Verify(key ed25519.PublicKey) bool {
+	return ed25519.Verify(key, cp.message(), cp.Signature)
+}
+
+// LoadKey reads ed25519 private key in PKCS #8 PEM format, e.g. generated with
+// 'openssl genpkey -algorithm ed25519'.
+func LoadKey(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit key file: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: no PEM data in %s", ErrInvalidKey, path)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: key type %T", ErrInvalidKey, key)
+	}
+	return edKey, nil
+}
+
+// AppendCheckpoint appends the checkpoint to the file as a JSON line.
+func AppendCheckpoint(path string, cp *Checkpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open checkpoint file: %w", err)
+	}
+	if _, err = f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("failed to close checkpoint file: %w", err)
+	}
+	return nil
+}
+
+// ReadCheckpoints reads checkpoints from the file and verifies their signatures, missing file
+// has no checkpoints.
+func ReadCheckpoints(path string, key ed25519.PublicKey) ([]Checkpoint, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open checkpoint file: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var checkpoints []Checkpoint
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		var cp Checkpoint
+		if err := json.Unmarshal(scanner.Bytes(), &cp); err != nil {
+			return nil, fmt.Errorf("failed to parse checkpoint at line %d: %w", line, err)
+		}
+		if !cp.Verify(key) {
+			return nil, fmt.Errorf("%w at line %d", ErrInvalidSignature, line)
+		}
+		checkpoints = append(checkpoints, cp)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint file: %w", err)
+	}
+	return checkpoints, nil
+}
+
+// WriteCheckpoint signs the head of the chain and appends it to c.AuditCheckpointFile unless
+// the head is the event of the last checkpoint. ID of the head event is returned.
+func WriteCheckpoint(ctx context.Context, c *models.Config, s Storage, key ed25519.PrivateKey,
+	lastID int64) (int64, error) {
+	head, err := s.AuditHead(ctx)
+	if err != nil {
+		return lastID, fmt.Errorf("failed to get head of audit log: %w", err)
+	}
+	// nothing has been chained yet
+	if head == nil || head.Hash == nil || head.ID == lastID {
+		return lastID, nil
+	}
+
+	cp := &Checkpoint{ID: head.ID, Hash: head.Hash, CreatedAt: time.Now().UTC()}
+	cp.Sign(key)
+	if err := AppendCheckpoint(c.AuditCheckpointFile, cp); err != nil {
+		return lastID, err
+	}
+	return head.ID, nil
+}
+
+// Run writes checkpoints every c.AuditCheckpointInterval until context is done.
+func Run(ctx context.Context, c *models.Config, s Storage, key ed25519.PrivateKey) {
+	logger := c.Logger
+	ticker := time.NewTicker(c.AuditCheckpointInterval)
+	defer ticker.Stop()
+
+	var lastID int64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			tickCtx, cancel := context.WithTimeout(ctx, c.ContextTimeout)
+			id, err := WriteCheckpoint(tickCtx, c, s, key, lastID)
+			cancel()
+			if err != nil {
+				logger.Sugar().Errorf("failed to write audit checkpoint: %v", err)
+				continue
+			}
+			lastID = id
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package auditlog
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+)
+
+func TestCheckpoints(t *testing.T) {
+	dir := t.TempDir()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	keyFile := filepath.Join(dir, "audit.pem")
+	if err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+
+	loaded, err := LoadKey(keyFile)
+	if err != nil {
+		t.Fatalf("failed to load key: %v", err)
+	}
+	if !loaded.Equal(key) {
+		t.Fatal("loaded key differs from the written one")
+	}
+	if _, err = LoadKey(filepath.Join(dir, "missing.pem")); err == nil {
+		t.Error("missing key file must fail")
+	}
+
+	ctx := context.Background()
+	cfg := &models.Config{AuditCheckpointFile: filepath.Join(dir, "checkpoints.jsonl")}
+	pub := key.Public().(ed25519.PublicKey)
+	m := newLog(1, 3)
+
+	checkpoints, err := ReadCheckpoints(cfg.AuditCheckpointFile, pub)
+	if err != nil || checkpoints != nil {
+		t.Fatalf("missing checkpoint file must have no checkpoints, got %v %v", checkpoints, err)
+	}
+
+	lastID, err := WriteCheckpoint(ctx, cfg, m, key, 0)
+	if err != nil || lastID != 4 {
+		t.Fatalf("Out -> \nWant: 4\nGot : %d %v", lastID, err)
+	}
+	// head has not changed
+	if _, err = WriteCheckpoint(ctx, cfg, m, key, lastID); err != nil {
+		t.Fatalf("failed to write checkpoint: %v", err)
+	}
+	m.add(m.records[0].AuditEvent, true)
+	if lastID, err = WriteCheckpoint(ctx, cfg, m, key, lastID); err != nil || lastID != 5 {
+		t.Fatalf("Out -> \nWant: 5\nGot : %d %v", lastID, err)
+	}
+
+	checkpoints, err = ReadCheckpoints(cfg.AuditCheckpointFile, pub)
+	if err != nil {
+		t.Fatalf("failed to read checkpoints: %v", err)
+	}
+	if len(checkpoints) != 2 || checkpoints[0].ID != 4 || checkpoints[1].ID != 5 {
+		t.Fatalf("Out -> \nWant: checkpoints of events 4 and 5\nGot : %+v", checkpoints)
+	}
+	result, err := Verify(ctx, m, checkpoints)
+	if err != nil || result.BrokenID != 0 || result.Checkpoints != 2 {
+		t.Errorf("Out -> \nWant: intact log\nGot : %+v %v", result, err)
+	}
+
+	// checkpoint of another chain signed with a different key
+	_, other, _ := ed25519.GenerateKey(rand.Reader)
+	forged := &Checkpoint{ID: 5, Hash: Genesis}
+	forged.Sign(other)
+	if err = AppendCheckpoint(cfg.AuditCheckpointFile, forged); err != nil {
+		t.Fatalf("failed to append checkpoint: %v", err)
+	}
+	if _, err = ReadCheckpoints(cfg.AuditCheckpointFile, pub); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Err -> \nWant: %v\nGot : %v", ErrInvalidSignature, err)
+	}
+}
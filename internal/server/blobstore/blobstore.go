@@ -0,0 +1,208 @@
+// Package blobstore keeps data of large secrets outside of the database. Data is split into chunks
+// stored as separate objects, every chunk is encrypted with the server blob key before upload, so
+// the object storage never gets the data even in the form encrypted by the client.
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// KeySize is the size of the blob key, blobs are encrypted with AES-256-GCM.
+const KeySize = 32
+
+var (
+	ErrNotFound   = errors.New("blob object not found")
+	ErrCorrupted  = errors.New("blob is corrupted")
+	ErrInvalidRef = errors.New("invalid blob reference")
+)
+
+// Store keeps objects by keys, keys are slash-separated paths.
+type Store interface {
+	Put(ctx context.Context, key string, data []byte) error
+	// Get returns ErrNotFound if there is no object with the key.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete does not fail if there is no object with the key.
+	Delete(ctx context.Context, key string) error
+}
+
+// Blobs writes and reads blobs as sequences of encrypted chunk objects. A blob is referred to by
+// its random ID and the number of chunks, the reference and SHA-256 hash of the data are kept
+// in the database.
+type Blobs struct {
+	store Store
+	aead  cipher.AEAD
+}
+
+func New(store Store, key []byte) (*Blobs, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("blob key must be %d bytes, got %d", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create blob cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create blob cipher: %w", err)
+	}
+	return &Blobs{store: store, aead: aead}, nil
+}
+
+// LoadKey reads the blob key kept hex encoded in the file.
+func LoadKey(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob key: %w", err)
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode blob key: %w", err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("blob key must be %d bytes, got %d", KeySize, len(key))
+	}
+	return key, nil
+}
+
+// Upload writes chunks of a new blob.
+type Upload struct {
+	blobs  *Blobs
+	hash   hash.Hash
+	id     string
+	chunks int
+}
+
+// Create starts upload of a new blob.
+func (b *Blobs) Create() (*Upload, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("failed to generate blob ID: %w", err)
+	}
+	return &Upload{blobs: b, hash: sha256.New(), id: hex.EncodeToString(id)}, nil
+}
+
+// Write encrypts the chunk and stores it as the next object of the blob.
+func (u *Upload) Write(ctx context.Context, chunk []byte) error {
+	key := chunkKey(u.id, u.chunks)
+	if err := u.blobs.store.Put(ctx, key, u.blobs.seal(key, chunk)); err != nil {
+		return fmt.Errorf("failed to store chunk %d of blob %s: %w", u.chunks, u.id, err)
+	}
+	u.hash.Write(chunk)
+	u.chunks++
+	return nil
+}
+
+// Ref returns reference to the written chunks.
+func (u *Upload) Ref() string {
+	return u.id + "/" + strconv.Itoa(u.chunks)
+}
+
+// Hash returns SHA-256 hash of the written data.
+func (u *Upload) Hash() []byte {
+	return u.hash.Sum(nil)
+}
+
+// Abort deletes the written chunks.
+func (u *Upload) Abort(ctx context.Context) error {
+	return u.blobs.Delete(ctx, u.Ref())
+}
+
+// Read calls f for every chunk of the blob in order and checks the data against its hash.
+// As chunks are passed to f before the whole blob is read, a caller must discard them if
+// ErrCorrupted is returned.
+func (b *Blobs) Read(ctx context.Context, ref string, sum []byte, f func(chunk []byte) error) error {
+	id, chunks, err := parseRef(ref)
+	if err != nil {
+		return err
+	}
+
+	h := sha256.New()
+	for seq := range chunks {
+		key := chunkKey(id, seq)
+		data, err := b.store.Get(ctx, key)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return fmt.Errorf("%w: chunk %d of blob %s is missing", ErrCorrupted, seq, id)
+			}
+			return fmt.Errorf("failed to read chunk %d of blob %s: %w", seq, id, err)
+		}
+		chunk, err := b.open(key, data)
+		if err != nil {
+			return fmt.Errorf("%w: chunk %d of blob %s: %w", ErrCorrupted, seq, id, err)
+		}
+		h.Write(chunk)
+		if err = f(chunk); err != nil {
+			return err
+		}
+	}
+
+	if !bytes.Equal(h.Sum(nil), sum) {
+		return fmt.Errorf("%w: hash of blob %s does not match", ErrCorrupted, id)
+	}
+	return nil
+}
+
+// Delete removes all chunks of the blob.
+func (b *Blobs) Delete(ctx context.Context, ref string) error {
+	id, chunks, err := parseRef(ref)
+	if err != nil {
+		return err
+	}
+	for seq := range chunks {
+		if err := b.store.Delete(ctx, chunkKey(id, seq)); err != nil {
+			return fmt.Errorf("failed to delete chunk %d of blob %s: %w", seq, id, err)
+		}
+	}
+	return nil
+}
+
+// seal encrypts the chunk bound to its object key, so chunks can not be swapped.
+func (b *Blobs) seal(key string, chunk []byte) []byte {
+	nonce := make([]byte, b.aead.NonceSize(), b.aead.NonceSize()+len(chunk)+b.aead.Overhead())
+	// crypto/rand never fails on supported platforms
+	_, _ = rand.Read(nonce)
+	return b.aead.Seal(nonce, nonce, chunk, []byte(key))
+}
+
+func (b *Blobs) open(key string, data []byte) ([]byte, error) {
+	if len(data) < b.aead.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+	nonce, ciphertext := data[:b.aead.NonceSize()], data[b.aead.NonceSize():]
+	chunk, err := b.aead.Open(nil, nonce, ciphertext, []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return chunk, nil
+}
+
+func chunkKey(id string, seq int) string {
+	return id + "/" + strconv.Itoa(seq)
+}
+
+func parseRef(ref string) (string, int, error) {
+	id, n, ok := strings.Cut(ref, "/")
+	if !ok {
+		return "", 0, fmt.Errorf("%w: %q", ErrInvalidRef, ref)
+	}
+	if _, err := hex.DecodeString(id); err != nil || id == "" {
+		return "", 0, fmt.Errorf("%w: %q", ErrInvalidRef, ref)
+	}
+	chunks, err := strconv.Atoi(n)
+	if err != nil || chunks < 0 {
+		return "", 0, fmt.Errorf("%w: %q", ErrInvalidRef, ref)
+	}
+	return id, chunks, nil
+}
@@ -0,0 +1,189 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newBlobs(t *testing.T) (*Blobs, string) {
+	t.Helper()
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("failed to create file store: %v", err)
+	}
+	key := make([]byte, KeySize)
+	_, _ = rand.Read(key)
+	b, err := New(store, key)
+	if err != nil {
+		t.Fatalf("failed to create blobs: %v", err)
+	}
+	return b, dir
+}
+
+// upload writes the chunks as a blob and returns its reference and hash.
+func upload(t *testing.T, b *Blobs, chunks ...string) (string, []byte) {
+	t.Helper()
+	u, err := b.Create()
+	if err != nil {
+		t.Fatalf("failed to create blob: %v", err)
+	}
+	for _, chunk := range chunks {
+		if err = u.Write(context.Background(), []byte(chunk)); err != nil {
+			t.Fatalf("failed to write chunk: %v", err)
+		}
+	}
+	return u.Ref(), u.Hash()
+}
+
+func read(b *Blobs, ref string, sum []byte) ([]byte, error) {
+	var data []byte
+	err := b.Read(context.Background(), ref, sum, func(chunk []byte) error {
+		data = append(data, chunk...)
+		return nil
+	})
+	return data, err
+}
+
+func TestBlobs(t *testing.T) {
+	ctx := context.Background()
+	b, dir := newBlobs(t)
+
+	ref, sum := upload(t, b, "first ", "second ", "third")
+	if want := sha256.Sum256([]byte("first second third")); !bytes.Equal(sum, want[:]) {
+		t.Errorf("Out -> \nWant: %x\nGot : %x", want, sum)
+	}
+	data, err := read(b, ref, sum)
+	if err != nil || string(data) != "first second third" {
+		t.Errorf("Out -> \nWant: first second third\nGot : %q, %v", data, err)
+	}
+
+	// objects keep only ciphertext
+	id, _, _ := parseRef(ref)
+	stored, err := os.ReadFile(filepath.Join(dir, id, "1"))
+	if err != nil {
+		t.Fatalf("failed to read chunk object: %v", err)
+	}
+	if bytes.Contains(stored, []byte("second")) {
+		t.Errorf("Out -> \nWant: encrypted chunk\nGot : %q", stored)
+	}
+
+	if err = b.Delete(ctx, ref); err != nil {
+		t.Fatalf("failed to delete blob: %v", err)
+	}
+	if _, err = os.Stat(filepath.Join(dir, id)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Err -> \nWant: %v\nGot : %v", os.ErrNotExist, err)
+	}
+}
+
+func TestBlobsCorrupted(t *testing.T) {
+	b, dir := newBlobs(t)
+
+	tests := []struct {
+		corrupt func(id string, ref string, sum []byte) (string, []byte)
+		name    string
+	}{
+		{
+			name: "Changed_Chunk",
+			corrupt: func(id string, ref string, sum []byte) (string, []byte) {
+				path := filepath.Join(dir, id, "0")
+				data, _ := os.ReadFile(path)
+				data[len(data)-1] ^= 1
+				_ = os.WriteFile(path, data, 0o600)
+				return ref, sum
+			},
+		},
+		{
+			name: "Swapped_Chunks",
+			corrupt: func(id string, ref string, sum []byte) (string, []byte) {
+				first, second := filepath.Join(dir, id, "0"), filepath.Join(dir, id, "1")
+				_ = os.Rename(first, first+".tmp")
+				_ = os.Rename(second, first)
+				_ = os.Rename(first+".tmp", second)
+				return ref, sum
+			},
+		},
+		{
+			name: "Missing_Chunk",
+			corrupt: func(id string, ref string, sum []byte) (string, []byte) {
+				_ = os.Remove(filepath.Join(dir, id, "1"))
+				return ref, sum
+			},
+		},
+		{
+			name: "Truncated_Ref",
+			corrupt: func(id string, ref string, sum []byte) (string, []byte) {
+				return id + "/1", sum
+			},
+		},
+		{
+			name: "Changed_Hash",
+			corrupt: func(id string, ref string, sum []byte) (string, []byte) {
+				return ref, make([]byte, len(sum))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, sum := upload(t, b, "first", "second")
+			id, _, _ := parseRef(ref)
+			ref, sum = tt.corrupt(id, ref, sum)
+			if _, err := read(b, ref, sum); !errors.Is(err, ErrCorrupted) {
+				t.Errorf("Err -> \nWant: %v\nGot : %v", ErrCorrupted, err)
+			}
+		})
+	}
+}
+
+func TestParseRef(t *testing.T) {
+	tests := []struct {
+		ref    string
+		id     string
+		chunks int
+		ok     bool
+	}{
+		{ref: "0a1b/3", id: "0a1b", chunks: 3, ok: true},
+		{ref: "0a1b"},
+		{ref: "/3"},
+		{ref: "../3"},
+		{ref: "0a1b/-1"},
+		{ref: "0a1b/x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			id, chunks, err := parseRef(tt.ref)
+			if (err == nil) != tt.ok || id != tt.id || chunks != tt.chunks {
+				t.Errorf("Out -> \nWant: %s %d ok %v\nGot : %s %d %v", tt.id, tt.chunks, tt.ok, id, chunks, err)
+			}
+		})
+	}
+}
+
+func TestLoadKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blob.key")
+	key := make([]byte, KeySize)
+	_, _ = rand.Read(key)
+	if err := os.WriteFile(path, []byte(hex.EncodeToString(key)+"\n"), 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+	got, err := LoadKey(path)
+	if err != nil || !bytes.Equal(got, key) {
+		t.Errorf("Out -> \nWant: %x\nGot : %x, %v", key, got, err)
+	}
+
+	if err = os.WriteFile(path, []byte("0a1b"), 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+	if _, err = LoadKey(path); err == nil {
+		t.Error("Err -> \nWant: short key error\nGot : nil")
+	}
+}
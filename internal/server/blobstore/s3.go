@@ -0,0 +1,152 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxErrorBody limits the size of S3 error responses read.
+const maxErrorBody = 64 * 1024
+
+// S3Config describes a bucket of S3-compatible object storage such as MinIO.
+type S3Config struct {
+	// Endpoint is URL of the storage, e.g. http://localhost:9000.
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// S3Store keeps objects in the bucket of S3-compatible storage. Objects are addressed path-style
+// (endpoint/bucket/key) as MinIO and other self-hosted storages expect.
+type S3Store struct {
+	client   *http.Client
+	endpoint *url.URL
+	creds    credentials
+	bucket   string
+}
+
+func NewS3Store(cfg S3Config) (*S3Store, error) {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 endpoint: %w", err)
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q: http or https URL is expected", cfg.Endpoint)
+	}
+	if cfg.Bucket == "" {
+		return nil, errors.New("S3 bucket is missing")
+	}
+	return &S3Store{
+		client:   &http.Client{},
+		endpoint: endpoint,
+		bucket:   cfg.Bucket,
+		creds: credentials{
+			accessKey: cfg.AccessKey,
+			secretKey: cfg.SecretKey,
+			region:    cfg.Region,
+			service:   "s3",
+		},
+	}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, data []byte) error {
+	resp, err := s.do(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp, http.MethodPut, key)
+	}
+	return nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, responseError(resp, http.MethodGet, key)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object %s: %w", key, err)
+	}
+	return data, nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	// S3 replies No Content whether the object existed or not
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK &&
+		resp.StatusCode != http.StatusNotFound {
+		return responseError(resp, http.MethodDelete, key)
+	}
+	return nil
+}
+
+// do sends the signed request for the object.
+func (s *S3Store) do(ctx context.Context, method string, key string, body []byte) (*http.Response, error) {
+	u := *s.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.bucket + "/" + key
+	u.RawPath = canonicalURI(&u)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s request of object %s: %w", method, key, err)
+	}
+	if body == nil {
+		// GET and DELETE requests have no body
+		req.Body = http.NoBody
+		req.ContentLength = 0
+	}
+	s.creds.sign(req, hexSHA256(body), time.Now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send %s request of object %s: %w", method, key, err)
+	}
+	return resp, nil
+}
+
+// responseError returns error with the code reported by S3.
+func responseError(resp *http.Response, method string, key string) error {
+	var e struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	if err := xml.Unmarshal(body, &e); err != nil || e.Code == "" {
+		return fmt.Errorf("%s of object %s failed: %s", method, key, resp.Status)
+	}
+	return fmt.Errorf("%s of object %s failed: %s: %s: %s", method, key, resp.Status, e.Code, e.Message)
+}
+
+func closeBody(resp *http.Response) {
+	// drain the body, so the connection is reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBody))
+	_ = resp.Body.Close()
+}
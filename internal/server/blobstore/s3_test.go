@@ -0,0 +1,157 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 is a local stand-in of S3-compatible storage, it checks signatures of requests
+// the way S3 does and keeps objects in memory.
+type fakeS3 struct {
+	objects map[string][]byte
+	creds   credentials
+	mu      sync.Mutex
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !f.verify(r, body) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = io.WriteString(w, "<Error><Code>SignatureDoesNotMatch</Code><Message>bad signature</Message></Error>")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[r.URL.Path] = body
+	case http.MethodGet:
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, "<Error><Code>NoSuchKey</Code></Error>")
+			return
+		}
+		_, _ = w.Write(data)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// verify signs a copy of the request having only the signed headers and compares signatures.
+func (f *fakeS3) verify(r *http.Request, body []byte) bool {
+	auth := r.Header.Get("Authorization")
+	_, signed, ok := strings.Cut(auth, "SignedHeaders=")
+	if !ok {
+		return false
+	}
+	signed, _, _ = strings.Cut(signed, ",")
+
+	date, err := time.Parse(sigV4DateFormat, r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return false
+	}
+	payloadHash := hexSHA256(body)
+	if r.Header.Get("X-Amz-Content-Sha256") != payloadHash {
+		return false
+	}
+
+	req, err := http.NewRequest(r.Method, "http://"+r.Host+r.URL.RequestURI(), http.NoBody)
+	if err != nil {
+		return false
+	}
+	for _, name := range strings.Split(signed, ";") {
+		if name != "host" {
+			req.Header.Set(name, r.Header.Get(name))
+		}
+	}
+	f.creds.sign(req, payloadHash, date)
+	return req.Header.Get("Authorization") == auth
+}
+
+func TestS3Store(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeS3{
+		objects: map[string][]byte{},
+		creds:   credentials{accessKey: "minio", secretKey: "minio-secret", region: "us-east-1", service: "s3"},
+	}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	s, err := NewS3Store(S3Config{
+		Endpoint:  srv.URL,
+		Region:    "us-east-1",
+		Bucket:    "gophkeeper",
+		AccessKey: "minio",
+		SecretKey: "minio-secret",
+	})
+	if err != nil {
+		t.Fatalf("failed to create S3 store: %v", err)
+	}
+
+	if err = s.Put(ctx, "blob/0", []byte("chunk")); err != nil {
+		t.Fatalf("failed to put object: %v", err)
+	}
+	fake.mu.Lock()
+	_, ok := fake.objects["/gophkeeper/blob/0"]
+	fake.mu.Unlock()
+	if !ok {
+		t.Error("Out -> \nWant: object in bucket gophkeeper\nGot : none")
+	}
+	got, err := s.Get(ctx, "blob/0")
+	if err != nil || !bytes.Equal(got, []byte("chunk")) {
+		t.Errorf("Out -> \nWant: chunk\nGot : %q, %v", got, err)
+	}
+	if err = s.Delete(ctx, "blob/0"); err != nil {
+		t.Fatalf("failed to delete object: %v", err)
+	}
+	if _, err = s.Get(ctx, "blob/0"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Err -> \nWant: %v\nGot : %v", ErrNotFound, err)
+	}
+	// deleting missing object is not an error as in S3
+	if err = s.Delete(ctx, "blob/0"); err != nil {
+		t.Errorf("Err -> \nWant: nil\nGot : %v", err)
+	}
+
+	s.creds.secretKey = "wrong"
+	err = s.Put(ctx, "blob/1", []byte("chunk"))
+	if err == nil || !strings.Contains(err.Error(), "SignatureDoesNotMatch") {
+		t.Errorf("Err -> \nWant: SignatureDoesNotMatch\nGot : %v", err)
+	}
+}
+
+func TestNewS3Store(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  S3Config
+		ok   bool
+	}{
+		{name: "MinIO", cfg: S3Config{Endpoint: "http://localhost:9000", Bucket: "gophkeeper"}, ok: true},
+		{name: "No_Scheme", cfg: S3Config{Endpoint: "localhost:9000", Bucket: "gophkeeper"}},
+		{name: "No_Bucket", cfg: S3Config{Endpoint: "https://s3.amazonaws.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewS3Store(tt.cfg); (err == nil) != tt.ok {
+				t.Errorf("Err -> \nWant: ok %v\nGot : %v", tt.ok, err)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	"go.uber.org/zap"
@@ -13,10 +14,16 @@ import (
 )
 
 const (
-	defaultContextTimeout time.Duration = 3 * time.Second
-	defaultJWTTokenTTL    time.Duration = 3600 * time.Second
-	defaultJWTKey         string        = "vcwYCYkum_2Fsukk"
-	defaultAddress        string        = "localhost:8080"
+	defaultContextTimeout    time.Duration = 3 * time.Second
+	defaultJWTTokenTTL       time.Duration = 3600 * time.Second
+	defaultRefreshTTL        time.Duration = 30 * 24 * time.Hour
+	defaultRevocationRefresh time.Duration = time.Minute
+	defaultAuditCheckpoint   time.Duration = time.Hour
+	defaultJWTKey            string        = "vcwYCYkum_2Fsukk"
+	defaultAddress           string        = "localhost:3200"
+	defaultSQLitePath        string        = "gophkeeper.db"
+	defaultBlobThreshold     int64         = 4 * 1024 * 1024
+	defaultS3Region          string        = "us-east-1"
 )
 
 func NewConfig() (*models.Config, error) {
@@ -26,8 +33,25 @@ func NewConfig() (*models.Config, error) {
 		return &models.Config{}, fmt.Errorf("failed to initialize Logger: %w", err)
 	}
 
-	a := flag.String("a", defaultAddress, "Gophermart server host address and port.")
+	a := flag.String("a", defaultAddress, "GophKeeper server listen address: host:port or unix:///path/to/socket.")
+	b := flag.String("backend", models.BackendPostgres, "Storage backend: postgres, sqlite or memory.")
 	d := flag.String("d", "", "PostgreSQL DSN")
+	sqlitePath := flag.String("sqlite-path", defaultSQLitePath, "Path to SQLite database file of sqlite backend.")
+	tlsCert := flag.String("tls-cert", "", "Path to TLS certificate file.")
+	tlsKey := flag.String("tls-key", "", "Path to TLS private key file.")
+	tlsClientCA := flag.String("tls-client-ca", "", "Path to CA bundle for client certificates verification.")
+	auditCheckpoint := flag.String("audit-checkpoint", "", "Path to file receiving signed audit log checkpoints.")
+	auditKey := flag.String("audit-key", "", "Path to ed25519 private key (PKCS #8 PEM) signing audit checkpoints.")
+	auditInterval := flag.Duration("audit-checkpoint-interval", defaultAuditCheckpoint,
+		"Interval of audit log checkpoints.")
+	blobBackend := flag.String("blob", "", "Blob store of large file and binary secrets: file or s3.")
+	blobThreshold := flag.Int64("blob-threshold", defaultBlobThreshold,
+		"Size in bytes above which file and binary secrets are kept in the blob store.")
+	blobKey := flag.String("blob-key", "", "Path to hex encoded AES-256 key encrypting blobs.")
+	blobDir := flag.String("blob-dir", "", "Directory of file blob store.")
+	s3Endpoint := flag.String("s3-endpoint", "", "URL of S3-compatible storage of s3 blob store.")
+	s3Region := flag.String("s3-region", defaultS3Region, "Region of S3-compatible storage.")
+	s3Bucket := flag.String("s3-bucket", "", "Bucket of s3 blob store.")
 
 	flag.Parse()
 
@@ -37,25 +61,193 @@ func NewConfig() (*models.Config, error) {
 		}
 	}
 
-	if *d == "" {
-		if envDSN, ok := os.LookupEnv("DATABASE_URI"); ok {
-			d = &envDSN
-		} else {
-			return &models.Config{}, errors.New("postgreSQL DSN is missing")
+	if *b == models.BackendPostgres {
+		if envBackend, ok := os.LookupEnv("STORAGE_BACKEND"); ok {
+			b = &envBackend
 		}
 	}
 
+	switch *b {
+	case models.BackendPostgres:
+		if *d == "" {
+			if envDSN, ok := os.LookupEnv("DATABASE_URI"); ok {
+				d = &envDSN
+			} else {
+				return &models.Config{}, errors.New("postgreSQL DSN is missing")
+			}
+		}
+	case models.BackendSQLite:
+		if *sqlitePath == defaultSQLitePath {
+			if envPath, ok := os.LookupEnv("SQLITE_PATH"); ok {
+				sqlitePath = &envPath
+			}
+		}
+	case models.BackendMemory:
+	default:
+		return &models.Config{}, fmt.Errorf("unknown storage backend %q", *b)
+	}
+
+	if *tlsCert == "" {
+		if envCert, ok := os.LookupEnv("TLS_CERT"); ok {
+			tlsCert = &envCert
+		}
+	}
+
+	if *tlsKey == "" {
+		if envKey, ok := os.LookupEnv("TLS_KEY"); ok {
+			tlsKey = &envKey
+		}
+	}
+
+	if *tlsClientCA == "" {
+		if envCA, ok := os.LookupEnv("TLS_CLIENT_CA"); ok {
+			tlsClientCA = &envCA
+		}
+	}
+
+	if *auditCheckpoint == "" {
+		if envCheckpoint, ok := os.LookupEnv("AUDIT_CHECKPOINT_FILE"); ok {
+			auditCheckpoint = &envCheckpoint
+		}
+	}
+
+	if *auditKey == "" {
+		if envAuditKey, ok := os.LookupEnv("AUDIT_KEY"); ok {
+			auditKey = &envAuditKey
+		}
+	}
+
+	if *auditInterval == defaultAuditCheckpoint {
+		if envInterval, ok := os.LookupEnv("AUDIT_CHECKPOINT_INTERVAL"); ok {
+			interval, err := time.ParseDuration(envInterval)
+			if err != nil {
+				return &models.Config{}, fmt.Errorf("invalid AUDIT_CHECKPOINT_INTERVAL: %w", err)
+			}
+			auditInterval = &interval
+		}
+	}
+
+	if *blobBackend == "" {
+		if envBlob, ok := os.LookupEnv("BLOB_BACKEND"); ok {
+			blobBackend = &envBlob
+		}
+	}
+
+	if *blobThreshold == defaultBlobThreshold {
+		if envThreshold, ok := os.LookupEnv("BLOB_THRESHOLD"); ok {
+			threshold, err := strconv.ParseInt(envThreshold, 10, 64)
+			if err != nil {
+				return &models.Config{}, fmt.Errorf("invalid BLOB_THRESHOLD: %w", err)
+			}
+			blobThreshold = &threshold
+		}
+	}
+
+	if *blobKey == "" {
+		if envBlobKey, ok := os.LookupEnv("BLOB_KEY"); ok {
+			blobKey = &envBlobKey
+		}
+	}
+
+	if *blobDir == "" {
+		if envBlobDir, ok := os.LookupEnv("BLOB_DIR"); ok {
+			blobDir = &envBlobDir
+		}
+	}
+
+	if *s3Endpoint == "" {
+		if envEndpoint, ok := os.LookupEnv("S3_ENDPOINT"); ok {
+			s3Endpoint = &envEndpoint
+		}
+	}
+
+	if *s3Region == defaultS3Region {
+		if envRegion, ok := os.LookupEnv("S3_REGION"); ok {
+			s3Region = &envRegion
+		}
+	}
+
+	if *s3Bucket == "" {
+		if envBucket, ok := os.LookupEnv("S3_BUCKET"); ok {
+			s3Bucket = &envBucket
+		}
+	}
+
+	// S3 credentials are not accepted as flags, so they do not show in the process list
+	s3AccessKey := os.Getenv("S3_ACCESS_KEY")
+	s3SecretKey := os.Getenv("S3_SECRET_KEY")
+
+	switch *blobBackend {
+	case "":
+	case models.BlobFile:
+		if *blobDir == "" {
+			return &models.Config{}, errors.New("file blob store requires the directory")
+		}
+	case models.BlobS3:
+		if *s3Endpoint == "" || *s3Bucket == "" {
+			return &models.Config{}, errors.New("s3 blob store requires the endpoint and the bucket")
+		}
+		if s3AccessKey == "" || s3SecretKey == "" {
+			return &models.Config{}, errors.New("s3 blob store requires S3_ACCESS_KEY and S3_SECRET_KEY")
+		}
+	default:
+		return &models.Config{}, fmt.Errorf("unknown blob store %q", *blobBackend)
+	}
+
+	if *blobBackend != "" && *blobKey == "" {
+		return &models.Config{}, errors.New("blob store requires the encryption key")
+	}
+
+	if *blobThreshold < 0 {
+		return &models.Config{}, errors.New("blob threshold must not be negative")
+	}
+
+	if (*tlsCert == "") != (*tlsKey == "") {
+		return &models.Config{}, errors.New("both TLS certificate and key must be specified")
+	}
+
+	if *tlsClientCA != "" && *tlsCert == "" {
+		return &models.Config{}, errors.New("client certificates verification requires TLS certificate and key")
+	}
+
+	if *auditCheckpoint != "" && *auditKey == "" {
+		return &models.Config{}, errors.New("audit checkpoints require the signing key")
+	}
+
+	if *auditInterval <= 0 {
+		return &models.Config{}, errors.New("audit checkpoint interval must be positive")
+	}
+
 	var JWTKey string
 	if envJWT, ok := os.LookupEnv("JWT"); ok {
 		JWTKey = envJWT
 	}
 
 	return &models.Config{
-		Address:        *a,
-		Logger:         logger,
-		PostgresDSN:    *d,
-		ContextTimeout: defaultContextTimeout,
-		JWTKey:         JWTKey,
-		JWTTokenTTL:    defaultJWTTokenTTL,
+		Address:                 *a,
+		Logger:                  logger,
+		Backend:                 *b,
+		PostgresDSN:             *d,
+		SQLitePath:              *sqlitePath,
+		ContextTimeout:          defaultContextTimeout,
+		JWTKey:                  JWTKey,
+		JWTTokenTTL:             defaultJWTTokenTTL,
+		RefreshTokenTTL:         defaultRefreshTTL,
+		RevocationRefresh:       defaultRevocationRefresh,
+		TLSCertFile:             *tlsCert,
+		TLSKeyFile:              *tlsKey,
+		TLSClientCAFile:         *tlsClientCA,
+		AuditCheckpointFile:     *auditCheckpoint,
+		AuditKeyFile:            *auditKey,
+		AuditCheckpointInterval: *auditInterval,
+		BlobBackend:             *blobBackend,
+		BlobThreshold:           *blobThreshold,
+		BlobKeyFile:             *blobKey,
+		BlobDir:                 *blobDir,
+		S3Endpoint:              *s3Endpoint,
+		S3Region:                *s3Region,
+		S3Bucket:                *s3Bucket,
+		S3AccessKey:             s3AccessKey,
+		S3SecretKey:             s3SecretKey,
 	}, nil
 }
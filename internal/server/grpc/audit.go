@@ -0,0 +1,106 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/vkupriya/gophkeeper/internal/proto"
+	"github.com/vkupriya/gophkeeper/internal/server/auditlog"
+	"github.com/vkupriya/gophkeeper/internal/server/helpers"
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+)
+
+// ListAuditEvents returns audit events of the caller, newest first.
+func (g *GophKeeperServer) ListAuditEvents(ctx context.Context,
+	in *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	logger := g.config.Logger
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Sugar().Error(msgMetadataNotFound)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
+	}
+	userid := md["userid"][0]
+
+	filter := models.AuditFilter{Limit: helpers.PageSize(in.GetLimit())}
+	if in.GetFrom() != nil {
+		filter.From = in.GetFrom().AsTime()
+	}
+	if in.GetTo() != nil {
+		filter.To = in.GetTo().AsTime()
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		logger.Sugar().Errorf("invalid audit time range from user %s: %v - %v", userid, filter.From, filter.To)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, msgAuditBadRequest))
+	}
+
+	events, err := g.Store.AuditList(ctx, userid, filter)
+	if err != nil {
+		logger.Sugar().Errorf("failed to get audit events of user %s: %v", userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgAuditFailedToGet))
+	}
+
+	response := &pb.ListAuditEventsResponse{}
+	for _, e := range *events {
+		response.Events = append(response.Events, &pb.AuditEvent{
+			Id:        e.ID,
+			Method:    e.Method,
+			Name:      e.Name,
+			Owner:     e.Owner,
+			ClientIp:  e.ClientIP,
+			Code:      e.Code,
+			CreatedAt: timestamppb.New(e.CreatedAt),
+		})
+	}
+	return response, nil
+}
+
+// VerifyAuditLog walks the hash chain of the whole audit log and reports the first broken link.
+// The chain is compared with signed checkpoints if they are configured. Only IDs of events are
+// returned, so any authenticated user may verify the log.
+func (g *GophKeeperServer) VerifyAuditLog(ctx context.Context, in *pb.Empty) (*pb.VerifyAuditLogResponse, error) {
+	logger := g.config.Logger
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Sugar().Error(msgMetadataNotFound)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
+	}
+	userid := md["userid"][0]
+
+	var checkpoints []auditlog.Checkpoint
+	if g.config.AuditCheckpointFile != "" {
+		var err error
+		checkpoints, err = auditlog.ReadCheckpoints(g.config.AuditCheckpointFile, g.auditKey)
+		if errors.Is(err, auditlog.ErrInvalidSignature) {
+			logger.Sugar().Errorf("audit checkpoints are forged: %v", err)
+			return &pb.VerifyAuditLogResponse{Reason: err.Error()}, nil
+		}
+		if err != nil {
+			logger.Sugar().Errorf("failed to read audit checkpoints for user %s: %v", userid, err)
+			return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgAuditFailedToVerify))
+		}
+	}
+
+	result, err := auditlog.Verify(ctx, g.Store, checkpoints)
+	if err != nil {
+		logger.Sugar().Errorf("failed to verify audit log for user %s: %v", userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgAuditFailedToVerify))
+	}
+	if result.BrokenID != 0 {
+		logger.Sugar().Errorf("audit log is broken at event %d: %s", result.BrokenID, result.Reason)
+	}
+
+	return &pb.VerifyAuditLogResponse{
+		Intact:      result.BrokenID == 0,
+		Verified:    result.Verified,
+		Unchained:   result.Unchained,
+		BrokenId:    result.BrokenID,
+		Reason:      result.Reason,
+		Checkpoints: int32(result.Checkpoints),
+	}, nil
+}
@@ -0,0 +1,141 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/vkupriya/gophkeeper/internal/server/blobstore"
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+	"github.com/vkupriya/gophkeeper/internal/server/storage"
+)
+
+// blobTypes are types of secrets which data may be kept in the blob store.
+var blobTypes = map[string]bool{
+	"file":   true,
+	"binary": true,
+}
+
+// receiveBlob reads chunks of the uploaded secret with next. Chunks of file and binary secrets are
+// kept in memory until their size exceeds BlobThreshold, then they and the rest of the upload are
+// written to the blob store and the secret gets the blob reference and hash. An update is checked
+// against the current version of the secret before the blob is written, so a stale upload fails
+// without writing it. The returned function yields chunks to be stored in DB, the upload is returned
+// if data went to the blob store.
+func (g *GophKeeperServer) receiveBlob(ctx context.Context, userid string, secret *models.Secret, update bool,
+	next func() ([]byte, error)) (func() ([]byte, error), *blobstore.Upload, error) {
+	if g.Blobs == nil || !blobTypes[secret.Type] {
+		return next, nil, nil
+	}
+
+	var chunks [][]byte
+	var size int64
+	for size <= g.config.BlobThreshold {
+		chunk, err := next()
+		if errors.Is(err, io.EOF) {
+			return replay(chunks), nil, nil
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to receive chunk %d: %w", len(chunks), err)
+		}
+		chunks = append(chunks, chunk)
+		size += int64(len(chunk))
+	}
+
+	if update {
+		if err := g.checkVersion(ctx, userid, secret); err != nil {
+			return nil, nil, err
+		}
+	}
+	upload, err := g.Blobs.Create()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = writeBlob(ctx, upload, chunks, next); err != nil {
+		g.abortUpload(ctx, upload)
+		return nil, nil, err
+	}
+	secret.BlobRef = upload.Ref()
+	secret.BlobHash = upload.Hash()
+	return replay(nil), upload, nil
+}
+
+// checkVersion fails if the current version of the secret is not the expected one. The version is
+// checked again when the secret is saved.
+func (g *GophKeeperServer) checkVersion(ctx context.Context, userid string, secret *models.Secret) error {
+	current, err := g.Store.SecretGet(ctx, userid, secret.Name)
+	if err != nil {
+		return err
+	}
+	if current.Version != secret.Version {
+		return fmt.Errorf("%w: expected version %d, current version %d", storage.ErrVersionConflict,
+			secret.Version, current.Version)
+	}
+	return nil
+}
+
+// writeBlob writes the received chunks and the rest of the upload.
+func writeBlob(ctx context.Context, upload *blobstore.Upload, chunks [][]byte, next func() ([]byte, error)) error {
+	for _, chunk := range chunks {
+		if err := upload.Write(ctx, chunk); err != nil {
+			return err
+		}
+	}
+	for seq := len(chunks); ; seq++ {
+		chunk, err := next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to receive chunk %d: %w", seq, err)
+		}
+		if err = upload.Write(ctx, chunk); err != nil {
+			return err
+		}
+	}
+}
+
+// replay returns function yielding the chunks followed by io.EOF.
+func replay(chunks [][]byte) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		if len(chunks) == 0 {
+			return nil, io.EOF
+		}
+		chunk := chunks[0]
+		chunks = chunks[1:]
+		return chunk, nil
+	}
+}
+
+// readBlob calls f for every chunk of the secret kept in the blob store.
+func (g *GophKeeperServer) readBlob(ctx context.Context, s *models.Secret, f func(chunk []byte) error) error {
+	if g.Blobs == nil {
+		return fmt.Errorf("secret %s is kept in the blob store which is not configured", s.Name)
+	}
+	return g.Blobs.Read(ctx, s.BlobRef, s.BlobHash, f)
+}
+
+// abortUpload deletes the written chunks of the upload which no secret refers to. Chunks are deleted
+// even if the call was cancelled, failures are logged as in deleteBlobs.
+func (g *GophKeeperServer) abortUpload(ctx context.Context, upload *blobstore.Upload) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), g.config.ContextTimeout)
+	defer cancel()
+
+	if err := upload.Abort(ctx); err != nil {
+		g.config.Logger.Sugar().Errorf("failed to delete blob %s: %v", upload.Ref(), err)
+	}
+}
+
+// deleteBlobs removes blobs which are no longer referenced. Blobs are removed even if the call
+// was cancelled, failures are logged as they leave only unreferenced objects behind.
+func (g *GophKeeperServer) deleteBlobs(ctx context.Context, refs ...string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), g.config.ContextTimeout)
+	defer cancel()
+
+	for _, ref := range refs {
+		if err := g.Blobs.Delete(ctx, ref); err != nil {
+			g.config.Logger.Sugar().Errorf("failed to delete blob %s: %v", ref, err)
+		}
+	}
+}
@@ -4,74 +4,170 @@ import (
 	// ...
 
 	"context"
+	"crypto/ed25519"
 	"errors"
 	"fmt"
 	"log"
-	"net"
-	"strings"
 	"sync"
+	"time"
 
 	pb "github.com/vkupriya/gophkeeper/internal/proto"
 	ic "github.com/vkupriya/gophkeeper/internal/server/grpc/interceptors"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/vkupriya/gophkeeper/internal/labels"
+	"github.com/vkupriya/gophkeeper/internal/search"
+	"github.com/vkupriya/gophkeeper/internal/secretpath"
+	"github.com/vkupriya/gophkeeper/internal/server/auditlog"
+	"github.com/vkupriya/gophkeeper/internal/server/blobstore"
 	"github.com/vkupriya/gophkeeper/internal/server/helpers"
 	"github.com/vkupriya/gophkeeper/internal/server/models"
+	"github.com/vkupriya/gophkeeper/internal/server/revocation"
 	"github.com/vkupriya/gophkeeper/internal/server/storage"
+	"github.com/vkupriya/gophkeeper/internal/vault"
 )
 
 type Storage interface {
-	UserAdd(c *models.Config, u models.User) error
-	UserGet(c *models.Config, userid string) (models.User, error)
-	SecretGet(c *models.Config, userid string, name string) (*models.Secret, error)
-	SecretList(c *models.Config, userid string) (*models.SecretList, error)
-	SecretAdd(c *models.Config, userid string, secret *models.Secret) error
-	SecretUpdate(c *models.Config, userid string, secret *models.Secret) error
-	SecretDelete(c *models.Config, userid string, name string) error
+	UserAdd(ctx context.Context, u models.User) error
+	UserGet(ctx context.Context, userid string) (models.User, error)
+	RefreshTokenAdd(ctx context.Context, userid string, tokenHash string, expiresAt time.Time) error
+	RefreshTokenUse(ctx context.Context, tokenHash string) (string, error)
+	RefreshTokenDelete(ctx context.Context, tokenHash string) error
+	TokenAdd(ctx context.Context, userid string, jti string, expiresAt time.Time) error
+	TokenRevoke(ctx context.Context, userid string, jti string) error
+	TokenRevokeAll(ctx context.Context, userid string) (models.RevokedTokens, error)
+	TokenRevokedList(ctx context.Context) (models.RevokedTokens, error)
+	TokensDeleteExpired(ctx context.Context) error
+	SecretGet(ctx context.Context, userid string, name string) (*models.Secret, error)
+	SecretGetVersion(ctx context.Context, userid string, name string, version int64) (*models.Secret, error)
+	SecretVersionList(ctx context.Context, userid string, name string) (*models.SecretVersionList, error)
+	SecretList(ctx context.Context, userid string, filter models.SecretFilter) (*models.SecretList, error)
+	SecretFolders(ctx context.Context, userid string, filter models.SecretFilter) ([]string, error)
+	SecretMove(ctx context.Context, userid string, from string, to string) (int64, error)
+	SecretSearch(ctx context.Context, userid string, terms []string, limit int) (*models.SecretSearchList, error)
+	SecretAdd(ctx context.Context, userid string, secret *models.Secret) error
+	SecretUpdate(ctx context.Context, userid string, secret *models.Secret) error
+	SecretDelete(ctx context.Context, userid string, name string) ([]string, error)
+	SecretReplaceVersions(ctx context.Context, userid string, name string, versions []*models.Secret,
+		remove []int64) ([]string, error)
+	SecretSaveStream(ctx context.Context, userid string, secret *models.Secret, update bool,
+		next func() ([]byte, error)) error
+	SecretRollback(ctx context.Context, userid string, name string, version int64,
+		expected int64) (*models.Secret, error)
+	SecretChunks(ctx context.Context, userid string, name string, version int64, f func(chunk []byte) error) error
+	UserSetPublicKey(ctx context.Context, userid string, publicKey []byte) error
+	UserGetPublicKey(ctx context.Context, userid string) ([]byte, error)
+	ShareAdd(ctx context.Context, share *models.Share) error
+	ShareRevoke(ctx context.Context, owner string, name string, recipient string) error
+	ShareList(ctx context.Context, userid string, outgoing bool) (*models.ShareList, error)
+	ShareGet(ctx context.Context, recipient string, owner string, name string) (*models.Share, error)
+	ShareUpdate(ctx context.Context, share *models.Share, ownerData []byte) error
+	VaultCreate(ctx context.Context, userid string, org string, name string, sealedKey []byte) error
+	VaultRole(ctx context.Context, userid string, org string, name string) (vault.Role, string, error)
+	OrgRole(ctx context.Context, userid string, org string) (vault.Role, error)
+	VaultKey(ctx context.Context, userid string, org string, name string) ([]byte, error)
+	VaultKeySet(ctx context.Context, userid string, org string, name string, sealedKey []byte) error
+	VaultList(ctx context.Context, userid string) (*models.VaultList, error)
+	VaultMemberSet(ctx context.Context, org string, name string, userid string, role vault.Role,
+		sealedKey []byte) error
+	VaultMemberDelete(ctx context.Context, org string, name string, userid string) error
+	VaultMemberList(ctx context.Context, org string, name string) (*models.VaultMemberList, error)
+	AuditAdd(ctx context.Context, event *models.AuditEvent) error
+	AuditList(ctx context.Context, userid string, filter models.AuditFilter) (*models.AuditEventList, error)
+	AuditChain(ctx context.Context, afterID int64, limit int) ([]models.AuditRecord, error)
+	AuditChainStart(ctx context.Context) (int64, error)
+	AuditHead(ctx context.Context) (*models.AuditRecord, error)
 }
 
 const (
-	errFormat                     = "error: %w"
-	msgUserCredentialsBadRequest  = "invalid credentials"
-	msgUserFailedToCreate         = "failed to create user"
-	msgUserAlreadyExists          = "user already exists"
-	msgUserFailedToCreateToken    = "failed to create user token"
-	msgUserTokenError             = "user token error"
-	msgUserInvalidLoginOrPassword = "user invalid login or password"
-	msgUserNotFound               = "user not found"
-	msgUserFailedToLogin          = "failed to login"
-	msgSecretsNotFound            = "secrets not found"
-	msgSecretsFailedToGet         = "failed to get secrets"
-	msgSecretBadRequest           = "invalid secret"
-	msgSecretFailedToCreate       = "failed to create secret"
-	msgSecretAlreadyExists        = "secret already exists"
-	msgSecretNotFound             = "secret not found"
-	msgSecretFailedToDelete       = "failed to delete secret"
-	msgSecretFailedToUpdate       = "failed to update secret"
-	msgMetadataNotFound           = "grpc metadata not found"
+	errFormat                        = "error: %w"
+	msgUserCredentialsBadRequest     = "invalid credentials"
+	msgUserFailedToCreate            = "failed to create user"
+	msgUserAlreadyExists             = "user already exists"
+	msgUserFailedToCreateToken       = "failed to create user token"
+	msgUserTokenError                = "user token error"
+	msgUserInvalidLoginOrPassword    = "user invalid login or password"
+	msgUserNotFound                  = "user not found"
+	msgUserFailedToLogin             = "failed to login"
+	msgUserFailedToLogout            = "failed to logout"
+	msgUserFailedToRevoke            = "failed to revoke sessions"
+	msgRefreshTokenInvalid           = "refresh token is invalid or expired"
+	msgSecretsNotFound               = "secrets not found"
+	msgSecretsFailedToGet            = "failed to get secrets"
+	msgSecretBadRequest              = "invalid secret"
+	msgSecretFailedToCreate          = "failed to create secret"
+	msgSecretAlreadyExists           = "secret already exists"
+	msgSecretNotFound                = "secret not found"
+	msgSecretFailedToDelete          = "failed to delete secret"
+	msgSecretFailedToUpdate          = "failed to update secret"
+	msgSecretVersionsFailedToReplace = "failed to replace secret versions"
+	msgSecretVersionRequired         = "expected current version of the secret is required"
+	msgSecretVersionsFailedToGet     = "failed to get secret versions"
+	msgSecretFailedToRollback        = "failed to roll back secret"
+	msgSecretHeaderMissing           = "secret header is missing"
+	msgSecretFailedToUpload          = "failed to upload secret"
+	msgSecretCorrupted               = "secret data is corrupted"
+	msgSecretFailedToMove            = "failed to move secret"
+	msgSecretsFailedToSearch         = "failed to search secrets"
+	msgPublicKeyBadRequest           = "invalid public key"
+	msgPublicKeyNotFound             = "public key not found"
+	msgPublicKeyFailedToSet          = "failed to set public key"
+	msgPublicKeyFailedToGet          = "failed to get public key"
+	msgShareBadRequest               = "invalid share"
+	msgShareNotFound                 = "share not found"
+	msgShareReadOnly                 = "secret is shared read-only"
+	msgSecretFailedToShare           = "failed to share secret"
+	msgShareFailedToRevoke           = "failed to revoke share"
+	msgSharesFailedToGet             = "failed to get shared secrets"
+	msgVaultBadRequest               = "invalid vault"
+	msgVaultNotFound                 = "vault not found"
+	msgVaultAlreadyExists            = "vault already exists"
+	msgVaultPermissionDenied         = "insufficient role in vault"
+	msgOrgPermissionDenied           = "organization owner role is required"
+	msgVaultFailedToCreate           = "failed to create vault"
+	msgVaultsFailedToGet             = "failed to get vaults"
+	msgVaultKeyNotFound              = "vault key not found, ask vault owner to add you to the vault"
+	msgVaultKeyFailedToGet           = "failed to get vault key"
+	msgVaultKeyFailedToSet           = "failed to set vault key"
+	msgMemberNotFound                = "vault member not found"
+	msgMemberLastOwner               = "organization must keep at least one owner"
+	msgMemberFailedToSet             = "failed to set vault member"
+	msgMemberFailedToRemove          = "failed to remove vault member"
+	msgMembersFailedToGet            = "failed to get vault members"
+	msgAuditBadRequest               = "invalid audit time range"
+	msgAuditFailedToGet              = "failed to get audit events"
+	msgAuditFailedToVerify           = "failed to verify audit log"
+	msgMetadataNotFound              = "grpc metadata not found"
 )
 
 type GophKeeperServer struct {
 	pb.UnimplementedGophKeeperServer
-	Store  Storage
-	config *models.Config
+	Store   Storage
+	config  *models.Config
+	revoked *revocation.Cache
+	// auditKey verifies signatures of audit checkpoints.
+	auditKey ed25519.PublicKey
+	// Blobs keeps data of large file and binary secrets, data of all secrets is kept in DB if it is nil.
+	Blobs *blobstore.Blobs
 }
 
 func (g *GophKeeperServer) Register(ctx context.Context, in *pb.User) (*pb.UserAuthToken, error) {
 	logger := g.config.Logger
-	var response pb.UserAuthToken
 	user := models.User{
 		UserID:   in.GetLogin(),
 		Password: in.GetPassword(),
 	}
 
-	if user.UserID == "" || user.Password == "" {
+	// logins of vault principals are reserved
+	if user.UserID == "" || user.Password == "" || vault.IsPrincipal(user.UserID) {
 		logger.Sugar().Errorf("invalid credentials for user %s", user.UserID)
 		return nil, fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, msgUserCredentialsBadRequest))
 	}
@@ -82,7 +178,7 @@ func (g *GophKeeperServer) Register(ctx context.Context, in *pb.User) (*pb.UserA
 	}
 	user.Password = password
 
-	err = g.Store.UserAdd(g.config, user)
+	err = g.Store.UserAdd(ctx, user)
 	if err != nil {
 		if errors.Is(err, storage.ErrUserAlreadyExists) {
 			logger.Sugar().Errorf("failed to create user %s: already exists", user.UserID)
@@ -92,21 +188,19 @@ func (g *GophKeeperServer) Register(ctx context.Context, in *pb.User) (*pb.UserA
 		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgUserFailedToCreate))
 	}
 
-	token, err := helpers.CreateJWTString(g.config, user.UserID)
+	response, err := g.issueTokens(ctx, user.UserID)
 	if err != nil {
-		logger.Sugar().Errorf("Error creating JWT token: %v", err)
+		logger.Sugar().Errorf("Error creating tokens for user %s: %v", user.UserID, err)
 		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgUserFailedToCreateToken))
 	}
-	response.Token = token
 
-	return &response, nil
+	return response, nil
 }
 
 func (g *GophKeeperServer) Login(ctx context.Context, in *pb.User) (*pb.UserAuthToken, error) {
 	logger := g.config.Logger
-	var response pb.UserAuthToken
 
-	user, err := g.Store.UserGet(g.config, in.GetLogin())
+	user, err := g.Store.UserGet(ctx, in.GetLogin())
 	if err != nil {
 		if errors.Is(err, storage.ErrUserNotFound) {
 			logger.Sugar().Errorf("login error for user %s: not found", user.UserID)
@@ -122,17 +216,66 @@ func (g *GophKeeperServer) Login(ctx context.Context, in *pb.User) (*pb.UserAuth
 		return nil, fmt.Errorf(errFormat, status.Error(codes.PermissionDenied, msgUserInvalidLoginOrPassword))
 	}
 
-	token, err := helpers.CreateJWTString(g.config, user.UserID)
+	response, err := g.issueTokens(ctx, user.UserID)
 	if err != nil {
-		logger.Sugar().Errorf("Error creating JWT token: %v", err)
+		logger.Sugar().Errorf("Error creating tokens for user %s: %v", user.UserID, err)
 		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgUserFailedToCreateToken))
 	}
-	response.Token = token
 
-	return &response, nil
+	return response, nil
+}
+
+func (g *GophKeeperServer) RefreshToken(ctx context.Context, in *pb.RefreshTokenRequest) (*pb.UserAuthToken, error) {
+	logger := g.config.Logger
+
+	userid, err := g.Store.RefreshTokenUse(ctx, helpers.HashToken(in.GetRefreshToken()))
+	if err != nil {
+		if errors.Is(err, storage.ErrTokenNotFound) {
+			logger.Sugar().Error("refresh token is invalid or expired")
+			return nil, fmt.Errorf(errFormat, status.Error(codes.Unauthenticated, msgRefreshTokenInvalid))
+		}
+		logger.Sugar().Errorf("failed to use refresh token: %v", err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgUserFailedToCreateToken))
+	}
+
+	response, err := g.issueTokens(ctx, userid)
+	if err != nil {
+		logger.Sugar().Errorf("Error creating tokens for user %s: %v", userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgUserFailedToCreateToken))
+	}
+
+	return response, nil
 }
 
-func (g *GophKeeperServer) ListSecrets(ctx context.Context, in *pb.Empty) (*pb.ListSecretsResponse, error) {
+func (g *GophKeeperServer) Logout(ctx context.Context, in *pb.LogoutRequest) (*pb.Empty, error) {
+	logger := g.config.Logger
+
+	err := g.Store.RefreshTokenDelete(ctx, helpers.HashToken(in.GetRefreshToken()))
+	if err != nil {
+		if errors.Is(err, storage.ErrTokenNotFound) {
+			logger.Sugar().Error("logout with invalid refresh token")
+			return nil, fmt.Errorf(errFormat, status.Error(codes.Unauthenticated, msgRefreshTokenInvalid))
+		}
+		logger.Sugar().Errorf("failed to revoke refresh token: %v", err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgUserFailedToLogout))
+	}
+
+	// Logout is not authenticated, access token is revoked only if client sent a valid one.
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md["authorization"]) != 0 {
+		claims, err := helpers.ValidateJWT(g.config, md["authorization"][0])
+		if err == nil {
+			if err := g.Store.TokenRevoke(ctx, claims.UserID, claims.ID); err != nil {
+				logger.Sugar().Errorf("failed to revoke access token: %v", err)
+				return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgUserFailedToLogout))
+			}
+			g.revoked.Add(models.RevokedTokens{claims.ID: claims.ExpiresAt.Time})
+		}
+	}
+
+	return &pb.Empty{}, nil
+}
+
+func (g *GophKeeperServer) RevokeSessions(ctx context.Context, in *pb.Empty) (*pb.Empty, error) {
 	logger := g.config.Logger
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
@@ -141,21 +284,141 @@ func (g *GophKeeperServer) ListSecrets(ctx context.Context, in *pb.Empty) (*pb.L
 	}
 	userid := md["userid"][0]
 
-	secretsDB, err := g.Store.SecretList(g.config, userid)
+	revoked, err := g.Store.TokenRevokeAll(ctx, userid)
+	if err != nil {
+		logger.Sugar().Errorf("failed to revoke sessions of user %s: %v", userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgUserFailedToRevoke))
+	}
+	g.revoked.Add(revoked)
+
+	return &pb.Empty{}, nil
+}
+
+// issueTokens creates access token and refresh token for the user.
+func (g *GophKeeperServer) issueTokens(ctx context.Context, userid string) (*pb.UserAuthToken, error) {
+	token, claims, err := helpers.CreateJWTString(g.config, userid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JWT token: %w", err)
+	}
+	if err := g.Store.TokenAdd(ctx, userid, claims.ID, claims.ExpiresAt.Time); err != nil {
+		return nil, fmt.Errorf("failed to save access token: %w", err)
+	}
+
+	refreshToken, refreshHash, err := helpers.CreateRefreshToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	expiresAt := time.Now().Add(g.config.RefreshTokenTTL)
+	if err := g.Store.RefreshTokenAdd(ctx, userid, refreshHash, expiresAt); err != nil {
+		return nil, fmt.Errorf("failed to save refresh token: %w", err)
+	}
+
+	return &pb.UserAuthToken{
+		Token:        token,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func (g *GophKeeperServer) ListSecrets(ctx context.Context,
+	in *pb.ListSecretsRequest) (*pb.ListSecretsResponse, error) {
+	logger := g.config.Logger
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Sugar().Error(msgMetadataNotFound)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
+	}
+	// owner of secrets is the user or the principal of the vault, set by the interceptor
+	userid := md["owner"][0]
+
+	selector, err := labels.ParseSelector(in.GetLabelSelector())
+	if err != nil {
+		logger.Sugar().Errorf("invalid label selector from user %s: %v", userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, err.Error()))
+	}
+
+	folder, err := secretpath.NormalizeFolder(in.GetPrefix())
+	if err != nil {
+		logger.Sugar().Errorf("invalid folder from user %s: %v", userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, err.Error()))
+	}
+	sort, err := protoToSort(in.GetSort())
+	if err != nil {
+		logger.Sugar().Errorf("invalid sort order from user %s: %v", userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, err.Error()))
+	}
+	pageSize := helpers.PageSize(in.GetPageSize())
+	filter := models.SecretFilter{
+		Selector:  selector,
+		Folder:    folder,
+		Recursive: in.GetRecursive(),
+		Sort:      sort,
+		// one more secret tells whether there is the next page
+		Limit: pageSize + 1,
+	}
+	for _, t := range in.GetTypes() {
+		filter.Types = append(filter.Types, ProtoToType(t))
+	}
+	if in.GetPageToken() != "" {
+		filter.After, err = helpers.DecodePageToken(in.GetPageToken(), sort)
+		if err != nil {
+			logger.Sugar().Errorf("invalid page token from user %s: %v", userid, err)
+			return nil, fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, err.Error()))
+		}
+	}
+
+	// subfolders are listed in the first page instead of their secrets unless listing is recursive
+	var folders []string
+	if !filter.Recursive && filter.After == nil {
+		folders, err = g.Store.SecretFolders(ctx, userid, filter)
+		if err != nil {
+			logger.Sugar().Errorf("failed to get list of secret folders: %v", err)
+			return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgSecretsFailedToGet))
+		}
+	}
+
+	secretsDB, err := g.Store.SecretList(ctx, userid, filter)
 	if err != nil {
-		if errors.Is(err, storage.ErrNoSecrets) {
+		if !errors.Is(err, storage.ErrNoSecrets) {
+			logger.Sugar().Errorf("failed to get list of secrets: %v", err)
+			return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgSecretsFailedToGet))
+		}
+		// the rest of secrets could be deleted while pages are iterated
+		if len(folders) == 0 && filter.After == nil {
 			return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgSecretsNotFound))
 		}
-		logger.Sugar().Errorf("failed to get list of secrets: %v", err)
-		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgSecretsFailedToGet))
+		secretsDB = &models.SecretList{}
 	}
 
-	response := &pb.ListSecretsResponse{Items: make([]*pb.SecretItem, 0, len(*secretsDB))}
-	for _, dbItem := range *secretsDB {
+	page := *secretsDB
+	var nextPageToken string
+	if len(page) > pageSize {
+		page = page[:pageSize]
+		last := page[pageSize-1]
+		nextPageToken, err = helpers.EncodePageToken(models.SecretCursor{
+			UpdatedAt: last.UpdatedAt,
+			Name:      last.Name,
+			Sort:      sort,
+		})
+		if err != nil {
+			logger.Sugar().Errorf("failed to create page token: %v", err)
+			return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgSecretsFailedToGet))
+		}
+	}
+
+	response := &pb.ListSecretsResponse{
+		Items:         make([]*pb.SecretItem, 0, len(page)),
+		Folders:       folders,
+		NextPageToken: nextPageToken,
+	}
+	for _, dbItem := range page {
 		response.Items = append(response.Items, &pb.SecretItem{
-			Name:    dbItem.Name,
-			Type:    TypeToProto(dbItem.Type),
-			Version: dbItem.Version,
+			Name:       dbItem.Name,
+			Type:       TypeToProto(dbItem.Type),
+			Labels:     dbItem.Labels,
+			Version:    dbItem.Version,
+			EncVersion: dbItem.EncVersion,
+			UpdatedAt:  timestamppb.New(dbItem.UpdatedAt),
 		})
 	}
 
@@ -169,22 +432,26 @@ func (g *GophKeeperServer) AddSecret(ctx context.Context, in *pb.AddSecretReques
 		logger.Sugar().Error(msgMetadataNotFound)
 		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
 	}
-	userid := md["userid"][0]
-	key := md["secretkey"][0]
-	data := in.Secret.GetData()
-	dataEncrypted, err := helpers.Encrypt(key, &data)
-	if err != nil {
-		logger.Sugar().Errorf("error encrypting data: %v", err)
-		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgSecretFailedToCreate))
-	}
+	userid := md["owner"][0]
+
+	// Secret data is encrypted by the client, server stores it as is.
 	secret := &models.Secret{
-		Name:    in.Secret.GetName(),
-		Meta:    in.Secret.GetMeta(),
-		Type:    ProtoToType(in.Secret.Type),
-		Data:    dataEncrypted,
-		Version: 1,
+		Name:       in.Secret.GetName(),
+		Labels:     in.Secret.GetLabels(),
+		Type:       ProtoToType(in.Secret.GetType()),
+		Data:       in.Secret.GetData(),
+		Version:    1,
+		EncVersion: in.Secret.GetEncVersion(),
 	}
-	err = g.Store.SecretAdd(g.config, userid, secret)
+	if err := secretpath.ValidateName(secret.Name); err != nil {
+		logger.Sugar().Errorf("invalid secret name from user %s: %v", userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, err.Error()))
+	}
+	if err := labels.Validate(secret.Labels); err != nil {
+		logger.Sugar().Errorf("invalid labels of secret %s for user %s: %v", secret.Name, userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, err.Error()))
+	}
+	err := g.Store.SecretAdd(ctx, userid, secret)
 	if err != nil {
 		if errors.Is(err, storage.ErrSecretAlreadyExists) {
 			logger.Sugar().Errorf("failed creating secret for user %s:  already exists", userid)
@@ -206,22 +473,36 @@ func (g *GophKeeperServer) UpdateSecret(
 		logger.Sugar().Error(msgMetadataNotFound)
 		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
 	}
-	userid := md["userid"][0]
-	key := md["secretkey"][0]
-	data := in.Secret.GetData()
-	dataEncrypted, err := helpers.Encrypt(key, &data)
-	if err != nil {
-		logger.Sugar().Errorf("error encrypting data: %v", err)
-		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgSecretFailedToUpdate))
-	}
+	userid := md["owner"][0]
+
+	// Version of the secret is the expected current version on the server,
+	// update is rejected if the secret has been changed since then.
 	secret := &models.Secret{
-		Name: in.Secret.GetName(),
-		Meta: in.Secret.GetMeta(),
-		Type: ProtoToType(in.Secret.Type),
-		Data: dataEncrypted,
+		Name:       in.Secret.GetName(),
+		Labels:     in.Secret.GetLabels(),
+		Type:       ProtoToType(in.Secret.GetType()),
+		Data:       in.Secret.GetData(),
+		Version:    in.Secret.GetVersion(),
+		EncVersion: in.Secret.GetEncVersion(),
+	}
+	if secret.Version <= 0 {
+		logger.Sugar().Errorf("update of secret %s by user %s without expected version", secret.Name, userid)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, msgSecretVersionRequired))
+	}
+	if err := labels.Validate(secret.Labels); err != nil {
+		logger.Sugar().Errorf("invalid labels of secret %s for user %s: %v", secret.Name, userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, err.Error()))
 	}
-	err = g.Store.SecretUpdate(g.config, userid, secret)
+	err := g.Store.SecretUpdate(ctx, userid, secret)
 	if err != nil {
+		if errors.Is(err, storage.ErrSecretNotFound) {
+			logger.Sugar().Errorf("secret %s not found for user %s", secret.Name, userid)
+			return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgSecretNotFound))
+		}
+		if errors.Is(err, storage.ErrVersionConflict) {
+			logger.Sugar().Errorf("failed updating secret %s for user %s: %v", secret.Name, userid, err)
+			return nil, fmt.Errorf(errFormat, status.Error(codes.Aborted, err.Error()))
+		}
 		logger.Sugar().Errorf("error updating secret: %v", err)
 		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgSecretFailedToUpdate))
 	}
@@ -238,10 +519,15 @@ func (g *GophKeeperServer) GetSecret(ctx context.Context, in *pb.GetSecretReques
 		logger.Sugar().Error(msgMetadataNotFound)
 		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
 	}
-	userid := md["userid"][0]
-	key := md["secretkey"][0]
+	userid := md["owner"][0]
 
-	s, err := g.Store.SecretGet(g.config, userid, in.GetName())
+	var s *models.Secret
+	var err error
+	if in.GetVersion() > 0 {
+		s, err = g.Store.SecretGetVersion(ctx, userid, in.GetName(), in.GetVersion())
+	} else {
+		s, err = g.Store.SecretGet(ctx, userid, in.GetName())
+	}
 	if err != nil {
 		if errors.Is(err, storage.ErrSecretNotFound) {
 			logger.Sugar().Errorf("secret %s not found for user %s", in.Name, userid)
@@ -250,25 +536,376 @@ func (g *GophKeeperServer) GetSecret(ctx context.Context, in *pb.GetSecretReques
 		logger.Sugar().Errorf("error getting secret from DB: %v", err)
 		return &response, fmt.Errorf(errFormat, status.Error(codes.Internal, msgSecretsFailedToGet))
 	}
-
-	data, err := helpers.Decrypt(key, s.Data)
-	if err != nil {
-		logger.Sugar().Errorf("error decrypting secret data: %v", err)
-		return &response, fmt.Errorf(errFormat, status.Error(codes.Internal, msgSecretsFailedToGet))
+	if err = verifyData(s); err != nil {
+		logger.Sugar().Errorf("version %d of secret %s of user %s: %v", s.Version, s.Name, userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.DataLoss, msgSecretCorrupted))
 	}
 
 	response = pb.GetSecretResponse{
 		Secret: &pb.Secret{
-			Name:    s.Name,
-			Type:    TypeToProto(s.Type),
-			Meta:    s.Meta,
-			Data:    *data,
-			Version: s.Version,
+			Name:       s.Name,
+			Type:       TypeToProto(s.Type),
+			Labels:     s.Labels,
+			Data:       s.Data,
+			Version:    s.Version,
+			EncVersion: s.EncVersion,
+			Hash:       s.Hash,
 		},
 	}
 	return &response, nil
 }
 
+// UploadSecret saves secret received in chunks, the first message of the stream is the
+// secret header. Chunks are stored as they arrive without buffering the whole secret, data of
+// large file and binary secrets goes to the blob store if it is configured.
+func (g *GophKeeperServer) UploadSecret(stream pb.GophKeeper_UploadSecretServer) error {
+	logger := g.config.Logger
+	ctx := stream.Context()
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Sugar().Error(msgMetadataNotFound)
+		return fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
+	}
+	userid := md["owner"][0]
+
+	req, err := stream.Recv()
+	if err != nil {
+		logger.Sugar().Errorf("failed to receive secret header: %v", err)
+		return fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, msgSecretHeaderMissing))
+	}
+	header := req.GetHeader()
+	if header == nil {
+		logger.Sugar().Errorf("user %s started upload without secret header", userid)
+		return fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, msgSecretHeaderMissing))
+	}
+
+	secret := &models.Secret{
+		Name:       header.Secret.GetName(),
+		Labels:     header.Secret.GetLabels(),
+		Type:       ProtoToType(header.Secret.GetType()),
+		Version:    header.Secret.GetVersion(),
+		EncVersion: header.Secret.GetEncVersion(),
+	}
+	if !header.GetUpdate() {
+		if err := secretpath.ValidateName(secret.Name); err != nil {
+			logger.Sugar().Errorf("invalid secret name from user %s: %v", userid, err)
+			return fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, err.Error()))
+		}
+	} else if secret.Version <= 0 {
+		logger.Sugar().Errorf("update of secret %s by user %s without expected version", secret.Name, userid)
+		return fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, msgSecretVersionRequired))
+	}
+	if err := labels.Validate(secret.Labels); err != nil {
+		logger.Sugar().Errorf("invalid labels of secret %s for user %s: %v", secret.Name, userid, err)
+		return fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, err.Error()))
+	}
+	next := func() ([]byte, error) {
+		req, err := stream.Recv()
+		if err != nil {
+			// io.EOF is returned as is, it marks the end of upload
+			return nil, err
+		}
+		if req.GetHeader() != nil {
+			return nil, errors.New("unexpected secret header")
+		}
+		return req.GetChunk(), nil
+	}
+
+	// a failed upload to the blob store is cleaned up by receiveBlob
+	next, upload, err := g.receiveBlob(ctx, userid, secret, header.GetUpdate(), next)
+	if err == nil {
+		err = g.Store.SecretSaveStream(ctx, userid, secret, header.GetUpdate(), next)
+		if upload != nil && (err != nil || secret.BlobRef != upload.Ref()) {
+			// the upload is not referenced if the secret was not saved or reuses the blob of a past version
+			g.abortUpload(ctx, upload)
+		}
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrSecretAlreadyExists):
+			logger.Sugar().Errorf("failed creating secret for user %s:  already exists", userid)
+			return fmt.Errorf(errFormat, status.Error(codes.AlreadyExists, msgSecretAlreadyExists))
+		case errors.Is(err, storage.ErrSecretNotFound):
+			logger.Sugar().Errorf("secret %s not found for user %s", secret.Name, userid)
+			return fmt.Errorf(errFormat, status.Error(codes.NotFound, msgSecretNotFound))
+		case errors.Is(err, storage.ErrVersionConflict):
+			logger.Sugar().Errorf("failed updating secret %s for user %s: %v", secret.Name, userid, err)
+			return fmt.Errorf(errFormat, status.Error(codes.Aborted, err.Error()))
+		}
+		logger.Sugar().Errorf("error uploading secret %s of user %s: %v", secret.Name, userid, err)
+		return fmt.Errorf(errFormat, status.Error(codes.Internal, msgSecretFailedToUpload))
+	}
+
+	if err := stream.SendAndClose(&pb.UploadSecretResponse{Version: secret.Version}); err != nil {
+		return fmt.Errorf("failed to send upload response: %w", err)
+	}
+	return nil
+}
+
+// DownloadSecret sends secret header followed by chunks of data. Secrets which were not uploaded
+// in chunks are sent as header only with data inside.
+func (g *GophKeeperServer) DownloadSecret(in *pb.DownloadSecretRequest,
+	stream pb.GophKeeper_DownloadSecretServer) error {
+	logger := g.config.Logger
+	ctx := stream.Context()
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Sugar().Error(msgMetadataNotFound)
+		return fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
+	}
+	userid := md["owner"][0]
+
+	var s *models.Secret
+	var err error
+	if in.GetVersion() > 0 {
+		s, err = g.Store.SecretGetVersion(ctx, userid, in.GetName(), in.GetVersion())
+	} else {
+		s, err = g.Store.SecretGet(ctx, userid, in.GetName())
+	}
+	if err != nil {
+		if errors.Is(err, storage.ErrSecretNotFound) {
+			logger.Sugar().Errorf("secret %s not found for user %s", in.Name, userid)
+			return fmt.Errorf(errFormat, status.Error(codes.NotFound, msgSecretNotFound))
+		}
+		logger.Sugar().Errorf("error getting secret from DB: %v", err)
+		return fmt.Errorf(errFormat, status.Error(codes.Internal, msgSecretsFailedToGet))
+	}
+	if err = verifyData(s); err != nil {
+		logger.Sugar().Errorf("version %d of secret %s of user %s: %v", s.Version, s.Name, userid, err)
+		return fmt.Errorf(errFormat, status.Error(codes.DataLoss, msgSecretCorrupted))
+	}
+
+	err = stream.Send(&pb.DownloadSecretResponse{
+		Payload: &pb.DownloadSecretResponse_Secret{
+			Secret: &pb.Secret{
+				Name:       s.Name,
+				Type:       TypeToProto(s.Type),
+				Labels:     s.Labels,
+				Data:       s.Data,
+				Version:    s.Version,
+				EncVersion: s.EncVersion,
+				Hash:       s.Hash,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send secret header: %w", err)
+	}
+
+	// chunks are verified as they are sent, corruption is reported after the last one
+	hash := newDataHash(s)
+	send := func(chunk []byte) error {
+		hash.Write(chunk)
+		return stream.Send(&pb.DownloadSecretResponse{
+			Payload: &pb.DownloadSecretResponse_Chunk{Chunk: chunk},
+		})
+	}
+	if s.BlobRef != "" {
+		err = g.readBlob(ctx, s, send)
+	} else {
+		err = g.Store.SecretChunks(ctx, userid, s.Name, s.Version, send)
+	}
+	if err == nil {
+		err = hash.Verify()
+	}
+	if err != nil {
+		logger.Sugar().Errorf("error sending chunks of secret %s: %v", s.Name, err)
+		if errors.Is(err, blobstore.ErrCorrupted) || errors.Is(err, errHashMismatch) {
+			return fmt.Errorf(errFormat, status.Error(codes.DataLoss, msgSecretCorrupted))
+		}
+		return fmt.Errorf(errFormat, status.Error(codes.Internal, msgSecretsFailedToGet))
+	}
+	return nil
+}
+
+// SearchSecrets returns secrets which name, type or labels match the query ordered by relevance.
+func (g *GophKeeperServer) SearchSecrets(ctx context.Context,
+	in *pb.SearchSecretsRequest) (*pb.SearchSecretsResponse, error) {
+	logger := g.config.Logger
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Sugar().Error(msgMetadataNotFound)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
+	}
+	userid := md["owner"][0]
+
+	terms, err := search.Terms(in.GetQuery())
+	if err != nil {
+		logger.Sugar().Errorf("invalid search query from user %s: %v", userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, err.Error()))
+	}
+
+	results, err := g.Store.SecretSearch(ctx, userid, terms, search.Limit(int(in.GetLimit())))
+	if err != nil {
+		logger.Sugar().Errorf("failed to search secrets for user %s: %v", userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgSecretsFailedToSearch))
+	}
+
+	response := &pb.SearchSecretsResponse{Results: make([]*pb.SearchResult, 0, len(*results))}
+	for _, r := range *results {
+		response.Results = append(response.Results, &pb.SearchResult{
+			Secret: &pb.SecretItem{
+				Name:       r.Name,
+				Type:       TypeToProto(r.Type),
+				Labels:     r.Labels,
+				Version:    r.Version,
+				EncVersion: r.EncVersion,
+			},
+			Rank: r.Rank,
+		})
+	}
+	return response, nil
+}
+
+// MoveSecret renames secret or folder, version history of moved secrets is preserved.
+func (g *GophKeeperServer) MoveSecret(ctx context.Context,
+	in *pb.MoveSecretRequest) (*pb.MoveSecretResponse, error) {
+	logger := g.config.Logger
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Sugar().Error(msgMetadataNotFound)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
+	}
+	userid := md["owner"][0]
+
+	to, err := secretpath.MoveTarget(in.GetFrom(), in.GetTo())
+	if err != nil {
+		logger.Sugar().Errorf("invalid move of secret %s to %s for user %s: %v", in.GetFrom(), in.GetTo(), userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, err.Error()))
+	}
+	from := in.GetFrom()
+	if secretpath.IsFolder(from) {
+		// folder was validated by MoveTarget
+		from, _ = secretpath.NormalizeFolder(from)
+	}
+
+	moved, err := g.Store.SecretMove(ctx, userid, from, to)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrSecretNotFound):
+			logger.Sugar().Errorf("secret %s not found for user %s", from, userid)
+			return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgSecretNotFound))
+		case errors.Is(err, storage.ErrSecretAlreadyExists):
+			logger.Sugar().Errorf("failed moving secret %s to %s for user %s: already exists", from, to, userid)
+			return nil, fmt.Errorf(errFormat, status.Error(codes.AlreadyExists, msgSecretAlreadyExists))
+		}
+		logger.Sugar().Errorf("failed to move secret %s for user %s: %v", from, userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgSecretFailedToMove))
+	}
+
+	return &pb.MoveSecretResponse{Moved: moved}, nil
+}
+
+func (g *GophKeeperServer) ListSecretVersions(ctx context.Context,
+	in *pb.ListSecretVersionsRequest) (*pb.ListSecretVersionsResponse, error) {
+	logger := g.config.Logger
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Sugar().Error(msgMetadataNotFound)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
+	}
+	userid := md["owner"][0]
+
+	versionsDB, err := g.Store.SecretVersionList(ctx, userid, in.GetName())
+	if err != nil {
+		if errors.Is(err, storage.ErrSecretNotFound) {
+			logger.Sugar().Errorf("secret %s not found for user %s", in.GetName(), userid)
+			return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgSecretNotFound))
+		}
+		logger.Sugar().Errorf("failed to get list of secret versions: %v", err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgSecretVersionsFailedToGet))
+	}
+
+	response := &pb.ListSecretVersionsResponse{Versions: make([]*pb.SecretVersionItem, 0, len(*versionsDB))}
+	for _, dbItem := range *versionsDB {
+		response.Versions = append(response.Versions, &pb.SecretVersionItem{
+			Version:   dbItem.Version,
+			Type:      TypeToProto(dbItem.Type),
+			CreatedAt: timestamppb.New(dbItem.CreatedAt),
+		})
+	}
+
+	return response, nil
+}
+
+// ReplaceSecretVersions replaces data of past versions of the secret and deletes past versions in one
+// transaction, so the client re-encrypting the history under a new key never leaves it half done.
+func (g *GophKeeperServer) ReplaceSecretVersions(ctx context.Context,
+	in *pb.ReplaceSecretVersionsRequest) (*pb.Empty, error) {
+	logger := g.config.Logger
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Sugar().Error(msgMetadataNotFound)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
+	}
+	userid := md["owner"][0]
+
+	versions := make([]*models.Secret, 0, len(in.GetVersions()))
+	for _, v := range in.GetVersions() {
+		if v.GetEncVersion() == models.EncVersionChunked {
+			return nil, fmt.Errorf(errFormat, status.Error(codes.InvalidArgument,
+				"data of secrets kept in chunks is uploaded by UploadSecret"))
+		}
+		versions = append(versions, &models.Secret{
+			Name:       in.GetName(),
+			Data:       v.GetData(),
+			Version:    v.GetVersion(),
+			EncVersion: v.GetEncVersion(),
+		})
+	}
+
+	blobRefs, err := g.Store.SecretReplaceVersions(ctx, userid, in.GetName(), versions, in.GetDelete())
+	if err != nil {
+		logger.Sugar().Errorf("failed to replace versions of secret %s for user %s: %v", in.GetName(), userid, err)
+		if errors.Is(err, storage.ErrSecretNotFound) {
+			return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, err.Error()))
+		}
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgSecretVersionsFailedToReplace))
+	}
+	if g.Blobs != nil {
+		g.deleteBlobs(ctx, blobRefs...)
+	}
+
+	return &pb.Empty{}, nil
+}
+
+// RollbackSecret adds the next version of the secret with data of its past version. Data is copied on
+// the server, so the client does not download and upload it again and versions kept in the blob store
+// share the blob.
+func (g *GophKeeperServer) RollbackSecret(ctx context.Context,
+	in *pb.RollbackSecretRequest) (*pb.RollbackSecretResponse, error) {
+	logger := g.config.Logger
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Sugar().Error(msgMetadataNotFound)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
+	}
+	userid := md["owner"][0]
+
+	if in.GetVersion() <= 0 {
+		logger.Sugar().Errorf("rollback of secret %s by user %s without version", in.GetName(), userid)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, msgSecretBadRequest))
+	}
+	if in.GetCurrentVersion() <= 0 {
+		logger.Sugar().Errorf("rollback of secret %s by user %s without expected version", in.GetName(), userid)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, msgSecretVersionRequired))
+	}
+
+	secret, err := g.Store.SecretRollback(ctx, userid, in.GetName(), in.GetVersion(), in.GetCurrentVersion())
+	if err != nil {
+		logger.Sugar().Errorf("failed to roll back secret %s for user %s: %v", in.GetName(), userid, err)
+		switch {
+		case errors.Is(err, storage.ErrSecretNotFound):
+			return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, err.Error()))
+		case errors.Is(err, storage.ErrVersionConflict):
+			return nil, fmt.Errorf(errFormat, status.Error(codes.Aborted, err.Error()))
+		}
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgSecretFailedToRollback))
+	}
+
+	return &pb.RollbackSecretResponse{Version: secret.Version}, nil
+}
+
 func (g *GophKeeperServer) DeleteSecret(ctx context.Context,
 	in *pb.DeleteSecretRequest) (*pb.Empty, error) {
 	logger := g.config.Logger
@@ -277,9 +914,9 @@ func (g *GophKeeperServer) DeleteSecret(ctx context.Context,
 		logger.Sugar().Error(msgMetadataNotFound)
 		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
 	}
-	userid := md["userid"][0]
+	userid := md["owner"][0]
 
-	err := g.Store.SecretDelete(g.config, userid, in.GetName())
+	blobRefs, err := g.Store.SecretDelete(ctx, userid, in.GetName())
 	if err != nil {
 		if errors.Is(err, storage.ErrSecretNotFound) {
 			logger.Sugar().Errorf("secret %s not found for user %s", in.Name, userid)
@@ -288,33 +925,64 @@ func (g *GophKeeperServer) DeleteSecret(ctx context.Context,
 		logger.Sugar().Errorf("failed to delete secret for user %s: %v", userid, err)
 		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgSecretFailedToDelete))
 	}
+	if g.Blobs != nil {
+		g.deleteBlobs(ctx, blobRefs...)
+	}
 
 	return &pb.Empty{}, nil
 }
 
-func Run(ctx context.Context, s Storage, c *models.Config) error {
+func Run(ctx context.Context, s Storage, blobs *blobstore.Blobs, c *models.Config) error {
 	const MaxSizeBytes = 10 * 1024 * 1024
 	logger := c.Logger
-	hostport := strings.Replace(c.Address, "http://", "", 1)
-	grpcHost := strings.Split(hostport, ":")[0]
-	if grpcHost == "" || grpcHost == "localhost" {
-		grpcHost = "127.0.0.1"
-	}
-	grpcHost += ":3200"
-	listen, err := net.Listen("tcp", grpcHost)
+	listen, err := Listen(c.Address)
 	if err != nil {
-		return fmt.Errorf("failed to set up listener on port 3200: %w", err)
+		return err
+	}
+
+	revoked := revocation.NewCache()
+	if err := revoked.Load(ctx, s); err != nil {
+		return fmt.Errorf("failed to initialize revoked tokens cache: %w", err)
 	}
+	go revoked.Run(ctx, c, s)
 
-	srv := grpc.NewServer(
-		grpc.UnaryInterceptor(ic.AuthInterceptor(c)),
+	var auditKey ed25519.PublicKey
+	if c.AuditCheckpointFile != "" {
+		key, err := auditlog.LoadKey(c.AuditKeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load audit checkpoint key: %w", err)
+		}
+		auditKey = key.Public().(ed25519.PublicKey)
+		go auditlog.Run(ctx, c, s, key)
+	}
+
+	opts := []grpc.ServerOption{
+		// audit precedes auth to record calls denied by it
+		grpc.ChainUnaryInterceptor(ic.AuditInterceptor(c, s), ic.ContextInterceptor(c), ic.AuthInterceptor(c, revoked, s)),
+		grpc.ChainStreamInterceptor(ic.AuditStreamInterceptor(c, s), ic.ContextStreamInterceptor(),
+			ic.AuthStreamInterceptor(c, revoked, s)),
 		grpc.MaxRecvMsgSize(MaxSizeBytes),
 		grpc.MaxSendMsgSize(MaxSizeBytes),
-	)
+	}
+
+	if c.TLSCertFile != "" {
+		tlsConfig, err := helpers.NewTLSConfig(c)
+		if err != nil {
+			return fmt.Errorf("failed to set up TLS: %w", err)
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	} else {
+		logger.Sugar().Warn("TLS is not configured, gRPC traffic is not encrypted")
+	}
+
+	srv := grpc.NewServer(opts...)
 
 	pb.RegisterGophKeeperServer(srv, &GophKeeperServer{
-		Store:  s,
-		config: c,
+		Store:    s,
+		config:   c,
+		revoked:  revoked,
+		auditKey: auditKey,
+		Blobs:    blobs,
 	})
 
 	wg := sync.WaitGroup{}
@@ -330,7 +998,7 @@ func Run(ctx context.Context, s Storage, c *models.Config) error {
 		wg.Done()
 	}()
 
-	logger.Sugar().Infow("gRPC server is starting", "Address", grpcHost)
+	logger.Sugar().Infow("gRPC server is starting", "Address", listen.Addr().String())
 
 	if err := srv.Serve(listen); err != nil {
 		logger.Sugar().Fatal(err)
@@ -349,6 +1017,10 @@ func TypeToProto(st string) pb.SecretType {
 		return pb.SecretType_CARD
 	case "file":
 		return pb.SecretType_FILE
+	case "login":
+		return pb.SecretType_CREDENTIALS
+	case "otp":
+		return pb.SecretType_OTP
 	case "unknown":
 		return pb.SecretType_UNKNOWN
 	default:
@@ -356,6 +1028,21 @@ func TypeToProto(st string) pb.SecretType {
 	}
 }
 
+func protoToSort(sort pb.SecretSort) (models.SecretSort, error) {
+	switch sort {
+	case pb.SecretSort_SORT_NAME_ASC:
+		return models.SortNameAsc, nil
+	case pb.SecretSort_SORT_NAME_DESC:
+		return models.SortNameDesc, nil
+	case pb.SecretSort_SORT_UPDATED_DESC:
+		return models.SortUpdatedDesc, nil
+	case pb.SecretSort_SORT_UPDATED_ASC:
+		return models.SortUpdatedAsc, nil
+	default:
+		return 0, fmt.Errorf("unknown sort order %d", sort)
+	}
+}
+
 func ProtoToType(st pb.SecretType) string {
 	switch st {
 	case pb.SecretType_TEXT:
@@ -366,6 +1053,10 @@ func ProtoToType(st pb.SecretType) string {
 		return "card"
 	case pb.SecretType_FILE:
 		return "file"
+	case pb.SecretType_CREDENTIALS:
+		return "login"
+	case pb.SecretType_OTP:
+		return "otp"
 	case pb.SecretType_UNKNOWN:
 		return "unknown"
 	default:
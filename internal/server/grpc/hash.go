@@ -0,0 +1,49 @@
+package grpcserver
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"hash"
+
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+)
+
+// errHashMismatch is returned when secret data read from storage does not match the hash stored with it.
+var errHashMismatch = errors.New("secret data does not match its hash")
+
+// verifyData checks data of the secret which is not kept in chunks. Secrets saved before hashes
+// were introduced have no hash and are not verified.
+func verifyData(s *models.Secret) error {
+	if s.Hash == nil || s.EncVersion == models.EncVersionChunked {
+		return nil
+	}
+	if sum := sha256.Sum256(s.Data); !bytes.Equal(sum[:], s.Hash) {
+		return errHashMismatch
+	}
+	return nil
+}
+
+// dataHash hashes secret data as it is sent: Data followed by chunks.
+type dataHash struct {
+	hash   hash.Hash
+	secret *models.Secret
+}
+
+func newDataHash(s *models.Secret) *dataHash {
+	h := &dataHash{hash: sha256.New(), secret: s}
+	h.hash.Write(s.Data)
+	return h
+}
+
+func (h *dataHash) Write(chunk []byte) {
+	h.hash.Write(chunk)
+}
+
+// Verify checks the hash of all data written.
+func (h *dataHash) Verify() error {
+	if h.secret.Hash != nil && !bytes.Equal(h.hash.Sum(nil), h.secret.Hash) {
+		return errHashMismatch
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package interceptors
+
+import (
+	"context"
+	"net"
+	"path"
+
+	pb "github.com/vkupriya/gophkeeper/internal/proto"
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// auditMethods are RPCs accessing or changing secrets, they are recorded in the audit log.
+var auditMethods = map[string]bool{
+	"/proto.GophKeeper/AddSecret":             true,
+	"/proto.GophKeeper/UpdateSecret":          true,
+	"/proto.GophKeeper/GetSecret":             true,
+	"/proto.GophKeeper/DeleteSecret":          true,
+	"/proto.GophKeeper/ListSecrets":           true,
+	"/proto.GophKeeper/SearchSecrets":         true,
+	"/proto.GophKeeper/MoveSecret":            true,
+	"/proto.GophKeeper/ListSecretVersions":    true,
+	"/proto.GophKeeper/ReplaceSecretVersions": true,
+	"/proto.GophKeeper/RollbackSecret":        true,
+	"/proto.GophKeeper/UploadSecret":          true,
+	"/proto.GophKeeper/DownloadSecret":        true,
+	"/proto.GophKeeper/ShareSecret":           true,
+	"/proto.GophKeeper/RevokeShare":           true,
+	"/proto.GophKeeper/ListSharedSecrets":     true,
+	"/proto.GophKeeper/GetSharedSecret":       true,
+	"/proto.GophKeeper/UpdateSharedSecret":    true,
+	"/proto.GophKeeper/GetVaultKey":           true,
+	"/proto.GophKeeper/SetVaultKey":           true,
+}
+
+// AuditWriter appends events to the audit log.
+type AuditWriter interface {
+	AuditAdd(ctx context.Context, event *models.AuditEvent) error
+}
+
+// auditKey is context key of the identity resolved by AuthInterceptor for the audit.
+type auditKey struct{}
+
+// auditIdentity is the user and the owner of secrets the call acts on, the owner is set
+// even if the user is not allowed to act on the vault.
+type auditIdentity struct {
+	userid string
+	owner  string
+}
+
+// setAuditIdentity updates the identity of the audited call, if any.
+func setAuditIdentity(ctx context.Context, userid string, owner string) {
+	if id, ok := ctx.Value(auditKey{}).(*auditIdentity); ok {
+		id.userid = userid
+		id.owner = owner
+	}
+}
+
+// AuditInterceptor records secret RPCs of authenticated users in the audit log after they are
+// handled, including calls denied by AuthInterceptor, so it must precede AuthInterceptor in the chain.
+func AuditInterceptor(cfg *models.Config, audit AuditWriter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if !auditMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		id := &auditIdentity{}
+		resp, err := handler(context.WithValue(ctx, auditKey{}, id), req)
+		record(ctx, cfg, audit, id, info.FullMethod, secretName(req), err)
+		return resp, err
+	}
+}
+
+// AuditStreamInterceptor records streaming secret RPCs the same way as AuditInterceptor,
+// secret name is taken from the first received message.
+func AuditStreamInterceptor(cfg *models.Config, audit AuditWriter) grpc.StreamServerInterceptor {
+	return func(srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if !auditMethods[info.FullMethod] {
+			return handler(srv, ss)
+		}
+
+		id := &auditIdentity{}
+		stream := &auditServerStream{ServerStream: ss, ctx: context.WithValue(ss.Context(), auditKey{}, id)}
+		err := handler(srv, stream)
+		record(ss.Context(), cfg, audit, id, info.FullMethod, stream.name, err)
+		return err
+	}
+}
+
+// auditServerStream passes the identity in context and keeps secret name of the first received message.
+type auditServerStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	name     string
+	received bool
+}
+
+func (s *auditServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *auditServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && !s.received {
+		s.received = true
+		s.name = secretName(m)
+	}
+	return err
+}
+
+// record writes the event, calls of unauthenticated users are not recorded. Failure to write
+// the event is logged and does not fail the call. The event is written even if the call was
+// cancelled, so the write has its own timeout.
+func record(ctx context.Context, cfg *models.Config, audit AuditWriter, id *auditIdentity, method string,
+	name string, err error) {
+	if id.userid == "" {
+		return
+	}
+	event := &models.AuditEvent{
+		UserID:   id.userid,
+		Owner:    id.owner,
+		Method:   path.Base(method),
+		Name:     name,
+		ClientIP: clientIP(ctx),
+		Code:     status.Code(err).String(),
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cfg.ContextTimeout)
+	defer cancel()
+	if aerr := audit.AuditAdd(ctx, event); aerr != nil {
+		cfg.Logger.Sugar().Errorf("failed to write audit event %s of user %s: %v", event.Method, event.UserID, aerr)
+	}
+}
+
+// secretName returns name of the secret, folder or move source from the request.
+func secretName(req interface{}) string {
+	switch r := req.(type) {
+	case *pb.AddSecretRequest:
+		return r.GetSecret().GetName()
+	case *pb.UpdateSecretRequest:
+		return r.GetSecret().GetName()
+	case *pb.UploadSecretRequest:
+		return r.GetHeader().GetSecret().GetName()
+	case *pb.ListSecretsRequest:
+		return r.GetPrefix()
+	case *pb.MoveSecretRequest:
+		return r.GetFrom()
+	case interface{ GetName() string }:
+		return r.GetName()
+	}
+	return ""
+}
+
+// clientIP returns address of the peer without port.
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
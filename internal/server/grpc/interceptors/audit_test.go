@@ -0,0 +1,124 @@
+package interceptors
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	pb "github.com/vkupriya/gophkeeper/internal/proto"
+	"github.com/vkupriya/gophkeeper/internal/server/helpers"
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+	"github.com/vkupriya/gophkeeper/internal/vault"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type auditRecorder struct {
+	events []*models.AuditEvent
+}
+
+func (a *auditRecorder) AuditAdd(_ context.Context, event *models.AuditEvent) error {
+	a.events = append(a.events, event)
+	return nil
+}
+
+type noRevocation struct{}
+
+func (noRevocation) IsRevoked(string) bool {
+	return false
+}
+
+type viewerVaults struct{}
+
+func (viewerVaults) VaultRole(_ context.Context, _ string, org string, name string) (vault.Role, string, error) {
+	return vault.RoleViewer, vault.Principal(org, name), nil
+}
+
+func TestAuditInterceptor(t *testing.T) {
+	cfg := &models.Config{
+		Logger:         zap.NewNop(),
+		JWTKey:         "auditkey",
+		JWTTokenTTL:    time.Minute,
+		ContextTimeout: time.Second,
+	}
+	token, _, err := helpers.CreateJWTString(cfg, "alice")
+	if err != nil {
+		t.Fatalf("failed to create token: %v", err)
+	}
+
+	recorder := &auditRecorder{}
+	audit := AuditInterceptor(cfg, recorder)
+	auth := AuthInterceptor(cfg, noRevocation{}, viewerVaults{})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "secret not found")
+	}
+	// call runs audit and auth interceptors in the server order
+	call := func(method string, md metadata.MD, req interface{}) error {
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.10"), Port: 50000}})
+		info := &grpc.UnaryServerInfo{FullMethod: method}
+		_, err := audit(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return auth(ctx, req, info, handler)
+		})
+		return err
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		md     metadata.MD
+		req    interface{}
+		want   *models.AuditEvent
+	}{
+		{
+			name:   "GetSecret",
+			method: "/proto.GophKeeper/GetSecret",
+			md:     metadata.Pairs("authorization", token),
+			req:    &pb.GetSecretRequest{Name: "work/db"},
+			want: &models.AuditEvent{UserID: "alice", Owner: "alice", Method: "GetSecret", Name: "work/db",
+				ClientIP: "192.0.2.10", Code: "NotFound"},
+		},
+		{
+			name:   "AddSecret_DeniedInVault",
+			method: "/proto.GophKeeper/AddSecret",
+			md:     metadata.Pairs("authorization", token, vault.MetadataKey, "acme/oncall"),
+			req:    &pb.AddSecretRequest{Secret: &pb.Secret{Name: "db"}},
+			want: &models.AuditEvent{UserID: "alice", Owner: "@acme/oncall", Method: "AddSecret", Name: "db",
+				ClientIP: "192.0.2.10", Code: "PermissionDenied"},
+		},
+		{
+			name:   "NotAudited",
+			method: "/proto.GophKeeper/ListVaults",
+			md:     metadata.Pairs("authorization", token),
+			req:    &pb.Empty{},
+		},
+		{
+			name:   "Unauthenticated",
+			method: "/proto.GophKeeper/GetSecret",
+			md:     metadata.Pairs("authorization", "invalid"),
+			req:    &pb.GetSecretRequest{Name: "work/db"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder.events = nil
+			_ = call(tt.method, tt.md, tt.req)
+
+			if tt.want == nil {
+				if len(recorder.events) != 0 {
+					t.Errorf("Out -> \nWant: no events\nGot : %+v", recorder.events[0])
+				}
+				return
+			}
+			if len(recorder.events) != 1 || *recorder.events[0] != *tt.want {
+				t.Errorf("Out -> \nWant: %+v\nGot : %+v", tt.want, recorder.events)
+			}
+		})
+	}
+}
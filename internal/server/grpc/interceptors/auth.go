@@ -2,9 +2,12 @@ package interceptors
 
 import (
 	"context"
+	"errors"
 
 	"github.com/vkupriya/gophkeeper/internal/server/helpers"
 	"github.com/vkupriya/gophkeeper/internal/server/models"
+	"github.com/vkupriya/gophkeeper/internal/server/storage"
+	"github.com/vkupriya/gophkeeper/internal/vault"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -13,9 +16,39 @@ import (
 
 var userServiceLogin = "/proto.GophKeeper/Login"
 var userServiceRegister = "/proto.GophKeeper/Register"
-var ignoreMethod = []string{userServiceLogin, userServiceRegister}
+var userServiceRefreshToken = "/proto.GophKeeper/RefreshToken"
+var userServiceLogout = "/proto.GophKeeper/Logout"
+var ignoreMethod = []string{userServiceLogin, userServiceRegister, userServiceRefreshToken, userServiceLogout}
 
-func AuthInterceptor(cfg *models.Config) grpc.UnaryServerInterceptor {
+// vaultMethods are RPCs acting on secrets of the vault set in metadata, with the role required for them.
+var vaultMethods = map[string]vault.Role{
+	"/proto.GophKeeper/GetSecret":          vault.RoleViewer,
+	"/proto.GophKeeper/ListSecrets":        vault.RoleViewer,
+	"/proto.GophKeeper/SearchSecrets":      vault.RoleViewer,
+	"/proto.GophKeeper/ListSecretVersions": vault.RoleViewer,
+	"/proto.GophKeeper/DownloadSecret":     vault.RoleViewer,
+	"/proto.GophKeeper/AddSecret":          vault.RoleEditor,
+	"/proto.GophKeeper/UpdateSecret":       vault.RoleEditor,
+	"/proto.GophKeeper/DeleteSecret":       vault.RoleEditor,
+	"/proto.GophKeeper/MoveSecret":         vault.RoleEditor,
+	"/proto.GophKeeper/UploadSecret":       vault.RoleEditor,
+	"/proto.GophKeeper/RollbackSecret":     vault.RoleEditor,
+	"/proto.GophKeeper/GetVaultKey":        vault.RoleViewer,
+	"/proto.GophKeeper/SetVaultKey":        vault.RoleViewer,
+}
+
+type RevocationChecker interface {
+	IsRevoked(jti string) bool
+}
+
+// VaultResolver returns the role of the user in the vault and the principal owning vault secrets.
+type VaultResolver interface {
+	VaultRole(ctx context.Context, userid string, org string, name string) (vault.Role, string, error)
+}
+
+// AuthInterceptor authenticates unary calls and puts into metadata the user ID, the owner of secrets
+// the call acts on (the user or the vault principal) and the role of the user.
+func AuthInterceptor(cfg *models.Config, revoked RevocationChecker, vaults VaultResolver) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context,
 		req interface{},
 		info *grpc.UnaryServerInfo,
@@ -26,24 +59,113 @@ func AuthInterceptor(cfg *models.Config) grpc.UnaryServerInterceptor {
 				return handler(ctx, req)
 			}
 		}
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Errorf(codes.Unauthenticated, "metadata is not provided")
-		}
 
-		values := md["authorization"]
-		if len(values) == 0 {
-			return nil, status.Errorf(codes.Unauthenticated, "authorization token is not provided")
+		ctx, err := authenticate(ctx, cfg, revoked)
+		if err != nil {
+			return nil, err
+		}
+		ctx, err = authorize(ctx, cfg, vaults, info.FullMethod)
+		if err != nil {
+			return nil, err
 		}
+		return handler(ctx, req)
+	}
+}
 
-		accessToken := values[0]
-		claims, err := helpers.ValidateJWT(cfg, accessToken)
+// AuthStreamInterceptor authenticates streaming calls the same way as AuthInterceptor.
+func AuthStreamInterceptor(cfg *models.Config, revoked RevocationChecker,
+	vaults VaultResolver) grpc.StreamServerInterceptor {
+	return func(srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := authenticate(ss.Context(), cfg, revoked)
+		if err != nil {
+			return err
+		}
+		ctx, err = authorize(ctx, cfg, vaults, info.FullMethod)
 		if err != nil {
-			return nil, status.Errorf(codes.Unauthenticated, "access token is invalid: %v", err)
+			return err
 		}
+		return handler(srv, &authServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
 
-		md.Append("userid", claims.UserID)
-		ctx = metadata.NewIncomingContext(ctx, md)
-		return handler(ctx, req)
+// authServerStream overrides context of the stream with authenticated one.
+type authServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate validates access token and returns context with user ID in metadata.
+func authenticate(ctx context.Context, cfg *models.Config, revoked RevocationChecker) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "metadata is not provided")
+	}
+
+	values := md["authorization"]
+	if len(values) == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "authorization token is not provided")
+	}
+
+	accessToken := values[0]
+	claims, err := helpers.ValidateJWT(cfg, accessToken)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "access token is invalid: %v", err)
+	}
+	if revoked.IsRevoked(claims.ID) {
+		return nil, status.Errorf(codes.Unauthenticated, "access token has been revoked")
 	}
+
+	// Set replaces values sent by the client under the same keys
+	md = md.Copy()
+	md.Set("userid", claims.UserID)
+	md.Set("jti", claims.ID)
+	return metadata.NewIncomingContext(ctx, md), nil
+}
+
+// authorize resolves the vault set in metadata and checks the role of the user for the method,
+// secrets of the user are used if the vault is not set or the method does not act on vaults.
+func authorize(ctx context.Context, cfg *models.Config, vaults VaultResolver, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	md = md.Copy()
+	userid := md["userid"][0]
+
+	refs := md[vault.MetadataKey]
+	required, ok := vaultMethods[method]
+	if !ok || len(refs) == 0 || refs[0] == "" {
+		setAuditIdentity(ctx, userid, userid)
+		md.Set("owner", userid)
+		md.Set("role", string(vault.RoleOwner))
+		return metadata.NewIncomingContext(ctx, md), nil
+	}
+
+	setAuditIdentity(ctx, userid, refs[0])
+	org, name, err := vault.Parse(refs[0])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	setAuditIdentity(ctx, userid, vault.Principal(org, name))
+
+	role, principal, err := vaults.VaultRole(ctx, userid, org, name)
+	if err != nil {
+		if errors.Is(err, storage.ErrVaultNotFound) {
+			return nil, status.Errorf(codes.NotFound, "vault %s not found", refs[0])
+		}
+		cfg.Logger.Sugar().Errorf("failed to get role of user %s in vault %s: %v", userid, refs[0], err)
+		return nil, status.Error(codes.Internal, "failed to get vault role")
+	}
+	if !role.Allows(required) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s role in vault %s is required", required, refs[0])
+	}
+
+	md.Set("owner", principal)
+	md.Set("role", string(role))
+	return metadata.NewIncomingContext(ctx, md), nil
 }
@@ -0,0 +1,47 @@
+package interceptors
+
+import (
+	"context"
+
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// ContextInterceptor limits unary calls by cfg.ContextTimeout, the deadline set by the client
+// applies if it is earlier. Calls failed because their context is done are reported as
+// Canceled or DeadlineExceeded instead of the error of the handler.
+func ContextInterceptor(cfg *models.Config) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, cancel := context.WithTimeout(ctx, cfg.ContextTimeout)
+		defer cancel()
+
+		resp, err := handler(ctx, req)
+		return resp, contextError(ctx, err)
+	}
+}
+
+// ContextStreamInterceptor reports streaming calls failed because their context is done the same
+// way as ContextInterceptor. Streams are not limited by cfg.ContextTimeout as transfer of a large
+// secret may take longer, they last until the client cancels them.
+func ContextStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		return contextError(ss.Context(), handler(srv, ss))
+	}
+}
+
+// contextError replaces err with the status of ctx if ctx is done.
+func contextError(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+	return status.FromContextError(ctx.Err()).Err()
+}
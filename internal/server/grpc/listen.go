@@ -0,0 +1,78 @@
+package grpcserver
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+)
+
+const (
+	defaultPort        = "3200"
+	unixSocketPrefix   = "unix://"
+	unixSocketFileMode = 0o600
+)
+
+// Listen creates listener for gRPC server. Address is either host:port (IPv6 host is
+// enclosed in brackets, default port is used if missing, empty host binds to all interfaces)
+// or path to unix domain socket prefixed with unix://.
+func Listen(address string) (net.Listener, error) {
+	if path, ok := strings.CutPrefix(address, unixSocketPrefix); ok {
+		return listenUnix(path)
+	}
+
+	hostport, err := tcpAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	listen, err := net.Listen("tcp", hostport)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up listener on %s: %w", hostport, err)
+	}
+	return listen, nil
+}
+
+func tcpAddress(address string) (string, error) {
+	address = strings.TrimPrefix(address, "http://")
+
+	if ip := net.ParseIP(strings.Trim(address, "[]")); ip != nil {
+		return net.JoinHostPort(ip.String(), defaultPort), nil
+	}
+
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		var addrErr *net.AddrError
+		if errors.As(err, &addrErr) && addrErr.Err == "missing port in address" {
+			return net.JoinHostPort(address, defaultPort), nil
+		}
+		return "", fmt.Errorf("invalid listen address %s: %w", address, err)
+	}
+	if port == "" {
+		port = defaultPort
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+func listenUnix(path string) (net.Listener, error) {
+	if path == "" {
+		return nil, errors.New("unix socket path is empty")
+	}
+
+	// socket file left by a server which was not stopped gracefully
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to remove stale unix socket %s: %w", path, err)
+	}
+
+	listen, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up listener on unix socket %s: %w", path, err)
+	}
+
+	if err := os.Chmod(path, unixSocketFileMode); err != nil {
+		_ = listen.Close()
+		return nil, fmt.Errorf("failed to set unix socket permissions: %w", err)
+	}
+	return listen, nil
+}
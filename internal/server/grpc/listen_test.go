@@ -0,0 +1,82 @@
+package grpcserver
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTCPAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		want    string
+		wantErr bool
+	}{
+		{name: "HostPort", address: "localhost:8080", want: "localhost:8080"},
+		{name: "HTTPPrefix", address: "http://127.0.0.1:8080", want: "127.0.0.1:8080"},
+		{name: "AllInterfaces", address: ":8080", want: ":8080"},
+		{name: "DefaultPort", address: "localhost", want: "localhost:3200"},
+		{name: "EmptyPort", address: "localhost:", want: "localhost:3200"},
+		{name: "IPv6", address: "[::1]:8080", want: "[::1]:8080"},
+		{name: "IPv6DefaultPort", address: "::1", want: "[::1]:3200"},
+		{name: "IPv6BracketsDefaultPort", address: "[::1]", want: "[::1]:3200"},
+		{name: "Invalid", address: "localhost:8080:1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tcpAddress(tt.address)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Err -> \nWant: %v\nGot: %v\n", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("Out -> \nWant: %q\nGot : %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestListen(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "gk.sock")
+	// stale socket file must be replaced
+	if err := os.WriteFile(socket, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		address string
+		network string
+	}{
+		{name: "TCP", address: "127.0.0.1:0", network: "tcp"},
+		{name: "Unix", address: "unix://" + socket, network: "unix"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listen, err := Listen(tt.address)
+			if err != nil {
+				t.Fatalf("failed to listen on %s: %v", tt.address, err)
+			}
+			defer func() {
+				_ = listen.Close()
+			}()
+
+			if listen.Addr().Network() != tt.network {
+				t.Errorf("Out -> \nWant: %q\nGot : %q", tt.network, listen.Addr().Network())
+			}
+
+			conn, err := net.Dial(listen.Addr().Network(), listen.Addr().String())
+			if err != nil {
+				t.Fatalf("failed to connect to %s: %v", listen.Addr(), err)
+			}
+			_ = conn.Close()
+		})
+	}
+
+	if _, err := Listen("unix://"); err == nil {
+		t.Error("listener on empty unix socket path must fail")
+	}
+}
@@ -0,0 +1,249 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/vkupriya/gophkeeper/internal/proto"
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+	"github.com/vkupriya/gophkeeper/internal/server/storage"
+)
+
+// publicKeySize is the size of X25519 public key.
+const publicKeySize = 32
+
+func (g *GophKeeperServer) SetPublicKey(ctx context.Context, in *pb.SetPublicKeyRequest) (*pb.Empty, error) {
+	logger := g.config.Logger
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Sugar().Error(msgMetadataNotFound)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
+	}
+	userid := md["userid"][0]
+
+	if len(in.GetPublicKey()) != publicKeySize {
+		logger.Sugar().Errorf("invalid public key of user %s: %d bytes", userid, len(in.GetPublicKey()))
+		return nil, fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, msgPublicKeyBadRequest))
+	}
+
+	if err := g.Store.UserSetPublicKey(ctx, userid, in.GetPublicKey()); err != nil {
+		logger.Sugar().Errorf("failed to set public key of user %s: %v", userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgPublicKeyFailedToSet))
+	}
+	return &pb.Empty{}, nil
+}
+
+func (g *GophKeeperServer) GetPublicKey(ctx context.Context,
+	in *pb.GetPublicKeyRequest) (*pb.GetPublicKeyResponse, error) {
+	logger := g.config.Logger
+
+	publicKey, err := g.Store.UserGetPublicKey(ctx, in.GetLogin())
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrUserNotFound):
+			logger.Sugar().Errorf("user %s not found", in.GetLogin())
+			return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgUserNotFound))
+		case errors.Is(err, storage.ErrPublicKeyNotFound):
+			logger.Sugar().Errorf("user %s has no public key", in.GetLogin())
+			return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgPublicKeyNotFound))
+		}
+		logger.Sugar().Errorf("failed to get public key of user %s: %v", in.GetLogin(), err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgPublicKeyFailedToGet))
+	}
+	return &pb.GetPublicKeyResponse{PublicKey: publicKey}, nil
+}
+
+func (g *GophKeeperServer) ShareSecret(ctx context.Context, in *pb.ShareSecretRequest) (*pb.Empty, error) {
+	logger := g.config.Logger
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Sugar().Error(msgMetadataNotFound)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
+	}
+	userid := md["userid"][0]
+
+	if in.GetRecipient() == "" || in.GetRecipient() == userid || len(in.GetData()) == 0 {
+		logger.Sugar().Errorf("invalid share of secret %s by user %s with %q", in.GetName(), userid,
+			in.GetRecipient())
+		return nil, fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, msgShareBadRequest))
+	}
+
+	share := &models.Share{
+		Owner:      userid,
+		Name:       in.GetName(),
+		Recipient:  in.GetRecipient(),
+		Access:     ProtoToAccess(in.GetAccess()),
+		Data:       in.GetData(),
+		Version:    in.GetVersion(),
+		EncVersion: in.GetEncVersion(),
+	}
+	if err := g.Store.ShareAdd(ctx, share); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrSecretNotFound):
+			logger.Sugar().Errorf("secret %s not found for user %s", share.Name, userid)
+			return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgSecretNotFound))
+		case errors.Is(err, storage.ErrUserNotFound):
+			logger.Sugar().Errorf("recipient %s of secret %s not found", share.Recipient, share.Name)
+			return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgUserNotFound))
+		case errors.Is(err, storage.ErrVersionConflict):
+			logger.Sugar().Errorf("failed sharing secret %s for user %s: %v", share.Name, userid, err)
+			return nil, fmt.Errorf(errFormat, status.Error(codes.Aborted, err.Error()))
+		}
+		logger.Sugar().Errorf("failed to share secret %s for user %s: %v", share.Name, userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgSecretFailedToShare))
+	}
+	return &pb.Empty{}, nil
+}
+
+func (g *GophKeeperServer) RevokeShare(ctx context.Context, in *pb.RevokeShareRequest) (*pb.Empty, error) {
+	logger := g.config.Logger
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Sugar().Error(msgMetadataNotFound)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
+	}
+	userid := md["userid"][0]
+
+	if err := g.Store.ShareRevoke(ctx, userid, in.GetName(), in.GetRecipient()); err != nil {
+		if errors.Is(err, storage.ErrShareNotFound) {
+			logger.Sugar().Errorf("share of secret %s by user %s with %s not found", in.GetName(), userid,
+				in.GetRecipient())
+			return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgShareNotFound))
+		}
+		logger.Sugar().Errorf("failed to revoke share of secret %s for user %s: %v", in.GetName(), userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgShareFailedToRevoke))
+	}
+	return &pb.Empty{}, nil
+}
+
+func (g *GophKeeperServer) ListSharedSecrets(ctx context.Context,
+	in *pb.ListSharedSecretsRequest) (*pb.ListSharedSecretsResponse, error) {
+	logger := g.config.Logger
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Sugar().Error(msgMetadataNotFound)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
+	}
+	userid := md["userid"][0]
+
+	sharesDB, err := g.Store.ShareList(ctx, userid, in.GetOutgoing())
+	if err != nil {
+		logger.Sugar().Errorf("failed to get shared secrets of user %s: %v", userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgSharesFailedToGet))
+	}
+
+	response := &pb.ListSharedSecretsResponse{
+		Items: make([]*pb.SharedSecretItem, 0, len(*sharesDB)),
+	}
+	for _, sh := range *sharesDB {
+		response.Items = append(response.Items, &pb.SharedSecretItem{
+			Owner:          sh.Owner,
+			Name:           sh.Name,
+			Recipient:      sh.Recipient,
+			Access:         AccessToProto(sh.Access),
+			Type:           TypeToProto(sh.Type),
+			Version:        sh.Version,
+			CurrentVersion: sh.CurrentVersion,
+			SharedAt:       timestamppb.New(sh.SharedAt),
+		})
+	}
+	return response, nil
+}
+
+func (g *GophKeeperServer) GetSharedSecret(ctx context.Context,
+	in *pb.GetSharedSecretRequest) (*pb.GetSharedSecretResponse, error) {
+	logger := g.config.Logger
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Sugar().Error(msgMetadataNotFound)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
+	}
+	userid := md["userid"][0]
+
+	sh, err := g.Store.ShareGet(ctx, userid, in.GetOwner(), in.GetName())
+	if err != nil {
+		if errors.Is(err, storage.ErrShareNotFound) {
+			logger.Sugar().Errorf("secret %s of %s is not shared with user %s", in.GetName(), in.GetOwner(), userid)
+			return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgShareNotFound))
+		}
+		logger.Sugar().Errorf("failed to get shared secret %s for user %s: %v", in.GetName(), userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgSharesFailedToGet))
+	}
+
+	return &pb.GetSharedSecretResponse{
+		Secret: &pb.Secret{
+			Name:       sh.Name,
+			Type:       TypeToProto(sh.Type),
+			Labels:     sh.Labels,
+			Data:       sh.Data,
+			Version:    sh.Version,
+			EncVersion: sh.EncVersion,
+		},
+		Access: AccessToProto(sh.Access),
+	}, nil
+}
+
+func (g *GophKeeperServer) UpdateSharedSecret(ctx context.Context,
+	in *pb.UpdateSharedSecretRequest) (*pb.Empty, error) {
+	logger := g.config.Logger
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Sugar().Error(msgMetadataNotFound)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
+	}
+	userid := md["userid"][0]
+
+	if len(in.GetOwnerData()) == 0 || len(in.GetData()) == 0 {
+		logger.Sugar().Errorf("invalid update of shared secret %s by user %s", in.GetName(), userid)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, msgShareBadRequest))
+	}
+	if in.GetVersion() <= 0 {
+		logger.Sugar().Errorf("update of shared secret %s by user %s without expected version", in.GetName(), userid)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, msgSecretVersionRequired))
+	}
+
+	share := &models.Share{
+		Owner:      in.GetOwner(),
+		Name:       in.GetName(),
+		Recipient:  userid,
+		Data:       in.GetData(),
+		Version:    in.GetVersion(),
+		EncVersion: in.GetEncVersion(),
+	}
+	if err := g.Store.ShareUpdate(ctx, share, in.GetOwnerData()); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrShareNotFound), errors.Is(err, storage.ErrSecretNotFound):
+			logger.Sugar().Errorf("secret %s of %s is not shared with user %s", share.Name, share.Owner, userid)
+			return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgShareNotFound))
+		case errors.Is(err, storage.ErrShareReadOnly):
+			logger.Sugar().Errorf("user %s can not update read-only secret %s of %s", userid, share.Name, share.Owner)
+			return nil, fmt.Errorf(errFormat, status.Error(codes.PermissionDenied, msgShareReadOnly))
+		case errors.Is(err, storage.ErrVersionConflict):
+			logger.Sugar().Errorf("failed updating shared secret %s for user %s: %v", share.Name, userid, err)
+			return nil, fmt.Errorf(errFormat, status.Error(codes.Aborted, err.Error()))
+		}
+		logger.Sugar().Errorf("failed to update shared secret %s for user %s: %v", share.Name, userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgSecretFailedToUpdate))
+	}
+	return &pb.Empty{}, nil
+}
+
+func AccessToProto(access string) pb.ShareAccess {
+	if access == models.AccessReadWrite {
+		return pb.ShareAccess_READ_WRITE
+	}
+	return pb.ShareAccess_READ_ONLY
+}
+
+func ProtoToAccess(access pb.ShareAccess) string {
+	if access == pb.ShareAccess_READ_WRITE {
+		return models.AccessReadWrite
+	}
+	return models.AccessReadOnly
+}
@@ -0,0 +1,313 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/vkupriya/gophkeeper/internal/proto"
+	"github.com/vkupriya/gophkeeper/internal/server/storage"
+	"github.com/vkupriya/gophkeeper/internal/vault"
+)
+
+func (g *GophKeeperServer) CreateVault(ctx context.Context, in *pb.CreateVaultRequest) (*pb.Empty, error) {
+	logger := g.config.Logger
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Sugar().Error(msgMetadataNotFound)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
+	}
+	userid := md["userid"][0]
+
+	for _, name := range []string{in.GetOrg(), in.GetName()} {
+		if err := vault.ValidateName(name); err != nil {
+			logger.Sugar().Errorf("invalid vault from user %s: %v", userid, err)
+			return nil, fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, err.Error()))
+		}
+	}
+	if len(in.GetSealedKey()) == 0 {
+		logger.Sugar().Errorf("vault %s/%s from user %s has no key", in.GetOrg(), in.GetName(), userid)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, msgVaultBadRequest))
+	}
+
+	if err := g.Store.VaultCreate(ctx, userid, in.GetOrg(), in.GetName(), in.GetSealedKey()); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotOrgOwner):
+			logger.Sugar().Errorf("user %s is not owner of organization %s", userid, in.GetOrg())
+			return nil, fmt.Errorf(errFormat, status.Error(codes.PermissionDenied, msgOrgPermissionDenied))
+		case errors.Is(err, storage.ErrVaultAlreadyExists):
+			logger.Sugar().Errorf("vault %s/%s already exists", in.GetOrg(), in.GetName())
+			return nil, fmt.Errorf(errFormat, status.Error(codes.AlreadyExists, msgVaultAlreadyExists))
+		}
+		logger.Sugar().Errorf("failed to create vault %s/%s for user %s: %v", in.GetOrg(), in.GetName(), userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgVaultFailedToCreate))
+	}
+	return &pb.Empty{}, nil
+}
+
+func (g *GophKeeperServer) ListVaults(ctx context.Context, in *pb.Empty) (*pb.ListVaultsResponse, error) {
+	logger := g.config.Logger
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Sugar().Error(msgMetadataNotFound)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
+	}
+	userid := md["userid"][0]
+
+	vaults, err := g.Store.VaultList(ctx, userid)
+	if err != nil {
+		logger.Sugar().Errorf("failed to get vaults of user %s: %v", userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgVaultsFailedToGet))
+	}
+
+	response := &pb.ListVaultsResponse{}
+	for _, v := range *vaults {
+		response.Vaults = append(response.Vaults, &pb.Vault{
+			Org:  v.Org,
+			Name: v.Name,
+			Role: RoleToProto(v.Role),
+		})
+	}
+	return response, nil
+}
+
+func (g *GophKeeperServer) AddVaultMember(ctx context.Context, in *pb.VaultMemberRequest) (*pb.Empty, error) {
+	logger := g.config.Logger
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Sugar().Error(msgMetadataNotFound)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
+	}
+	userid := md["userid"][0]
+
+	// members of the vault get the vault key, members of the organization get keys of the vaults they are added to
+	if in.GetLogin() == "" || vault.IsPrincipal(in.GetLogin()) ||
+		(in.GetVault() == "") != (len(in.GetSealedKey()) == 0) {
+		logger.Sugar().Errorf("invalid member %q of vault %s/%s from user %s", in.GetLogin(), in.GetOrg(),
+			in.GetVault(), userid)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, msgVaultBadRequest))
+	}
+	if err := g.checkVaultRole(ctx, userid, in.GetOrg(), in.GetVault(), vault.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	err := g.Store.VaultMemberSet(ctx, in.GetOrg(), in.GetVault(), in.GetLogin(), ProtoToRole(in.GetRole()),
+		in.GetSealedKey())
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrVaultNotFound):
+			logger.Sugar().Errorf("vault %s/%s not found", in.GetOrg(), in.GetVault())
+			return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgVaultNotFound))
+		case errors.Is(err, storage.ErrUserNotFound):
+			logger.Sugar().Errorf("member %s of vault %s/%s not found", in.GetLogin(), in.GetOrg(), in.GetVault())
+			return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgUserNotFound))
+		case errors.Is(err, storage.ErrLastOwner):
+			logger.Sugar().Errorf("user %s tried to remove last owner of organization %s", userid, in.GetOrg())
+			return nil, fmt.Errorf(errFormat, status.Error(codes.FailedPrecondition, msgMemberLastOwner))
+		}
+		logger.Sugar().Errorf("failed to set member %s of vault %s/%s: %v", in.GetLogin(), in.GetOrg(),
+			in.GetVault(), err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgMemberFailedToSet))
+	}
+	return &pb.Empty{}, nil
+}
+
+func (g *GophKeeperServer) RemoveVaultMember(ctx context.Context, in *pb.VaultMemberRequest) (*pb.Empty, error) {
+	logger := g.config.Logger
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Sugar().Error(msgMetadataNotFound)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
+	}
+	userid := md["userid"][0]
+
+	if err := g.checkVaultRole(ctx, userid, in.GetOrg(), in.GetVault(), vault.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	if err := g.Store.VaultMemberDelete(ctx, in.GetOrg(), in.GetVault(), in.GetLogin()); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrMemberNotFound):
+			logger.Sugar().Errorf("member %s of vault %s/%s not found", in.GetLogin(), in.GetOrg(), in.GetVault())
+			return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMemberNotFound))
+		case errors.Is(err, storage.ErrLastOwner):
+			logger.Sugar().Errorf("user %s tried to remove last owner of organization %s", userid, in.GetOrg())
+			return nil, fmt.Errorf(errFormat, status.Error(codes.FailedPrecondition, msgMemberLastOwner))
+		}
+		logger.Sugar().Errorf("failed to remove member %s of vault %s/%s: %v", in.GetLogin(), in.GetOrg(),
+			in.GetVault(), err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgMemberFailedToRemove))
+	}
+	return &pb.Empty{}, nil
+}
+
+func (g *GophKeeperServer) ListVaultMembers(ctx context.Context,
+	in *pb.ListVaultMembersRequest) (*pb.ListVaultMembersResponse, error) {
+	logger := g.config.Logger
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Sugar().Error(msgMetadataNotFound)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
+	}
+	userid := md["userid"][0]
+
+	if err := g.checkVaultRole(ctx, userid, in.GetOrg(), in.GetVault(), vault.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	members, err := g.Store.VaultMemberList(ctx, in.GetOrg(), in.GetVault())
+	if err != nil {
+		logger.Sugar().Errorf("failed to get members of vault %s/%s: %v", in.GetOrg(), in.GetVault(), err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgMembersFailedToGet))
+	}
+
+	response := &pb.ListVaultMembersResponse{}
+	for _, m := range *members {
+		response.Members = append(response.Members, &pb.VaultMember{
+			Login: m.UserID,
+			Vault: m.Vault,
+			Role:  RoleToProto(m.Role),
+		})
+	}
+	return response, nil
+}
+
+func (g *GophKeeperServer) GetVaultKey(ctx context.Context, in *pb.Empty) (*pb.GetVaultKeyResponse, error) {
+	logger := g.config.Logger
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Sugar().Error(msgMetadataNotFound)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
+	}
+	userid := md["userid"][0]
+
+	org, name, err := g.metadataVault(md, userid)
+	if err != nil {
+		return nil, err
+	}
+
+	sealedKey, err := g.Store.VaultKey(ctx, userid, org, name)
+	if err != nil {
+		if errors.Is(err, storage.ErrVaultKeyNotFound) {
+			logger.Sugar().Errorf("user %s has no key of vault %s/%s", userid, org, name)
+			return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgVaultKeyNotFound))
+		}
+		logger.Sugar().Errorf("failed to get key of vault %s/%s for user %s: %v", org, name, userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgVaultKeyFailedToGet))
+	}
+	return &pb.GetVaultKeyResponse{SealedKey: sealedKey}, nil
+}
+
+// SetVaultKey replaces the vault key sealed for the caller, it is used when the caller changes the
+// master password and so the public key. Only a key the caller already has may be replaced.
+func (g *GophKeeperServer) SetVaultKey(ctx context.Context, in *pb.SetVaultKeyRequest) (*pb.Empty, error) {
+	logger := g.config.Logger
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Sugar().Error(msgMetadataNotFound)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgMetadataNotFound))
+	}
+	userid := md["userid"][0]
+
+	org, name, err := g.metadataVault(md, userid)
+	if err != nil {
+		return nil, err
+	}
+	if len(in.GetSealedKey()) == 0 {
+		logger.Sugar().Errorf("empty key of vault %s/%s from user %s", org, name, userid)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, msgVaultBadRequest))
+	}
+
+	if err = g.Store.VaultKeySet(ctx, userid, org, name, in.GetSealedKey()); err != nil {
+		if errors.Is(err, storage.ErrVaultKeyNotFound) {
+			logger.Sugar().Errorf("user %s has no key of vault %s/%s", userid, org, name)
+			return nil, fmt.Errorf(errFormat, status.Error(codes.NotFound, msgVaultKeyNotFound))
+		}
+		logger.Sugar().Errorf("failed to set key of vault %s/%s for user %s: %v", org, name, userid, err)
+		return nil, fmt.Errorf(errFormat, status.Error(codes.Internal, msgVaultKeyFailedToSet))
+	}
+	return &pb.Empty{}, nil
+}
+
+// metadataVault returns the vault set in metadata, it is resolved and checked by the interceptor,
+// the key of the user is not a vault key.
+func (g *GophKeeperServer) metadataVault(md metadata.MD, userid string) (string, string, error) {
+	logger := g.config.Logger
+
+	refs := md[vault.MetadataKey]
+	if len(refs) == 0 || refs[0] == "" {
+		logger.Sugar().Errorf("vault key call of user %s without vault", userid)
+		return "", "", fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, msgVaultBadRequest))
+	}
+	org, name, err := vault.Parse(refs[0])
+	if err != nil {
+		logger.Sugar().Errorf("invalid vault from user %s: %v", userid, err)
+		return "", "", fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, err.Error()))
+	}
+	return org, name, nil
+}
+
+// checkVaultRole checks that the user has the required role in the vault, empty vault is the organization.
+func (g *GophKeeperServer) checkVaultRole(ctx context.Context, userid string, org string, name string,
+	required vault.Role) error {
+	logger := g.config.Logger
+
+	if err := vault.ValidateName(org); err != nil {
+		logger.Sugar().Errorf("invalid organization from user %s: %v", userid, err)
+		return fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, err.Error()))
+	}
+
+	var role vault.Role
+	var err error
+	if name == "" {
+		role, err = g.Store.OrgRole(ctx, userid, org)
+	} else {
+		if err = vault.ValidateName(name); err != nil {
+			logger.Sugar().Errorf("invalid vault from user %s: %v", userid, err)
+			return fmt.Errorf(errFormat, status.Error(codes.InvalidArgument, err.Error()))
+		}
+		role, _, err = g.Store.VaultRole(ctx, userid, org, name)
+	}
+	if err != nil {
+		if errors.Is(err, storage.ErrVaultNotFound) {
+			logger.Sugar().Errorf("vault %s/%s not found", org, name)
+			return fmt.Errorf(errFormat, status.Error(codes.NotFound, msgVaultNotFound))
+		}
+		logger.Sugar().Errorf("failed to get role of user %s in vault %s/%s: %v", userid, org, name, err)
+		return fmt.Errorf(errFormat, status.Error(codes.Internal, msgVaultsFailedToGet))
+	}
+
+	if !role.Allows(required) {
+		logger.Sugar().Errorf("user %s has role %q in vault %s/%s, %s is required", userid, role, org, name, required)
+		return fmt.Errorf(errFormat, status.Error(codes.PermissionDenied, msgVaultPermissionDenied))
+	}
+	return nil
+}
+
+// RoleToProto converts vault role to protobuf enum.
+func RoleToProto(role vault.Role) pb.VaultRole {
+	switch role {
+	case vault.RoleOwner:
+		return pb.VaultRole_OWNER
+	case vault.RoleEditor:
+		return pb.VaultRole_EDITOR
+	default:
+		return pb.VaultRole_VIEWER
+	}
+}
+
+// ProtoToRole converts protobuf enum to vault role.
+func ProtoToRole(role pb.VaultRole) vault.Role {
+	switch role {
+	case pb.VaultRole_OWNER:
+		return vault.RoleOwner
+	case pb.VaultRole_EDITOR:
+		return vault.RoleEditor
+	default:
+		return vault.RoleViewer
+	}
+}
@@ -1,6 +1,10 @@
 package helpers
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"time"
@@ -10,22 +14,42 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
-func CreateJWTString(c *models.Config, userid string) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, models.Claims{
+// CreateJWTString returns signed access token and its claims, every token has unique ID
+// which is used for token revocation.
+func CreateJWTString(c *models.Config, userid string) (string, *models.Claims, error) {
+	jti, err := newTokenID()
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := time.Now()
+	claims := &models.Claims{
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(c.JWTTokenTTL)),
+			ID:        jti,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(c.JWTTokenTTL)),
 		},
 		UserID: userid,
-	})
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
 	// создаём строку токена
 	tokenString, err := token.SignedString([]byte(c.JWTKey))
 	if err != nil {
-		return "", fmt.Errorf("failed to sign token: %w", err)
+		return "", nil, fmt.Errorf("failed to sign token: %w", err)
 	}
 
 	// возвращаем строку токена
-	return tokenString, nil
+	return tokenString, claims, nil
+}
+
+func newTokenID() (string, error) {
+	const tokenIDSize = 16
+	b := make([]byte, tokenIDSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating random bytes: %w", err)
+	}
+	return hex.EncodeToString(b), nil
 }
 
 func ValidateJWT(c *models.Config, tokenString string) (*models.Claims, error) {
@@ -43,9 +67,29 @@ func ValidateJWT(c *models.Config, tokenString string) (*models.Claims, error) {
 	if !token.Valid {
 		return nil, errors.New("token is invalid")
 	}
+	if claims.ID == "" {
+		return nil, errors.New("token has no ID")
+	}
 	return claims, nil
 }
 
+// CreateRefreshToken returns random refresh token and its hash, only hash is kept in DB.
+func CreateRefreshToken() (string, string, error) {
+	const refreshTokenSize = 32
+	b := make([]byte, refreshTokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("error generating random bytes: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, HashToken(token), nil
+}
+
+// HashToken returns hex encoded sha256 of the refresh token.
+func HashToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
 func HashPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
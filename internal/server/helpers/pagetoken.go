@@ -0,0 +1,60 @@
+package helpers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+)
+
+// Page size limits of ListSecrets.
+const (
+	DefaultPageSize = 100
+	MaxPageSize     = 1000
+)
+
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// PageSize returns page size requested by the client bounded by MaxPageSize, DefaultPageSize
+// is used if it is not set.
+func PageSize(size int32) int {
+	switch {
+	case size <= 0:
+		return DefaultPageSize
+	case size > MaxPageSize:
+		return MaxPageSize
+	default:
+		return int(size)
+	}
+}
+
+// EncodePageToken returns opaque page token holding the cursor.
+func EncodePageToken(cursor models.SecretCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal page cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodePageToken returns the cursor of the page token, the token must be issued for the same
+// sort order.
+func DecodePageToken(token string, sort models.SecretSort) (*models.SecretCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPageToken, err)
+	}
+	var cursor models.SecretCursor
+	if err = json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPageToken, err)
+	}
+	if cursor.Name == "" {
+		return nil, fmt.Errorf("%w: empty cursor", ErrInvalidPageToken)
+	}
+	if cursor.Sort != sort {
+		return nil, fmt.Errorf("%w: token was issued for another sort order", ErrInvalidPageToken)
+	}
+	return &cursor, nil
+}
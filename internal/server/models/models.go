@@ -5,15 +5,63 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 	"go.uber.org/zap"
+
+	"github.com/vkupriya/gophkeeper/internal/labels"
+	"github.com/vkupriya/gophkeeper/internal/vault"
+)
+
+// Storage backends of the server, data of the memory backend is lost when the server stops.
+const (
+	BackendPostgres = "postgres"
+	BackendSQLite   = "sqlite"
+	BackendMemory   = "memory"
 )
 
+// Blob stores keeping data of large file and binary secrets.
+const (
+	BlobFile = "file"
+	BlobS3   = "s3"
+)
+
+// EncVersionChunked is encryption version of secrets uploaded in chunks, their data is kept
+// in chunks or in the blob store rather than in Secret.Data.
+const EncVersionChunked int32 = 2
+
 type Config struct {
-	Logger         *zap.Logger
-	Address        string
-	PostgresDSN    string
-	JWTKey         string
-	JWTTokenTTL    time.Duration
-	ContextTimeout time.Duration
+	Logger          *zap.Logger
+	Address         string
+	Backend         string
+	PostgresDSN     string
+	SQLitePath      string
+	JWTKey          string
+	JWTTokenTTL     time.Duration
+	RefreshTokenTTL time.Duration
+	ContextTimeout  time.Duration
+	// RevocationRefresh is an interval of revoked tokens cache reload from DB.
+	RevocationRefresh time.Duration
+	// TLSCertFile and TLSKeyFile enable TLS on gRPC server.
+	TLSCertFile string
+	TLSKeyFile  string
+	// TLSClientCAFile enables verification of client certificates (mTLS).
+	TLSClientCAFile string
+	// AuditCheckpointFile receives audit log checkpoints signed with the ed25519 key
+	// from AuditKeyFile every AuditCheckpointInterval.
+	AuditCheckpointFile     string
+	AuditKeyFile            string
+	AuditCheckpointInterval time.Duration
+	// BlobBackend keeps data of file and binary secrets larger than BlobThreshold bytes in
+	// the blob store encrypted with the key from BlobKeyFile, data of all secrets is kept
+	// in DB if it is empty. BlobDir is the directory of file blob store.
+	BlobBackend   string
+	BlobThreshold int64
+	BlobKeyFile   string
+	BlobDir       string
+	// S3Endpoint, S3Region and S3Bucket locate the bucket of s3 blob store.
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
 }
 
 type User struct {
@@ -26,19 +74,160 @@ type Claims struct {
 	jwt.RegisteredClaims
 }
 
+// RevokedTokens maps IDs of revoked access tokens to their expiration time.
+type RevokedTokens map[string]time.Time
+
 type Secret struct {
-	UserID  string
-	Name    string
-	Type    string
-	Meta    string
-	Data    []byte
-	Version int64
+	UserID     string
+	Name       string
+	Type       string
+	Labels     map[string]string
+	Data       []byte
+	Version    int64
+	EncVersion int32
+	// BlobRef refers to blob store objects keeping data of large file and binary secrets,
+	// BlobHash is SHA-256 hash of the data.
+	BlobRef  string
+	BlobHash []byte
+	// Hash is SHA-256 hash of the data as stored: of Data followed by chunks or of the blob.
+	// It is nil for secrets saved before hashes were introduced.
+	Hash []byte
 }
 
 type SecretList []SecretItem
 
 type SecretItem struct {
-	Name    string
-	Type    string
-	Version int64
+	UpdatedAt  time.Time
+	Labels     map[string]string
+	Name       string
+	Type       string
+	Version    int64
+	EncVersion int32
+}
+
+type SecretSearchList []SecretSearchItem
+
+// SecretSearchItem is a secret found by search with its relevance.
+type SecretSearchItem struct {
+	SecretItem
+	Rank float64
+}
+
+// SecretSort is an order of secrets in ListSecrets.
+type SecretSort int
+
+const (
+	SortNameAsc SecretSort = iota
+	SortNameDesc
+	SortUpdatedDesc
+	SortUpdatedAsc
+)
+
+// SecretCursor is the position of the last secret of a page, the next page starts after it.
+type SecretCursor struct {
+	UpdatedAt time.Time  `json:"updated_at,omitempty"`
+	Name      string     `json:"name"`
+	Sort      SecretSort `json:"sort"`
+}
+
+// SecretFilter selects secrets in ListSecrets.
+type SecretFilter struct {
+	// After is set to get the page which follows the cursor.
+	After    *SecretCursor
+	Selector labels.Selector
+	// Folder is a name prefix ending with '/', empty folder is the root one.
+	Folder string
+	// Types limits secret types, secrets of any type are listed if it is empty.
+	Types     []string
+	Sort      SecretSort
+	Limit     int
+	Recursive bool
+}
+
+// Access levels of shared secrets.
+const (
+	AccessReadOnly  = "ro"
+	AccessReadWrite = "rw"
+)
+
+type ShareList []Share
+
+// Share is a secret shared by its owner with the recipient, Data is sealed with the recipient public key.
+type Share struct {
+	SharedAt       time.Time
+	Labels         map[string]string
+	Owner          string
+	Name           string
+	Recipient      string
+	Access         string
+	Type           string
+	Data           []byte
+	Version        int64
+	CurrentVersion int64
+	EncVersion     int32
+}
+
+type VaultList []Vault
+
+// Vault is a team vault with the role of the user in it.
+type Vault struct {
+	Org  string
+	Name string
+	Role vault.Role
+}
+
+type VaultMemberList []VaultMember
+
+// VaultMember is a member of the vault, empty Vault is membership in the organization.
+type VaultMember struct {
+	UserID string
+	Vault  string
+	Role   vault.Role
+}
+
+type SecretVersionList []SecretVersionItem
+
+type SecretVersionItem struct {
+	CreatedAt time.Time
+	Type      string
+	Version   int64
+}
+
+type AuditEventList []AuditEvent
+
+// AuditEvent is a secret access or mutation, Owner is the user or the vault principal.
+type AuditEvent struct {
+	CreatedAt time.Time
+	UserID    string
+	Owner     string
+	Method    string
+	Name      string
+	ClientIP  string
+	Code      string
+	ID        int64
+}
+
+// AuditRecord is an audit event chained to the previous one, Hash covers PrevHash and the event.
+// Events written before the chain was introduced have no hashes.
+type AuditRecord struct {
+	PrevHash []byte
+	Hash     []byte
+	AuditEvent
+}
+
+// AuditVerification is a result of the audit log hash chain check, BrokenID is the first event
+// whose link is broken or 0 if the chain is intact.
+type AuditVerification struct {
+	Reason      string
+	Verified    int64
+	Unchained   int64
+	BrokenID    int64
+	Checkpoints int
+}
+
+// AuditFilter selects events of the user in [From, To), zero time is not limited.
+type AuditFilter struct {
+	From  time.Time
+	To    time.Time
+	Limit int
 }
@@ -0,0 +1,98 @@
+package revocation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+)
+
+type Storage interface {
+	TokenRevokedList(ctx context.Context) (models.RevokedTokens, error)
+	TokensDeleteExpired(ctx context.Context) error
+}
+
+// Cache keeps IDs of revoked access tokens in memory, so that AuthInterceptor
+// does not query DB on every call. Revoked tokens are stored in DB and the cache
+// is reloaded periodically to pick up revocations made by other server instances.
+type Cache struct {
+	revoked models.RevokedTokens
+	mu      sync.RWMutex
+}
+
+func NewCache() *Cache {
+	return &Cache{
+		revoked: models.RevokedTokens{},
+	}
+}
+
+// Add puts revoked tokens into the cache.
+func (r *Cache) Add(tokens models.RevokedTokens) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for jti, expiresAt := range tokens {
+		r.revoked[jti] = expiresAt
+	}
+}
+
+// IsRevoked reports whether token with given ID has been revoked.
+func (r *Cache) IsRevoked(jti string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.revoked[jti]
+	return ok
+}
+
+// Load replaces the cache content with revoked tokens from DB.
+func (r *Cache) Load(ctx context.Context, s Storage) error {
+	revoked, err := s.TokenRevokedList(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load revoked tokens: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// keep tokens revoked by this instance while the query was running
+	now := time.Now()
+	for jti, expiresAt := range r.revoked {
+		if _, ok := revoked[jti]; !ok && expiresAt.After(now) {
+			revoked[jti] = expiresAt
+		}
+	}
+	r.revoked = revoked
+	return nil
+}
+
+// Run reloads the cache and removes expired tokens from DB until context is done.
+func (r *Cache) Run(ctx context.Context, c *models.Config, s Storage) {
+	ticker := time.NewTicker(c.RevocationRefresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.refresh(ctx, c, s)
+		}
+	}
+}
+
+// refresh runs a single reload, DB queries are limited by c.ContextTimeout.
+func (r *Cache) refresh(ctx context.Context, c *models.Config, s Storage) {
+	logger := c.Logger
+	ctx, cancel := context.WithTimeout(ctx, c.ContextTimeout)
+	defer cancel()
+
+	if err := s.TokensDeleteExpired(ctx); err != nil {
+		logger.Sugar().Errorf("failed to delete expired tokens: %v", err)
+	}
+	if err := r.Load(ctx, s); err != nil {
+		logger.Sugar().Errorf("failed to reload revoked tokens: %v", err)
+	}
+}
@@ -8,8 +8,10 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/vkupriya/gophkeeper/internal/server/blobstore"
 	"github.com/vkupriya/gophkeeper/internal/server/config"
 	grpcserver "github.com/vkupriya/gophkeeper/internal/server/grpc"
+	"github.com/vkupriya/gophkeeper/internal/server/models"
 	"github.com/vkupriya/gophkeeper/internal/server/storage"
 
 	"go.uber.org/zap"
@@ -39,15 +41,20 @@ func Start(logger *zap.Logger) error {
 		logger.Sugar().Error("failed to gracefully shutdown the service")
 	})
 
-	s, err := storage.NewPostgresDB(cfg.PostgresDSN)
+	s, err := newStorage(cfg)
 	if err != nil {
-		return fmt.Errorf("failed to initialize PostgresDB: %w", err)
+		return err
+	}
+
+	blobs, err := newBlobs(cfg)
+	if err != nil {
+		return err
 	}
 
 	g.Go(func() error {
 		defer logger.Sugar().Info("closed GRPC server")
 
-		if err := grpcserver.Run(ctx, s, cfg); err != nil {
+		if err := grpcserver.Run(ctx, s, blobs, cfg); err != nil {
 			return fmt.Errorf("failed to run grpc server: %w", err)
 		}
 
@@ -55,7 +62,7 @@ func Start(logger *zap.Logger) error {
 	})
 
 	g.Go(func() error {
-		defer logger.Sugar().Info("closed Postgres DB")
+		defer logger.Sugar().Infof("closed %s storage", cfg.Backend)
 
 		<-ctx.Done()
 
@@ -67,3 +74,67 @@ func Start(logger *zap.Logger) error {
 	}
 	return nil
 }
+
+// closableStorage is a storage backend which is closed when the server stops.
+type closableStorage interface {
+	grpcserver.Storage
+	Close()
+}
+
+// newStorage opens storage backend selected in the config.
+func newStorage(cfg *models.Config) (closableStorage, error) {
+	switch cfg.Backend {
+	case models.BackendSQLite:
+		s, err := storage.NewSQLiteDB(cfg.SQLitePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize SQLiteDB: %w", err)
+		}
+		return s, nil
+	case models.BackendMemory:
+		cfg.Logger.Sugar().Warn("using in-memory storage, data is lost when the server stops")
+		return storage.NewMemoryDB(), nil
+	default:
+		s, err := storage.NewPostgresDB(cfg.PostgresDSN)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize PostgresDB: %w", err)
+		}
+		return s, nil
+	}
+}
+
+// newBlobs opens blob store selected in the config, nil is returned if it is not configured.
+func newBlobs(cfg *models.Config) (*blobstore.Blobs, error) {
+	var store blobstore.Store
+	switch cfg.BlobBackend {
+	case models.BlobFile:
+		s, err := blobstore.NewFileStore(cfg.BlobDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize file blob store: %w", err)
+		}
+		store = s
+	case models.BlobS3:
+		s, err := blobstore.NewS3Store(blobstore.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize s3 blob store: %w", err)
+		}
+		store = s
+	default:
+		return nil, nil
+	}
+
+	key, err := blobstore.LoadKey(cfg.BlobKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	blobs, err := blobstore.New(store, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize blob store: %w", err)
+	}
+	return blobs, nil
+}
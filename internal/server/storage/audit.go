@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/vkupriya/gophkeeper/internal/server/auditlog"
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+)
+
+// auditChainLock is the advisory lock key serializing writers of the audit chain.
+const auditChainLock int64 = 0x617564697463
+
+// AuditAdd appends the event to the audit log chained to the last event by hash.
+func (p *PostgresDB) AuditAdd(ctx context.Context, event *models.AuditEvent) error {
+	db := p.pool
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	// the last event must not change until the event chained to it is stored
+	if _, err = tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", auditChainLock); err != nil {
+		return fmt.Errorf("failed to lock audit log: %w", err)
+	}
+	var prev []byte
+	err = tx.QueryRow(ctx, "SELECT hash FROM audit_events ORDER BY id DESC LIMIT 1").Scan(&prev)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to query last audit event: %w", err)
+	}
+	if prev == nil {
+		prev = auditlog.Genesis
+	}
+
+	e := *event
+	e.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	querySQL := "INSERT INTO audit_events (userid, owner, method, name, client_ip, code, created_at, prev_hash, hash) " +
+		"VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)"
+	_, err = tx.Exec(ctx, querySQL, e.UserID, e.Owner, e.Method, e.Name, e.ClientIP, e.Code, e.CreatedAt, prev,
+		auditlog.Hash(prev, &e))
+	if err != nil {
+		return fmt.Errorf("failed to insert audit event %s of user %s: %w", event.Method, event.UserID, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit audit event %s of user %s: %w", event.Method, event.UserID, err)
+	}
+	return nil
+}
+
+// AuditChain returns up to limit events following the event afterID in the order of IDs.
+func (p *PostgresDB) AuditChain(ctx context.Context, afterID int64, limit int) ([]models.AuditRecord, error) {
+	db := p.pool
+	records := []models.AuditRecord{}
+
+	querySQL := "SELECT id, userid, owner, method, name, client_ip, code, created_at, prev_hash, hash " +
+		"FROM audit_events WHERE id > $1 ORDER BY id LIMIT $2"
+	rows, err := db.Query(ctx, querySQL, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error querying audit chain: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r models.AuditRecord
+		err = rows.Scan(&r.ID, &r.UserID, &r.Owner, &r.Method, &r.Name, &r.ClientIP, &r.Code, &r.CreatedAt,
+			&r.PrevHash, &r.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit event: %w", err)
+		}
+		records = append(records, r)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan audit events: %w", err)
+	}
+	return records, nil
+}
+
+// AuditChainStart returns ID of the first event written after the audit chain was introduced,
+// every event from it must be chained.
+func (p *PostgresDB) AuditChainStart(ctx context.Context) (int64, error) {
+	db := p.pool
+	var id int64
+
+	if err := db.QueryRow(ctx, "SELECT id FROM audit_chain_start").Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to query start of audit chain: %w", err)
+	}
+	return id, nil
+}
+
+// AuditHead returns ID and hash of the last event, nil is returned if the audit log is empty.
+func (p *PostgresDB) AuditHead(ctx context.Context) (*models.AuditRecord, error) {
+	db := p.pool
+	var r models.AuditRecord
+
+	err := db.QueryRow(ctx, "SELECT id, hash FROM audit_events ORDER BY id DESC LIMIT 1").Scan(&r.ID, &r.Hash)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to query last audit event: %w", err)
+	}
+	return &r, nil
+}
+
+// AuditList returns events of the user, newest first.
+func (p *PostgresDB) AuditList(ctx context.Context, userid string, filter models.AuditFilter) (*models.AuditEventList,
+	error) {
+	db := p.pool
+	events := models.AuditEventList{}
+
+	querySQL := "SELECT id, userid, owner, method, name, client_ip, code, created_at FROM audit_events WHERE userid=$1"
+	args := []any{userid}
+	if !filter.From.IsZero() {
+		args = append(args, filter.From)
+		querySQL += fmt.Sprintf(" AND created_at >= $%d", len(args))
+	}
+	if !filter.To.IsZero() {
+		args = append(args, filter.To)
+		querySQL += fmt.Sprintf(" AND created_at < $%d", len(args))
+	}
+	args = append(args, filter.Limit)
+	querySQL += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", len(args))
+
+	rows, err := db.Query(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying audit events: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e models.AuditEvent
+		err = rows.Scan(&e.ID, &e.UserID, &e.Owner, &e.Method, &e.Name, &e.ClientIP, &e.Code, &e.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit event: %w", err)
+		}
+		events = append(events, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan audit events: %w", err)
+	}
+	return &events, nil
+}
@@ -0,0 +1,955 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/vkupriya/gophkeeper/internal/labels"
+	"github.com/vkupriya/gophkeeper/internal/server/auditlog"
+	grpcserver "github.com/vkupriya/gophkeeper/internal/server/grpc"
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+	"github.com/vkupriya/gophkeeper/internal/server/storage"
+	"github.com/vkupriya/gophkeeper/internal/vault"
+)
+
+// TestConformance runs the same checks against every storage backend, so the server behaves the same
+// with any of them. PostgreSQL backend is checked only if DATABASE_URI is set.
+func TestConformance(t *testing.T) {
+	backends := []struct {
+		open func(t *testing.T) grpcserver.Storage
+		name string
+	}{
+		{
+			name: models.BackendMemory,
+			open: func(t *testing.T) grpcserver.Storage {
+				return storage.NewMemoryDB()
+			},
+		},
+		{
+			name: models.BackendSQLite,
+			open: func(t *testing.T) grpcserver.Storage {
+				s, err := storage.NewSQLiteDB(filepath.Join(t.TempDir(), "gophkeeper.db"))
+				if err != nil {
+					t.Fatalf("failed to open SQLite DB: %v", err)
+				}
+				t.Cleanup(s.Close)
+				return s
+			},
+		},
+		{
+			name: models.BackendPostgres,
+			open: func(t *testing.T) grpcserver.Storage {
+				dsn := os.Getenv("DATABASE_URI")
+				if dsn == "" {
+					t.Skip("DATABASE_URI is not set")
+				}
+				s, err := storage.NewPostgresDB(dsn)
+				if err != nil {
+					t.Fatalf("failed to open Postgres DB: %v", err)
+				}
+				t.Cleanup(s.Close)
+				return s
+			},
+		},
+	}
+	tests := []struct {
+		test func(t *testing.T, s grpcserver.Storage)
+		name string
+	}{
+		{name: "Users", test: testUsers},
+		{name: "Tokens", test: testTokens},
+		{name: "Secrets", test: testSecrets},
+		{name: "SecretStream", test: testSecretStream},
+		{name: "SecretBlobs", test: testSecretBlobs},
+		{name: "SecretHashes", test: testSecretHashes},
+		{name: "SecretReplaceVersions", test: testSecretReplaceVersions},
+		{name: "SecretRollback", test: testSecretRollback},
+		{name: "SecretList", test: testSecretList},
+		{name: "Shares", test: testShares},
+		{name: "Vaults", test: testVaults},
+		{name: "Audit", test: testAudit},
+		{name: "Cancel", test: testCancel},
+	}
+
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			s := b.open(t)
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					tt.test(t, s)
+				})
+			}
+		})
+	}
+}
+
+var ctx = context.Background()
+
+// addUser registers a user with a random name, so tests do not collide in a shared database.
+func addUser(t *testing.T, s grpcserver.Storage) string {
+	t.Helper()
+	userid := randomName("user-")
+	if err := s.UserAdd(ctx, models.User{UserID: userid, Password: "hash"}); err != nil {
+		t.Fatalf("failed to add user: %v", err)
+	}
+	return userid
+}
+
+// randomName returns prefix followed by random hex digits.
+func randomName(prefix string) string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return prefix + hex.EncodeToString(b)
+}
+
+// addSecret adds the secret and fails the test on error.
+func addSecret(t *testing.T, s grpcserver.Storage, userid string, secret *models.Secret) {
+	t.Helper()
+	if err := s.SecretAdd(ctx, userid, secret); err != nil {
+		t.Fatalf("failed to add secret %s: %v", secret.Name, err)
+	}
+}
+
+func wantErr(t *testing.T, want error, got error) {
+	t.Helper()
+	if !errors.Is(got, want) {
+		t.Errorf("Err -> \nWant: %v\nGot : %v", want, got)
+	}
+}
+
+func testUsers(t *testing.T, s grpcserver.Storage) {
+	userid := addUser(t, s)
+
+	wantErr(t, storage.ErrUserAlreadyExists, s.UserAdd(ctx, models.User{UserID: userid, Password: "other"}))
+	u, err := s.UserGet(ctx, userid)
+	if err != nil || u.UserID != userid || u.Password != "hash" {
+		t.Errorf("Out -> \nWant: %s hash\nGot : %+v %v", userid, u, err)
+	}
+	_, err = s.UserGet(ctx, userid+"-missing")
+	wantErr(t, storage.ErrUserNotFound, err)
+
+	_, err = s.UserGetPublicKey(ctx, userid)
+	wantErr(t, storage.ErrPublicKeyNotFound, err)
+	wantErr(t, storage.ErrUserNotFound, s.UserSetPublicKey(ctx, userid+"-missing", []byte("key")))
+	if err = s.UserSetPublicKey(ctx, userid, []byte("key")); err != nil {
+		t.Fatalf("failed to set public key: %v", err)
+	}
+	key, err := s.UserGetPublicKey(ctx, userid)
+	if err != nil || string(key) != "key" {
+		t.Errorf("Out -> \nWant: key\nGot : %q %v", key, err)
+	}
+}
+
+func testTokens(t *testing.T, s grpcserver.Storage) {
+	userid := addUser(t, s)
+	expiresAt := time.Now().Add(time.Hour)
+
+	refresh := randomName("refresh-")
+	if err := s.RefreshTokenAdd(ctx, userid, refresh, expiresAt); err != nil {
+		t.Fatalf("failed to add refresh token: %v", err)
+	}
+	got, err := s.RefreshTokenUse(ctx, refresh)
+	if err != nil || got != userid {
+		t.Errorf("Out -> \nWant: %s\nGot : %s %v", userid, got, err)
+	}
+	_, err = s.RefreshTokenUse(ctx, refresh)
+	wantErr(t, storage.ErrTokenNotFound, err)
+
+	expired := randomName("expired-")
+	if err = s.RefreshTokenAdd(ctx, userid, expired, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("failed to add refresh token: %v", err)
+	}
+	_, err = s.RefreshTokenUse(ctx, expired)
+	wantErr(t, storage.ErrTokenNotFound, err)
+	if err = s.RefreshTokenDelete(ctx, expired); err != nil {
+		t.Errorf("failed to delete refresh token: %v", err)
+	}
+	wantErr(t, storage.ErrTokenNotFound, s.RefreshTokenDelete(ctx, expired))
+
+	first, second := randomName("jti-"), randomName("jti-")
+	for _, jti := range []string{first, second} {
+		if err = s.TokenAdd(ctx, userid, jti, expiresAt); err != nil {
+			t.Fatalf("failed to add access token: %v", err)
+		}
+	}
+	if err = s.TokenRevoke(ctx, userid, first); err != nil {
+		t.Fatalf("failed to revoke access token: %v", err)
+	}
+	revoked, err := s.TokenRevokedList(ctx)
+	if err != nil {
+		t.Fatalf("failed to list revoked tokens: %v", err)
+	}
+	if _, ok := revoked[first]; !ok {
+		t.Errorf("revoked token %s is not listed", first)
+	}
+	if _, ok := revoked[second]; ok {
+		t.Errorf("active token %s is listed as revoked", second)
+	}
+
+	if err = s.RefreshTokenAdd(ctx, userid, refresh, expiresAt); err != nil {
+		t.Fatalf("failed to add refresh token: %v", err)
+	}
+	revoked, err = s.TokenRevokeAll(ctx, userid)
+	if err != nil {
+		t.Fatalf("failed to revoke tokens: %v", err)
+	}
+	if _, ok := revoked[second]; !ok {
+		t.Errorf("Out -> \nWant: %s revoked\nGot : %v", second, revoked)
+	}
+	_, err = s.RefreshTokenUse(ctx, refresh)
+	wantErr(t, storage.ErrTokenNotFound, err)
+
+	if err = s.TokensDeleteExpired(ctx); err != nil {
+		t.Errorf("failed to delete expired tokens: %v", err)
+	}
+}
+
+func testSecrets(t *testing.T, s grpcserver.Storage) {
+	userid := addUser(t, s)
+
+	secret := &models.Secret{Name: "bank/card", Type: "card", Labels: map[string]string{"env": "prod"},
+		Data: []byte("v1"), EncVersion: 1}
+	addSecret(t, s, userid, secret)
+	if secret.Version != 1 {
+		t.Errorf("Out -> \nWant: version 1\nGot : %d", secret.Version)
+	}
+	wantErr(t, storage.ErrSecretAlreadyExists,
+		s.SecretAdd(ctx, userid, &models.Secret{Name: "bank/card", Data: []byte("v")}))
+
+	update := &models.Secret{Name: "bank/card", Labels: map[string]string{"env": "dev"}, Data: []byte("v2"),
+		Version: 1, EncVersion: 1}
+	if err := s.SecretUpdate(ctx, userid, update); err != nil {
+		t.Fatalf("failed to update secret: %v", err)
+	}
+	if update.Version != 2 || update.Type != "card" {
+		t.Errorf("Out -> \nWant: version 2 of card\nGot : %d %s", update.Version, update.Type)
+	}
+	stale := &models.Secret{Name: "bank/card", Data: []byte("v3"), Version: 1}
+	wantErr(t, storage.ErrVersionConflict, s.SecretUpdate(ctx, userid, stale))
+	// there is no unconditional update
+	wantErr(t, storage.ErrVersionConflict,
+		s.SecretUpdate(ctx, userid, &models.Secret{Name: "bank/card", Data: []byte("v3")}))
+	wantErr(t, storage.ErrSecretNotFound, s.SecretUpdate(ctx, userid, &models.Secret{Name: "missing"}))
+
+	got, err := s.SecretGet(ctx, userid, "bank/card")
+	if err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	hash := sha256.Sum256([]byte("v2"))
+	want := &models.Secret{UserID: userid, Name: "bank/card", Type: "card", Labels: map[string]string{"env": "dev"},
+		Data: []byte("v2"), Version: 2, EncVersion: 1, Hash: hash[:]}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Out -> \nWant: %+v\nGot : %+v", want, got)
+	}
+	_, err = s.SecretGet(ctx, userid, "missing")
+	wantErr(t, storage.ErrSecretNotFound, err)
+
+	got, err = s.SecretGetVersion(ctx, userid, "bank/card", 1)
+	if err != nil || string(got.Data) != "v1" || got.Labels["env"] != "prod" {
+		t.Errorf("Out -> \nWant: version 1\nGot : %+v %v", got, err)
+	}
+	_, err = s.SecretGetVersion(ctx, userid, "bank/card", 3)
+	wantErr(t, storage.ErrSecretNotFound, err)
+
+	versions, err := s.SecretVersionList(ctx, userid, "bank/card")
+	if err != nil || len(*versions) != 2 || (*versions)[0].Version != 1 || (*versions)[1].Version != 2 {
+		t.Errorf("Out -> \nWant: versions 1 and 2\nGot : %+v %v", versions, err)
+	}
+	_, err = s.SecretVersionList(ctx, userid, "missing")
+	wantErr(t, storage.ErrSecretNotFound, err)
+
+	// history follows the moved secret
+	addSecret(t, s, userid, &models.Secret{Name: "bank/pin", Type: "text", Data: []byte("pin")})
+	addSecret(t, s, userid, &models.Secret{Name: "archive/pin", Type: "text", Data: []byte("old")})
+	n, err := s.SecretMove(ctx, userid, "bank/", "cards/")
+	if err != nil || n != 2 {
+		t.Fatalf("Out -> \nWant: 2 moved\nGot : %d %v", n, err)
+	}
+	got, err = s.SecretGetVersion(ctx, userid, "cards/card", 1)
+	if err != nil || string(got.Data) != "v1" {
+		t.Errorf("Out -> \nWant: version 1 of moved secret\nGot : %+v %v", got, err)
+	}
+	if n, err = s.SecretMove(ctx, userid, "cards/pin", "archive/pin"); !errors.Is(err, storage.ErrSecretAlreadyExists) {
+		t.Errorf("Err -> \nWant: %v\nGot : %d %v", storage.ErrSecretAlreadyExists, n, err)
+	}
+	_, err = s.SecretMove(ctx, userid, "bank/", "other/")
+	wantErr(t, storage.ErrSecretNotFound, err)
+
+	if _, err = s.SecretDelete(ctx, userid, "cards/card"); err != nil {
+		t.Fatalf("failed to delete secret: %v", err)
+	}
+	_, err = s.SecretDelete(ctx, userid, "cards/card")
+	wantErr(t, storage.ErrSecretNotFound, err)
+	_, err = s.SecretGetVersion(ctx, userid, "cards/card", 1)
+	wantErr(t, storage.ErrSecretNotFound, err)
+}
+
+func testSecretStream(t *testing.T, s grpcserver.Storage) {
+	userid := addUser(t, s)
+	chunks := [][]byte{[]byte("first "), []byte("second "), []byte("third")}
+
+	next := func(chunks [][]byte, fail error) func() ([]byte, error) {
+		return func() ([]byte, error) {
+			if len(chunks) == 0 {
+				return nil, fail
+			}
+			chunk := chunks[0]
+			chunks = chunks[1:]
+			return chunk, nil
+		}
+	}
+	read := func(version int64) []byte {
+		var data []byte
+		err := s.SecretChunks(ctx, userid, "file", version, func(chunk []byte) error {
+			data = append(data, chunk...)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("failed to read chunks: %v", err)
+		}
+		return data
+	}
+
+	secret := &models.Secret{Name: "file", Type: "file", EncVersion: 1}
+	if err := s.SecretSaveStream(ctx, userid, secret, false, next(chunks, io.EOF)); err != nil {
+		t.Fatalf("failed to save secret: %v", err)
+	}
+	if got := read(1); !bytes.Equal(got, bytes.Join(chunks, nil)) {
+		t.Errorf("Out -> \nWant: %q\nGot : %q", bytes.Join(chunks, nil), got)
+	}
+
+	// interrupted upload leaves no trace
+	broken := errors.New("stream broken")
+	secret = &models.Secret{Name: "file", Version: 1, EncVersion: 1}
+	wantErr(t, broken, s.SecretSaveStream(ctx, userid, secret, true, next(chunks[:1], broken)))
+	if got, err := s.SecretGet(ctx, userid, "file"); err != nil || got.Version != 1 {
+		t.Errorf("Out -> \nWant: version 1\nGot : %+v %v", got, err)
+	}
+	wantErr(t, storage.ErrSecretAlreadyExists,
+		s.SecretSaveStream(ctx, userid, &models.Secret{Name: "file"}, false, next(nil, io.EOF)))
+
+	secret = &models.Secret{Name: "file", Version: 1, EncVersion: 1}
+	if err := s.SecretSaveStream(ctx, userid, secret, true, next(chunks[2:], io.EOF)); err != nil {
+		t.Fatalf("failed to update secret: %v", err)
+	}
+	if secret.Version != 2 || string(read(2)) != "third" || !bytes.Equal(read(1), bytes.Join(chunks, nil)) {
+		t.Errorf("Out -> \nWant: version 2 with the last chunk\nGot : %d %q", secret.Version, read(2))
+	}
+	if got := read(3); len(got) != 0 {
+		t.Errorf("missing version must have no chunks, got %q", got)
+	}
+}
+
+func testSecretList(t *testing.T, s grpcserver.Storage) {
+	userid := addUser(t, s)
+	for _, secret := range []*models.Secret{
+		{Name: "web/mail", Type: "credentials", Labels: map[string]string{"env": "prod"}, Data: []byte("1")},
+		{Name: "web/shop/login", Type: "credentials", Labels: map[string]string{"env": "dev"}, Data: []byte("2")},
+		{Name: "bank/visa", Type: "card", Labels: map[string]string{"env": "prod", "team": "ops"}, Data: []byte("3")},
+		{Name: "notes", Type: "text", Data: []byte("4")},
+	} {
+		addSecret(t, s, userid, secret)
+	}
+
+	names := func(filter models.SecretFilter) []string {
+		t.Helper()
+		list, err := s.SecretList(ctx, userid, filter)
+		if errors.Is(err, storage.ErrNoSecrets) {
+			return nil
+		}
+		if err != nil {
+			t.Fatalf("failed to list secrets: %v", err)
+		}
+		result := make([]string, 0, len(*list))
+		for _, item := range *list {
+			result = append(result, item.Name)
+		}
+		return result
+	}
+	selector := func(s string) labels.Selector {
+		t.Helper()
+		selector, err := labels.ParseSelector(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return selector
+	}
+
+	tests := []struct {
+		name   string
+		want   []string
+		filter models.SecretFilter
+	}{
+		{name: "Root", filter: models.SecretFilter{}, want: []string{"notes"}},
+		{
+			name:   "Recursive",
+			filter: models.SecretFilter{Recursive: true},
+			want:   []string{"bank/visa", "notes", "web/mail", "web/shop/login"},
+		},
+		{name: "Folder", filter: models.SecretFilter{Folder: "web/"}, want: []string{"web/mail"}},
+		{
+			name:   "Labels",
+			filter: models.SecretFilter{Recursive: true, Selector: selector("env=prod,team!=ops")},
+			want:   []string{"web/mail"},
+		},
+		{
+			name:   "LabelExists",
+			filter: models.SecretFilter{Recursive: true, Selector: selector("env,!team")},
+			want:   []string{"web/mail", "web/shop/login"},
+		},
+		{
+			name:   "Types",
+			filter: models.SecretFilter{Recursive: true, Types: []string{"card", "text"}},
+			want:   []string{"bank/visa", "notes"},
+		},
+		{
+			name:   "NameDescPage",
+			filter: models.SecretFilter{Recursive: true, Sort: models.SortNameDesc, Limit: 2},
+			want:   []string{"web/shop/login", "web/mail"},
+		},
+		{
+			name: "NextPage",
+			filter: models.SecretFilter{Recursive: true, Sort: models.SortNameDesc, Limit: 2,
+				After: &models.SecretCursor{Name: "web/mail"}},
+			want: []string{"notes", "bank/visa"},
+		},
+		{name: "Empty", filter: models.SecretFilter{Folder: "missing/"}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := names(tt.filter); !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Out -> \nWant: %v\nGot : %v", tt.want, got)
+			}
+		})
+	}
+
+	// the updated secret comes first
+	time.Sleep(time.Millisecond)
+	if err := s.SecretUpdate(ctx, userid, &models.Secret{Name: "bank/visa", Data: []byte("new"), Version: 1}); err != nil {
+		t.Fatalf("failed to update secret: %v", err)
+	}
+	got := names(models.SecretFilter{Recursive: true, Sort: models.SortUpdatedDesc, Limit: 1})
+	if !reflect.DeepEqual([]string{"bank/visa"}, got) {
+		t.Errorf("Out -> \nWant: [bank/visa]\nGot : %v", got)
+	}
+
+	folders, err := s.SecretFolders(ctx, userid, models.SecretFilter{})
+	if err != nil || !reflect.DeepEqual([]string{"bank/", "web/"}, folders) {
+		t.Errorf("Out -> \nWant: [bank/ web/]\nGot : %v %v", folders, err)
+	}
+	folders, err = s.SecretFolders(ctx, userid, models.SecretFilter{Folder: "web/", Selector: selector("env=prod")})
+	if err != nil || len(folders) != 0 {
+		t.Errorf("Out -> \nWant: []\nGot : %v %v", folders, err)
+	}
+
+	results, err := s.SecretSearch(ctx, userid, []string{"visa"}, 10)
+	if err != nil || len(*results) == 0 || (*results)[0].Name != "bank/visa" || (*results)[0].Rank <= 0 {
+		t.Errorf("Out -> \nWant: bank/visa first\nGot : %+v %v", results, err)
+	}
+	results, err = s.SecretSearch(ctx, userid, []string{"zzzz"}, 10)
+	if err != nil || len(*results) != 0 {
+		t.Errorf("Out -> \nWant: no results\nGot : %+v %v", results, err)
+	}
+
+	// metadata of legacy secrets is kept in 'meta' label as is
+	meta := `{"a":"x=1","b":"y"}`
+	addSecret(t, s, userid, &models.Secret{Name: "legacy", Type: "text", Labels: map[string]string{"meta": meta},
+		Data: []byte("5")})
+	got = names(models.SecretFilter{Selector: selector("meta=" + labels.EscapeValue(meta))})
+	if !reflect.DeepEqual([]string{"legacy"}, got) {
+		t.Errorf("Out -> \nWant: [legacy]\nGot : %v", got)
+	}
+}
+
+func testShares(t *testing.T, s grpcserver.Storage) {
+	owner, recipient := addUser(t, s), addUser(t, s)
+	addSecret(t, s, owner, &models.Secret{Name: "wifi", Type: "text", Labels: map[string]string{"env": "home"},
+		Data: []byte("owner v1"), EncVersion: 1})
+
+	share := &models.Share{Owner: owner, Name: "wifi", Recipient: recipient, Access: models.AccessReadOnly,
+		Data: []byte("recipient v1"), Version: 1, EncVersion: 1}
+	wantErr(t, storage.ErrSecretNotFound, s.ShareAdd(ctx, &models.Share{Owner: owner, Name: "missing",
+		Recipient: recipient, Version: 1}))
+	wantErr(t, storage.ErrVersionConflict, s.ShareAdd(ctx, &models.Share{Owner: owner, Name: "wifi",
+		Recipient: recipient, Version: 2}))
+	wantErr(t, storage.ErrUserNotFound, s.ShareAdd(ctx, &models.Share{Owner: owner, Name: "wifi",
+		Recipient: recipient + "-missing", Access: models.AccessReadOnly, Data: []byte("data"), Version: 1}))
+	if err := s.ShareAdd(ctx, share); err != nil {
+		t.Fatalf("failed to share secret: %v", err)
+	}
+
+	for _, outgoing := range []bool{false, true} {
+		userid := recipient
+		if outgoing {
+			userid = owner
+		}
+		list, err := s.ShareList(ctx, userid, outgoing)
+		if err != nil || len(*list) != 1 {
+			t.Fatalf("Out -> \nWant: 1 share\nGot : %+v %v", list, err)
+		}
+		got := (*list)[0]
+		if got.Owner != owner || got.Recipient != recipient || got.Type != "text" || got.CurrentVersion != 1 ||
+			got.SharedAt.IsZero() || got.Data != nil {
+			t.Errorf("Out -> \nWant: share of wifi without data\nGot : %+v", got)
+		}
+	}
+
+	got, err := s.ShareGet(ctx, recipient, owner, "wifi")
+	if err != nil || string(got.Data) != "recipient v1" || got.Labels["env"] != "home" {
+		t.Errorf("Out -> \nWant: shared data\nGot : %+v %v", got, err)
+	}
+	_, err = s.ShareGet(ctx, owner, owner, "wifi")
+	wantErr(t, storage.ErrShareNotFound, err)
+
+	update := &models.Share{Owner: owner, Name: "wifi", Recipient: recipient, Data: []byte("recipient v2"),
+		Version: 1, EncVersion: 1}
+	wantErr(t, storage.ErrShareReadOnly, s.ShareUpdate(ctx, update, []byte("owner v2")))
+
+	share.Access = models.AccessReadWrite
+	if err = s.ShareAdd(ctx, share); err != nil {
+		t.Fatalf("failed to share secret: %v", err)
+	}
+	if err = s.ShareUpdate(ctx, update, []byte("owner v2")); err != nil {
+		t.Fatalf("failed to update shared secret: %v", err)
+	}
+	if update.Version != 2 {
+		t.Errorf("Out -> \nWant: version 2\nGot : %d", update.Version)
+	}
+	secret, err := s.SecretGet(ctx, owner, "wifi")
+	if err != nil || string(secret.Data) != "owner v2" || secret.Version != 2 || secret.Labels["env"] != "home" {
+		t.Errorf("Out -> \nWant: owner v2\nGot : %+v %v", secret, err)
+	}
+	update.Version = 1
+	wantErr(t, storage.ErrVersionConflict, s.ShareUpdate(ctx, update, []byte("owner v3")))
+
+	// shares follow the moved secret and go away with the deleted one
+	if _, err = s.SecretMove(ctx, owner, "wifi", "home/wifi"); err != nil {
+		t.Fatalf("failed to move secret: %v", err)
+	}
+	got, err = s.ShareGet(ctx, recipient, owner, "home/wifi")
+	if err != nil || string(got.Data) != "recipient v2" || got.Version != 2 {
+		t.Errorf("Out -> \nWant: moved share\nGot : %+v %v", got, err)
+	}
+	if _, err = s.SecretDelete(ctx, owner, "home/wifi"); err != nil {
+		t.Fatalf("failed to delete secret: %v", err)
+	}
+	_, err = s.ShareGet(ctx, recipient, owner, "home/wifi")
+	wantErr(t, storage.ErrShareNotFound, err)
+	wantErr(t, storage.ErrShareNotFound, s.ShareRevoke(ctx, owner, "home/wifi", recipient))
+}
+
+func testVaults(t *testing.T, s grpcserver.Storage) {
+	owner, editor := addUser(t, s), addUser(t, s)
+	org := randomName("org-")
+
+	if err := s.VaultCreate(ctx, owner, org, "ops", []byte("owner key")); err != nil {
+		t.Fatalf("failed to create vault: %v", err)
+	}
+	wantErr(t, storage.ErrVaultAlreadyExists, s.VaultCreate(ctx, owner, org, "ops", []byte("key")))
+	wantErr(t, storage.ErrNotOrgOwner, s.VaultCreate(ctx, editor, org, "dev", []byte("key")))
+
+	role, principal, err := s.VaultRole(ctx, owner, org, "ops")
+	if err != nil || role != vault.RoleOwner || principal != vault.Principal(org, "ops") {
+		t.Errorf("Out -> \nWant: owner of %s\nGot : %s %s %v", vault.Principal(org, "ops"), role, principal, err)
+	}
+	// secrets of the vault belong to its principal
+	addSecret(t, s, principal, &models.Secret{Name: "db", Type: "text", Data: []byte("data")})
+	_, _, err = s.VaultRole(ctx, owner, org, "missing")
+	wantErr(t, storage.ErrVaultNotFound, err)
+	if role, _, err = s.VaultRole(ctx, editor, org, "ops"); err != nil || role != "" {
+		t.Errorf("Out -> \nWant: no role\nGot : %q %v", role, err)
+	}
+
+	wantErr(t, storage.ErrUserNotFound,
+		s.VaultMemberSet(ctx, org, "ops", editor+"-missing", vault.RoleEditor, []byte("key")))
+	wantErr(t, storage.ErrVaultNotFound, s.VaultMemberSet(ctx, org, "missing", editor, vault.RoleEditor, nil))
+	if err = s.VaultMemberSet(ctx, org, "ops", editor, vault.RoleEditor, []byte("editor key")); err != nil {
+		t.Fatalf("failed to add vault member: %v", err)
+	}
+	if err = s.VaultMemberSet(ctx, org, "", editor, vault.RoleViewer, nil); err != nil {
+		t.Fatalf("failed to add organization member: %v", err)
+	}
+
+	if role, _, err = s.VaultRole(ctx, editor, org, "ops"); err != nil || role != vault.RoleEditor {
+		t.Errorf("Out -> \nWant: editor\nGot : %q %v", role, err)
+	}
+	if role, err = s.OrgRole(ctx, editor, org); err != nil || role != vault.RoleViewer {
+		t.Errorf("Out -> \nWant: viewer\nGot : %q %v", role, err)
+	}
+	key, err := s.VaultKey(ctx, editor, org, "ops")
+	if err != nil || string(key) != "editor key" {
+		t.Errorf("Out -> \nWant: editor key\nGot : %q %v", key, err)
+	}
+	_, err = s.VaultKey(ctx, editor, org, "")
+	wantErr(t, storage.ErrVaultKeyNotFound, err)
+
+	// members replace only keys they have, role is kept
+	if err = s.VaultKeySet(ctx, editor, org, "ops", []byte("resealed key")); err != nil {
+		t.Fatalf("failed to set vault key: %v", err)
+	}
+	if key, err = s.VaultKey(ctx, editor, org, "ops"); err != nil || string(key) != "resealed key" {
+		t.Errorf("Out -> \nWant: resealed key\nGot : %q %v", key, err)
+	}
+	if role, _, err = s.VaultRole(ctx, editor, org, "ops"); err != nil || role != vault.RoleEditor {
+		t.Errorf("Out -> \nWant: editor\nGot : %q %v", role, err)
+	}
+	wantErr(t, storage.ErrVaultKeyNotFound, s.VaultKeySet(ctx, editor, org, "", []byte("key")))
+	wantErr(t, storage.ErrVaultKeyNotFound, s.VaultKeySet(ctx, owner, org, "missing", []byte("key")))
+
+	vaults, err := s.VaultList(ctx, editor)
+	want := models.VaultList{{Org: org, Name: "ops", Role: vault.RoleEditor}}
+	if err != nil || !reflect.DeepEqual(want, *vaults) {
+		t.Errorf("Out -> \nWant: %+v\nGot : %+v %v", want, vaults, err)
+	}
+	members, err := s.VaultMemberList(ctx, org, "ops")
+	if err != nil || len(*members) != 4 || (*members)[0].Vault != "" || (*members)[3].Vault != "ops" {
+		t.Errorf("Out -> \nWant: 2 organization and 2 vault members\nGot : %+v %v", members, err)
+	}
+
+	wantErr(t, storage.ErrLastOwner, s.VaultMemberSet(ctx, org, "", owner, vault.RoleEditor, nil))
+	wantErr(t, storage.ErrLastOwner, s.VaultMemberDelete(ctx, org, "", owner))
+	if role, err = s.OrgRole(ctx, owner, org); err != nil || role != vault.RoleOwner {
+		t.Errorf("failed change must be rolled back, got %q %v", role, err)
+	}
+	if err = s.VaultMemberDelete(ctx, org, "ops", editor); err != nil {
+		t.Fatalf("failed to delete vault member: %v", err)
+	}
+	wantErr(t, storage.ErrMemberNotFound, s.VaultMemberDelete(ctx, org, "ops", editor))
+	if role, _, err = s.VaultRole(ctx, editor, org, "ops"); err != nil || role != vault.RoleViewer {
+		t.Errorf("Out -> \nWant: viewer by organization\nGot : %q %v", role, err)
+	}
+}
+
+func testAudit(t *testing.T, s grpcserver.Storage) {
+	userid := addUser(t, s)
+	from := time.Now().Add(-time.Second)
+
+	for _, method := range []string{"SecretGet", "SecretUpdate", "SecretDelete"} {
+		event := &models.AuditEvent{UserID: userid, Owner: userid, Method: method, Name: "mail",
+			ClientIP: "127.0.0.1", Code: "OK"}
+		if err := s.AuditAdd(ctx, event); err != nil {
+			t.Fatalf("failed to add audit event: %v", err)
+		}
+	}
+
+	events, err := s.AuditList(ctx, userid, models.AuditFilter{From: from, Limit: 2})
+	if err != nil || len(*events) != 2 || (*events)[0].Method != "SecretDelete" || (*events)[1].Method != "SecretUpdate" {
+		t.Fatalf("Out -> \nWant: 2 newest events\nGot : %+v %v", events, err)
+	}
+	events, err = s.AuditList(ctx, userid, models.AuditFilter{To: from, Limit: 10})
+	if err != nil || len(*events) != 0 {
+		t.Errorf("Out -> \nWant: no events\nGot : %+v %v", events, err)
+	}
+
+	head, err := s.AuditHead(ctx)
+	if err != nil || head == nil || len(head.Hash) == 0 {
+		t.Fatalf("Out -> \nWant: head of the log\nGot : %+v %v", head, err)
+	}
+	chain, err := s.AuditChain(ctx, head.ID-1, 10)
+	if err != nil || len(chain) != 1 || chain[0].ID != head.ID || chain[0].Method != "SecretDelete" {
+		t.Errorf("Out -> \nWant: the last event\nGot : %+v %v", chain, err)
+	}
+
+	// events written by the storage are within the chain
+	start, err := s.AuditChainStart(ctx)
+	if err != nil || start < 1 || start > head.ID {
+		t.Errorf("Out -> \nWant: chain start up to %d\nGot : %d %v", head.ID, start, err)
+	}
+
+	result, err := auditlog.Verify(ctx, s, nil)
+	if err != nil || result.BrokenID != 0 || result.Verified < 3 {
+		t.Errorf("Out -> \nWant: intact log\nGot : %+v %v", result, err)
+	}
+}
+
+// testSecretBlobs checks that references to blob store objects are kept with every version.
+func testSecretBlobs(t *testing.T, s grpcserver.Storage) {
+	userid := addUser(t, s)
+	eof := func() ([]byte, error) { return nil, io.EOF }
+
+	secret := &models.Secret{Name: "large", Type: "file", BlobRef: "0a/2", BlobHash: []byte("hash1")}
+	if err := s.SecretSaveStream(ctx, userid, secret, false, eof); err != nil {
+		t.Fatalf("failed to save secret: %v", err)
+	}
+	secret = &models.Secret{Name: "large", Version: 1, BlobRef: "0b/3", BlobHash: []byte("hash2")}
+	if err := s.SecretSaveStream(ctx, userid, secret, true, eof); err != nil {
+		t.Fatalf("failed to update secret: %v", err)
+	}
+	// data of the last version is kept in DB
+	if err := s.SecretUpdate(ctx, userid, &models.Secret{Name: "large", Data: []byte("small"), Version: 2}); err != nil {
+		t.Fatalf("failed to update secret: %v", err)
+	}
+
+	got, err := s.SecretGetVersion(ctx, userid, "large", 1)
+	if err != nil || got.BlobRef != "0a/2" || string(got.BlobHash) != "hash1" {
+		t.Errorf("Out -> \nWant: blob 0a/2\nGot : %+v %v", got, err)
+	}
+	got, err = s.SecretGet(ctx, userid, "large")
+	if err != nil || got.BlobRef != "" || got.BlobHash != nil {
+		t.Errorf("Out -> \nWant: no blob\nGot : %+v %v", got, err)
+	}
+
+	// blobs of all versions are returned by deletion of the secret
+	refs, err := s.SecretDelete(ctx, userid, "large")
+	sort.Strings(refs)
+	if want := []string{"0a/2", "0b/3"}; err != nil || !reflect.DeepEqual(refs, want) {
+		t.Errorf("Out -> \nWant: %v\nGot : %v %v", want, refs, err)
+	}
+	_, err = s.SecretDelete(ctx, userid, "large")
+	wantErr(t, storage.ErrSecretNotFound, err)
+}
+
+// testSecretHashes checks that every version keeps hash of its data, chunks or blob.
+func testSecretHashes(t *testing.T, s grpcserver.Storage) {
+	userid := addUser(t, s)
+	sum := func(data string) []byte {
+		hash := sha256.Sum256([]byte(data))
+		return hash[:]
+	}
+
+	if err := s.SecretAdd(ctx, userid, &models.Secret{Name: "notes", Type: "text", Data: []byte("v1")}); err != nil {
+		t.Fatalf("failed to add secret: %v", err)
+	}
+	chunks := replay([]byte("chunk0"), []byte("chunk1"))
+	stream := &models.Secret{Name: "notes", Version: 1, EncVersion: models.EncVersionChunked}
+	if err := s.SecretSaveStream(ctx, userid, stream, true, chunks); err != nil {
+		t.Fatalf("failed to save secret: %v", err)
+	}
+	blob := &models.Secret{Name: "notes", Version: 2, BlobRef: "0a/1", BlobHash: sum("blob")}
+	if err := s.SecretSaveStream(ctx, userid, blob, true, replay()); err != nil {
+		t.Fatalf("failed to save secret: %v", err)
+	}
+	if !bytes.Equal(stream.Hash, sum("chunk0chunk1")) {
+		t.Errorf("Out -> \nWant: %x\nGot : %x", sum("chunk0chunk1"), stream.Hash)
+	}
+
+	for version, want := range [][]byte{sum("v1"), sum("chunk0chunk1"), sum("blob")} {
+		got, err := s.SecretGetVersion(ctx, userid, "notes", int64(version+1))
+		if err != nil || !bytes.Equal(got.Hash, want) {
+			t.Errorf("Out -> \nWant: version %d hash %x\nGot : %+v %v", version+1, want, got, err)
+		}
+	}
+	got, err := s.SecretGet(ctx, userid, "notes")
+	if err != nil || !bytes.Equal(got.Hash, sum("blob")) {
+		t.Errorf("Out -> \nWant: hash %x\nGot : %+v %v", sum("blob"), got, err)
+	}
+}
+
+// testSecretReplaceVersions checks that past versions are replaced and deleted at once.
+func testSecretReplaceVersions(t *testing.T, s grpcserver.Storage) {
+	userid := addUser(t, s)
+
+	if err := s.SecretAdd(ctx, userid, &models.Secret{Name: "notes", Type: "text", Data: []byte("v1")}); err != nil {
+		t.Fatalf("failed to add secret: %v", err)
+	}
+	stream := &models.Secret{Name: "notes", Version: 1, EncVersion: models.EncVersionChunked}
+	if err := s.SecretSaveStream(ctx, userid, stream, true, replay([]byte("chunk"))); err != nil {
+		t.Fatalf("failed to save secret: %v", err)
+	}
+	blob := &models.Secret{Name: "notes", Version: 2, BlobRef: "0a/1", BlobHash: []byte("hash")}
+	if err := s.SecretSaveStream(ctx, userid, blob, true, replay()); err != nil {
+		t.Fatalf("failed to save secret: %v", err)
+	}
+	if err := s.SecretUpdate(ctx, userid, &models.Secret{Name: "notes", Data: []byte("v4"), Version: 3}); err != nil {
+		t.Fatalf("failed to update secret: %v", err)
+	}
+
+	replaced := func() []*models.Secret {
+		return []*models.Secret{{Name: "notes", Version: 1, Data: []byte("r1"), EncVersion: 1}}
+	}
+	// the current version, versions kept in chunks and missing versions are refused, nothing is changed
+	for _, c := range []struct {
+		versions []*models.Secret
+		remove   []int64
+	}{
+		{versions: replaced(), remove: []int64{4}},
+		{versions: []*models.Secret{{Name: "notes", Version: 2, Data: []byte("r2"), EncVersion: 1}}},
+		{versions: replaced(), remove: []int64{7}},
+	} {
+		_, err := s.SecretReplaceVersions(ctx, userid, "notes", c.versions, c.remove)
+		wantErr(t, storage.ErrSecretNotFound, err)
+	}
+	_, err := s.SecretReplaceVersions(ctx, userid, "missing", replaced(), nil)
+	wantErr(t, storage.ErrSecretNotFound, err)
+	got, err := s.SecretGetVersion(ctx, userid, "notes", 1)
+	if err != nil || string(got.Data) != "v1" {
+		t.Errorf("Out -> \nWant: version 1 intact\nGot : %+v %v", got, err)
+	}
+
+	refs, err := s.SecretReplaceVersions(ctx, userid, "notes", replaced(), []int64{2, 3})
+	if want := []string{"0a/1"}; err != nil || !reflect.DeepEqual(refs, want) {
+		t.Fatalf("Out -> \nWant: %v\nGot : %v %v", want, refs, err)
+	}
+	sum := sha256.Sum256([]byte("r1"))
+	got, err = s.SecretGetVersion(ctx, userid, "notes", 1)
+	if err != nil || string(got.Data) != "r1" || got.EncVersion != 1 || !bytes.Equal(got.Hash, sum[:]) {
+		t.Errorf("Out -> \nWant: replaced version 1\nGot : %+v %v", got, err)
+	}
+	for _, version := range []int64{2, 3} {
+		_, err = s.SecretGetVersion(ctx, userid, "notes", version)
+		wantErr(t, storage.ErrSecretNotFound, err)
+	}
+	versions, err := s.SecretVersionList(ctx, userid, "notes")
+	if err != nil || len(*versions) != 2 || (*versions)[0].Version != 1 || (*versions)[1].Version != 4 {
+		t.Errorf("Out -> \nWant: versions 1 and 4\nGot : %+v %v", versions, err)
+	}
+
+	// numbering of new versions goes on
+	if err = s.SecretUpdate(ctx, userid, &models.Secret{Name: "notes", Data: []byte("v5"), Version: 4}); err != nil {
+		t.Fatalf("failed to update secret: %v", err)
+	}
+	got, err = s.SecretGetVersion(ctx, userid, "notes", 5)
+	if err != nil || string(got.Data) != "v5" {
+		t.Errorf("Out -> \nWant: version 5\nGot : %+v %v", got, err)
+	}
+}
+
+// testSecretRollback checks that rolled back and uploaded again versions share data with past versions
+// and blobs are returned for deletion only when no version refers to them.
+func testSecretRollback(t *testing.T, s grpcserver.Storage) {
+	userid := addUser(t, s)
+	sum := func(data string) []byte {
+		hash := sha256.Sum256([]byte(data))
+		return hash[:]
+	}
+
+	addSecret(t, s, userid, &models.Secret{Name: "notes", Type: "file", Labels: map[string]string{"env": "prod"},
+		Data: []byte("v1")})
+	stream := &models.Secret{Name: "notes", Version: 1, EncVersion: models.EncVersionChunked}
+	if err := s.SecretSaveStream(ctx, userid, stream, true, replay([]byte("c0"), []byte("c1"))); err != nil {
+		t.Fatalf("failed to save secret: %v", err)
+	}
+	blob := &models.Secret{Name: "notes", Version: 2, BlobRef: "0a/1", BlobHash: sum("blob")}
+	if err := s.SecretSaveStream(ctx, userid, blob, true, replay()); err != nil {
+		t.Fatalf("failed to save secret: %v", err)
+	}
+	if err := s.SecretUpdate(ctx, userid, &models.Secret{Name: "notes", Data: []byte("v4"), Version: 3}); err != nil {
+		t.Fatalf("failed to update secret: %v", err)
+	}
+
+	_, err := s.SecretRollback(ctx, userid, "notes", 9, 4)
+	wantErr(t, storage.ErrSecretNotFound, err)
+	_, err = s.SecretRollback(ctx, userid, "missing", 1, 1)
+	wantErr(t, storage.ErrSecretNotFound, err)
+	_, err = s.SecretRollback(ctx, userid, "notes", 1, 3)
+	wantErr(t, storage.ErrVersionConflict, err)
+
+	got, err := s.SecretRollback(ctx, userid, "notes", 1, 4)
+	if err != nil || got.Version != 5 {
+		t.Fatalf("Out -> \nWant: version 5\nGot : %+v %v", got, err)
+	}
+	got, err = s.SecretGet(ctx, userid, "notes")
+	if err != nil || string(got.Data) != "v1" || got.Type != "file" || got.Labels["env"] != "prod" ||
+		!bytes.Equal(got.Hash, sum("v1")) {
+		t.Errorf("Out -> \nWant: data of version 1\nGot : %+v %v", got, err)
+	}
+
+	// chunks kept in DB are copied
+	if got, err = s.SecretRollback(ctx, userid, "notes", 2, 5); err != nil || got.Version != 6 {
+		t.Fatalf("Out -> \nWant: version 6\nGot : %+v %v", got, err)
+	}
+	var chunks []byte
+	err = s.SecretChunks(ctx, userid, "notes", 6, func(chunk []byte) error {
+		chunks = append(chunks, chunk...)
+		return nil
+	})
+	if err != nil || string(chunks) != "c0c1" {
+		t.Errorf("Out -> \nWant: c0c1\nGot : %s %v", chunks, err)
+	}
+	got, err = s.SecretGetVersion(ctx, userid, "notes", 6)
+	if err != nil || got.EncVersion != models.EncVersionChunked || !bytes.Equal(got.Hash, sum("c0c1")) {
+		t.Errorf("Out -> \nWant: chunked version 6\nGot : %+v %v", got, err)
+	}
+
+	// blobs are shared
+	if got, err = s.SecretRollback(ctx, userid, "notes", 3, 6); err != nil || got.Version != 7 {
+		t.Fatalf("Out -> \nWant: version 7\nGot : %+v %v", got, err)
+	}
+	got, err = s.SecretGetVersion(ctx, userid, "notes", 7)
+	if err != nil || got.BlobRef != "0a/1" || !bytes.Equal(got.Hash, sum("blob")) {
+		t.Errorf("Out -> \nWant: blob 0a/1\nGot : %+v %v", got, err)
+	}
+	upload := &models.Secret{Name: "notes", Version: 7, BlobRef: "0b/1", BlobHash: sum("blob")}
+	if err = s.SecretSaveStream(ctx, userid, upload, true, replay()); err != nil {
+		t.Fatalf("failed to save secret: %v", err)
+	}
+	if upload.BlobRef != "0a/1" {
+		t.Errorf("Out -> \nWant: blob 0a/1\nGot : %s", upload.BlobRef)
+	}
+	got, err = s.SecretGet(ctx, userid, "notes")
+	if err != nil || got.Version != 8 || got.BlobRef != "0a/1" {
+		t.Errorf("Out -> \nWant: version 8 with blob 0a/1\nGot : %+v %v", got, err)
+	}
+
+	refs, err := s.SecretReplaceVersions(ctx, userid, "notes", nil, []int64{3, 7})
+	if err != nil || len(refs) != 0 {
+		t.Errorf("Out -> \nWant: no blobs\nGot : %v %v", refs, err)
+	}
+	refs, err = s.SecretDelete(ctx, userid, "notes")
+	if want := []string{"0a/1"}; err != nil || !reflect.DeepEqual(refs, want) {
+		t.Errorf("Out -> \nWant: %v\nGot : %v %v", want, refs, err)
+	}
+}
+
+// replay returns function yielding the chunks followed by io.EOF.
+func replay(chunks ...[]byte) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		if len(chunks) == 0 {
+			return nil, io.EOF
+		}
+		chunk := chunks[0]
+		chunks = chunks[1:]
+		return chunk, nil
+	}
+}
+
+// testCancel checks that calls with a done context fail with its error and change nothing.
+func testCancel(t *testing.T, s grpcserver.Storage) {
+	userid := addUser(t, s)
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	expired, cancel := context.WithDeadline(ctx, time.Now().Add(-time.Second))
+	defer cancel()
+
+	for _, c := range []struct {
+		ctx  context.Context
+		want error
+	}{
+		{ctx: cancelled, want: context.Canceled},
+		{ctx: expired, want: context.DeadlineExceeded},
+	} {
+		other := randomName("user-")
+		wantErr(t, c.want, s.UserAdd(c.ctx, models.User{UserID: other, Password: "hash"}))
+		_, err := s.UserGet(ctx, other)
+		wantErr(t, storage.ErrUserNotFound, err)
+
+		_, err = s.UserGet(c.ctx, userid)
+		wantErr(t, c.want, err)
+		_, err = s.TokenRevokedList(c.ctx)
+		wantErr(t, c.want, err)
+
+		secret := &models.Secret{Name: randomName("secret-"), Type: "text", Data: []byte("data")}
+		wantErr(t, c.want, s.SecretAdd(c.ctx, userid, secret))
+		_, err = s.SecretGet(ctx, userid, secret.Name)
+		wantErr(t, storage.ErrSecretNotFound, err)
+
+		next := func() ([]byte, error) { return nil, io.EOF }
+		wantErr(t, c.want, s.SecretSaveStream(c.ctx, userid, secret, false, next))
+		_, err = s.SecretList(c.ctx, userid, models.SecretFilter{Limit: 10})
+		wantErr(t, c.want, err)
+		_, err = s.VaultList(c.ctx, userid)
+		wantErr(t, c.want, err)
+		wantErr(t, c.want, s.AuditAdd(c.ctx, &models.AuditEvent{UserID: userid, Method: "GetSecret"}))
+	}
+}
@@ -0,0 +1,804 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vkupriya/gophkeeper/internal/search"
+	"github.com/vkupriya/gophkeeper/internal/secretpath"
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+)
+
+// MemoryDB is the storage backend keeping data in process memory, data is lost when the server
+// stops. Every method holds a single lock, so changes are atomic and serialized.
+type MemoryDB struct {
+	users         map[string]*memoryUser
+	refreshTokens map[string]*memoryToken
+	accessTokens  map[string]*memoryToken
+	secrets       map[string]map[string]*memorySecret
+	shares        map[memoryShareKey]*models.Share
+	orgs          map[string]struct{}
+	vaults        map[memoryVaultKey]string
+	members       map[memoryMemberKey]*memoryMember
+	audit         []models.AuditRecord
+	mu            sync.Mutex
+}
+
+type memoryUser struct {
+	password  string
+	publicKey []byte
+}
+
+type memoryToken struct {
+	expiresAt time.Time
+	userid    string
+	revoked   bool
+}
+
+// memorySecret keeps every version of the secret, the last one is the current version. Deleted past
+// versions are nil.
+type memorySecret struct {
+	updatedAt time.Time
+	versions  []*memoryVersion
+}
+
+type memoryVersion struct {
+	createdAt time.Time
+	secret    models.Secret
+	chunks    [][]byte
+}
+
+func NewMemoryDB() *MemoryDB {
+	return &MemoryDB{
+		users:         make(map[string]*memoryUser),
+		refreshTokens: make(map[string]*memoryToken),
+		accessTokens:  make(map[string]*memoryToken),
+		secrets:       make(map[string]map[string]*memorySecret),
+		shares:        make(map[memoryShareKey]*models.Share),
+		orgs:          make(map[string]struct{}),
+		vaults:        make(map[memoryVaultKey]string),
+		members:       make(map[memoryMemberKey]*memoryMember),
+	}
+}
+
+// lock acquires the lock unless ctx is done, so a cancelled request does not change the storage.
+func (m *MemoryDB) lock(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	return nil
+}
+
+// current returns the current version of the secret.
+func (s *memorySecret) current() *models.Secret {
+	return &s.versions[len(s.versions)-1].secret
+}
+
+// cloneLabels copies labels, so stored labels are not shared with callers.
+func cloneLabels(secretLabels map[string]string) map[string]string {
+	if secretLabels == nil {
+		return map[string]string{}
+	}
+	return maps.Clone(secretLabels)
+}
+
+func (m *MemoryDB) UserAdd(ctx context.Context, u models.User) error {
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[u.UserID]; ok {
+		return ErrUserAlreadyExists
+	}
+	m.users[u.UserID] = &memoryUser{password: u.Password}
+	return nil
+}
+
+func (m *MemoryDB) UserGet(ctx context.Context, userid string) (models.User, error) {
+	if err := m.lock(ctx); err != nil {
+		return models.User{}, err
+	}
+	defer m.mu.Unlock()
+
+	u, ok := m.users[userid]
+	if !ok {
+		return models.User{}, ErrUserNotFound
+	}
+	return models.User{UserID: userid, Password: u.password}, nil
+}
+
+func (m *MemoryDB) RefreshTokenAdd(ctx context.Context, userid string, tokenHash string, expiresAt time.Time) error {
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+	defer m.mu.Unlock()
+
+	m.refreshTokens[tokenHash] = &memoryToken{userid: userid, expiresAt: expiresAt}
+	return nil
+}
+
+// RefreshTokenUse removes valid refresh token and returns user it was issued for,
+// refresh token can be used only once.
+func (m *MemoryDB) RefreshTokenUse(ctx context.Context, tokenHash string) (string, error) {
+	if err := m.lock(ctx); err != nil {
+		return "", err
+	}
+	defer m.mu.Unlock()
+
+	t, ok := m.refreshTokens[tokenHash]
+	if !ok || !t.expiresAt.After(time.Now()) {
+		return "", ErrTokenNotFound
+	}
+	delete(m.refreshTokens, tokenHash)
+	return t.userid, nil
+}
+
+func (m *MemoryDB) RefreshTokenDelete(ctx context.Context, tokenHash string) error {
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+	defer m.mu.Unlock()
+
+	if _, ok := m.refreshTokens[tokenHash]; !ok {
+		return ErrTokenNotFound
+	}
+	delete(m.refreshTokens, tokenHash)
+	return nil
+}
+
+func (m *MemoryDB) TokenAdd(ctx context.Context, userid string, jti string, expiresAt time.Time) error {
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+	defer m.mu.Unlock()
+
+	m.accessTokens[jti] = &memoryToken{userid: userid, expiresAt: expiresAt}
+	return nil
+}
+
+func (m *MemoryDB) TokenRevoke(ctx context.Context, userid string, jti string) error {
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+	defer m.mu.Unlock()
+
+	if t, ok := m.accessTokens[jti]; ok && t.userid == userid {
+		t.revoked = true
+	}
+	return nil
+}
+
+// TokenRevokeAll revokes all active access tokens and refresh tokens of the user,
+// revoked access tokens are returned.
+func (m *MemoryDB) TokenRevokeAll(ctx context.Context, userid string) (models.RevokedTokens, error) {
+	if err := m.lock(ctx); err != nil {
+		return models.RevokedTokens{}, err
+	}
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	revoked := models.RevokedTokens{}
+	for jti, t := range m.accessTokens {
+		if t.userid == userid && t.expiresAt.After(now) {
+			t.revoked = true
+			revoked[jti] = t.expiresAt
+		}
+	}
+	for tokenHash, t := range m.refreshTokens {
+		if t.userid == userid {
+			delete(m.refreshTokens, tokenHash)
+		}
+	}
+	return revoked, nil
+}
+
+func (m *MemoryDB) TokenRevokedList(ctx context.Context) (models.RevokedTokens, error) {
+	if err := m.lock(ctx); err != nil {
+		return models.RevokedTokens{}, err
+	}
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	revoked := models.RevokedTokens{}
+	for jti, t := range m.accessTokens {
+		if t.revoked && t.expiresAt.After(now) {
+			revoked[jti] = t.expiresAt
+		}
+	}
+	return revoked, nil
+}
+
+// TokensDeleteExpired removes expired access and refresh tokens.
+func (m *MemoryDB) TokensDeleteExpired(ctx context.Context) error {
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for jti, t := range m.accessTokens {
+		if t.expiresAt.Before(now) {
+			delete(m.accessTokens, jti)
+		}
+	}
+	for tokenHash, t := range m.refreshTokens {
+		if t.expiresAt.Before(now) {
+			delete(m.refreshTokens, tokenHash)
+		}
+	}
+	return nil
+}
+
+func (m *MemoryDB) SecretAdd(ctx context.Context, userid string, secret *models.Secret) error {
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+	defer m.mu.Unlock()
+
+	if err := m.checkInsert(userid, secret.Name); err != nil {
+		return err
+	}
+	m.insertSecret(userid, secret, nil)
+	return nil
+}
+
+// checkInsert fails if the user already has the secret.
+func (m *MemoryDB) checkInsert(userid string, name string) error {
+	if _, ok := m.secrets[userid][name]; ok {
+		return ErrSecretAlreadyExists
+	}
+	return nil
+}
+
+// insertSecret adds the first version of the secret with its chunks.
+func (m *MemoryDB) insertSecret(userid string, secret *models.Secret, chunks [][]byte) {
+	if m.secrets[userid] == nil {
+		m.secrets[userid] = make(map[string]*memorySecret)
+	}
+	secret.Version = 1
+	s := &memorySecret{}
+	m.secrets[userid][secret.Name] = s
+	s.addVersion(secret, chunks)
+}
+
+func (m *MemoryDB) SecretUpdate(ctx context.Context, userid string, secret *models.Secret) error {
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+	defer m.mu.Unlock()
+
+	s, err := m.checkUpdate(userid, secret.Name, secret.Version)
+	if err != nil {
+		return err
+	}
+	s.update(secret, nil)
+	return nil
+}
+
+// checkUpdate returns the secret if its current version is the expected one.
+func (m *MemoryDB) checkUpdate(userid string, name string, expected int64) (*memorySecret, error) {
+	s, ok := m.secrets[userid][name]
+	if !ok {
+		return nil, ErrSecretNotFound
+	}
+	if current := s.current().Version; expected != current {
+		return nil, fmt.Errorf("%w: expected version %d, current version %d", ErrVersionConflict, expected, current)
+	}
+	return s, nil
+}
+
+// update adds the next version of the secret keeping its type, secret is set to the stored version.
+func (s *memorySecret) update(secret *models.Secret, chunks [][]byte) {
+	current := s.current()
+	secret.Type = current.Type
+	secret.Version = current.Version + 1
+	s.addVersion(secret, chunks)
+}
+
+// addVersion stores a copy of the secret as its next version.
+func (s *memorySecret) addVersion(secret *models.Secret, chunks [][]byte) {
+	now := nowMicro()
+	v := &memoryVersion{
+		createdAt: now,
+		secret: models.Secret{
+			Type:       secret.Type,
+			Labels:     cloneLabels(secret.Labels),
+			Data:       bytes.Clone(secret.Data),
+			Version:    secret.Version,
+			EncVersion: secret.EncVersion,
+			BlobRef:    secret.BlobRef,
+			BlobHash:   bytes.Clone(secret.BlobHash),
+			Hash:       secretHash(secret, chunks...),
+		},
+		chunks: chunks,
+	}
+	secret.Hash = bytes.Clone(v.secret.Hash)
+	s.versions = append(s.versions, v)
+	s.updatedAt = now
+}
+
+// SecretSaveStream adds (or updates) secret which data is received in chunks. Chunks are
+// read with next until it returns io.EOF without holding the lock and the secret is stored
+// after the last one, so interrupted upload leaves no trace.
+func (m *MemoryDB) SecretSaveStream(ctx context.Context, userid string, secret *models.Secret, update bool,
+	next func() ([]byte, error)) error {
+	// fail early before the upload, the check is repeated when the secret is stored
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+	err := m.checkSaveStream(userid, secret, update)
+	m.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	var chunks [][]byte
+	for seq := 0; ; seq++ {
+		chunk, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to receive chunk %d of secret %s: %w", seq, secret.Name, err)
+		}
+		chunks = append(chunks, bytes.Clone(chunk))
+	}
+
+	if err = m.lock(ctx); err != nil {
+		return err
+	}
+	defer m.mu.Unlock()
+
+	if err = m.checkSaveStream(userid, secret, update); err != nil {
+		return err
+	}
+	secret.Data = []byte{}
+	if update {
+		s := m.secrets[userid][secret.Name]
+		if ref := s.blobOf(secret.BlobHash); ref != "" {
+			// identical data uploaded again is kept once, the caller deletes the uploaded blob
+			secret.BlobRef = ref
+		}
+		s.update(secret, chunks)
+	} else {
+		m.insertSecret(userid, secret, chunks)
+	}
+	return nil
+}
+
+// blobOf returns reference to the blob of a version of the secret with the blob hash, empty string is
+// returned if there is none.
+func (s *memorySecret) blobOf(hash []byte) string {
+	if hash == nil {
+		return ""
+	}
+	for _, v := range s.versions {
+		if v != nil && v.secret.BlobRef != "" && bytes.Equal(v.secret.BlobHash, hash) {
+			return v.secret.BlobRef
+		}
+	}
+	return ""
+}
+
+// blobUsed reports whether a version of the secret refers to the blob.
+func (s *memorySecret) blobUsed(ref string) bool {
+	for _, v := range s.versions {
+		if v != nil && v.secret.BlobRef == ref {
+			return true
+		}
+	}
+	return false
+}
+
+// SecretRollback adds the next version of the secret with the stored data and labels of its past version,
+// the current version must be the expected one. Versions kept in the blob store share the blob, chunks
+// never change, so they are shared too. The new version is returned.
+func (m *MemoryDB) SecretRollback(ctx context.Context, userid string, name string, version int64,
+	expected int64) (*models.Secret, error) {
+	if err := m.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer m.mu.Unlock()
+
+	v := m.version(userid, name, version)
+	if v == nil {
+		return nil, fmt.Errorf("%w: no version %d of secret %s", ErrSecretNotFound, version, name)
+	}
+	s, err := m.checkUpdate(userid, name, expected)
+	if err != nil {
+		return nil, err
+	}
+	secret := copySecret(userid, name, &v.secret)
+	s.update(secret, v.chunks)
+	return secret, nil
+}
+
+func (m *MemoryDB) checkSaveStream(userid string, secret *models.Secret, update bool) error {
+	if update {
+		_, err := m.checkUpdate(userid, secret.Name, secret.Version)
+		return err
+	}
+	return m.checkInsert(userid, secret.Name)
+}
+
+// SecretChunks calls f for every chunk of the secret version in order. The lock is not held
+// while f is called, so a slow receiver does not block other requests.
+func (m *MemoryDB) SecretChunks(ctx context.Context, userid string, name string, version int64,
+	f func(chunk []byte) error) error {
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+	var chunks [][]byte
+	if v := m.version(userid, name, version); v != nil {
+		// chunks of a stored version never change
+		chunks = v.chunks
+	}
+	m.mu.Unlock()
+
+	for _, chunk := range chunks {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := f(bytes.Clone(chunk)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// version returns the version of the secret, nil is returned if there is none.
+func (m *MemoryDB) version(userid string, name string, version int64) *memoryVersion {
+	s, ok := m.secrets[userid][name]
+	if !ok || version < 1 || version > int64(len(s.versions)) {
+		return nil
+	}
+	return s.versions[version-1]
+}
+
+func (m *MemoryDB) SecretGet(ctx context.Context, userid string, name string) (*models.Secret, error) {
+	if err := m.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer m.mu.Unlock()
+
+	s, ok := m.secrets[userid][name]
+	if !ok {
+		return &models.Secret{}, ErrSecretNotFound
+	}
+	return copySecret(userid, name, s.current()), nil
+}
+
+func (m *MemoryDB) SecretGetVersion(ctx context.Context, userid string, name string,
+	version int64) (*models.Secret, error) {
+	if err := m.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer m.mu.Unlock()
+
+	v := m.version(userid, name, version)
+	if v == nil {
+		return &models.Secret{}, ErrSecretNotFound
+	}
+	return copySecret(userid, name, &v.secret), nil
+}
+
+// copySecret returns a copy of the stored secret version.
+func copySecret(userid string, name string, stored *models.Secret) *models.Secret {
+	return &models.Secret{
+		UserID:     userid,
+		Name:       name,
+		Type:       stored.Type,
+		Labels:     cloneLabels(stored.Labels),
+		Data:       bytes.Clone(stored.Data),
+		Version:    stored.Version,
+		EncVersion: stored.EncVersion,
+		BlobRef:    stored.BlobRef,
+		BlobHash:   bytes.Clone(stored.BlobHash),
+		Hash:       bytes.Clone(stored.Hash),
+	}
+}
+
+func (m *MemoryDB) SecretVersionList(ctx context.Context, userid string,
+	name string) (*models.SecretVersionList, error) {
+	if err := m.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer m.mu.Unlock()
+
+	s, ok := m.secrets[userid][name]
+	if !ok {
+		return nil, ErrSecretNotFound
+	}
+	versions := make(models.SecretVersionList, 0, len(s.versions))
+	for _, v := range s.versions {
+		if v == nil {
+			continue
+		}
+		versions = append(versions, models.SecretVersionItem{
+			Version:   v.secret.Version,
+			Type:      v.secret.Type,
+			CreatedAt: v.createdAt,
+		})
+	}
+	return &versions, nil
+}
+
+// SecretDelete removes the secret with its versions and shares and returns references to blob store
+// objects keeping data of the deleted versions.
+func (m *MemoryDB) SecretDelete(ctx context.Context, userid string, name string) ([]string, error) {
+	if err := m.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer m.mu.Unlock()
+
+	s, ok := m.secrets[userid][name]
+	if !ok {
+		return nil, ErrSecretNotFound
+	}
+	// versions may share a blob
+	refs := []string{}
+	for _, v := range s.versions {
+		if v != nil && v.secret.BlobRef != "" && !contains(refs, v.secret.BlobRef) {
+			refs = append(refs, v.secret.BlobRef)
+		}
+	}
+	delete(m.secrets[userid], name)
+	for key := range m.shares {
+		if key.owner == userid && key.name == name {
+			delete(m.shares, key)
+		}
+	}
+	return refs, nil
+}
+
+// SecretReplaceVersions replaces data of past versions of the secret and deletes past versions in remove,
+// references to blob store objects used only by the deleted versions are returned. Versions kept in
+// chunks can not be replaced, nothing is changed if a version is not a past version of the secret.
+func (m *MemoryDB) SecretReplaceVersions(ctx context.Context, userid string, name string,
+	versions []*models.Secret, remove []int64) ([]string, error) {
+	if err := m.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer m.mu.Unlock()
+
+	s, ok := m.secrets[userid][name]
+	if !ok {
+		return nil, ErrSecretNotFound
+	}
+	current := s.current().Version
+	past := func(version int64) (*memoryVersion, error) {
+		v := m.version(userid, name, version)
+		if v == nil || version >= current {
+			return nil, fmt.Errorf("%w: no past version %d of secret %s", ErrSecretNotFound, version, name)
+		}
+		return v, nil
+	}
+	for _, secret := range versions {
+		v, err := past(secret.Version)
+		if err != nil {
+			return nil, err
+		}
+		if v.secret.EncVersion == models.EncVersionChunked {
+			return nil, fmt.Errorf("%w: version %d of secret %s is kept in chunks", ErrSecretNotFound,
+				secret.Version, name)
+		}
+	}
+	for _, version := range remove {
+		if _, err := past(version); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, secret := range versions {
+		v := s.versions[secret.Version-1]
+		v.secret.Data = bytes.Clone(secret.Data)
+		v.secret.EncVersion = secret.EncVersion
+		v.secret.Hash = secretHash(secret)
+	}
+	refs := []string{}
+	for _, version := range remove {
+		if v := s.versions[version-1]; v != nil && v.secret.BlobRef != "" && !contains(refs, v.secret.BlobRef) {
+			refs = append(refs, v.secret.BlobRef)
+		}
+		// numbers of versions are their positions, so the deleted version leaves a gap
+		s.versions[version-1] = nil
+	}
+	// blobs shared with the remaining versions are kept
+	unused := []string{}
+	for _, ref := range refs {
+		if !s.blobUsed(ref) {
+			unused = append(unused, ref)
+		}
+	}
+	return unused, nil
+}
+
+// SecretList returns a page of user secrets matching the filter in filter sort order, at most
+// filter.Limit secrets are returned. Pages are selected by keyset of the sort order as in PostgresDB.
+func (m *MemoryDB) SecretList(ctx context.Context, userid string,
+	filter models.SecretFilter) (*models.SecretList, error) {
+	if err := m.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer m.mu.Unlock()
+
+	secrets := models.SecretList{}
+	for name, s := range m.secrets[userid] {
+		current := s.current()
+		if !secretpath.InFolder(name, filter.Folder, filter.Recursive) || !filter.Selector.Matches(current.Labels) {
+			continue
+		}
+		if len(filter.Types) > 0 && !contains(filter.Types, current.Type) {
+			continue
+		}
+		item := models.SecretItem{
+			Name:       name,
+			Type:       current.Type,
+			Labels:     cloneLabels(current.Labels),
+			Version:    current.Version,
+			EncVersion: current.EncVersion,
+			UpdatedAt:  s.updatedAt,
+		}
+		if filter.After != nil && !secretBefore(filter.Sort, filter.After.UpdatedAt, filter.After.Name, item) {
+			continue
+		}
+		secrets = append(secrets, item)
+	}
+
+	sort.Slice(secrets, func(i, j int) bool {
+		return secretBefore(filter.Sort, secrets[i].UpdatedAt, secrets[i].Name, secrets[j])
+	})
+	if filter.Limit > 0 && len(secrets) > filter.Limit {
+		secrets = secrets[:filter.Limit]
+	}
+
+	if len(secrets) == 0 {
+		return nil, ErrNoSecrets
+	}
+	return &secrets, nil
+}
+
+// secretBefore reports whether the secret with updatedAt and name precedes item in the sort order.
+func secretBefore(order models.SecretSort, updatedAt time.Time, name string, item models.SecretItem) bool {
+	switch order {
+	case models.SortNameDesc:
+		return name > item.Name
+	case models.SortUpdatedDesc:
+		if !updatedAt.Equal(item.UpdatedAt) {
+			return updatedAt.After(item.UpdatedAt)
+		}
+		return name > item.Name
+	case models.SortUpdatedAsc:
+		if !updatedAt.Equal(item.UpdatedAt) {
+			return updatedAt.Before(item.UpdatedAt)
+		}
+		return name < item.Name
+	default:
+		return name < item.Name
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// SecretSearch returns user secrets which name, type or labels contain words of the query, words
+// match by prefix. Secrets are ranked with search.Rank, there is no similarity match of names.
+func (m *MemoryDB) SecretSearch(ctx context.Context, userid string, terms []string,
+	limit int) (*models.SecretSearchList, error) {
+	if err := m.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer m.mu.Unlock()
+
+	results := models.SecretSearchList{}
+	for name, s := range m.secrets[userid] {
+		current := s.current()
+		rank := search.Rank(terms, name, current.Type, current.Labels)
+		if rank == 0 {
+			continue
+		}
+		results = append(results, models.SecretSearchItem{
+			SecretItem: models.SecretItem{
+				Name:       name,
+				Type:       current.Type,
+				Labels:     cloneLabels(current.Labels),
+				Version:    current.Version,
+				EncVersion: current.EncVersion,
+			},
+			Rank: rank,
+		})
+	}
+	return rankResults(results, limit), nil
+}
+
+// SecretFolders returns immediate subfolders of filter folder which contain secrets matching
+// filter label selector.
+func (m *MemoryDB) SecretFolders(ctx context.Context, userid string, filter models.SecretFilter) ([]string, error) {
+	if err := m.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer m.mu.Unlock()
+
+	seen := make(map[string]struct{})
+	folders := make([]string, 0)
+	for name, s := range m.secrets[userid] {
+		folder, ok := secretpath.Subfolder(name, filter.Folder)
+		if !ok || !filter.Selector.Matches(s.current().Labels) {
+			continue
+		}
+		if _, ok = seen[folder]; !ok {
+			seen[folder] = struct{}{}
+			folders = append(folders, folder)
+		}
+	}
+	sort.Strings(folders)
+	return folders, nil
+}
+
+// SecretMove renames secret, or every secret of the folder if from ends with '/'. Version history,
+// chunks and shares follow the secret. Number of moved secrets is returned.
+func (m *MemoryDB) SecretMove(ctx context.Context, userid string, from string, to string) (int64, error) {
+	if err := m.lock(ctx); err != nil {
+		return 0, err
+	}
+	defer m.mu.Unlock()
+
+	secrets := m.secrets[userid]
+	moves := make(map[string]string)
+	for name := range secrets {
+		switch {
+		case secretpath.IsFolder(from) && strings.HasPrefix(name, from):
+			moves[name] = to + strings.TrimPrefix(name, from)
+		case name == from:
+			moves[name] = to
+		}
+	}
+	if len(moves) == 0 {
+		return 0, ErrSecretNotFound
+	}
+	for _, target := range moves {
+		if _, moved := moves[target]; !moved {
+			if _, ok := secrets[target]; ok {
+				return 0, ErrSecretAlreadyExists
+			}
+		}
+	}
+
+	moved := make(map[string]*memorySecret, len(moves))
+	for name := range moves {
+		moved[name] = secrets[name]
+		delete(secrets, name)
+	}
+	for name, target := range moves {
+		secrets[target] = moved[name]
+	}
+	movedShares := make(map[memoryShareKey]*models.Share)
+	for key, share := range m.shares {
+		if target, ok := moves[key.name]; ok && key.owner == userid {
+			delete(m.shares, key)
+			key.name, share.Name = target, target
+			movedShares[key] = share
+		}
+	}
+	maps.Copy(m.shares, movedShares)
+	return int64(len(moves)), nil
+}
+
+// Close does nothing, it is there to match other backends.
+func (m *MemoryDB) Close() {}
@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"context"
+	"github.com/vkupriya/gophkeeper/internal/server/auditlog"
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+)
+
+// AuditAdd appends the event to the audit log chained to the last event by hash.
+func (m *MemoryDB) AuditAdd(ctx context.Context, event *models.AuditEvent) error {
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+	defer m.mu.Unlock()
+
+	prev := auditlog.Genesis
+	if n := len(m.audit); n > 0 {
+		prev = m.audit[n-1].Hash
+	}
+
+	r := models.AuditRecord{AuditEvent: *event, PrevHash: prev}
+	r.ID = int64(len(m.audit)) + 1
+	r.CreatedAt = nowMicro()
+	r.Hash = auditlog.Hash(prev, &r.AuditEvent)
+	m.audit = append(m.audit, r)
+	return nil
+}
+
+// AuditList returns events of the user, newest first.
+func (m *MemoryDB) AuditList(ctx context.Context, userid string, filter models.AuditFilter) (*models.AuditEventList,
+	error) {
+	if err := m.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer m.mu.Unlock()
+
+	events := models.AuditEventList{}
+	// events are appended in order of time
+	for i := len(m.audit) - 1; i >= 0 && len(events) < filter.Limit; i-- {
+		e := m.audit[i].AuditEvent
+		if e.UserID != userid {
+			continue
+		}
+		if (!filter.From.IsZero() && e.CreatedAt.Before(filter.From)) ||
+			(!filter.To.IsZero() && !e.CreatedAt.Before(filter.To)) {
+			continue
+		}
+		events = append(events, e)
+	}
+	return &events, nil
+}
+
+// AuditChain returns up to limit events following the event afterID in the order of IDs.
+func (m *MemoryDB) AuditChain(ctx context.Context, afterID int64, limit int) ([]models.AuditRecord, error) {
+	if err := m.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer m.mu.Unlock()
+
+	records := []models.AuditRecord{}
+	// ID of the event is its position in the log plus one
+	for i := max(afterID, 0); i < int64(len(m.audit)) && len(records) < limit; i++ {
+		records = append(records, m.audit[i])
+	}
+	return records, nil
+}
+
+// AuditChainStart returns ID of the first event which must be chained, every event is chained
+// in memory.
+func (m *MemoryDB) AuditChainStart(_ context.Context) (int64, error) {
+	return 1, nil
+}
+
+// AuditHead returns ID and hash of the last event, nil is returned if the audit log is empty.
+func (m *MemoryDB) AuditHead(ctx context.Context) (*models.AuditRecord, error) {
+	if err := m.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer m.mu.Unlock()
+
+	if len(m.audit) == 0 {
+		return nil, nil
+	}
+	r := m.audit[len(m.audit)-1]
+	return &models.AuditRecord{AuditEvent: models.AuditEvent{ID: r.ID}, Hash: r.Hash}, nil
+}
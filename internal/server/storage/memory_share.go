@@ -0,0 +1,188 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+)
+
+type memoryShareKey struct {
+	owner     string
+	name      string
+	recipient string
+}
+
+// UserSetPublicKey registers public key of the user, secrets shared with the user are sealed with it.
+func (m *MemoryDB) UserSetPublicKey(ctx context.Context, userid string, publicKey []byte) error {
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+	defer m.mu.Unlock()
+
+	u, ok := m.users[userid]
+	if !ok {
+		return ErrUserNotFound
+	}
+	u.publicKey = bytes.Clone(publicKey)
+	return nil
+}
+
+// UserGetPublicKey returns public key of the user, ErrPublicKeyNotFound is returned if the user has
+// not registered it yet.
+func (m *MemoryDB) UserGetPublicKey(ctx context.Context, userid string) ([]byte, error) {
+	if err := m.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer m.mu.Unlock()
+
+	u, ok := m.users[userid]
+	switch {
+	case !ok:
+		return nil, ErrUserNotFound
+	case u.publicKey == nil:
+		return nil, ErrPublicKeyNotFound
+	}
+	return bytes.Clone(u.publicKey), nil
+}
+
+// ShareAdd shares owner secret with the recipient or replaces the existing share. Shared data must
+// be sealed from the current version of the secret, ErrVersionConflict is returned otherwise.
+func (m *MemoryDB) ShareAdd(ctx context.Context, share *models.Share) error {
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+	defer m.mu.Unlock()
+
+	s, ok := m.secrets[share.Owner][share.Name]
+	if !ok {
+		return ErrSecretNotFound
+	}
+	if current := s.current().Version; current != share.Version {
+		return fmt.Errorf("%w: shared version %d, current version %d", ErrVersionConflict, share.Version, current)
+	}
+	if _, ok = m.users[share.Recipient]; !ok {
+		return ErrUserNotFound
+	}
+
+	m.shares[memoryShareKey{owner: share.Owner, name: share.Name, recipient: share.Recipient}] = &models.Share{
+		Owner:      share.Owner,
+		Name:       share.Name,
+		Recipient:  share.Recipient,
+		Access:     share.Access,
+		Data:       bytes.Clone(share.Data),
+		EncVersion: share.EncVersion,
+		Version:    share.Version,
+		SharedAt:   nowMicro(),
+	}
+	return nil
+}
+
+// ShareRevoke deletes share of owner secret with the recipient.
+func (m *MemoryDB) ShareRevoke(ctx context.Context, owner string, name string, recipient string) error {
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+	defer m.mu.Unlock()
+
+	key := memoryShareKey{owner: owner, name: name, recipient: recipient}
+	if _, ok := m.shares[key]; !ok {
+		return ErrShareNotFound
+	}
+	delete(m.shares, key)
+	return nil
+}
+
+// ShareList returns secrets shared with the user, or secrets shared by the user if outgoing is set.
+// Shares do not include data.
+func (m *MemoryDB) ShareList(ctx context.Context, userid string, outgoing bool) (*models.ShareList, error) {
+	if err := m.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer m.mu.Unlock()
+
+	shares := models.ShareList{}
+	for key, stored := range m.shares {
+		if (outgoing && key.owner != userid) || (!outgoing && key.recipient != userid) {
+			continue
+		}
+		current := m.secrets[key.owner][key.name].current()
+		shares = append(shares, models.Share{
+			Owner:          stored.Owner,
+			Name:           stored.Name,
+			Recipient:      stored.Recipient,
+			Access:         stored.Access,
+			Type:           current.Type,
+			Version:        stored.Version,
+			CurrentVersion: current.Version,
+			SharedAt:       stored.SharedAt,
+		})
+	}
+
+	sort.Slice(shares, func(i, j int) bool {
+		a, b := shares[i], shares[j]
+		if outgoing {
+			return a.Name < b.Name || (a.Name == b.Name && a.Recipient < b.Recipient)
+		}
+		return a.Owner < b.Owner || (a.Owner == b.Owner && a.Name < b.Name)
+	})
+	return &shares, nil
+}
+
+// ShareGet returns secret shared by the owner with the recipient.
+func (m *MemoryDB) ShareGet(ctx context.Context, recipient string, owner string, name string) (*models.Share, error) {
+	if err := m.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer m.mu.Unlock()
+
+	stored, ok := m.shares[memoryShareKey{owner: owner, name: name, recipient: recipient}]
+	if !ok {
+		return nil, ErrShareNotFound
+	}
+	current := m.secrets[owner][name].current()
+	sh := *stored
+	sh.Type = current.Type
+	sh.Labels = cloneLabels(current.Labels)
+	sh.Data = bytes.Clone(stored.Data)
+	sh.CurrentVersion = current.Version
+	return &sh, nil
+}
+
+// ShareUpdate stores new data of the secret shared read-write with the recipient: ownerData sealed for
+// the owner becomes the next version of the secret and share.Data replaces the recipient copy.
+// share.Version is the expected current version of the secret, it is set to the new version.
+func (m *MemoryDB) ShareUpdate(ctx context.Context, share *models.Share, ownerData []byte) error {
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+	defer m.mu.Unlock()
+
+	stored, ok := m.shares[memoryShareKey{owner: share.Owner, name: share.Name, recipient: share.Recipient}]
+	if !ok {
+		return ErrShareNotFound
+	}
+	if stored.Access != models.AccessReadWrite {
+		return ErrShareReadOnly
+	}
+
+	s, err := m.checkUpdate(share.Owner, share.Name, share.Version)
+	if err != nil {
+		return err
+	}
+	secret := &models.Secret{
+		Name:       share.Name,
+		Labels:     s.current().Labels,
+		Data:       ownerData,
+		EncVersion: share.EncVersion,
+	}
+	s.update(secret, nil)
+
+	stored.Data = bytes.Clone(share.Data)
+	stored.EncVersion = share.EncVersion
+	stored.Version = secret.Version
+	share.Version = secret.Version
+	return nil
+}
@@ -0,0 +1,241 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+	"github.com/vkupriya/gophkeeper/internal/vault"
+)
+
+type memoryVaultKey struct {
+	org  string
+	name string
+}
+
+// memoryMemberKey identifies membership of the user in the vault, empty vault is the organization.
+type memoryMemberKey struct {
+	org    string
+	vault  string
+	userid string
+}
+
+type memoryMember struct {
+	role      vault.Role
+	sealedKey []byte
+}
+
+// VaultCreate creates vault of the organization and its principal user, the user becomes vault owner
+// with the vault key sealed for the user. Organization is created with the user as its owner
+// if it does not exist, otherwise the user must be its owner.
+func (m *MemoryDB) VaultCreate(ctx context.Context, userid string, org string, name string, sealedKey []byte) error {
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+	defer m.mu.Unlock()
+
+	_, orgExists := m.orgs[org]
+	if orgExists && m.memberRole(userid, org, "") != vault.RoleOwner {
+		return ErrNotOrgOwner
+	}
+	principal := vault.Principal(org, name)
+	if _, ok := m.users[principal]; ok {
+		return ErrVaultAlreadyExists
+	}
+
+	if !orgExists {
+		m.orgs[org] = struct{}{}
+		m.members[memoryMemberKey{org: org, userid: userid}] = &memoryMember{role: vault.RoleOwner}
+	}
+	// principal has no password, so nobody can login as the vault
+	m.users[principal] = &memoryUser{}
+	m.vaults[memoryVaultKey{org: org, name: name}] = principal
+	m.members[memoryMemberKey{org: org, vault: name, userid: userid}] = &memoryMember{
+		role:      vault.RoleOwner,
+		sealedKey: bytes.Clone(sealedKey),
+	}
+	return nil
+}
+
+// VaultRole returns the role of the user in the vault and the principal owning vault secrets,
+// empty role is returned if the user is not a member of the vault or its organization.
+func (m *MemoryDB) VaultRole(ctx context.Context, userid string, org string, name string) (vault.Role, string, error) {
+	if err := m.lock(ctx); err != nil {
+		return "", "", err
+	}
+	defer m.mu.Unlock()
+
+	principal, ok := m.vaults[memoryVaultKey{org: org, name: name}]
+	if !ok {
+		return "", "", ErrVaultNotFound
+	}
+	return vault.Max(m.memberRole(userid, org, ""), m.memberRole(userid, org, name)), principal, nil
+}
+
+// OrgRole returns the role of the user in the organization, empty role is returned if the user
+// is not its member.
+func (m *MemoryDB) OrgRole(ctx context.Context, userid string, org string) (vault.Role, error) {
+	if err := m.lock(ctx); err != nil {
+		return "", err
+	}
+	defer m.mu.Unlock()
+
+	return m.memberRole(userid, org, ""), nil
+}
+
+// VaultKey returns the vault key sealed for the user.
+func (m *MemoryDB) VaultKey(ctx context.Context, userid string, org string, name string) ([]byte, error) {
+	if err := m.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer m.mu.Unlock()
+
+	member, ok := m.members[memoryMemberKey{org: org, vault: name, userid: userid}]
+	if !ok || len(member.sealedKey) == 0 {
+		return nil, ErrVaultKeyNotFound
+	}
+	return bytes.Clone(member.sealedKey), nil
+}
+
+// VaultKeySet replaces the vault key sealed for the user, ErrVaultKeyNotFound is returned if the user
+// has no key of the vault.
+func (m *MemoryDB) VaultKeySet(ctx context.Context, userid string, org string, name string, sealedKey []byte) error {
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+	defer m.mu.Unlock()
+
+	member, ok := m.members[memoryMemberKey{org: org, vault: name, userid: userid}]
+	if !ok || len(member.sealedKey) == 0 {
+		return ErrVaultKeyNotFound
+	}
+	member.sealedKey = bytes.Clone(sealedKey)
+	return nil
+}
+
+// VaultList returns vaults where the user is a member directly or by the organization.
+func (m *MemoryDB) VaultList(ctx context.Context, userid string) (*models.VaultList, error) {
+	if err := m.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer m.mu.Unlock()
+
+	vaults := models.VaultList{}
+	for key := range m.vaults {
+		role := vault.Max(m.memberRole(userid, key.org, ""), m.memberRole(userid, key.org, key.name))
+		if role != "" {
+			vaults = append(vaults, models.Vault{Org: key.org, Name: key.name, Role: role})
+		}
+	}
+
+	sort.Slice(vaults, func(i, j int) bool {
+		a, b := vaults[i], vaults[j]
+		return a.Org < b.Org || (a.Org == b.Org && a.Name < b.Name)
+	})
+	return &vaults, nil
+}
+
+// VaultMemberSet adds member to the vault or changes the member role and sealed vault key,
+// empty vault is the organization.
+func (m *MemoryDB) VaultMemberSet(ctx context.Context, org string, name string, userid string,
+	role vault.Role, sealedKey []byte) error {
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+	defer m.mu.Unlock()
+
+	if err := m.vaultExists(org, name); err != nil {
+		return err
+	}
+	if _, ok := m.users[userid]; !ok {
+		return ErrUserNotFound
+	}
+
+	key := memoryMemberKey{org: org, vault: name, userid: userid}
+	previous, existed := m.members[key]
+	m.members[key] = &memoryMember{role: role, sealedKey: bytes.Clone(sealedKey)}
+	if err := m.checkOwners(org); err != nil {
+		if existed {
+			m.members[key] = previous
+		} else {
+			delete(m.members, key)
+		}
+		return err
+	}
+	return nil
+}
+
+// VaultMemberDelete removes member from the vault, empty vault is the organization.
+func (m *MemoryDB) VaultMemberDelete(ctx context.Context, org string, name string, userid string) error {
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+	defer m.mu.Unlock()
+
+	key := memoryMemberKey{org: org, vault: name, userid: userid}
+	previous, ok := m.members[key]
+	if !ok {
+		return ErrMemberNotFound
+	}
+	delete(m.members, key)
+	if err := m.checkOwners(org); err != nil {
+		m.members[key] = previous
+		return err
+	}
+	return nil
+}
+
+// VaultMemberList returns members of the organization and of the vault unless it is empty.
+func (m *MemoryDB) VaultMemberList(ctx context.Context, org string, name string) (*models.VaultMemberList, error) {
+	if err := m.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer m.mu.Unlock()
+
+	members := models.VaultMemberList{}
+	for key, member := range m.members {
+		if key.org == org && (key.vault == "" || key.vault == name) {
+			members = append(members, models.VaultMember{UserID: key.userid, Vault: key.vault, Role: member.role})
+		}
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		a, b := members[i], members[j]
+		return a.Vault < b.Vault || (a.Vault == b.Vault && a.UserID < b.UserID)
+	})
+	return &members, nil
+}
+
+// memberRole returns role of direct membership of the user in the vault, empty vault is
+// the organization.
+func (m *MemoryDB) memberRole(userid string, org string, name string) vault.Role {
+	if member, ok := m.members[memoryMemberKey{org: org, vault: name, userid: userid}]; ok {
+		return member.role
+	}
+	return ""
+}
+
+// vaultExists checks that the vault exists, empty vault is the organization.
+func (m *MemoryDB) vaultExists(org string, name string) error {
+	exists := false
+	if name == "" {
+		_, exists = m.orgs[org]
+	} else {
+		_, exists = m.vaults[memoryVaultKey{org: org, name: name}]
+	}
+	if !exists {
+		return ErrVaultNotFound
+	}
+	return nil
+}
+
+// checkOwners fails if the organization is left without owners.
+func (m *MemoryDB) checkOwners(org string) error {
+	for key, member := range m.members {
+		if key.org == org && key.vault == "" && member.role == vault.RoleOwner {
+			return nil
+		}
+	}
+	return ErrLastOwner
+}
@@ -0,0 +1,7 @@
+BEGIN TRANSACTION;
+
+-- enc_version 0 marks rows encrypted by the server with the user secret key,
+-- rows added by clients with end-to-end encryption have enc_version 1.
+ALTER TABLE secrets ADD COLUMN enc_version INTEGER NOT NULL DEFAULT 0;
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN TRANSACTION;
+
+-- secret names are unique per user only
+ALTER TABLE secrets DROP CONSTRAINT IF EXISTS secrets_name_key;
+ALTER TABLE secrets DROP CONSTRAINT secrets_pkey;
+ALTER TABLE secrets ADD PRIMARY KEY (userid, name);
+ALTER TABLE secrets ADD CONSTRAINT secrets_userid_fkey
+    FOREIGN KEY (userid) REFERENCES users (userid) ON DELETE CASCADE;
+
+COMMIT;
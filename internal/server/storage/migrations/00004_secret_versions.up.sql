@@ -0,0 +1,20 @@
+BEGIN TRANSACTION;
+
+CREATE TABLE secret_versions(
+    userid VARCHAR(200) NOT NULL,
+    name VARCHAR(200) NOT NULL,
+    version BIGINT NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    meta VARCHAR(255),
+    data BYTEA NOT NULL,
+    enc_version INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (userid, name, version),
+    FOREIGN KEY (userid, name) REFERENCES secrets (userid, name) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+-- current value of every secret becomes the first known revision
+INSERT INTO secret_versions (userid, name, version, type, meta, data, enc_version)
+    SELECT userid, name, version, type, meta, data, enc_version FROM secrets;
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN TRANSACTION;
+
+CREATE TABLE refresh_tokens(
+    token_hash VARCHAR(64) NOT NULL,
+    userid VARCHAR(200) NOT NULL REFERENCES users (userid) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (token_hash)
+);
+
+CREATE INDEX refresh_tokens_userid_idx ON refresh_tokens (userid);
+
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN TRANSACTION;
+
+-- issued access tokens, revoked ones are rejected by AuthInterceptor until they expire
+CREATE TABLE access_tokens(
+    jti VARCHAR(64) NOT NULL,
+    userid VARCHAR(200) NOT NULL REFERENCES users (userid) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (jti)
+);
+
+CREATE INDEX access_tokens_userid_idx ON access_tokens (userid);
+CREATE INDEX access_tokens_revoked_idx ON access_tokens (expires_at) WHERE revoked;
+
+COMMIT;
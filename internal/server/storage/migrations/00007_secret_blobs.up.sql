@@ -0,0 +1,15 @@
+BEGIN TRANSACTION;
+
+-- data of large secrets uploaded by chunks, secret_versions.data is empty for such secrets
+CREATE TABLE secret_blobs(
+    userid VARCHAR(200) NOT NULL,
+    name VARCHAR(200) NOT NULL,
+    version BIGINT NOT NULL,
+    seq INTEGER NOT NULL,
+    data BYTEA NOT NULL,
+    PRIMARY KEY (userid, name, version, seq),
+    FOREIGN KEY (userid, name, version) REFERENCES secret_versions (userid, name, version)
+        ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+COMMIT;
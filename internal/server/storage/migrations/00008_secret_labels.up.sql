@@ -0,0 +1,14 @@
+BEGIN TRANSACTION;
+
+-- free-form meta is replaced by labels, existing meta is kept as 'meta' label
+ALTER TABLE secrets ADD COLUMN labels JSONB NOT NULL DEFAULT '{}'::jsonb;
+UPDATE secrets SET labels = jsonb_build_object('meta', meta) WHERE meta IS NOT NULL AND meta <> '';
+ALTER TABLE secrets DROP COLUMN meta;
+
+ALTER TABLE secret_versions ADD COLUMN labels JSONB NOT NULL DEFAULT '{}'::jsonb;
+UPDATE secret_versions SET labels = jsonb_build_object('meta', meta) WHERE meta IS NOT NULL AND meta <> '';
+ALTER TABLE secret_versions DROP COLUMN meta;
+
+CREATE INDEX secrets_labels_idx ON secrets USING GIN (labels);
+
+COMMIT;
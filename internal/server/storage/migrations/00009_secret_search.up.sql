@@ -0,0 +1,16 @@
+BEGIN TRANSACTION;
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- name segments weigh more than type and labels, path separators are replaced so that
+-- 'work/aws/prod' is not parsed as a single file path token
+ALTER TABLE secrets ADD COLUMN search TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', translate(name, '/-_.', '    ')), 'A') ||
+    setweight(to_tsvector('simple', type), 'B') ||
+    setweight(jsonb_to_tsvector('simple', labels, '["key", "string"]'), 'C')
+) STORED;
+
+CREATE INDEX secrets_search_idx ON secrets USING GIN (search);
+CREATE INDEX secrets_name_trgm_idx ON secrets USING GIN (name gin_trgm_ops);
+
+COMMIT;
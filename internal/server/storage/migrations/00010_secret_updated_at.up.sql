@@ -0,0 +1,13 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE secrets ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+-- existing secrets were last updated when their current version was created
+UPDATE secrets s SET updated_at = v.created_at
+    FROM secret_versions v
+    WHERE v.userid = s.userid AND v.name = s.name AND v.version = s.version;
+
+-- keyset pagination of ListSecrets by name is served by the primary key
+CREATE INDEX secrets_updated_at_idx ON secrets (userid, updated_at, name);
+
+COMMIT;
@@ -0,0 +1,22 @@
+BEGIN TRANSACTION;
+
+-- X25519 public key, secrets shared with the user are sealed with it by the owner client
+ALTER TABLE users ADD COLUMN public_key BYTEA;
+
+-- copy of the secret sealed for the recipient, version is the version of the shared data
+CREATE TABLE shared_secrets(
+    owner VARCHAR(200) NOT NULL,
+    name VARCHAR(200) NOT NULL,
+    recipient VARCHAR(200) NOT NULL REFERENCES users (userid) ON DELETE CASCADE,
+    access VARCHAR(8) NOT NULL,
+    data BYTEA NOT NULL,
+    enc_version INTEGER NOT NULL,
+    version BIGINT NOT NULL,
+    shared_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (owner, name, recipient),
+    FOREIGN KEY (owner, name) REFERENCES secrets (userid, name) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX shared_secrets_recipient_idx ON shared_secrets (recipient);
+
+COMMIT;
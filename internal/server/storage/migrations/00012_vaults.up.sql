@@ -0,0 +1,31 @@
+BEGIN TRANSACTION;
+
+CREATE TABLE organizations(
+    name VARCHAR(100) PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- secrets of the vault are owned by principal user '@org/vault' which has no password,
+-- so the vault secrets follow the same schema and cascades as secrets of a user
+CREATE TABLE vaults(
+    org VARCHAR(100) NOT NULL REFERENCES organizations (name) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    principal VARCHAR(200) NOT NULL UNIQUE REFERENCES users (userid) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (org, name)
+);
+
+-- empty vault is membership in the organization, which gives the role in every vault of it;
+-- sealed_key is the vault key sealed with the member public key, it is set for vault members only
+CREATE TABLE vault_members(
+    org VARCHAR(100) NOT NULL REFERENCES organizations (name) ON DELETE CASCADE,
+    vault VARCHAR(100) NOT NULL DEFAULT '',
+    userid VARCHAR(200) NOT NULL REFERENCES users (userid) ON DELETE CASCADE,
+    role VARCHAR(16) NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    sealed_key BYTEA,
+    PRIMARY KEY (org, vault, userid)
+);
+
+CREATE INDEX vault_members_userid_idx ON vault_members (userid);
+
+COMMIT;
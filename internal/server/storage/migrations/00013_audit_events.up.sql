@@ -0,0 +1,27 @@
+BEGIN TRANSACTION;
+
+-- audit of secret access and mutations written by the server interceptor, rows are never changed,
+-- so there are no foreign keys and the table survives deletion of users and vaults
+CREATE TABLE audit_events(
+    id BIGSERIAL PRIMARY KEY,
+    userid VARCHAR(200) NOT NULL,
+    owner VARCHAR(200) NOT NULL,
+    method VARCHAR(100) NOT NULL,
+    name VARCHAR(200) NOT NULL DEFAULT '',
+    client_ip VARCHAR(64) NOT NULL DEFAULT '',
+    code VARCHAR(32) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX audit_events_userid_idx ON audit_events (userid, created_at);
+
+CREATE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only BEFORE UPDATE OR DELETE OR TRUNCATE ON audit_events
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_events_append_only();
+
+COMMIT;
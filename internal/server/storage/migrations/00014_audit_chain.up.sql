@@ -0,0 +1,7 @@
+BEGIN TRANSACTION;
+
+-- every event is chained to the previous one by hash, so that changes made bypassing the
+-- append-only trigger are detected; events written before have no hashes
+ALTER TABLE audit_events ADD COLUMN prev_hash BYTEA, ADD COLUMN hash BYTEA;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN TRANSACTION;
+
+-- SHA-256 hash of secret data is verified when the secret is read, rows saved before have no hash
+ALTER TABLE secrets ADD COLUMN hash BYTEA;
+ALTER TABLE secret_versions ADD COLUMN hash BYTEA;
+
+COMMIT;
@@ -0,0 +1,22 @@
+BEGIN TRANSACTION;
+
+-- events from the first chained one must have hashes, so that removing hashes of the whole chain is
+-- detected; if no event is chained yet, the chain starts with the next event
+CREATE TABLE audit_chain_start(
+    id BIGINT NOT NULL
+);
+
+INSERT INTO audit_chain_start (id)
+    SELECT COALESCE((SELECT MIN(id) FROM audit_events WHERE hash IS NOT NULL),
+        (SELECT MAX(id) + 1 FROM audit_events), 1);
+
+CREATE FUNCTION audit_chain_start_read_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_chain_start is read-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_chain_start_read_only BEFORE INSERT OR UPDATE OR DELETE OR TRUNCATE ON audit_chain_start
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_chain_start_read_only();
+
+COMMIT;
@@ -0,0 +1,125 @@
+-- schema of sqlite backend matches PostgreSQL schema after all its migrations, times are
+-- Unix microseconds and labels are JSON objects
+
+CREATE TABLE users(
+    userid TEXT NOT NULL PRIMARY KEY,
+    password TEXT NOT NULL,
+    public_key BLOB
+);
+
+CREATE TABLE secrets(
+    userid TEXT NOT NULL REFERENCES users (userid) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL,
+    labels TEXT NOT NULL DEFAULT '{}',
+    data BLOB NOT NULL,
+    version INTEGER NOT NULL,
+    enc_version INTEGER NOT NULL DEFAULT 0,
+    updated_at INTEGER NOT NULL,
+    PRIMARY KEY (userid, name)
+);
+
+CREATE INDEX secrets_updated_at_idx ON secrets (userid, updated_at, name);
+
+CREATE TABLE secret_versions(
+    userid TEXT NOT NULL,
+    name TEXT NOT NULL,
+    version INTEGER NOT NULL,
+    type TEXT NOT NULL,
+    labels TEXT NOT NULL DEFAULT '{}',
+    data BLOB NOT NULL,
+    enc_version INTEGER NOT NULL DEFAULT 0,
+    created_at INTEGER NOT NULL,
+    PRIMARY KEY (userid, name, version),
+    FOREIGN KEY (userid, name) REFERENCES secrets (userid, name) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE secret_blobs(
+    userid TEXT NOT NULL,
+    name TEXT NOT NULL,
+    version INTEGER NOT NULL,
+    seq INTEGER NOT NULL,
+    data BLOB NOT NULL,
+    PRIMARY KEY (userid, name, version, seq),
+    FOREIGN KEY (userid, name, version) REFERENCES secret_versions (userid, name, version)
+        ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE refresh_tokens(
+    token_hash TEXT NOT NULL PRIMARY KEY,
+    userid TEXT NOT NULL REFERENCES users (userid) ON DELETE CASCADE,
+    expires_at INTEGER NOT NULL
+);
+
+CREATE INDEX refresh_tokens_userid_idx ON refresh_tokens (userid);
+
+CREATE TABLE access_tokens(
+    jti TEXT NOT NULL PRIMARY KEY,
+    userid TEXT NOT NULL REFERENCES users (userid) ON DELETE CASCADE,
+    expires_at INTEGER NOT NULL,
+    revoked INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX access_tokens_userid_idx ON access_tokens (userid);
+
+CREATE TABLE shared_secrets(
+    owner TEXT NOT NULL,
+    name TEXT NOT NULL,
+    recipient TEXT NOT NULL REFERENCES users (userid) ON DELETE CASCADE,
+    access TEXT NOT NULL,
+    data BLOB NOT NULL,
+    enc_version INTEGER NOT NULL,
+    version INTEGER NOT NULL,
+    shared_at INTEGER NOT NULL,
+    PRIMARY KEY (owner, name, recipient),
+    FOREIGN KEY (owner, name) REFERENCES secrets (userid, name) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX shared_secrets_recipient_idx ON shared_secrets (recipient);
+
+CREATE TABLE organizations(
+    name TEXT NOT NULL PRIMARY KEY
+);
+
+CREATE TABLE vaults(
+    org TEXT NOT NULL REFERENCES organizations (name) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    principal TEXT NOT NULL UNIQUE REFERENCES users (userid) ON DELETE CASCADE,
+    PRIMARY KEY (org, name)
+);
+
+CREATE TABLE vault_members(
+    org TEXT NOT NULL REFERENCES organizations (name) ON DELETE CASCADE,
+    vault TEXT NOT NULL DEFAULT '',
+    userid TEXT NOT NULL REFERENCES users (userid) ON DELETE CASCADE,
+    role TEXT NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    sealed_key BLOB,
+    PRIMARY KEY (org, vault, userid)
+);
+
+CREATE INDEX vault_members_userid_idx ON vault_members (userid);
+
+CREATE TABLE audit_events(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    userid TEXT NOT NULL,
+    owner TEXT NOT NULL,
+    method TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    client_ip TEXT NOT NULL DEFAULT '',
+    code TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    prev_hash BLOB,
+    hash BLOB
+);
+
+CREATE INDEX audit_events_userid_idx ON audit_events (userid, created_at);
+
+CREATE TRIGGER audit_events_no_update BEFORE UPDATE ON audit_events
+BEGIN
+    SELECT RAISE(ABORT, 'audit_events is append-only');
+END;
+
+CREATE TRIGGER audit_events_no_delete BEFORE DELETE ON audit_events
+BEGIN
+    SELECT RAISE(ABORT, 'audit_events is append-only');
+END;
@@ -0,0 +1,6 @@
+-- data of large file and binary secrets is kept in the blob store, the row keeps the reference
+-- to blob objects and SHA-256 hash of the data
+ALTER TABLE secrets ADD COLUMN blob_ref TEXT;
+ALTER TABLE secrets ADD COLUMN blob_hash BLOB;
+ALTER TABLE secret_versions ADD COLUMN blob_ref TEXT;
+ALTER TABLE secret_versions ADD COLUMN blob_hash BLOB;
@@ -0,0 +1,3 @@
+-- SHA-256 hash of secret data is verified when the secret is read, rows saved before have no hash
+ALTER TABLE secrets ADD COLUMN hash BLOB;
+ALTER TABLE secret_versions ADD COLUMN hash BLOB;
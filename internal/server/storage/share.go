@@ -0,0 +1,229 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+)
+
+// UserSetPublicKey registers public key of the user, secrets shared with the user are sealed with it.
+func (p *PostgresDB) UserSetPublicKey(ctx context.Context, userid string, publicKey []byte) error {
+	db := p.pool
+
+	tag, err := db.Exec(ctx, "UPDATE users SET public_key=$1 WHERE userid=$2", publicKey, userid)
+	if err != nil {
+		return fmt.Errorf("failed to set public key of user %s: %w", userid, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// UserGetPublicKey returns public key of the user, ErrPublicKeyNotFound is returned if the user has
+// not registered it yet.
+func (p *PostgresDB) UserGetPublicKey(ctx context.Context, userid string) ([]byte, error) {
+	db := p.pool
+	var publicKey []byte
+
+	err := db.QueryRow(ctx, "SELECT public_key FROM users WHERE userid=$1", userid).Scan(&publicKey)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return nil, ErrUserNotFound
+	case err != nil:
+		return nil, fmt.Errorf("failed to query public key of user %s: %w", userid, err)
+	case publicKey == nil:
+		return nil, ErrPublicKeyNotFound
+	}
+	return publicKey, nil
+}
+
+// ShareAdd shares owner secret with the recipient or replaces the existing share. Shared data must
+// be sealed from the current version of the secret, ErrVersionConflict is returned otherwise.
+func (p *PostgresDB) ShareAdd(ctx context.Context, share *models.Share) error {
+	db := p.pool
+	var pgErr *pgconn.PgError
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	// the secret must not change until the share is stored
+	var current int64
+	row := tx.QueryRow(ctx, "SELECT version FROM secrets WHERE userid=$1 AND name=$2 FOR SHARE",
+		share.Owner, share.Name)
+	err = row.Scan(&current)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return ErrSecretNotFound
+	case err != nil:
+		return fmt.Errorf("failed to query secret %s version: %w", share.Name, err)
+	}
+	if current != share.Version {
+		return fmt.Errorf("%w: shared version %d, current version %d", ErrVersionConflict, share.Version, current)
+	}
+
+	querySQL := "INSERT INTO shared_secrets (owner, name, recipient, access, data, enc_version, version) " +
+		"VALUES($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (owner, name, recipient) DO UPDATE SET " +
+		"access=EXCLUDED.access, data=EXCLUDED.data, enc_version=EXCLUDED.enc_version, " +
+		"version=EXCLUDED.version, shared_at=now()"
+	_, err = tx.Exec(ctx, querySQL, share.Owner, share.Name, share.Recipient, share.Access, share.Data,
+		share.EncVersion, share.Version)
+	if err != nil {
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to share secret %s with %s: %w", share.Name, share.Recipient, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit share of secret %s: %w", share.Name, err)
+	}
+	return nil
+}
+
+// ShareRevoke deletes share of owner secret with the recipient.
+func (p *PostgresDB) ShareRevoke(ctx context.Context, owner string, name string, recipient string) error {
+	db := p.pool
+
+	tag, err := db.Exec(ctx, "DELETE FROM shared_secrets WHERE owner=$1 AND name=$2 AND recipient=$3",
+		owner, name, recipient)
+	if err != nil {
+		return fmt.Errorf("failed to revoke share of secret %s with %s: %w", name, recipient, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrShareNotFound
+	}
+	return nil
+}
+
+// ShareList returns secrets shared with the user, or secrets shared by the user if outgoing is set.
+// Shares do not include data.
+func (p *PostgresDB) ShareList(ctx context.Context, userid string, outgoing bool) (*models.ShareList, error) {
+	db := p.pool
+	shares := models.ShareList{}
+
+	querySQL := "SELECT sh.owner, sh.name, sh.recipient, sh.access, s.type, sh.version, s.version, sh.shared_at " +
+		"FROM shared_secrets sh JOIN secrets s ON s.userid = sh.owner AND s.name = sh.name "
+	if outgoing {
+		querySQL += "WHERE sh.owner=$1 ORDER BY sh.name, sh.recipient"
+	} else {
+		querySQL += "WHERE sh.recipient=$1 ORDER BY sh.owner, sh.name"
+	}
+
+	rows, err := db.Query(ctx, querySQL, userid)
+	if err != nil {
+		return nil, fmt.Errorf("error querying shared secrets: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sh models.Share
+		if err = rows.Scan(
+			&sh.Owner,
+			&sh.Name,
+			&sh.Recipient,
+			&sh.Access,
+			&sh.Type,
+			&sh.Version,
+			&sh.CurrentVersion,
+			&sh.SharedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row of shared secrets: %w", err)
+		}
+		shares = append(shares, sh)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan rows of shared secrets: %w", err)
+	}
+	return &shares, nil
+}
+
+// ShareGet returns secret shared by the owner with the recipient.
+func (p *PostgresDB) ShareGet(ctx context.Context, recipient string, owner string, name string) (*models.Share, error) {
+	db := p.pool
+	var sh models.Share
+
+	querySQL := "SELECT sh.owner, sh.name, sh.recipient, sh.access, s.type, s.labels, sh.data, sh.enc_version, " +
+		"sh.version, s.version, sh.shared_at FROM shared_secrets sh " +
+		"JOIN secrets s ON s.userid = sh.owner AND s.name = sh.name " +
+		"WHERE sh.recipient=$1 AND sh.owner=$2 AND sh.name=$3"
+
+	row := db.QueryRow(ctx, querySQL, recipient, owner, name)
+	err := row.Scan(&sh.Owner, &sh.Name, &sh.Recipient, &sh.Access, &sh.Type, &sh.Labels, &sh.Data,
+		&sh.EncVersion, &sh.Version, &sh.CurrentVersion, &sh.SharedAt)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return nil, ErrShareNotFound
+	case err != nil:
+		return nil, fmt.Errorf("failed to query shared secret %s: %w", name, err)
+	}
+	return &sh, nil
+}
+
+// ShareUpdate stores new data of the secret shared read-write with the recipient: ownerData sealed for
+// the owner becomes the next version of the secret and share.Data replaces the recipient copy.
+// share.Version is the expected current version of the secret, it is set to the new version.
+func (p *PostgresDB) ShareUpdate(ctx context.Context, share *models.Share, ownerData []byte) error {
+	db := p.pool
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	querySQL := "SELECT sh.access, s.labels FROM shared_secrets sh " +
+		"JOIN secrets s ON s.userid = sh.owner AND s.name = sh.name " +
+		"WHERE sh.recipient=$1 AND sh.owner=$2 AND sh.name=$3 FOR UPDATE"
+	var access string
+	var secretLabels map[string]string
+	err = tx.QueryRow(ctx, querySQL, share.Recipient, share.Owner, share.Name).Scan(&access, &secretLabels)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return ErrShareNotFound
+	case err != nil:
+		return fmt.Errorf("failed to query share of secret %s: %w", share.Name, err)
+	}
+	if access != models.AccessReadWrite {
+		return ErrShareReadOnly
+	}
+
+	secret := &models.Secret{
+		Name:       share.Name,
+		Labels:     secretLabels,
+		Data:       ownerData,
+		Version:    share.Version,
+		EncVersion: share.EncVersion,
+	}
+	if err = updateSecret(ctx, tx, share.Owner, secret); err != nil {
+		return err
+	}
+
+	querySQL = "UPDATE shared_secrets SET data=$1, enc_version=$2, version=$3 " +
+		"WHERE recipient=$4 AND owner=$5 AND name=$6"
+	_, err = tx.Exec(ctx, querySQL, share.Data, share.EncVersion, secret.Version, share.Recipient, share.Owner,
+		share.Name)
+	if err != nil {
+		return fmt.Errorf("failed to update share of secret %s: %w", share.Name, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit shared secret %s update: %w", share.Name, err)
+	}
+	share.Version = secret.Version
+	return nil
+}
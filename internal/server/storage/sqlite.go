@@ -0,0 +1,1066 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/vkupriya/gophkeeper/internal/labels"
+	"github.com/vkupriya/gophkeeper/internal/search"
+	"github.com/vkupriya/gophkeeper/internal/secretpath"
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+)
+
+// SQLiteDB is the embedded storage backend keeping data in a single file, so the server runs
+// without PostgreSQL. Transactions take the write lock when they begin, so writers are
+// serialized and wait for each other up to the busy timeout.
+type SQLiteDB struct {
+	db *sql.DB
+}
+
+const (
+	// sqliteBusyTimeout limits waiting for the write lock, the wait is aborted earlier if the call
+	// is canceled.
+	sqliteBusyTimeout = 10 * time.Second
+	// sqliteBusyAttempt is the busy timeout of the connection. SQLite does not interrupt waiting for
+	// the lock when the statement is canceled, so the lock is taken by short attempts.
+	sqliteBusyAttempt = 50 * time.Millisecond
+)
+
+func NewSQLiteDB(path string) (*SQLiteDB, error) {
+	options := url.Values{}
+	options.Set("_foreign_keys", "1")
+	options.Set("_journal_mode", "WAL")
+	options.Set("_busy_timeout", strconv.FormatInt(sqliteBusyAttempt.Milliseconds(), 10))
+	options.Set("_txlock", "immediate")
+
+	db, err := sql.Open("sqlite3", "file:"+path+"?"+options.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite db: %w", err)
+	}
+	if err := runSQLiteMigrations(db); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to run DB migrations: %w", err)
+	}
+
+	return &SQLiteDB{
+		db: db,
+	}, nil
+}
+
+//go:embed migrations/sqlite/*.sql
+var sqliteMigrationsDir embed.FS
+
+func runSQLiteMigrations(db *sql.DB) error {
+	d, err := iofs.New(sqliteMigrationsDir, "migrations/sqlite")
+	if err != nil {
+		return fmt.Errorf("failed to return an iofs driver: %w", err)
+	}
+	target, err := sqlite.WithInstance(db, new(sqlite.Config))
+	if err != nil {
+		return fmt.Errorf("invalid target sqlite instance: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", d, "sqlite3", target)
+	if err != nil {
+		return fmt.Errorf("failed to get a new migrate instance: %w", err)
+	}
+	if err := m.Up(); err != nil {
+		if !errors.Is(err, migrate.ErrNoChange) {
+			return fmt.Errorf("failed to apply migrations to the DB: %w", err)
+		}
+	}
+	return nil
+}
+
+// sqliteQuerier is implemented by both DB and transaction.
+type sqliteQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// sqliteRetryBusy repeats fn while DB is locked by another connection, until ctx is done or the busy
+// timeout is elapsed.
+func sqliteRetryBusy(ctx context.Context, fn func() error) error {
+	deadline := time.Now().Add(sqliteBusyTimeout)
+	for {
+		err := fn()
+		var sqliteErr sqlite3.Error
+		if !errors.As(err, &sqliteErr) || sqliteErr.Code != sqlite3.ErrBusy || time.Now().After(deadline) {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// beginTx begins transaction taking the write lock.
+func (s *SQLiteDB) beginTx(ctx context.Context) (*sql.Tx, error) {
+	var tx *sql.Tx
+	err := sqliteRetryBusy(ctx, func() error {
+		var err error
+		tx, err = s.db.BeginTx(ctx, nil)
+		return err
+	})
+	return tx, err
+}
+
+// exec executes the statement outside of transaction.
+func (s *SQLiteDB) exec(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	var res sql.Result
+	err := sqliteRetryBusy(ctx, func() error {
+		var err error
+		res, err = s.db.ExecContext(ctx, query, args...)
+		return err
+	})
+	return res, err
+}
+
+// isConstraint reports whether err is a violation of the constraint, primary key violation is
+// reported as sqlite3.ErrConstraintUnique.
+func isConstraint(err error, code sqlite3.ErrNoExtended) bool {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	if code == sqlite3.ErrConstraintUnique && sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey {
+		return true
+	}
+	return sqliteErr.ExtendedCode == code
+}
+
+// nowMicro returns current time with precision of stored times.
+func nowMicro() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// sqliteLabels encodes secret labels as JSON object.
+func sqliteLabels(secretLabels map[string]string) (string, error) {
+	if secretLabels == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(secretLabels)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal labels: %w", err)
+	}
+	return string(data), nil
+}
+
+// scanLabels decodes labels stored as JSON object.
+func scanLabels(data string) (map[string]string, error) {
+	secretLabels := map[string]string{}
+	if err := json.Unmarshal([]byte(data), &secretLabels); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal labels: %w", err)
+	}
+	return secretLabels, nil
+}
+
+func (s *SQLiteDB) UserAdd(ctx context.Context, u models.User) error {
+	_, err := s.exec(ctx, "INSERT INTO users (userid, password) VALUES(?, ?)", u.UserID, u.Password)
+	if err != nil {
+		if isConstraint(err, sqlite3.ErrConstraintUnique) {
+			return ErrUserAlreadyExists
+		}
+		return fmt.Errorf("failed to insert user %s into SQLite DB: %w", u.UserID, err)
+	}
+	return nil
+}
+
+func (s *SQLiteDB) UserGet(ctx context.Context, userid string) (models.User, error) {
+	db := s.db
+	var user models.User
+
+	row := db.QueryRowContext(ctx, "SELECT userid, password FROM users WHERE userid=?", userid)
+	err := row.Scan(&user.UserID, &user.Password)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return models.User{}, ErrUserNotFound
+	case err != nil:
+		return models.User{}, fmt.Errorf("failed to query user in DB: %w", err)
+	}
+
+	return user, nil
+}
+
+func (s *SQLiteDB) RefreshTokenAdd(ctx context.Context, userid string, tokenHash string, expiresAt time.Time) error {
+	querySQL := "INSERT INTO refresh_tokens (token_hash, userid, expires_at) VALUES(?, ?, ?)"
+
+	_, err := s.exec(ctx, querySQL, tokenHash, userid, expiresAt.UnixMicro())
+	if err != nil {
+		return fmt.Errorf("failed to insert refresh token for user %s: %w", userid, err)
+	}
+	return nil
+}
+
+// RefreshTokenUse removes valid refresh token from DB and returns user it was issued for,
+// refresh token can be used only once.
+func (s *SQLiteDB) RefreshTokenUse(ctx context.Context, tokenHash string) (string, error) {
+	db := s.db
+	var userid string
+
+	querySQL := "DELETE FROM refresh_tokens WHERE token_hash=? AND expires_at > ? RETURNING userid"
+
+	row := db.QueryRowContext(ctx, querySQL, tokenHash, nowMicro().UnixMicro())
+	err := row.Scan(&userid)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return "", ErrTokenNotFound
+	case err != nil:
+		return "", fmt.Errorf("failed to query refresh token: %w", err)
+	}
+	return userid, nil
+}
+
+func (s *SQLiteDB) RefreshTokenDelete(ctx context.Context, tokenHash string) error {
+	res, err := s.exec(ctx, "DELETE FROM refresh_tokens WHERE token_hash=?", tokenHash)
+	if err != nil {
+		return fmt.Errorf("failed to delete refresh token: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get number of deleted refresh tokens: %w", err)
+	}
+	if n == 0 {
+		return ErrTokenNotFound
+	}
+	return nil
+}
+
+func (s *SQLiteDB) TokenAdd(ctx context.Context, userid string, jti string, expiresAt time.Time) error {
+	querySQL := "INSERT INTO access_tokens (jti, userid, expires_at) VALUES(?, ?, ?)"
+
+	_, err := s.exec(ctx, querySQL, jti, userid, expiresAt.UnixMicro())
+	if err != nil {
+		return fmt.Errorf("failed to insert access token for user %s: %w", userid, err)
+	}
+	return nil
+}
+
+func (s *SQLiteDB) TokenRevoke(ctx context.Context, userid string, jti string) error {
+	_, err := s.exec(ctx, "UPDATE access_tokens SET revoked=1 WHERE jti=? AND userid=?", jti, userid)
+	if err != nil {
+		return fmt.Errorf("failed to revoke access token %s: %w", jti, err)
+	}
+	return nil
+}
+
+// TokenRevokeAll revokes all active access tokens and refresh tokens of the user,
+// revoked access tokens are returned.
+func (s *SQLiteDB) TokenRevokeAll(ctx context.Context, userid string) (models.RevokedTokens, error) {
+	tx, err := s.beginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	querySQL := "UPDATE access_tokens SET revoked=1 WHERE userid=? AND expires_at > ? RETURNING jti, expires_at"
+	revoked, err := scanRevoked(tx.QueryContext(ctx, querySQL, userid, nowMicro().UnixMicro()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke access tokens of user %s: %w", userid, err)
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM refresh_tokens WHERE userid=?", userid); err != nil {
+		return nil, fmt.Errorf("failed to delete refresh tokens of user %s: %w", userid, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit tokens revocation: %w", err)
+	}
+	return revoked, nil
+}
+
+func (s *SQLiteDB) TokenRevokedList(ctx context.Context) (models.RevokedTokens, error) {
+	db := s.db
+
+	querySQL := "SELECT jti, expires_at FROM access_tokens WHERE revoked AND expires_at > ?"
+	revoked, err := scanRevoked(db.QueryContext(ctx, querySQL, nowMicro().UnixMicro()))
+	if err != nil {
+		return nil, fmt.Errorf("error querying revoked tokens: %w", err)
+	}
+	return revoked, nil
+}
+
+// scanRevoked reads IDs and expiration times of access tokens.
+func scanRevoked(rows *sql.Rows, err error) (models.RevokedTokens, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	revoked := models.RevokedTokens{}
+	for rows.Next() {
+		var jti string
+		var expiresAt int64
+		if err = rows.Scan(&jti, &expiresAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row in access_tokens table: %w", err)
+		}
+		revoked[jti] = time.UnixMicro(expiresAt).UTC()
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan rows in access_tokens table: %w", err)
+	}
+	return revoked, nil
+}
+
+// TokensDeleteExpired removes expired access and refresh tokens.
+func (s *SQLiteDB) TokensDeleteExpired(ctx context.Context) error {
+	now := nowMicro().UnixMicro()
+	if _, err := s.exec(ctx, "DELETE FROM access_tokens WHERE expires_at < ?", now); err != nil {
+		return fmt.Errorf("failed to delete expired access tokens: %w", err)
+	}
+	if _, err := s.exec(ctx, "DELETE FROM refresh_tokens WHERE expires_at < ?", now); err != nil {
+		return fmt.Errorf("failed to delete expired refresh tokens: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLiteDB) SecretAdd(ctx context.Context, userid string, secret *models.Secret) error {
+	tx, err := s.beginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err = sqliteInsertSecret(ctx, tx, userid, secret); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit secret %s into SQLite DB: %w", secret.Name, err)
+	}
+	return nil
+}
+
+// sqliteInsertSecret adds the first version of the secret.
+func sqliteInsertSecret(ctx context.Context, tx *sql.Tx, userid string, secret *models.Secret) error {
+	secretLabels, err := sqliteLabels(secret.Labels)
+	if err != nil {
+		return err
+	}
+	querySQL := "INSERT INTO secrets (userid, name, type, labels, data, version, enc_version, updated_at, " +
+		"blob_ref, blob_hash, hash) VALUES(?, ?, ?, ?, ?, ?, ?, ?, NULLIF(?, ''), ?, ?)"
+
+	secret.Version = 1
+	secret.Hash = secretHash(secret)
+	_, err = tx.ExecContext(ctx, querySQL, userid, secret.Name, secret.Type, secretLabels, secret.Data,
+		secret.Version, secret.EncVersion, nowMicro().UnixMicro(), secret.BlobRef, secret.BlobHash, secret.Hash)
+	if err != nil {
+		if isConstraint(err, sqlite3.ErrConstraintUnique) {
+			return ErrSecretAlreadyExists
+		}
+		return fmt.Errorf("failed to insert secret %s into SQLite DB: %w", secret.Name, err)
+	}
+
+	return sqliteInsertSecretVersion(ctx, tx, userid, secret)
+}
+
+func (s *SQLiteDB) SecretUpdate(ctx context.Context, userid string, secret *models.Secret) error {
+	tx, err := s.beginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err = sqliteUpdateSecret(ctx, tx, userid, secret); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit secret %s update in SQLite DB: %w", secret.Name, err)
+	}
+	return nil
+}
+
+// sqliteUpdateSecret adds the next version of the secret.
+func sqliteUpdateSecret(ctx context.Context, tx *sql.Tx, userid string, secret *models.Secret) error {
+	secretLabels, err := sqliteLabels(secret.Labels)
+	if err != nil {
+		return err
+	}
+	// secret.Version holds the version the client expects to update
+	expected := secret.Version
+	querySQL := "UPDATE secrets SET version = version + 1, labels=?1, data=?2, enc_version=?3, updated_at=?4, " +
+		"blob_ref=NULLIF(?8, ''), blob_hash=?9, hash=?10 " +
+		"WHERE userid=?5 AND name=?6 AND version = ?7 RETURNING type, version"
+
+	secret.Hash = secretHash(secret)
+	row := tx.QueryRowContext(ctx, querySQL, secretLabels, secret.Data, secret.EncVersion, nowMicro().UnixMicro(),
+		userid, secret.Name, expected, secret.BlobRef, secret.BlobHash, secret.Hash)
+	err = row.Scan(&secret.Type, &secret.Version)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		var current int64
+		row = tx.QueryRowContext(ctx, "SELECT version FROM secrets WHERE userid=? AND name=?", userid, secret.Name)
+		err = row.Scan(&current)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrSecretNotFound
+		case err != nil:
+			return fmt.Errorf("failed to query secret %s version: %w", secret.Name, err)
+		}
+		return fmt.Errorf("%w: expected version %d, current version %d", ErrVersionConflict, expected, current)
+	case err != nil:
+		return fmt.Errorf("failed to update secret %s in SQLite DB: %w", secret.Name, err)
+	}
+
+	return sqliteInsertSecretVersion(ctx, tx, userid, secret)
+}
+
+// sqliteInsertSecretVersion keeps a copy of the secret revision in secret_versions table.
+func sqliteInsertSecretVersion(ctx context.Context, tx *sql.Tx, userid string, secret *models.Secret) error {
+	secretLabels, err := sqliteLabels(secret.Labels)
+	if err != nil {
+		return err
+	}
+	querySQL := "INSERT INTO secret_versions (userid, name, version, type, labels, data, enc_version, created_at, " +
+		"blob_ref, blob_hash, hash) VALUES(?, ?, ?, ?, ?, ?, ?, ?, NULLIF(?, ''), ?, ?)"
+
+	_, err = tx.ExecContext(ctx, querySQL, userid, secret.Name, secret.Version, secret.Type, secretLabels,
+		secret.Data, secret.EncVersion, nowMicro().UnixMicro(), secret.BlobRef, secret.BlobHash, secret.Hash)
+	if err != nil {
+		return fmt.Errorf("failed to insert version %d of secret %s: %w", secret.Version, secret.Name, err)
+	}
+	return nil
+}
+
+// sqliteSetSecretHash stores hash of the secret streamed after it was saved.
+func sqliteSetSecretHash(ctx context.Context, tx *sql.Tx, userid string, secret *models.Secret) error {
+	_, err := tx.ExecContext(ctx, "UPDATE secrets SET hash=? WHERE userid=? AND name=?",
+		secret.Hash, userid, secret.Name)
+	if err != nil {
+		return fmt.Errorf("failed to set hash of secret %s: %w", secret.Name, err)
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE secret_versions SET hash=? WHERE userid=? AND name=? AND version=?",
+		secret.Hash, userid, secret.Name, secret.Version)
+	if err != nil {
+		return fmt.Errorf("failed to set hash of version %d of secret %s: %w", secret.Version, secret.Name, err)
+	}
+	return nil
+}
+
+// SecretSaveStream adds (or updates) secret which data is received in chunks. Chunks are
+// read with next until it returns io.EOF and saved in the same transaction with the secret,
+// so interrupted upload leaves no trace. The upload is bound to ctx of the stream rather than
+// to a fixed timeout, as the upload of a large secret may take longer than ContextTimeout.
+func (s *SQLiteDB) SecretSaveStream(ctx context.Context, userid string, secret *models.Secret, update bool,
+	next func() ([]byte, error)) error {
+	tx, err := s.beginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	secret.Data = []byte{}
+	if update {
+		err = sqliteUpdateSecret(ctx, tx, userid, secret)
+	} else {
+		err = sqliteInsertSecret(ctx, tx, userid, secret)
+	}
+	if err != nil {
+		return err
+	}
+	if update && secret.BlobRef != "" {
+		if err = sqliteReuseBlob(ctx, tx, userid, secret); err != nil {
+			return err
+		}
+	}
+
+	querySQL := "INSERT INTO secret_blobs (userid, name, version, seq, data) VALUES(?, ?, ?, ?, ?)"
+	hash := sha256.New()
+	for seq := 0; ; seq++ {
+		chunk, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to receive chunk %d of secret %s: %w", seq, secret.Name, err)
+		}
+
+		_, err = tx.ExecContext(ctx, querySQL, userid, secret.Name, secret.Version, seq, chunk)
+		if err != nil {
+			return fmt.Errorf("failed to insert chunk %d of secret %s: %w", seq, secret.Name, err)
+		}
+		hash.Write(chunk)
+	}
+
+	// data of blob secrets is not streamed, their hash is known before the upload
+	if secret.BlobRef == "" {
+		secret.Hash = hash.Sum(nil)
+		if err = sqliteSetSecretHash(ctx, tx, userid, secret); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit secret %s into SQLite DB: %w", secret.Name, err)
+	}
+	return nil
+}
+
+// sqliteReuseBlob points the new version of the secret to the blob of its past version with the same data,
+// so that identical data uploaded again is kept once. The caller deletes the blob it has uploaded if
+// secret.BlobRef is changed.
+func sqliteReuseBlob(ctx context.Context, tx *sql.Tx, userid string, secret *models.Secret) error {
+	var ref string
+	querySQL := "SELECT blob_ref FROM secret_versions WHERE userid=? AND name=? AND version<? AND blob_hash=? " +
+		"AND blob_ref IS NOT NULL LIMIT 1"
+	err := tx.QueryRowContext(ctx, querySQL, userid, secret.Name, secret.Version, secret.BlobHash).Scan(&ref)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to query blobs of secret %s: %w", secret.Name, err)
+	}
+
+	if _, err = tx.ExecContext(ctx, "UPDATE secrets SET blob_ref=? WHERE userid=? AND name=?",
+		ref, userid, secret.Name); err != nil {
+		return fmt.Errorf("failed to reuse blob of secret %s: %w", secret.Name, err)
+	}
+	if _, err = tx.ExecContext(ctx, "UPDATE secret_versions SET blob_ref=? WHERE userid=? AND name=? AND version=?",
+		ref, userid, secret.Name, secret.Version); err != nil {
+		return fmt.Errorf("failed to reuse blob of secret %s: %w", secret.Name, err)
+	}
+	secret.BlobRef = ref
+	return nil
+}
+
+// SecretRollback adds the next version of the secret with the stored data and labels of its past version,
+// the current version must be the expected one. Versions kept in the blob store share the blob, data and
+// chunks kept in DB are copied. The new version is returned.
+func (s *SQLiteDB) SecretRollback(ctx context.Context, userid string, name string, version int64,
+	expected int64) (*models.Secret, error) {
+	tx, err := s.beginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var secret models.Secret
+	var secretLabels string
+	querySQL := "SELECT name, labels, data, enc_version, COALESCE(blob_ref, ''), blob_hash, hash " +
+		"FROM secret_versions WHERE userid=? AND name=? AND version=?"
+	err = tx.QueryRowContext(ctx, querySQL, userid, name, version).Scan(&secret.Name, &secretLabels, &secret.Data,
+		&secret.EncVersion, &secret.BlobRef, &secret.BlobHash, &secret.Hash)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, fmt.Errorf("%w: no version %d of secret %s", ErrSecretNotFound, version, name)
+	case err != nil:
+		return nil, fmt.Errorf("failed to query version %d of secret %s: %w", version, name, err)
+	}
+	if secret.Labels, err = scanLabels(secretLabels); err != nil {
+		return nil, err
+	}
+	hash := secret.Hash
+
+	secret.Version = expected
+	if err = sqliteUpdateSecret(ctx, tx, userid, &secret); err != nil {
+		return nil, err
+	}
+	if secret.EncVersion == models.EncVersionChunked && secret.BlobRef == "" {
+		querySQL = "INSERT INTO secret_blobs (userid, name, version, seq, data) " +
+			"SELECT userid, name, ?4, seq, data FROM secret_blobs WHERE userid=?1 AND name=?2 AND version=?3"
+		if _, err = tx.ExecContext(ctx, querySQL, userid, name, version, secret.Version); err != nil {
+			return nil, fmt.Errorf("failed to copy chunks of version %d of secret %s: %w", version, name, err)
+		}
+		// hash of chunked data covers the chunks
+		secret.Hash = hash
+		if err = sqliteSetSecretHash(ctx, tx, userid, &secret); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit rollback of secret %s: %w", name, err)
+	}
+	return &secret, nil
+}
+
+// SecretChunks calls f for every chunk of the secret version in order. Chunks are queried
+// one by one, so a slow receiver does not keep a query open.
+func (s *SQLiteDB) SecretChunks(ctx context.Context, userid string, name string, version int64,
+	f func(chunk []byte) error) error {
+	db := s.db
+	querySQL := "SELECT data FROM secret_blobs WHERE userid=? AND name=? AND version=? AND seq=?"
+
+	for seq := 0; ; seq++ {
+		var chunk []byte
+		err := db.QueryRowContext(ctx, querySQL, userid, name, version, seq).Scan(&chunk)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil
+		case err != nil:
+			return fmt.Errorf("failed to query chunk %d of secret %s: %w", seq, name, err)
+		}
+
+		if err := f(chunk); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *SQLiteDB) SecretGet(ctx context.Context, userid string, name string) (*models.Secret, error) {
+	querySQL := "SELECT userid, name, type, labels, data, version, enc_version, COALESCE(blob_ref, ''), blob_hash, " +
+		"hash FROM secrets WHERE userid=? AND name=?"
+	return s.querySecret(ctx, querySQL, userid, name)
+}
+
+func (s *SQLiteDB) SecretGetVersion(ctx context.Context, userid string, name string,
+	version int64) (*models.Secret, error) {
+	querySQL := "SELECT userid, name, type, labels, data, version, enc_version, COALESCE(blob_ref, ''), blob_hash, " +
+		"hash FROM secret_versions WHERE userid=? AND name=? AND version=?"
+	return s.querySecret(ctx, querySQL, userid, name, version)
+}
+
+// querySecret returns secret selected by the query, ErrSecretNotFound is returned if there is none.
+func (s *SQLiteDB) querySecret(ctx context.Context, querySQL string, args ...any) (*models.Secret, error) {
+	var secret models.Secret
+	var secretLabels string
+
+	row := s.db.QueryRowContext(ctx, querySQL, args...)
+	err := row.Scan(&secret.UserID, &secret.Name, &secret.Type, &secretLabels, &secret.Data, &secret.Version,
+		&secret.EncVersion, &secret.BlobRef, &secret.BlobHash, &secret.Hash)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return &models.Secret{}, ErrSecretNotFound
+	case err != nil:
+		return &models.Secret{}, fmt.Errorf("failed to query secret: %w", err)
+	}
+	if secret.Labels, err = scanLabels(secretLabels); err != nil {
+		return &models.Secret{}, err
+	}
+	return &secret, nil
+}
+
+func (s *SQLiteDB) SecretVersionList(ctx context.Context, userid string,
+	name string) (*models.SecretVersionList, error) {
+	db := s.db
+	versions := models.SecretVersionList{}
+
+	querySQL := "SELECT version, type, created_at FROM secret_versions WHERE userid=? AND name=? ORDER BY version"
+
+	rows, err := db.QueryContext(ctx, querySQL, userid, name)
+	if err != nil {
+		return nil, fmt.Errorf("error querying secret versions: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var v models.SecretVersionItem
+		var createdAt int64
+		if err = rows.Scan(&v.Version, &v.Type, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row in secret_versions table: %w", err)
+		}
+		v.CreatedAt = time.UnixMicro(createdAt).UTC()
+		versions = append(versions, v)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan rows in secret_versions table: %w", err)
+	}
+
+	if len(versions) == 0 {
+		return nil, ErrSecretNotFound
+	}
+
+	return &versions, nil
+}
+
+// SecretDelete removes the secret with its versions and shares and returns references to blob store
+// objects keeping data of the deleted versions, the transaction holds the write lock, so no version is
+// added meanwhile.
+func (s *SQLiteDB) SecretDelete(ctx context.Context, userid string, name string) ([]string, error) {
+	tx, err := s.beginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	querySQL := "DELETE FROM secret_versions WHERE userid=? AND name=? RETURNING blob_ref"
+	rows, err := tx.QueryContext(ctx, querySQL, userid, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete versions of secret %s: %w", name, err)
+	}
+	// versions may share a blob
+	refs := []string{}
+	for rows.Next() {
+		var ref sql.NullString
+		if err = rows.Scan(&ref); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan blob of secret %s: %w", name, err)
+		}
+		if ref.Valid && !contains(refs, ref.String) {
+			refs = append(refs, ref.String)
+		}
+	}
+	if err = rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to delete versions of secret %s: %w", name, err)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to delete versions of secret %s: %w", name, err)
+	}
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM secrets WHERE userid=? AND name=?", userid, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete secret: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get number of deleted secrets: %w", err)
+	}
+	if n == 0 {
+		return nil, ErrSecretNotFound
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit deletion of secret %s: %w", name, err)
+	}
+	return refs, nil
+}
+
+// SecretReplaceVersions replaces data of past versions of the secret and deletes past versions in remove
+// in one transaction, references to blob store objects used only by the deleted versions are returned.
+// Versions kept in chunks can not be replaced, nothing is changed if a version is not a past version of
+// the secret.
+func (s *SQLiteDB) SecretReplaceVersions(ctx context.Context, userid string, name string,
+	versions []*models.Secret, remove []int64) ([]string, error) {
+	tx, err := s.beginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// the transaction holds the write lock, so the current version does not change
+	var current int64
+	querySQL := "SELECT version FROM secrets WHERE userid=? AND name=?"
+	if err = tx.QueryRowContext(ctx, querySQL, userid, name).Scan(&current); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSecretNotFound
+		}
+		return nil, fmt.Errorf("failed to query secret %s: %w", name, err)
+	}
+
+	querySQL = "UPDATE secret_versions SET data=?, enc_version=?, hash=? " +
+		"WHERE userid=? AND name=? AND version=? AND version<? AND enc_version<>?"
+	for _, secret := range versions {
+		secret.Hash = secretHash(secret)
+		res, err := tx.ExecContext(ctx, querySQL, secret.Data, secret.EncVersion, secret.Hash, userid, name,
+			secret.Version, current, models.EncVersionChunked)
+		if err != nil {
+			return nil, fmt.Errorf("failed to replace version %d of secret %s: %w", secret.Version, name, err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get number of replaced versions: %w", err)
+		}
+		if n == 0 {
+			return nil, fmt.Errorf("%w: no past version %d of secret %s kept in DB", ErrSecretNotFound,
+				secret.Version, name)
+		}
+	}
+
+	refs := []string{}
+	querySQL = "DELETE FROM secret_versions WHERE userid=? AND name=? AND version=? AND version<? " +
+		"RETURNING blob_ref"
+	for _, version := range remove {
+		var ref sql.NullString
+		err = tx.QueryRowContext(ctx, querySQL, userid, name, version, current).Scan(&ref)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, fmt.Errorf("%w: no past version %d of secret %s", ErrSecretNotFound, version, name)
+		case err != nil:
+			return nil, fmt.Errorf("failed to delete version %d of secret %s: %w", version, name, err)
+		}
+		if ref.Valid && !contains(refs, ref.String) {
+			refs = append(refs, ref.String)
+		}
+	}
+	// blobs shared with the remaining versions are kept
+	unused := []string{}
+	querySQL = "SELECT EXISTS (SELECT 1 FROM secret_versions WHERE userid=? AND name=? AND blob_ref=?)"
+	for _, ref := range refs {
+		var used bool
+		if err = tx.QueryRowContext(ctx, querySQL, userid, name, ref).Scan(&used); err != nil {
+			return nil, fmt.Errorf("failed to query blobs of secret %s: %w", name, err)
+		}
+		if !used {
+			unused = append(unused, ref)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit versions of secret %s: %w", name, err)
+	}
+	return unused, nil
+}
+
+// SecretList returns a page of user secrets matching the filter in filter sort order, at most
+// filter.Limit secrets are returned. Pages are selected by keyset of the sort order as in PostgresDB.
+func (s *SQLiteDB) SecretList(ctx context.Context, userid string,
+	filter models.SecretFilter) (*models.SecretList, error) {
+	db := s.db
+	secrets := models.SecretList{}
+
+	// rest of the name after folder must not contain '/' unless listing is recursive
+	querySQL := "SELECT name, type, labels, version, enc_version, updated_at FROM secrets " +
+		"WHERE userid=?1 AND substr(name, 1, length(?2)) = ?2 AND (?3 OR instr(substr(name, length(?2) + 1), '/') = 0)"
+	cond, args := sqliteLabelsCondition(filter.Selector, []any{userid, filter.Folder, filter.Recursive})
+	querySQL += cond
+	if len(filter.Types) > 0 {
+		placeholders := make([]string, 0, len(filter.Types))
+		for _, t := range filter.Types {
+			args = append(args, t)
+			placeholders = append(placeholders, fmt.Sprintf("?%d", len(args)))
+		}
+		querySQL += " AND type IN (" + strings.Join(placeholders, ", ") + ")"
+	}
+	cond, args = sqlitePageCondition(filter, args)
+	querySQL += cond
+
+	rows, err := db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying secrets db: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var item models.SecretItem
+		var secretLabels string
+		var updatedAt int64
+		if err = rows.Scan(
+			&item.Name,
+			&item.Type,
+			&secretLabels,
+			&item.Version,
+			&item.EncVersion,
+			&updatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row in secrets table: %w", err)
+		}
+		if item.Labels, err = scanLabels(secretLabels); err != nil {
+			return nil, err
+		}
+		item.UpdatedAt = time.UnixMicro(updatedAt).UTC()
+		secrets = append(secrets, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan rows in secrets table: %w", err)
+	}
+
+	if len(secrets) == 0 {
+		return nil, ErrNoSecrets
+	}
+	return &secrets, nil
+}
+
+// sqlitePageCondition translates filter sort order, cursor and limit into SQL keyset condition,
+// ORDER BY and LIMIT clauses, which are appended to a query which already has args.
+func sqlitePageCondition(filter models.SecretFilter, args []any) (string, []any) {
+	var cond strings.Builder
+	after := filter.After
+	switch filter.Sort {
+	case models.SortNameDesc:
+		if after != nil {
+			args = append(args, after.Name)
+			fmt.Fprintf(&cond, " AND name < ?%d", len(args))
+		}
+		cond.WriteString(" ORDER BY name DESC")
+	case models.SortUpdatedDesc:
+		if after != nil {
+			args = append(args, after.UpdatedAt.UnixMicro(), after.Name)
+			fmt.Fprintf(&cond, " AND (updated_at, name) < (?%d, ?%d)", len(args)-1, len(args))
+		}
+		cond.WriteString(" ORDER BY updated_at DESC, name DESC")
+	case models.SortUpdatedAsc:
+		if after != nil {
+			args = append(args, after.UpdatedAt.UnixMicro(), after.Name)
+			fmt.Fprintf(&cond, " AND (updated_at, name) > (?%d, ?%d)", len(args)-1, len(args))
+		}
+		cond.WriteString(" ORDER BY updated_at, name")
+	default:
+		if after != nil {
+			args = append(args, after.Name)
+			fmt.Fprintf(&cond, " AND name > ?%d", len(args))
+		}
+		cond.WriteString(" ORDER BY name")
+	}
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		fmt.Fprintf(&cond, " LIMIT ?%d", len(args))
+	}
+	return cond.String(), args
+}
+
+// sqliteLabelsCondition translates label selector into SQL condition over labels JSON column,
+// the condition is appended to a query which already has args. Label keys contain no quotes,
+// so they are safe in JSON path.
+func sqliteLabelsCondition(selector labels.Selector, args []any) (string, []any) {
+	var cond strings.Builder
+	for _, r := range selector {
+		args = append(args, `$."`+r.Key+`"`)
+		switch r.Operator {
+		case labels.Equals:
+			args = append(args, r.Value)
+			fmt.Fprintf(&cond, " AND json_extract(labels, ?%d) = ?%d", len(args)-1, len(args))
+		case labels.NotEquals:
+			args = append(args, r.Value)
+			fmt.Fprintf(&cond, " AND json_extract(labels, ?%d) IS NOT ?%d", len(args)-1, len(args))
+		case labels.Exists:
+			fmt.Fprintf(&cond, " AND json_type(labels, ?%d) IS NOT NULL", len(args))
+		case labels.NotExists:
+			fmt.Fprintf(&cond, " AND json_type(labels, ?%d) IS NULL", len(args))
+		}
+	}
+	return cond.String(), args
+}
+
+// SecretSearch returns user secrets which name, type or labels contain words of the query, words
+// match by prefix. Secrets are ranked with search.Rank, there is no similarity match of names.
+func (s *SQLiteDB) SecretSearch(ctx context.Context, userid string, terms []string,
+	limit int) (*models.SecretSearchList, error) {
+	db := s.db
+	results := models.SecretSearchList{}
+
+	querySQL := "SELECT name, type, labels, version, enc_version FROM secrets WHERE userid=?"
+
+	rows, err := db.QueryContext(ctx, querySQL, userid)
+	if err != nil {
+		return nil, fmt.Errorf("error searching secrets: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var item models.SecretSearchItem
+		var secretLabels string
+		if err = rows.Scan(&item.Name, &item.Type, &secretLabels, &item.Version, &item.EncVersion); err != nil {
+			return nil, fmt.Errorf("failed to scan row of secret search: %w", err)
+		}
+		if item.Labels, err = scanLabels(secretLabels); err != nil {
+			return nil, err
+		}
+		if item.Rank = search.Rank(terms, item.Name, item.Type, item.Labels); item.Rank > 0 {
+			results = append(results, item)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan rows of secret search: %w", err)
+	}
+	return rankResults(results, limit), nil
+}
+
+// rankResults orders search results by rank and name and keeps at most limit of them.
+func rankResults(results models.SecretSearchList, limit int) *models.SecretSearchList {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Rank != results[j].Rank {
+			return results[i].Rank > results[j].Rank
+		}
+		return results[i].Name < results[j].Name
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return &results
+}
+
+// SecretFolders returns immediate subfolders of filter folder which contain secrets matching
+// filter label selector.
+func (s *SQLiteDB) SecretFolders(ctx context.Context, userid string, filter models.SecretFilter) ([]string, error) {
+	db := s.db
+	folders := make([]string, 0)
+
+	querySQL := "SELECT DISTINCT ?2 || substr(rest, 1, instr(rest, '/') - 1) || '/' AS folder " +
+		"FROM (SELECT substr(name, length(?2) + 1) AS rest, labels FROM secrets " +
+		"WHERE userid=?1 AND substr(name, 1, length(?2)) = ?2) WHERE instr(rest, '/') > 0"
+	cond, args := sqliteLabelsCondition(filter.Selector, []any{userid, filter.Folder})
+	querySQL += cond + " ORDER BY folder"
+
+	rows, err := db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying secret folders: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var folder string
+		if err = rows.Scan(&folder); err != nil {
+			return nil, fmt.Errorf("failed to scan secret folder: %w", err)
+		}
+		folders = append(folders, folder)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan secret folders: %w", err)
+	}
+	return folders, nil
+}
+
+// SecretMove renames secret, or every secret of the folder if from ends with '/'. Version history,
+// chunks and shares follow the secret by ON UPDATE CASCADE foreign keys. Number of moved secrets
+// is returned.
+func (s *SQLiteDB) SecretMove(ctx context.Context, userid string, from string, to string) (int64, error) {
+	querySQL := "UPDATE secrets SET name=?3 WHERE userid=?1 AND name=?2"
+	if secretpath.IsFolder(from) {
+		querySQL = "UPDATE secrets SET name = ?3 || substr(name, length(?2) + 1) " +
+			"WHERE userid=?1 AND substr(name, 1, length(?2)) = ?2"
+	}
+
+	res, err := s.exec(ctx, querySQL, userid, from, to)
+	if err != nil {
+		if isConstraint(err, sqlite3.ErrConstraintUnique) {
+			return 0, ErrSecretAlreadyExists
+		}
+		return 0, fmt.Errorf("failed to move secret %s to %s: %w", from, to, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get number of moved secrets: %w", err)
+	}
+	if n == 0 {
+		return 0, ErrSecretNotFound
+	}
+	return n, nil
+}
+
+func (s *SQLiteDB) Close() {
+	_ = s.db.Close()
+}
@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vkupriya/gophkeeper/internal/server/auditlog"
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+)
+
+// AuditAdd appends the event to the audit log chained to the last event by hash.
+func (s *SQLiteDB) AuditAdd(ctx context.Context, event *models.AuditEvent) error {
+	// the transaction holds the write lock, so the last event does not change
+	tx, err := s.beginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var prev []byte
+	err = tx.QueryRowContext(ctx, "SELECT hash FROM audit_events ORDER BY id DESC LIMIT 1").Scan(&prev)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to query last audit event: %w", err)
+	}
+	if prev == nil {
+		prev = auditlog.Genesis
+	}
+
+	e := *event
+	e.CreatedAt = nowMicro()
+	querySQL := "INSERT INTO audit_events (userid, owner, method, name, client_ip, code, created_at, prev_hash, hash) " +
+		"VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	_, err = tx.ExecContext(ctx, querySQL, e.UserID, e.Owner, e.Method, e.Name, e.ClientIP, e.Code,
+		e.CreatedAt.UnixMicro(), prev, auditlog.Hash(prev, &e))
+	if err != nil {
+		return fmt.Errorf("failed to insert audit event %s of user %s: %w", event.Method, event.UserID, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit audit event %s of user %s: %w", event.Method, event.UserID, err)
+	}
+	return nil
+}
+
+// AuditList returns events of the user, newest first.
+func (s *SQLiteDB) AuditList(ctx context.Context, userid string, filter models.AuditFilter) (*models.AuditEventList,
+	error) {
+	db := s.db
+	events := models.AuditEventList{}
+
+	querySQL := "SELECT id, userid, owner, method, name, client_ip, code, created_at FROM audit_events WHERE userid=?"
+	args := []any{userid}
+	if !filter.From.IsZero() {
+		args = append(args, filter.From.UnixMicro())
+		querySQL += " AND created_at >= ?"
+	}
+	if !filter.To.IsZero() {
+		args = append(args, filter.To.UnixMicro())
+		querySQL += " AND created_at < ?"
+	}
+	args = append(args, filter.Limit)
+	querySQL += " ORDER BY created_at DESC, id DESC LIMIT ?"
+
+	rows, err := db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying audit events: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var e models.AuditEvent
+		var createdAt int64
+		err = rows.Scan(&e.ID, &e.UserID, &e.Owner, &e.Method, &e.Name, &e.ClientIP, &e.Code, &createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit event: %w", err)
+		}
+		e.CreatedAt = time.UnixMicro(createdAt).UTC()
+		events = append(events, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan audit events: %w", err)
+	}
+	return &events, nil
+}
+
+// AuditChain returns up to limit events following the event afterID in the order of IDs.
+func (s *SQLiteDB) AuditChain(ctx context.Context, afterID int64, limit int) ([]models.AuditRecord, error) {
+	db := s.db
+	records := []models.AuditRecord{}
+
+	querySQL := "SELECT id, userid, owner, method, name, client_ip, code, created_at, prev_hash, hash " +
+		"FROM audit_events WHERE id > ? ORDER BY id LIMIT ?"
+	rows, err := db.QueryContext(ctx, querySQL, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error querying audit chain: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var r models.AuditRecord
+		var createdAt int64
+		err = rows.Scan(&r.ID, &r.UserID, &r.Owner, &r.Method, &r.Name, &r.ClientIP, &r.Code, &createdAt,
+			&r.PrevHash, &r.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit event: %w", err)
+		}
+		r.CreatedAt = time.UnixMicro(createdAt).UTC()
+		records = append(records, r)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan audit events: %w", err)
+	}
+	return records, nil
+}
+
+// AuditChainStart returns ID of the first event which must be chained, SQLite DB chains events
+// from the first one.
+func (s *SQLiteDB) AuditChainStart(_ context.Context) (int64, error) {
+	return 1, nil
+}
+
+// AuditHead returns ID and hash of the last event, nil is returned if the audit log is empty.
+func (s *SQLiteDB) AuditHead(ctx context.Context) (*models.AuditRecord, error) {
+	db := s.db
+	var r models.AuditRecord
+
+	err := db.QueryRowContext(ctx, "SELECT id, hash FROM audit_events ORDER BY id DESC LIMIT 1").Scan(&r.ID, &r.Hash)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to query last audit event: %w", err)
+	}
+	return &r, nil
+}
@@ -0,0 +1,238 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+)
+
+// UserSetPublicKey registers public key of the user, secrets shared with the user are sealed with it.
+func (s *SQLiteDB) UserSetPublicKey(ctx context.Context, userid string, publicKey []byte) error {
+	res, err := s.exec(ctx, "UPDATE users SET public_key=? WHERE userid=?", publicKey, userid)
+	if err != nil {
+		return fmt.Errorf("failed to set public key of user %s: %w", userid, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get number of updated users: %w", err)
+	}
+	if n == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// UserGetPublicKey returns public key of the user, ErrPublicKeyNotFound is returned if the user has
+// not registered it yet.
+func (s *SQLiteDB) UserGetPublicKey(ctx context.Context, userid string) ([]byte, error) {
+	db := s.db
+	var publicKey []byte
+
+	err := db.QueryRowContext(ctx, "SELECT public_key FROM users WHERE userid=?", userid).Scan(&publicKey)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, ErrUserNotFound
+	case err != nil:
+		return nil, fmt.Errorf("failed to query public key of user %s: %w", userid, err)
+	case publicKey == nil:
+		return nil, ErrPublicKeyNotFound
+	}
+	return publicKey, nil
+}
+
+// ShareAdd shares owner secret with the recipient or replaces the existing share. Shared data must
+// be sealed from the current version of the secret, ErrVersionConflict is returned otherwise.
+func (s *SQLiteDB) ShareAdd(ctx context.Context, share *models.Share) error {
+	tx, err := s.beginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// the secret does not change until the share is stored as writers are serialized
+	var current int64
+	row := tx.QueryRowContext(ctx, "SELECT version FROM secrets WHERE userid=? AND name=?", share.Owner, share.Name)
+	err = row.Scan(&current)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return ErrSecretNotFound
+	case err != nil:
+		return fmt.Errorf("failed to query secret %s version: %w", share.Name, err)
+	}
+	if current != share.Version {
+		return fmt.Errorf("%w: shared version %d, current version %d", ErrVersionConflict, share.Version, current)
+	}
+
+	querySQL := "INSERT INTO shared_secrets (owner, name, recipient, access, data, enc_version, version, shared_at) " +
+		"VALUES(?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT (owner, name, recipient) DO UPDATE SET " +
+		"access=excluded.access, data=excluded.data, enc_version=excluded.enc_version, " +
+		"version=excluded.version, shared_at=excluded.shared_at"
+	_, err = tx.ExecContext(ctx, querySQL, share.Owner, share.Name, share.Recipient, share.Access, share.Data,
+		share.EncVersion, share.Version, nowMicro().UnixMicro())
+	if err != nil {
+		// the secret exists, so the recipient does not
+		if isConstraint(err, sqlite3.ErrConstraintForeignKey) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to share secret %s with %s: %w", share.Name, share.Recipient, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit share of secret %s: %w", share.Name, err)
+	}
+	return nil
+}
+
+// ShareRevoke deletes share of owner secret with the recipient.
+func (s *SQLiteDB) ShareRevoke(ctx context.Context, owner string, name string, recipient string) error {
+	res, err := s.exec(ctx, "DELETE FROM shared_secrets WHERE owner=? AND name=? AND recipient=?",
+		owner, name, recipient)
+	if err != nil {
+		return fmt.Errorf("failed to revoke share of secret %s with %s: %w", name, recipient, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get number of revoked shares: %w", err)
+	}
+	if n == 0 {
+		return ErrShareNotFound
+	}
+	return nil
+}
+
+// ShareList returns secrets shared with the user, or secrets shared by the user if outgoing is set.
+// Shares do not include data.
+func (s *SQLiteDB) ShareList(ctx context.Context, userid string, outgoing bool) (*models.ShareList, error) {
+	db := s.db
+	shares := models.ShareList{}
+
+	querySQL := "SELECT sh.owner, sh.name, sh.recipient, sh.access, s.type, sh.version, s.version, sh.shared_at " +
+		"FROM shared_secrets sh JOIN secrets s ON s.userid = sh.owner AND s.name = sh.name "
+	if outgoing {
+		querySQL += "WHERE sh.owner=? ORDER BY sh.name, sh.recipient"
+	} else {
+		querySQL += "WHERE sh.recipient=? ORDER BY sh.owner, sh.name"
+	}
+
+	rows, err := db.QueryContext(ctx, querySQL, userid)
+	if err != nil {
+		return nil, fmt.Errorf("error querying shared secrets: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var sh models.Share
+		var sharedAt int64
+		if err = rows.Scan(
+			&sh.Owner,
+			&sh.Name,
+			&sh.Recipient,
+			&sh.Access,
+			&sh.Type,
+			&sh.Version,
+			&sh.CurrentVersion,
+			&sharedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row of shared secrets: %w", err)
+		}
+		sh.SharedAt = time.UnixMicro(sharedAt).UTC()
+		shares = append(shares, sh)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan rows of shared secrets: %w", err)
+	}
+	return &shares, nil
+}
+
+// ShareGet returns secret shared by the owner with the recipient.
+func (s *SQLiteDB) ShareGet(ctx context.Context, recipient string, owner string, name string) (*models.Share, error) {
+	db := s.db
+	var sh models.Share
+	var secretLabels string
+	var sharedAt int64
+
+	querySQL := "SELECT sh.owner, sh.name, sh.recipient, sh.access, s.type, s.labels, sh.data, sh.enc_version, " +
+		"sh.version, s.version, sh.shared_at FROM shared_secrets sh " +
+		"JOIN secrets s ON s.userid = sh.owner AND s.name = sh.name " +
+		"WHERE sh.recipient=? AND sh.owner=? AND sh.name=?"
+
+	row := db.QueryRowContext(ctx, querySQL, recipient, owner, name)
+	err := row.Scan(&sh.Owner, &sh.Name, &sh.Recipient, &sh.Access, &sh.Type, &secretLabels, &sh.Data,
+		&sh.EncVersion, &sh.Version, &sh.CurrentVersion, &sharedAt)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, ErrShareNotFound
+	case err != nil:
+		return nil, fmt.Errorf("failed to query shared secret %s: %w", name, err)
+	}
+	if sh.Labels, err = scanLabels(secretLabels); err != nil {
+		return nil, err
+	}
+	sh.SharedAt = time.UnixMicro(sharedAt).UTC()
+	return &sh, nil
+}
+
+// ShareUpdate stores new data of the secret shared read-write with the recipient: ownerData sealed for
+// the owner becomes the next version of the secret and share.Data replaces the recipient copy.
+// share.Version is the expected current version of the secret, it is set to the new version.
+func (s *SQLiteDB) ShareUpdate(ctx context.Context, share *models.Share, ownerData []byte) error {
+	tx, err := s.beginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	querySQL := "SELECT sh.access, s.labels FROM shared_secrets sh " +
+		"JOIN secrets s ON s.userid = sh.owner AND s.name = sh.name " +
+		"WHERE sh.recipient=? AND sh.owner=? AND sh.name=?"
+	var access, secretLabels string
+	err = tx.QueryRowContext(ctx, querySQL, share.Recipient, share.Owner, share.Name).Scan(&access, &secretLabels)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return ErrShareNotFound
+	case err != nil:
+		return fmt.Errorf("failed to query share of secret %s: %w", share.Name, err)
+	}
+	if access != models.AccessReadWrite {
+		return ErrShareReadOnly
+	}
+
+	secret := &models.Secret{
+		Name:       share.Name,
+		Data:       ownerData,
+		Version:    share.Version,
+		EncVersion: share.EncVersion,
+	}
+	if secret.Labels, err = scanLabels(secretLabels); err != nil {
+		return err
+	}
+	if err = sqliteUpdateSecret(ctx, tx, share.Owner, secret); err != nil {
+		return err
+	}
+
+	querySQL = "UPDATE shared_secrets SET data=?, enc_version=?, version=? WHERE recipient=? AND owner=? AND name=?"
+	_, err = tx.ExecContext(ctx, querySQL, share.Data, share.EncVersion, secret.Version, share.Recipient, share.Owner,
+		share.Name)
+	if err != nil {
+		return fmt.Errorf("failed to update share of secret %s: %w", share.Name, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit shared secret %s update: %w", share.Name, err)
+	}
+	share.Version = secret.Version
+	return nil
+}
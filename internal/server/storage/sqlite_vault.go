@@ -0,0 +1,335 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+	"github.com/vkupriya/gophkeeper/internal/vault"
+)
+
+// VaultCreate creates vault of the organization and its principal user, the user becomes vault owner
+// with the vault key sealed for the user. Organization is created with the user as its owner
+// if it does not exist, otherwise the user must be its owner.
+func (s *SQLiteDB) VaultCreate(ctx context.Context, userid string, org string, name string, sealedKey []byte) error {
+	tx, err := s.beginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	res, err := tx.ExecContext(ctx, "INSERT INTO organizations (name) VALUES(?) ON CONFLICT DO NOTHING", org)
+	if err != nil {
+		return fmt.Errorf("failed to insert organization %s: %w", org, err)
+	}
+	created, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get number of inserted organizations: %w", err)
+	}
+	if created == 1 {
+		_, err = tx.ExecContext(ctx, "INSERT INTO vault_members (org, vault, userid, role) VALUES(?, '', ?, ?)",
+			org, userid, vault.RoleOwner)
+		if err != nil {
+			return fmt.Errorf("failed to insert owner of organization %s: %w", org, err)
+		}
+	} else {
+		role, err := sqliteMemberRole(ctx, tx, userid, org, "")
+		if err != nil {
+			return err
+		}
+		if role != vault.RoleOwner {
+			return ErrNotOrgOwner
+		}
+	}
+
+	// principal has no password, so nobody can login as the vault
+	principal := vault.Principal(org, name)
+	_, err = tx.ExecContext(ctx, "INSERT INTO users (userid, password) VALUES(?, '')", principal)
+	if err != nil {
+		if isConstraint(err, sqlite3.ErrConstraintUnique) {
+			return ErrVaultAlreadyExists
+		}
+		return fmt.Errorf("failed to insert principal of vault %s: %w", principal, err)
+	}
+	_, err = tx.ExecContext(ctx, "INSERT INTO vaults (org, name, principal) VALUES(?, ?, ?)", org, name, principal)
+	if err != nil {
+		return fmt.Errorf("failed to insert vault %s/%s: %w", org, name, err)
+	}
+	_, err = tx.ExecContext(ctx, "INSERT INTO vault_members (org, vault, userid, role, sealed_key) VALUES(?, ?, ?, ?, ?)",
+		org, name, userid, vault.RoleOwner, sealedKey)
+	if err != nil {
+		return fmt.Errorf("failed to insert owner of vault %s/%s: %w", org, name, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit vault %s/%s: %w", org, name, err)
+	}
+	return nil
+}
+
+// VaultRole returns the role of the user in the vault and the principal owning vault secrets,
+// empty role is returned if the user is not a member of the vault or its organization.
+func (s *SQLiteDB) VaultRole(ctx context.Context, userid string, org string, name string) (vault.Role, string, error) {
+	db := s.db
+
+	querySQL := "SELECT v.principal, m.role FROM vaults v LEFT JOIN vault_members m " +
+		"ON m.org = v.org AND m.vault IN ('', v.name) AND m.userid=?3 WHERE v.org=?1 AND v.name=?2"
+
+	rows, err := db.QueryContext(ctx, querySQL, org, name, userid)
+	if err != nil {
+		return "", "", fmt.Errorf("error querying role in vault %s/%s: %w", org, name, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var principal string
+	var role vault.Role
+	for rows.Next() {
+		var r sql.NullString
+		if err = rows.Scan(&principal, &r); err != nil {
+			return "", "", fmt.Errorf("failed to scan role in vault %s/%s: %w", org, name, err)
+		}
+		if r.Valid {
+			role = vault.Max(role, vault.Role(r.String))
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return "", "", fmt.Errorf("failed to scan roles in vault %s/%s: %w", org, name, err)
+	}
+	if principal == "" {
+		return "", "", ErrVaultNotFound
+	}
+	return role, principal, nil
+}
+
+// OrgRole returns the role of the user in the organization, empty role is returned if the user
+// is not its member.
+func (s *SQLiteDB) OrgRole(ctx context.Context, userid string, org string) (vault.Role, error) {
+	return sqliteMemberRole(ctx, s.db, userid, org, "")
+}
+
+// VaultKey returns the vault key sealed for the user.
+func (s *SQLiteDB) VaultKey(ctx context.Context, userid string, org string, name string) ([]byte, error) {
+	db := s.db
+
+	var sealedKey []byte
+	err := db.QueryRowContext(ctx, "SELECT sealed_key FROM vault_members WHERE org=? AND vault=? AND userid=?",
+		org, name, userid).Scan(&sealedKey)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrVaultKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to query key of vault %s/%s: %w", org, name, err)
+	}
+	if len(sealedKey) == 0 {
+		return nil, ErrVaultKeyNotFound
+	}
+	return sealedKey, nil
+}
+
+// VaultKeySet replaces the vault key sealed for the user, ErrVaultKeyNotFound is returned if the user
+// has no key of the vault.
+func (s *SQLiteDB) VaultKeySet(ctx context.Context, userid string, org string, name string, sealedKey []byte) error {
+	querySQL := "UPDATE vault_members SET sealed_key=? " +
+		"WHERE org=? AND vault=? AND userid=? AND length(sealed_key) > 0"
+	res, err := s.exec(ctx, querySQL, sealedKey, org, name, userid)
+	if err != nil {
+		return fmt.Errorf("failed to set key of vault %s/%s: %w", org, name, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get number of updated vault keys: %w", err)
+	}
+	if n == 0 {
+		return ErrVaultKeyNotFound
+	}
+	return nil
+}
+
+// VaultList returns vaults where the user is a member directly or by the organization.
+func (s *SQLiteDB) VaultList(ctx context.Context, userid string) (*models.VaultList, error) {
+	db := s.db
+	vaults := models.VaultList{}
+
+	querySQL := "SELECT v.org, v.name, m.role FROM vaults v " +
+		"JOIN vault_members m ON m.org = v.org AND m.vault IN ('', v.name) " +
+		"WHERE m.userid=? ORDER BY v.org, v.name"
+
+	rows, err := db.QueryContext(ctx, querySQL, userid)
+	if err != nil {
+		return nil, fmt.Errorf("error querying vaults: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var v models.Vault
+		if err = rows.Scan(&v.Org, &v.Name, &v.Role); err != nil {
+			return nil, fmt.Errorf("failed to scan vault: %w", err)
+		}
+		// rows are ordered, so roles of the same vault are adjacent
+		if last := len(vaults) - 1; last >= 0 && vaults[last].Org == v.Org && vaults[last].Name == v.Name {
+			vaults[last].Role = vault.Max(vaults[last].Role, v.Role)
+			continue
+		}
+		vaults = append(vaults, v)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan vaults: %w", err)
+	}
+	return &vaults, nil
+}
+
+// VaultMemberSet adds member to the vault or changes the member role and sealed vault key,
+// empty vault is the organization.
+func (s *SQLiteDB) VaultMemberSet(ctx context.Context, org string, name string, userid string,
+	role vault.Role, sealedKey []byte) error {
+	tx, err := s.beginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err = sqliteVaultExists(ctx, tx, org, name); err != nil {
+		return err
+	}
+
+	querySQL := "INSERT INTO vault_members (org, vault, userid, role, sealed_key) VALUES(?, ?, ?, ?, ?) " +
+		"ON CONFLICT (org, vault, userid) DO UPDATE SET role=excluded.role, sealed_key=excluded.sealed_key"
+	_, err = tx.ExecContext(ctx, querySQL, org, name, userid, role, sealedKey)
+	if err != nil {
+		// the organization exists, so the user does not
+		if isConstraint(err, sqlite3.ErrConstraintForeignKey) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to set member %s of vault %s/%s: %w", userid, org, name, err)
+	}
+
+	if err = sqliteCheckOwners(ctx, tx, org); err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit member of vault %s/%s: %w", org, name, err)
+	}
+	return nil
+}
+
+// VaultMemberDelete removes member from the vault, empty vault is the organization.
+func (s *SQLiteDB) VaultMemberDelete(ctx context.Context, org string, name string, userid string) error {
+	tx, err := s.beginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM vault_members WHERE org=? AND vault=? AND userid=?",
+		org, name, userid)
+	if err != nil {
+		return fmt.Errorf("failed to delete member %s of vault %s/%s: %w", userid, org, name, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get number of deleted members: %w", err)
+	}
+	if n == 0 {
+		return ErrMemberNotFound
+	}
+
+	if err = sqliteCheckOwners(ctx, tx, org); err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit member removal of vault %s/%s: %w", org, name, err)
+	}
+	return nil
+}
+
+// VaultMemberList returns members of the organization and of the vault unless it is empty.
+func (s *SQLiteDB) VaultMemberList(ctx context.Context, org string, name string) (*models.VaultMemberList, error) {
+	db := s.db
+	members := models.VaultMemberList{}
+
+	querySQL := "SELECT userid, vault, role FROM vault_members WHERE org=? AND vault IN ('', ?) " +
+		"ORDER BY vault, userid"
+
+	rows, err := db.QueryContext(ctx, querySQL, org, name)
+	if err != nil {
+		return nil, fmt.Errorf("error querying members of vault %s/%s: %w", org, name, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var m models.VaultMember
+		if err = rows.Scan(&m.UserID, &m.Vault, &m.Role); err != nil {
+			return nil, fmt.Errorf("failed to scan vault member: %w", err)
+		}
+		members = append(members, m)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan vault members: %w", err)
+	}
+	return &members, nil
+}
+
+// sqliteMemberRole returns role of direct membership of the user in the vault, empty vault is
+// the organization.
+func sqliteMemberRole(ctx context.Context, db sqliteQuerier, userid string, org string,
+	name string) (vault.Role, error) {
+	var role vault.Role
+	err := db.QueryRowContext(ctx, "SELECT role FROM vault_members WHERE org=? AND vault=? AND userid=?",
+		org, name, userid).Scan(&role)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return "", nil
+	case err != nil:
+		return "", fmt.Errorf("failed to query role of %s in %s/%s: %w", userid, org, name, err)
+	}
+	return role, nil
+}
+
+// sqliteVaultExists checks that the vault exists, empty vault is the organization.
+func sqliteVaultExists(ctx context.Context, tx *sql.Tx, org string, name string) error {
+	querySQL, args := "SELECT EXISTS (SELECT 1 FROM vaults WHERE org=? AND name=?)", []any{org, name}
+	if name == "" {
+		querySQL, args = "SELECT EXISTS (SELECT 1 FROM organizations WHERE name=?)", []any{org}
+	}
+	var exists bool
+	if err := tx.QueryRowContext(ctx, querySQL, args...).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to query vault %s/%s: %w", org, name, err)
+	}
+	if !exists {
+		return ErrVaultNotFound
+	}
+	return nil
+}
+
+// sqliteCheckOwners fails if the organization is left without owners.
+func sqliteCheckOwners(ctx context.Context, tx *sql.Tx, org string) error {
+	var owners int
+	err := tx.QueryRowContext(ctx, "SELECT count(*) FROM vault_members WHERE org=? AND vault='' AND role=?",
+		org, vault.RoleOwner).Scan(&owners)
+	if err != nil {
+		return fmt.Errorf("failed to count owners of organization %s: %w", org, err)
+	}
+	if owners == 0 {
+		return ErrLastOwner
+	}
+	return nil
+}
@@ -1,10 +1,15 @@
 package storage
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"embed"
 	"errors"
 	"fmt"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
@@ -14,6 +19,8 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/vkupriya/gophkeeper/internal/labels"
+	"github.com/vkupriya/gophkeeper/internal/secretpath"
 	"github.com/vkupriya/gophkeeper/internal/server/models"
 )
 
@@ -23,6 +30,17 @@ var (
 	ErrSecretAlreadyExists = errors.New("secret already exists")
 	ErrSecretNotFound      = errors.New("secret not found")
 	ErrNoSecrets           = errors.New("no secrets")
+	ErrVersionConflict     = errors.New("secret version conflict")
+	ErrTokenNotFound       = errors.New("refresh token not found")
+	ErrPublicKeyNotFound   = errors.New("public key not found")
+	ErrShareNotFound       = errors.New("share not found")
+	ErrShareReadOnly       = errors.New("secret is shared read-only")
+	ErrVaultNotFound       = errors.New("vault not found")
+	ErrVaultAlreadyExists  = errors.New("vault already exists")
+	ErrNotOrgOwner         = errors.New("user is not owner of the organization")
+	ErrMemberNotFound      = errors.New("vault member not found")
+	ErrLastOwner           = errors.New("organization must have an owner")
+	ErrVaultKeyNotFound    = errors.New("vault key not found")
 )
 
 type PostgresDB struct {
@@ -72,11 +90,9 @@ func runMigrations(dsn string) error {
 	return nil
 }
 
-func (p *PostgresDB) UserAdd(c *models.Config, u models.User) error {
+func (p *PostgresDB) UserAdd(ctx context.Context, u models.User) error {
 	db := p.pool
 	var pgErr *pgconn.PgError
-	ctx, cancel := context.WithTimeout(context.Background(), c.ContextTimeout)
-	defer cancel()
 
 	querySQL := "INSERT INTO users (userid, password) VALUES($1, $2)"
 
@@ -90,13 +106,11 @@ func (p *PostgresDB) UserAdd(c *models.Config, u models.User) error {
 	return nil
 }
 
-func (p *PostgresDB) UserGet(c *models.Config, userid string) (models.User, error) {
+func (p *PostgresDB) UserGet(ctx context.Context, userid string) (models.User, error) {
 	db := p.pool
 	var user models.User
-	ctx, cancel := context.WithTimeout(context.Background(), c.ContextTimeout)
-	defer cancel()
 
-	row := db.QueryRow(ctx, "SELECT * FROM users WHERE userid=$1", userid)
+	row := db.QueryRow(ctx, "SELECT userid, password FROM users WHERE userid=$1", userid)
 	err := row.Scan(&user.UserID, &user.Password)
 	switch {
 	case errors.Is(err, pgx.ErrNoRows):
@@ -108,51 +122,486 @@ func (p *PostgresDB) UserGet(c *models.Config, userid string) (models.User, erro
 	return user, nil
 }
 
-func (p *PostgresDB) SecretAdd(c *models.Config, userid string, secret *models.Secret) error {
+func (p *PostgresDB) RefreshTokenAdd(ctx context.Context, userid string, tokenHash string, expiresAt time.Time) error {
+	db := p.pool
+
+	querySQL := "INSERT INTO refresh_tokens (token_hash, userid, expires_at) VALUES($1, $2, $3)"
+
+	_, err := db.Exec(ctx, querySQL, tokenHash, userid, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert refresh token for user %s: %w", userid, err)
+	}
+	return nil
+}
+
+// RefreshTokenUse removes valid refresh token from DB and returns user it was issued for,
+// refresh token can be used only once.
+func (p *PostgresDB) RefreshTokenUse(ctx context.Context, tokenHash string) (string, error) {
+	db := p.pool
+	var userid string
+
+	querySQL := "DELETE FROM refresh_tokens WHERE token_hash=$1 AND expires_at > now() RETURNING userid"
+
+	row := db.QueryRow(ctx, querySQL, tokenHash)
+	err := row.Scan(&userid)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return "", ErrTokenNotFound
+	case err != nil:
+		return "", fmt.Errorf("failed to query refresh token: %w", err)
+	}
+	return userid, nil
+}
+
+func (p *PostgresDB) RefreshTokenDelete(ctx context.Context, tokenHash string) error {
+	db := p.pool
+
+	querySQL := "DELETE FROM refresh_tokens WHERE token_hash=$1"
+
+	tag, err := db.Exec(ctx, querySQL, tokenHash)
+	if err != nil {
+		return fmt.Errorf("failed to delete refresh token: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTokenNotFound
+	}
+	return nil
+}
+
+func (p *PostgresDB) TokenAdd(ctx context.Context, userid string, jti string, expiresAt time.Time) error {
+	db := p.pool
+
+	querySQL := "INSERT INTO access_tokens (jti, userid, expires_at) VALUES($1, $2, $3)"
+
+	_, err := db.Exec(ctx, querySQL, jti, userid, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert access token for user %s: %w", userid, err)
+	}
+	return nil
+}
+
+func (p *PostgresDB) TokenRevoke(ctx context.Context, userid string, jti string) error {
+	db := p.pool
+
+	querySQL := "UPDATE access_tokens SET revoked=TRUE WHERE jti=$1 AND userid=$2"
+
+	_, err := db.Exec(ctx, querySQL, jti, userid)
+	if err != nil {
+		return fmt.Errorf("failed to revoke access token %s: %w", jti, err)
+	}
+	return nil
+}
+
+// TokenRevokeAll revokes all active access tokens and refresh tokens of the user,
+// revoked access tokens are returned.
+func (p *PostgresDB) TokenRevokeAll(ctx context.Context, userid string) (models.RevokedTokens, error) {
+	db := p.pool
+	revoked := models.RevokedTokens{}
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	querySQL := "UPDATE access_tokens SET revoked=TRUE WHERE userid=$1 AND expires_at > now() " +
+		"RETURNING jti, expires_at"
+
+	rows, err := tx.Query(ctx, querySQL, userid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke access tokens of user %s: %w", userid, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var jti string
+		var expiresAt time.Time
+		if err = rows.Scan(&jti, &expiresAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row in access_tokens table: %w", err)
+		}
+		revoked[jti] = expiresAt
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan rows in access_tokens table: %w", err)
+	}
+
+	if _, err = tx.Exec(ctx, "DELETE FROM refresh_tokens WHERE userid=$1", userid); err != nil {
+		return nil, fmt.Errorf("failed to delete refresh tokens of user %s: %w", userid, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit tokens revocation: %w", err)
+	}
+	return revoked, nil
+}
+
+func (p *PostgresDB) TokenRevokedList(ctx context.Context) (models.RevokedTokens, error) {
+	db := p.pool
+	revoked := models.RevokedTokens{}
+
+	querySQL := "SELECT jti, expires_at FROM access_tokens WHERE revoked AND expires_at > now()"
+
+	rows, err := db.Query(ctx, querySQL)
+	if err != nil {
+		return nil, fmt.Errorf("error querying revoked tokens: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var jti string
+		var expiresAt time.Time
+		if err = rows.Scan(&jti, &expiresAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row in access_tokens table: %w", err)
+		}
+		revoked[jti] = expiresAt
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan rows in access_tokens table: %w", err)
+	}
+	return revoked, nil
+}
+
+// TokensDeleteExpired removes expired access and refresh tokens.
+func (p *PostgresDB) TokensDeleteExpired(ctx context.Context) error {
 	db := p.pool
-	var pgErr *pgconn.PgError
-	ctx, cancel := context.WithTimeout(context.Background(), c.ContextTimeout)
-	defer cancel()
 
-	querySQL := "INSERT INTO secrets (userid, name, type, meta, data, version) VALUES($1, $2, $3, $4, $5, $6)"
+	if _, err := db.Exec(ctx, "DELETE FROM access_tokens WHERE expires_at < now()"); err != nil {
+		return fmt.Errorf("failed to delete expired access tokens: %w", err)
+	}
+	if _, err := db.Exec(ctx, "DELETE FROM refresh_tokens WHERE expires_at < now()"); err != nil {
+		return fmt.Errorf("failed to delete expired refresh tokens: %w", err)
+	}
+	return nil
+}
+
+func (p *PostgresDB) SecretAdd(ctx context.Context, userid string, secret *models.Secret) error {
+	db := p.pool
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err = insertSecret(ctx, tx, userid, secret); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit secret %s into Postgres DB: %w", secret.Name, err)
+	}
+	return nil
+}
 
-	_, err := db.Exec(ctx, querySQL, userid, secret.Name, secret.Type, secret.Meta, secret.Data, 1)
+// insertSecret adds the first version of the secret.
+func insertSecret(ctx context.Context, tx pgx.Tx, userid string, secret *models.Secret) error {
+	var pgErr *pgconn.PgError
+	querySQL := "INSERT INTO secrets (userid, name, type, labels, data, version, enc_version, blob_ref, blob_hash, " +
+		"hash) VALUES($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9, $10)"
+
+	secret.Version = 1
+	secret.Hash = secretHash(secret)
+	_, err := tx.Exec(ctx, querySQL, userid, secret.Name, secret.Type, labelsOf(secret), secret.Data,
+		secret.Version, secret.EncVersion, secret.BlobRef, secret.BlobHash, secret.Hash)
 	if err != nil {
 		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
 			return ErrSecretAlreadyExists
 		}
 		return fmt.Errorf("failed to insert secret %s into Postgres DB: %w", secret.Name, err)
 	}
-	return nil
+
+	return insertSecretVersion(ctx, tx, userid, secret)
 }
 
-func (p *PostgresDB) SecretUpdate(c *models.Config, userid string, secret *models.Secret) error {
+func (p *PostgresDB) SecretUpdate(ctx context.Context, userid string, secret *models.Secret) error {
 	db := p.pool
-	ctx, cancel := context.WithTimeout(context.Background(), c.ContextTimeout)
-	defer cancel()
 
-	querySQL := "UPDATE secrets SET version = version + 1, meta=$1, data=$2 WHERE (userid=$3 AND name=$4)"
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err = updateSecret(ctx, tx, userid, secret); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit secret %s update in Postgres DB: %w", secret.Name, err)
+	}
+	return nil
+}
 
-	_, err := db.Exec(ctx, querySQL, secret.Meta, secret.Data, userid, secret.Name)
+// updateSecret adds the next version of the secret.
+func updateSecret(ctx context.Context, tx pgx.Tx, userid string, secret *models.Secret) error {
+	// secret.Version holds the version the client expects to update
+	expected := secret.Version
+	querySQL := "UPDATE secrets SET version = version + 1, labels=$1, data=$2, enc_version=$3, updated_at=now(), " +
+		"blob_ref=NULLIF($7, ''), blob_hash=$8, hash=$9 " +
+		"WHERE (userid=$4 AND name=$5 AND version = $6) RETURNING type, version"
+
+	secret.Hash = secretHash(secret)
+	row := tx.QueryRow(ctx, querySQL, labelsOf(secret), secret.Data, secret.EncVersion, userid, secret.Name, expected,
+		secret.BlobRef, secret.BlobHash, secret.Hash)
+	err := row.Scan(&secret.Type, &secret.Version)
 	switch {
 	case errors.Is(err, pgx.ErrNoRows):
-		return ErrSecretNotFound
+		var current int64
+		row = tx.QueryRow(ctx, "SELECT version FROM secrets WHERE userid=$1 AND name=$2", userid, secret.Name)
+		err = row.Scan(&current)
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return ErrSecretNotFound
+		case err != nil:
+			return fmt.Errorf("failed to query secret %s version: %w", secret.Name, err)
+		}
+		return fmt.Errorf("%w: expected version %d, current version %d", ErrVersionConflict, expected, current)
 	case err != nil:
 		return fmt.Errorf("failed to update secret %s in Postgres DB: %w", secret.Name, err)
 	}
+
+	return insertSecretVersion(ctx, tx, userid, secret)
+}
+
+// SecretSaveStream adds (or updates) secret which data is received in chunks. Chunks are
+// read with next until it returns io.EOF and saved in the same transaction with the secret,
+// so interrupted upload leaves no trace. The upload is bound to ctx of the stream rather than
+// to a fixed timeout, as the upload of a large secret may take longer than ContextTimeout.
+func (p *PostgresDB) SecretSaveStream(ctx context.Context, userid string, secret *models.Secret, update bool,
+	next func() ([]byte, error)) error {
+	db := p.pool
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(context.Background())
+	}()
+
+	secret.Data = []byte{}
+	if update {
+		err = updateSecret(ctx, tx, userid, secret)
+	} else {
+		err = insertSecret(ctx, tx, userid, secret)
+	}
+	if err != nil {
+		return err
+	}
+	if update && secret.BlobRef != "" {
+		if err = reuseBlob(ctx, tx, userid, secret); err != nil {
+			return err
+		}
+	}
+
+	querySQL := "INSERT INTO secret_blobs (userid, name, version, seq, data) VALUES($1, $2, $3, $4, $5)"
+	hash := sha256.New()
+	for seq := 0; ; seq++ {
+		chunk, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to receive chunk %d of secret %s: %w", seq, secret.Name, err)
+		}
+
+		_, err = tx.Exec(ctx, querySQL, userid, secret.Name, secret.Version, seq, chunk)
+		if err != nil {
+			return fmt.Errorf("failed to insert chunk %d of secret %s: %w", seq, secret.Name, err)
+		}
+		hash.Write(chunk)
+	}
+
+	// data of blob secrets is not streamed, their hash is known before the upload
+	if secret.BlobRef == "" {
+		secret.Hash = hash.Sum(nil)
+		if err = setSecretHash(ctx, tx, userid, secret); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit secret %s into Postgres DB: %w", secret.Name, err)
+	}
 	return nil
 }
 
-func (p *PostgresDB) SecretGet(c *models.Config, userid string, name string) (*models.Secret, error) {
+// reuseBlob points the new version of the secret to the blob of its past version with the same data,
+// so that identical data uploaded again is kept once. The caller deletes the blob it has uploaded if
+// secret.BlobRef is changed. The secret row is locked by the update.
+func reuseBlob(ctx context.Context, tx pgx.Tx, userid string, secret *models.Secret) error {
+	var ref string
+	querySQL := "SELECT blob_ref FROM secret_versions WHERE userid=$1 AND name=$2 AND version<$3 AND blob_hash=$4 " +
+		"AND blob_ref IS NOT NULL LIMIT 1"
+	err := tx.QueryRow(ctx, querySQL, userid, secret.Name, secret.Version, secret.BlobHash).Scan(&ref)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to query blobs of secret %s: %w", secret.Name, err)
+	}
+
+	if _, err = tx.Exec(ctx, "UPDATE secrets SET blob_ref=$3 WHERE userid=$1 AND name=$2",
+		userid, secret.Name, ref); err != nil {
+		return fmt.Errorf("failed to reuse blob of secret %s: %w", secret.Name, err)
+	}
+	if _, err = tx.Exec(ctx, "UPDATE secret_versions SET blob_ref=$4 WHERE userid=$1 AND name=$2 AND version=$3",
+		userid, secret.Name, secret.Version, ref); err != nil {
+		return fmt.Errorf("failed to reuse blob of secret %s: %w", secret.Name, err)
+	}
+	secret.BlobRef = ref
+	return nil
+}
+
+// SecretRollback adds the next version of the secret with the stored data and labels of its past version,
+// the current version must be the expected one. Versions kept in the blob store share the blob, data and
+// chunks kept in DB are copied. The new version is returned.
+func (p *PostgresDB) SecretRollback(ctx context.Context, userid string, name string, version int64,
+	expected int64) (*models.Secret, error) {
 	db := p.pool
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	// the secret row is locked first, so the past version is not replaced or deleted meanwhile
+	var found int
+	querySQL := "SELECT 1 FROM secrets WHERE userid=$1 AND name=$2 FOR UPDATE"
+	if err = tx.QueryRow(ctx, querySQL, userid, name).Scan(&found); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSecretNotFound
+		}
+		return nil, fmt.Errorf("failed to lock secret %s: %w", name, err)
+	}
+
 	var secret models.Secret
-	ctx, cancel := context.WithTimeout(context.Background(), c.ContextTimeout)
-	defer cancel()
+	querySQL = "SELECT name, labels, data, enc_version, COALESCE(blob_ref, ''), blob_hash, hash " +
+		"FROM secret_versions WHERE userid=$1 AND name=$2 AND version=$3"
+	err = tx.QueryRow(ctx, querySQL, userid, name, version).Scan(&secret.Name, &secret.Labels, &secret.Data,
+		&secret.EncVersion, &secret.BlobRef, &secret.BlobHash, &secret.Hash)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return nil, fmt.Errorf("%w: no version %d of secret %s", ErrSecretNotFound, version, name)
+	case err != nil:
+		return nil, fmt.Errorf("failed to query version %d of secret %s: %w", version, name, err)
+	}
+	hash := secret.Hash
 
-	querySQL := "SELECT * FROM secrets WHERE userid=$1 AND name=$2"
+	secret.Version = expected
+	if err = updateSecret(ctx, tx, userid, &secret); err != nil {
+		return nil, err
+	}
+	if secret.EncVersion == models.EncVersionChunked && secret.BlobRef == "" {
+		querySQL = "INSERT INTO secret_blobs (userid, name, version, seq, data) " +
+			"SELECT userid, name, $4, seq, data FROM secret_blobs WHERE userid=$1 AND name=$2 AND version=$3"
+		if _, err = tx.Exec(ctx, querySQL, userid, name, version, secret.Version); err != nil {
+			return nil, fmt.Errorf("failed to copy chunks of version %d of secret %s: %w", version, name, err)
+		}
+		// hash of chunked data covers the chunks
+		secret.Hash = hash
+		if err = setSecretHash(ctx, tx, userid, &secret); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit rollback of secret %s: %w", name, err)
+	}
+	return &secret, nil
+}
+
+// SecretChunks calls f for every chunk of the secret version in order. Chunks are queried
+// one by one, so a slow receiver does not keep a query open.
+func (p *PostgresDB) SecretChunks(ctx context.Context, userid string, name string, version int64,
+	f func(chunk []byte) error) error {
+	db := p.pool
+	querySQL := "SELECT data FROM secret_blobs WHERE userid=$1 AND name=$2 AND version=$3 AND seq=$4"
+
+	for seq := 0; ; seq++ {
+		var chunk []byte
+		err := db.QueryRow(ctx, querySQL, userid, name, version, seq).Scan(&chunk)
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return nil
+		case err != nil:
+			return fmt.Errorf("failed to query chunk %d of secret %s: %w", seq, name, err)
+		}
+
+		if err := f(chunk); err != nil {
+			return err
+		}
+	}
+}
+
+// setSecretHash stores hash of the secret streamed after it was saved.
+func setSecretHash(ctx context.Context, tx pgx.Tx, userid string, secret *models.Secret) error {
+	_, err := tx.Exec(ctx, "UPDATE secrets SET hash=$3 WHERE userid=$1 AND name=$2", userid, secret.Name, secret.Hash)
+	if err != nil {
+		return fmt.Errorf("failed to set hash of secret %s: %w", secret.Name, err)
+	}
+	_, err = tx.Exec(ctx, "UPDATE secret_versions SET hash=$4 WHERE userid=$1 AND name=$2 AND version=$3",
+		userid, secret.Name, secret.Version, secret.Hash)
+	if err != nil {
+		return fmt.Errorf("failed to set hash of version %d of secret %s: %w", secret.Version, secret.Name, err)
+	}
+	return nil
+}
+
+// insertSecretVersion keeps a copy of the secret revision in secret_versions table.
+func insertSecretVersion(ctx context.Context, tx pgx.Tx, userid string, secret *models.Secret) error {
+	querySQL := "INSERT INTO secret_versions (userid, name, version, type, labels, data, enc_version, " +
+		"blob_ref, blob_hash, hash) VALUES($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9, $10)"
+
+	_, err := tx.Exec(ctx, querySQL, userid, secret.Name, secret.Version, secret.Type, labelsOf(secret),
+		secret.Data, secret.EncVersion, secret.BlobRef, secret.BlobHash, secret.Hash)
+	if err != nil {
+		return fmt.Errorf("failed to insert version %d of secret %s: %w", secret.Version, secret.Name, err)
+	}
+	return nil
+}
+
+// secretHash returns SHA-256 hash of the secret data: of Data followed by the chunks or of the blob.
+func secretHash(secret *models.Secret, chunks ...[]byte) []byte {
+	if secret.BlobRef != "" {
+		return bytes.Clone(secret.BlobHash)
+	}
+	hash := sha256.New()
+	hash.Write(secret.Data)
+	for _, chunk := range chunks {
+		hash.Write(chunk)
+	}
+	return hash.Sum(nil)
+}
+
+// labelsOf returns secret labels to be stored in JSONB column, nil map would be stored as JSON null.
+func labelsOf(secret *models.Secret) map[string]string {
+	if secret.Labels == nil {
+		return map[string]string{}
+	}
+	return secret.Labels
+}
+
+func (p *PostgresDB) SecretGet(ctx context.Context, userid string, name string) (*models.Secret, error) {
+	db := p.pool
+	var secret models.Secret
+
+	querySQL := "SELECT userid, name, type, labels, data, version, enc_version, COALESCE(blob_ref, ''), blob_hash, " +
+		"hash FROM secrets WHERE userid=$1 AND name=$2"
 
 	row := db.QueryRow(ctx, querySQL, userid, name)
-	err := row.Scan(&secret.UserID, &secret.Name, &secret.Type, &secret.Meta, &secret.Data, &secret.Version)
+	err := row.Scan(&secret.UserID, &secret.Name, &secret.Type, &secret.Labels, &secret.Data, &secret.Version,
+		&secret.EncVersion, &secret.BlobRef, &secret.BlobHash, &secret.Hash)
 	switch {
 	case errors.Is(err, pgx.ErrNoRows):
 		return &models.Secret{}, ErrSecretNotFound
@@ -162,32 +611,210 @@ func (p *PostgresDB) SecretGet(c *models.Config, userid string, name string) (*m
 	return &secret, nil
 }
 
-func (p *PostgresDB) SecretDelete(c *models.Config, userid string, name string) error {
+func (p *PostgresDB) SecretGetVersion(ctx context.Context, userid string, name string,
+	version int64) (*models.Secret, error) {
 	db := p.pool
-	ctx, cancel := context.WithTimeout(context.Background(), c.ContextTimeout)
-	defer cancel()
+	var secret models.Secret
 
-	querySQL := "DELETE FROM secrets WHERE userid=$1 AND name=$2"
+	querySQL := "SELECT userid, name, type, labels, data, version, enc_version, COALESCE(blob_ref, ''), blob_hash, " +
+		"hash FROM secret_versions WHERE userid=$1 AND name=$2 AND version=$3"
 
-	_, err := db.Exec(ctx, querySQL, userid, name)
+	row := db.QueryRow(ctx, querySQL, userid, name, version)
+	err := row.Scan(&secret.UserID, &secret.Name, &secret.Type, &secret.Labels, &secret.Data, &secret.Version,
+		&secret.EncVersion, &secret.BlobRef, &secret.BlobHash, &secret.Hash)
 	switch {
 	case errors.Is(err, pgx.ErrNoRows):
-		return ErrSecretNotFound
+		return &models.Secret{}, ErrSecretNotFound
 	case err != nil:
-		return fmt.Errorf("failed to delete secret: %w", err)
+		return &models.Secret{}, fmt.Errorf("failed to query secret version: %w", err)
 	}
-	return nil
+	return &secret, nil
 }
 
-func (p *PostgresDB) SecretList(c *models.Config, userid string) (*models.SecretList, error) {
+func (p *PostgresDB) SecretVersionList(ctx context.Context, userid string,
+	name string) (*models.SecretVersionList, error) {
+	db := p.pool
+	versions := models.SecretVersionList{}
+
+	querySQL := "SELECT version, type, created_at FROM secret_versions WHERE userid=$1 AND name=$2 ORDER BY version"
+
+	rows, err := db.Query(ctx, querySQL, userid, name)
+	if err != nil {
+		return nil, fmt.Errorf("error querying secret versions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var v models.SecretVersionItem
+		if err = rows.Scan(&v.Version, &v.Type, &v.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row in secret_versions table: %w", err)
+		}
+		versions = append(versions, v)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan rows in secret_versions table: %w", err)
+	}
+
+	if len(versions) == 0 {
+		return nil, ErrSecretNotFound
+	}
+
+	return &versions, nil
+}
+
+// SecretDelete removes the secret with its versions and shares and returns references to blob store
+// objects keeping data of the deleted versions. The secret row is locked first, so a version added by
+// a concurrent update is either deleted and returned or the update fails.
+func (p *PostgresDB) SecretDelete(ctx context.Context, userid string, name string) ([]string, error) {
+	db := p.pool
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	var found int
+	querySQL := "SELECT 1 FROM secrets WHERE userid=$1 AND name=$2 FOR UPDATE"
+	if err = tx.QueryRow(ctx, querySQL, userid, name).Scan(&found); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSecretNotFound
+		}
+		return nil, fmt.Errorf("failed to lock secret %s: %w", name, err)
+	}
+
+	querySQL = "DELETE FROM secret_versions WHERE userid=$1 AND name=$2 RETURNING blob_ref"
+	rows, err := tx.Query(ctx, querySQL, userid, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete versions of secret %s: %w", name, err)
+	}
+	// versions may share a blob
+	refs := []string{}
+	for rows.Next() {
+		var ref *string
+		if err = rows.Scan(&ref); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan blob of secret %s: %w", name, err)
+		}
+		if ref != nil && !contains(refs, *ref) {
+			refs = append(refs, *ref)
+		}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to delete versions of secret %s: %w", name, err)
+	}
+
+	if _, err = tx.Exec(ctx, "DELETE FROM secrets WHERE userid=$1 AND name=$2", userid, name); err != nil {
+		return nil, fmt.Errorf("failed to delete secret: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit deletion of secret %s: %w", name, err)
+	}
+	return refs, nil
+}
+
+// SecretReplaceVersions replaces data of past versions of the secret and deletes past versions in remove
+// in one transaction, references to blob store objects used only by the deleted versions are returned.
+// Versions kept in chunks can not be replaced, nothing is changed if a version is not a past version of
+// the secret.
+func (p *PostgresDB) SecretReplaceVersions(ctx context.Context, userid string, name string,
+	versions []*models.Secret, remove []int64) ([]string, error) {
+	db := p.pool
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	// the current version does not change while the secret row is locked
+	var current int64
+	querySQL := "SELECT version FROM secrets WHERE userid=$1 AND name=$2 FOR UPDATE"
+	if err = tx.QueryRow(ctx, querySQL, userid, name).Scan(&current); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSecretNotFound
+		}
+		return nil, fmt.Errorf("failed to lock secret %s: %w", name, err)
+	}
+
+	querySQL = "UPDATE secret_versions SET data=$1, enc_version=$2, hash=$3 " +
+		"WHERE userid=$4 AND name=$5 AND version=$6 AND version<$7 AND enc_version<>$8"
+	for _, secret := range versions {
+		secret.Hash = secretHash(secret)
+		tag, err := tx.Exec(ctx, querySQL, secret.Data, secret.EncVersion, secret.Hash, userid, name,
+			secret.Version, current, models.EncVersionChunked)
+		if err != nil {
+			return nil, fmt.Errorf("failed to replace version %d of secret %s: %w", secret.Version, name, err)
+		}
+		if tag.RowsAffected() == 0 {
+			return nil, fmt.Errorf("%w: no past version %d of secret %s kept in DB", ErrSecretNotFound,
+				secret.Version, name)
+		}
+	}
+
+	refs := []string{}
+	querySQL = "DELETE FROM secret_versions WHERE userid=$1 AND name=$2 AND version=$3 AND version<$4 " +
+		"RETURNING blob_ref"
+	for _, version := range remove {
+		var ref *string
+		err = tx.QueryRow(ctx, querySQL, userid, name, version, current).Scan(&ref)
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return nil, fmt.Errorf("%w: no past version %d of secret %s", ErrSecretNotFound, version, name)
+		case err != nil:
+			return nil, fmt.Errorf("failed to delete version %d of secret %s: %w", version, name, err)
+		}
+		if ref != nil && !contains(refs, *ref) {
+			refs = append(refs, *ref)
+		}
+	}
+	// blobs shared with the remaining versions are kept
+	unused := []string{}
+	querySQL = "SELECT EXISTS (SELECT 1 FROM secret_versions WHERE userid=$1 AND name=$2 AND blob_ref=$3)"
+	for _, ref := range refs {
+		var used bool
+		if err = tx.QueryRow(ctx, querySQL, userid, name, ref).Scan(&used); err != nil {
+			return nil, fmt.Errorf("failed to query blobs of secret %s: %w", name, err)
+		}
+		if !used {
+			unused = append(unused, ref)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit versions of secret %s: %w", name, err)
+	}
+	return unused, nil
+}
+
+// SecretList returns a page of user secrets matching the filter in filter sort order, at most
+// filter.Limit secrets are returned. Pages are selected by keyset of the sort order, i.e. the
+// page starts after filter.After cursor, so concurrent changes never shift page boundaries.
+func (p *PostgresDB) SecretList(ctx context.Context, userid string,
+	filter models.SecretFilter) (*models.SecretList, error) {
 	db := p.pool
 	secrets := models.SecretList{}
-	ctx, cancel := context.WithTimeout(context.Background(), c.ContextTimeout)
-	defer cancel()
 
-	querySQL := "SELECT * FROM secrets WHERE userid=$1"
+	// rest of the name after folder must not contain '/' unless listing is recursive
+	querySQL := "SELECT name, type, labels, version, enc_version, updated_at FROM secrets " +
+		"WHERE userid=$1 AND starts_with(name, $2::text) AND ($3 OR strpos(substr(name, length($2) + 1), '/') = 0)"
+	cond, args := labelsCondition(filter.Selector, []any{userid, filter.Folder, filter.Recursive})
+	querySQL += cond
+	if len(filter.Types) > 0 {
+		args = append(args, filter.Types)
+		querySQL += fmt.Sprintf(" AND type = ANY($%d)", len(args))
+	}
+	cond, args = pageCondition(filter, args)
+	querySQL += cond
 
-	rows, err := db.Query(ctx, querySQL, userid)
+	rows, err := db.Query(ctx, querySQL, args...)
 	if err != nil {
 		return nil, fmt.Errorf("error querying secrets db: %w", err)
 	}
@@ -196,12 +823,12 @@ func (p *PostgresDB) SecretList(c *models.Config, userid string) (*models.Secret
 	for rows.Next() {
 		var s models.SecretItem
 		if err = rows.Scan(
-			nil,
 			&s.Name,
 			&s.Type,
-			nil,
-			nil,
+			&s.Labels,
 			&s.Version,
+			&s.EncVersion,
+			&s.UpdatedAt,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan row in secrets table: %w", err)
 		}
@@ -219,6 +846,170 @@ func (p *PostgresDB) SecretList(c *models.Config, userid string) (*models.Secret
 	return &secrets, nil
 }
 
+// pageCondition translates filter sort order, cursor and limit into SQL keyset condition,
+// ORDER BY and LIMIT clauses, which are appended to a query which already has args.
+func pageCondition(filter models.SecretFilter, args []any) (string, []any) {
+	var cond strings.Builder
+	after := filter.After
+	switch filter.Sort {
+	case models.SortNameDesc:
+		if after != nil {
+			args = append(args, after.Name)
+			fmt.Fprintf(&cond, " AND name < $%d", len(args))
+		}
+		cond.WriteString(" ORDER BY name DESC")
+	case models.SortUpdatedDesc:
+		if after != nil {
+			args = append(args, after.UpdatedAt, after.Name)
+			fmt.Fprintf(&cond, " AND (updated_at, name) < ($%d, $%d)", len(args)-1, len(args))
+		}
+		cond.WriteString(" ORDER BY updated_at DESC, name DESC")
+	case models.SortUpdatedAsc:
+		if after != nil {
+			args = append(args, after.UpdatedAt, after.Name)
+			fmt.Fprintf(&cond, " AND (updated_at, name) > ($%d, $%d)", len(args)-1, len(args))
+		}
+		cond.WriteString(" ORDER BY updated_at, name")
+	default:
+		if after != nil {
+			args = append(args, after.Name)
+			fmt.Fprintf(&cond, " AND name > $%d", len(args))
+		}
+		cond.WriteString(" ORDER BY name")
+	}
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		fmt.Fprintf(&cond, " LIMIT $%d", len(args))
+	}
+	return cond.String(), args
+}
+
+// SecretSearch returns user secrets which name, type or labels contain words of the query, words
+// match by prefix and names also match by trigram similarity. Results are ordered by rank.
+func (p *PostgresDB) SecretSearch(ctx context.Context, userid string, terms []string,
+	limit int) (*models.SecretSearchList, error) {
+	db := p.pool
+	results := models.SecretSearchList{}
+
+	// terms contain only letters and digits, so they are safe in tsquery syntax
+	prefixes := make([]string, 0, len(terms))
+	for _, term := range terms {
+		prefixes = append(prefixes, term+":*")
+	}
+	tsQuery := strings.Join(prefixes, " & ")
+
+	querySQL := "SELECT name, type, labels, version, enc_version, " +
+		"ts_rank(search, to_tsquery('simple', $2)) + similarity(name, $3) AS rank FROM secrets " +
+		"WHERE userid=$1 AND (search @@ to_tsquery('simple', $2) OR name % $3) " +
+		"ORDER BY rank DESC, name LIMIT $4"
+
+	rows, err := db.Query(ctx, querySQL, userid, tsQuery, strings.Join(terms, " "), limit)
+	if err != nil {
+		return nil, fmt.Errorf("error searching secrets: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s models.SecretSearchItem
+		if err = rows.Scan(
+			&s.Name,
+			&s.Type,
+			&s.Labels,
+			&s.Version,
+			&s.EncVersion,
+			&s.Rank,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row of secret search: %w", err)
+		}
+		results = append(results, s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan rows of secret search: %w", err)
+	}
+	return &results, nil
+}
+
+// SecretFolders returns immediate subfolders of filter folder which contain secrets matching
+// filter label selector.
+func (p *PostgresDB) SecretFolders(ctx context.Context, userid string, filter models.SecretFilter) ([]string, error) {
+	db := p.pool
+	folders := make([]string, 0)
+
+	querySQL := "SELECT DISTINCT $2 || split_part(substr(name, length($2) + 1), '/', 1) || '/' AS folder " +
+		"FROM secrets WHERE userid=$1 AND starts_with(name, $2::text) " +
+		"AND strpos(substr(name, length($2) + 1), '/') > 0"
+	cond, args := labelsCondition(filter.Selector, []any{userid, filter.Folder})
+	querySQL += cond + " ORDER BY folder"
+
+	rows, err := db.Query(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying secret folders: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var folder string
+		if err = rows.Scan(&folder); err != nil {
+			return nil, fmt.Errorf("failed to scan secret folder: %w", err)
+		}
+		folders = append(folders, folder)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan secret folders: %w", err)
+	}
+	return folders, nil
+}
+
+// SecretMove renames secret, or every secret of the folder if from ends with '/'. Version history
+// and chunks follow the secret by ON UPDATE CASCADE foreign keys. Number of moved secrets is returned.
+func (p *PostgresDB) SecretMove(ctx context.Context, userid string, from string, to string) (int64, error) {
+	db := p.pool
+	var pgErr *pgconn.PgError
+
+	querySQL := "UPDATE secrets SET name=$3 WHERE userid=$1 AND name=$2"
+	if secretpath.IsFolder(from) {
+		querySQL = "UPDATE secrets SET name = $3 || substr(name, length($2) + 1) " +
+			"WHERE userid=$1 AND starts_with(name, $2::text)"
+	}
+
+	tag, err := db.Exec(ctx, querySQL, userid, from, to)
+	if err != nil {
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return 0, ErrSecretAlreadyExists
+		}
+		return 0, fmt.Errorf("failed to move secret %s to %s: %w", from, to, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return 0, ErrSecretNotFound
+	}
+	return tag.RowsAffected(), nil
+}
+
+// labelsCondition translates label selector into SQL condition over labels JSONB column,
+// the condition is appended to a query which already has args.
+func labelsCondition(selector labels.Selector, args []any) (string, []any) {
+	var cond strings.Builder
+	for _, r := range selector {
+		switch r.Operator {
+		case labels.Equals:
+			args = append(args, map[string]string{r.Key: r.Value})
+			fmt.Fprintf(&cond, " AND labels @> $%d::jsonb", len(args))
+		case labels.NotEquals:
+			args = append(args, map[string]string{r.Key: r.Value})
+			fmt.Fprintf(&cond, " AND NOT labels @> $%d::jsonb", len(args))
+		case labels.Exists:
+			args = append(args, r.Key)
+			fmt.Fprintf(&cond, " AND labels ? $%d", len(args))
+		case labels.NotExists:
+			args = append(args, r.Key)
+			fmt.Fprintf(&cond, " AND NOT labels ? $%d", len(args))
+		}
+	}
+	return cond.String(), args
+}
+
 func (p *PostgresDB) Close() {
 	p.pool.Close()
 }
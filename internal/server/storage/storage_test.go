@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vkupriya/gophkeeper/internal/labels"
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+)
+
+func TestLabelsCondition(t *testing.T) {
+	selector, err := labels.ParseSelector("env=prod,team!=dev,owner,!archived")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cond, args := labelsCondition(selector, []any{"user01"})
+
+	wantCond := " AND labels @> $2::jsonb AND NOT labels @> $3::jsonb AND labels ? $4 AND NOT labels ? $5"
+	if cond != wantCond {
+		t.Errorf("Out -> \nWant: %q\nGot : %q", wantCond, cond)
+	}
+	wantArgs := []any{
+		"user01",
+		map[string]string{"env": "prod"},
+		map[string]string{"team": "dev"},
+		"owner",
+		"archived",
+	}
+	if !reflect.DeepEqual(wantArgs, args) {
+		t.Errorf("Out -> \nWant: %v\nGot : %v", wantArgs, args)
+	}
+
+	cond, args = labelsCondition(labels.Selector{}, []any{"user01"})
+	if cond != "" || len(args) != 1 {
+		t.Errorf("empty selector must not add conditions, got %q %v", cond, args)
+	}
+}
+
+func TestPageCondition(t *testing.T) {
+	updated := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name     string
+		filter   models.SecretFilter
+		wantCond string
+		wantArgs []any
+	}{
+		{
+			name:     "FirstPage",
+			filter:   models.SecretFilter{Limit: 11},
+			wantCond: " ORDER BY name LIMIT $2",
+			wantArgs: []any{"user01", 11},
+		},
+		{
+			name:     "NameDesc",
+			filter:   models.SecretFilter{Sort: models.SortNameDesc, After: &models.SecretCursor{Name: "b"}, Limit: 11},
+			wantCond: " AND name < $2 ORDER BY name DESC LIMIT $3",
+			wantArgs: []any{"user01", "b", 11},
+		},
+		{
+			name: "UpdatedDesc",
+			filter: models.SecretFilter{
+				Sort:  models.SortUpdatedDesc,
+				After: &models.SecretCursor{Name: "b", UpdatedAt: updated},
+			},
+			wantCond: " AND (updated_at, name) < ($2, $3) ORDER BY updated_at DESC, name DESC",
+			wantArgs: []any{"user01", updated, "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cond, args := pageCondition(tt.filter, []any{"user01"})
+			if cond != tt.wantCond {
+				t.Errorf("Out -> \nWant: %q\nGot : %q", tt.wantCond, cond)
+			}
+			if !reflect.DeepEqual(tt.wantArgs, args) {
+				t.Errorf("Out -> \nWant: %v\nGot : %v", tt.wantArgs, args)
+			}
+		})
+	}
+}
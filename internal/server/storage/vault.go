@@ -0,0 +1,332 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/vkupriya/gophkeeper/internal/server/models"
+	"github.com/vkupriya/gophkeeper/internal/vault"
+)
+
+// VaultCreate creates vault of the organization and its principal user, the user becomes vault owner
+// with the vault key sealed for the user. Organization is created with the user as its owner
+// if it does not exist, otherwise the user must be its owner.
+func (p *PostgresDB) VaultCreate(ctx context.Context, userid string, org string, name string, sealedKey []byte) error {
+	db := p.pool
+	var pgErr *pgconn.PgError
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	tag, err := tx.Exec(ctx, "INSERT INTO organizations (name) VALUES($1) ON CONFLICT DO NOTHING", org)
+	if err != nil {
+		return fmt.Errorf("failed to insert organization %s: %w", org, err)
+	}
+	if tag.RowsAffected() == 1 {
+		_, err = tx.Exec(ctx, "INSERT INTO vault_members (org, vault, userid, role) VALUES($1, '', $2, $3)",
+			org, userid, vault.RoleOwner)
+		if err != nil {
+			return fmt.Errorf("failed to insert owner of organization %s: %w", org, err)
+		}
+	} else {
+		role, err := memberRole(ctx, tx, userid, org, "")
+		if err != nil {
+			return err
+		}
+		if role != vault.RoleOwner {
+			return ErrNotOrgOwner
+		}
+	}
+
+	// principal has no password, so nobody can login as the vault
+	principal := vault.Principal(org, name)
+	_, err = tx.Exec(ctx, "INSERT INTO users (userid, password) VALUES($1, '')", principal)
+	if err != nil {
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return ErrVaultAlreadyExists
+		}
+		return fmt.Errorf("failed to insert principal of vault %s: %w", principal, err)
+	}
+	_, err = tx.Exec(ctx, "INSERT INTO vaults (org, name, principal) VALUES($1, $2, $3)", org, name, principal)
+	if err != nil {
+		return fmt.Errorf("failed to insert vault %s/%s: %w", org, name, err)
+	}
+	_, err = tx.Exec(ctx, "INSERT INTO vault_members (org, vault, userid, role, sealed_key) VALUES($1, $2, $3, $4, $5)",
+		org, name, userid, vault.RoleOwner, sealedKey)
+	if err != nil {
+		return fmt.Errorf("failed to insert owner of vault %s/%s: %w", org, name, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit vault %s/%s: %w", org, name, err)
+	}
+	return nil
+}
+
+// VaultRole returns the role of the user in the vault and the principal owning vault secrets,
+// empty role is returned if the user is not a member of the vault or its organization.
+func (p *PostgresDB) VaultRole(ctx context.Context, userid string, org string,
+	name string) (vault.Role, string, error) {
+	db := p.pool
+
+	querySQL := "SELECT v.principal, m.role FROM vaults v LEFT JOIN vault_members m " +
+		"ON m.org = v.org AND m.vault IN ('', v.name) AND m.userid=$3 WHERE v.org=$1 AND v.name=$2"
+
+	rows, err := db.Query(ctx, querySQL, org, name, userid)
+	if err != nil {
+		return "", "", fmt.Errorf("error querying role in vault %s/%s: %w", org, name, err)
+	}
+	defer rows.Close()
+
+	var principal string
+	var role vault.Role
+	for rows.Next() {
+		var r *string
+		if err = rows.Scan(&principal, &r); err != nil {
+			return "", "", fmt.Errorf("failed to scan role in vault %s/%s: %w", org, name, err)
+		}
+		if r != nil {
+			role = vault.Max(role, vault.Role(*r))
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return "", "", fmt.Errorf("failed to scan roles in vault %s/%s: %w", org, name, err)
+	}
+	if principal == "" {
+		return "", "", ErrVaultNotFound
+	}
+	return role, principal, nil
+}
+
+// OrgRole returns the role of the user in the organization, empty role is returned if the user
+// is not its member.
+func (p *PostgresDB) OrgRole(ctx context.Context, userid string, org string) (vault.Role, error) {
+	return memberRole(ctx, p.pool, userid, org, "")
+}
+
+// VaultKey returns the vault key sealed for the user.
+func (p *PostgresDB) VaultKey(ctx context.Context, userid string, org string, name string) ([]byte, error) {
+	db := p.pool
+
+	var sealedKey []byte
+	err := db.QueryRow(ctx, "SELECT sealed_key FROM vault_members WHERE org=$1 AND vault=$2 AND userid=$3",
+		org, name, userid).Scan(&sealedKey)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrVaultKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to query key of vault %s/%s: %w", org, name, err)
+	}
+	if len(sealedKey) == 0 {
+		return nil, ErrVaultKeyNotFound
+	}
+	return sealedKey, nil
+}
+
+// VaultKeySet replaces the vault key sealed for the user, ErrVaultKeyNotFound is returned if the user
+// has no key of the vault.
+func (p *PostgresDB) VaultKeySet(ctx context.Context, userid string, org string, name string, sealedKey []byte) error {
+	db := p.pool
+
+	querySQL := "UPDATE vault_members SET sealed_key=$4 " +
+		"WHERE org=$1 AND vault=$2 AND userid=$3 AND octet_length(sealed_key) > 0"
+	tag, err := db.Exec(ctx, querySQL, org, name, userid, sealedKey)
+	if err != nil {
+		return fmt.Errorf("failed to set key of vault %s/%s: %w", org, name, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrVaultKeyNotFound
+	}
+	return nil
+}
+
+// VaultList returns vaults where the user is a member directly or by the organization.
+func (p *PostgresDB) VaultList(ctx context.Context, userid string) (*models.VaultList, error) {
+	db := p.pool
+	vaults := models.VaultList{}
+
+	querySQL := "SELECT v.org, v.name, m.role FROM vaults v " +
+		"JOIN vault_members m ON m.org = v.org AND m.vault IN ('', v.name) " +
+		"WHERE m.userid=$1 ORDER BY v.org, v.name"
+
+	rows, err := db.Query(ctx, querySQL, userid)
+	if err != nil {
+		return nil, fmt.Errorf("error querying vaults: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var v models.Vault
+		if err = rows.Scan(&v.Org, &v.Name, &v.Role); err != nil {
+			return nil, fmt.Errorf("failed to scan vault: %w", err)
+		}
+		// rows are ordered, so roles of the same vault are adjacent
+		if last := len(vaults) - 1; last >= 0 && vaults[last].Org == v.Org && vaults[last].Name == v.Name {
+			vaults[last].Role = vault.Max(vaults[last].Role, v.Role)
+			continue
+		}
+		vaults = append(vaults, v)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan vaults: %w", err)
+	}
+	return &vaults, nil
+}
+
+// VaultMemberSet adds member to the vault or changes the member role and sealed vault key,
+// empty vault is the organization.
+func (p *PostgresDB) VaultMemberSet(ctx context.Context, org string, name string, userid string,
+	role vault.Role, sealedKey []byte) error {
+	db := p.pool
+	var pgErr *pgconn.PgError
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err = vaultExists(ctx, tx, org, name); err != nil {
+		return err
+	}
+
+	querySQL := "INSERT INTO vault_members (org, vault, userid, role, sealed_key) VALUES($1, $2, $3, $4, $5) " +
+		"ON CONFLICT (org, vault, userid) DO UPDATE SET role=EXCLUDED.role, sealed_key=EXCLUDED.sealed_key"
+	_, err = tx.Exec(ctx, querySQL, org, name, userid, role, sealedKey)
+	if err != nil {
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation &&
+			strings.Contains(pgErr.ConstraintName, "userid") {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to set member %s of vault %s/%s: %w", userid, org, name, err)
+	}
+
+	if err = checkOwners(ctx, tx, org); err != nil {
+		return err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit member of vault %s/%s: %w", org, name, err)
+	}
+	return nil
+}
+
+// VaultMemberDelete removes member from the vault, empty vault is the organization.
+func (p *PostgresDB) VaultMemberDelete(ctx context.Context, org string, name string, userid string) error {
+	db := p.pool
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	tag, err := tx.Exec(ctx, "DELETE FROM vault_members WHERE org=$1 AND vault=$2 AND userid=$3", org, name, userid)
+	if err != nil {
+		return fmt.Errorf("failed to delete member %s of vault %s/%s: %w", userid, org, name, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrMemberNotFound
+	}
+
+	if err = checkOwners(ctx, tx, org); err != nil {
+		return err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit member removal of vault %s/%s: %w", org, name, err)
+	}
+	return nil
+}
+
+// VaultMemberList returns members of the organization and of the vault unless it is empty.
+func (p *PostgresDB) VaultMemberList(ctx context.Context, org string, name string) (*models.VaultMemberList, error) {
+	db := p.pool
+	members := models.VaultMemberList{}
+
+	querySQL := "SELECT userid, vault, role FROM vault_members WHERE org=$1 AND vault IN ('', $2) " +
+		"ORDER BY vault, userid"
+
+	rows, err := db.Query(ctx, querySQL, org, name)
+	if err != nil {
+		return nil, fmt.Errorf("error querying members of vault %s/%s: %w", org, name, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var m models.VaultMember
+		if err = rows.Scan(&m.UserID, &m.Vault, &m.Role); err != nil {
+			return nil, fmt.Errorf("failed to scan vault member: %w", err)
+		}
+		members = append(members, m)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan vault members: %w", err)
+	}
+	return &members, nil
+}
+
+// rowQuerier is implemented by both connection pool and transaction.
+type rowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// memberRole returns role of direct membership of the user in the vault, empty vault is the organization.
+func memberRole(ctx context.Context, db rowQuerier, userid string, org string, name string) (vault.Role, error) {
+	var role vault.Role
+	err := db.QueryRow(ctx, "SELECT role FROM vault_members WHERE org=$1 AND vault=$2 AND userid=$3",
+		org, name, userid).Scan(&role)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return "", nil
+	case err != nil:
+		return "", fmt.Errorf("failed to query role of %s in %s/%s: %w", userid, org, name, err)
+	}
+	return role, nil
+}
+
+// vaultExists checks that the vault exists, empty vault is the organization.
+func vaultExists(ctx context.Context, tx pgx.Tx, org string, name string) error {
+	querySQL, args := "SELECT EXISTS (SELECT 1 FROM vaults WHERE org=$1 AND name=$2)", []any{org, name}
+	if name == "" {
+		querySQL, args = "SELECT EXISTS (SELECT 1 FROM organizations WHERE name=$1)", []any{org}
+	}
+	var exists bool
+	if err := tx.QueryRow(ctx, querySQL, args...).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to query vault %s/%s: %w", org, name, err)
+	}
+	if !exists {
+		return ErrVaultNotFound
+	}
+	return nil
+}
+
+// checkOwners fails if the organization is left without owners.
+func checkOwners(ctx context.Context, tx pgx.Tx, org string) error {
+	var owners int
+	err := tx.QueryRow(ctx, "SELECT count(*) FROM vault_members WHERE org=$1 AND vault='' AND role=$2",
+		org, vault.RoleOwner).Scan(&owners)
+	if err != nil {
+		return fmt.Errorf("failed to count owners of organization %s: %w", org, err)
+	}
+	if owners == 0 {
+		return ErrLastOwner
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// Package vault implements team vault references and roles shared by gophkeeper server and client.
+// Vault is referenced as 'org/vault', e.g. 'acme/payments-oncall', secret RPCs act on the vault
+// when the reference is sent in MetadataKey of the call.
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MetadataKey is gRPC metadata key of the vault reference.
+const MetadataKey = "vault"
+
+// principalPrefix starts logins of vault principals, users can not register such logins.
+const principalPrefix = "@"
+
+var (
+	ErrInvalidName = errors.New("invalid organization or vault name")
+	ErrInvalidRole = errors.New("invalid role")
+)
+
+var nameRe = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,62}[a-z0-9])?$`)
+
+// Role is a role of vault member, every role has permissions of the lower ones.
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	RoleEditor Role = "editor"
+	RoleOwner  Role = "owner"
+)
+
+var roleRanks = map[Role]int{RoleViewer: 1, RoleEditor: 2, RoleOwner: 3}
+
+// ParseRole validates role name.
+func ParseRole(s string) (Role, error) {
+	if _, ok := roleRanks[Role(s)]; !ok {
+		return "", fmt.Errorf("%w %q, use owner, editor or viewer", ErrInvalidRole, s)
+	}
+	return Role(s), nil
+}
+
+// Allows reports whether the role has permissions of the required role.
+func (r Role) Allows(required Role) bool {
+	return roleRanks[r] >= roleRanks[required]
+}
+
+// Max returns the higher of two roles, empty role is lower than any role.
+func Max(a, b Role) Role {
+	if roleRanks[a] >= roleRanks[b] {
+		return a
+	}
+	return b
+}
+
+// ValidateName checks organization or vault name: lowercase letters, digits and '-', at most 64 characters.
+func ValidateName(name string) error {
+	if !nameRe.MatchString(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+	return nil
+}
+
+// Parse splits vault reference 'org/vault' into organization and vault names.
+func Parse(ref string) (string, string, error) {
+	org, name, ok := strings.Cut(ref, "/")
+	if !ok {
+		return "", "", fmt.Errorf("%w: %q, use org/vault", ErrInvalidName, ref)
+	}
+	if err := ValidateName(org); err != nil {
+		return "", "", err
+	}
+	if err := ValidateName(name); err != nil {
+		return "", "", err
+	}
+	return org, name, nil
+}
+
+// Principal returns login of the principal owning secrets of the vault.
+func Principal(org, name string) string {
+	return principalPrefix + org + "/" + name
+}
+
+// IsPrincipal reports whether login is reserved for vault principals.
+func IsPrincipal(login string) bool {
+	return strings.HasPrefix(login, principalPrefix)
+}
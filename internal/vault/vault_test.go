@@ -0,0 +1,56 @@
+package vault
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		ref     string
+		org     string
+		name    string
+		wantErr bool
+	}{
+		{ref: "acme/payments-oncall", org: "acme", name: "payments-oncall"},
+		{ref: "acme", wantErr: true},
+		{ref: "acme/", wantErr: true},
+		{ref: "/vault", wantErr: true},
+		{ref: "Acme/vault", wantErr: true},
+		{ref: "acme/team/vault", wantErr: true},
+		{ref: "acme/-vault", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			org, name, err := Parse(tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Err -> \nWant: %v\nGot : %v", tt.wantErr, err)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidName) {
+				t.Errorf("Err -> \nWant: %v\nGot : %v", ErrInvalidName, err)
+			}
+			if org != tt.org || name != tt.name {
+				t.Errorf("Out -> \nWant: %q %q\nGot : %q %q", tt.org, tt.name, org, name)
+			}
+		})
+	}
+}
+
+func TestRole(t *testing.T) {
+	if !RoleOwner.Allows(RoleEditor) || !RoleEditor.Allows(RoleEditor) || RoleViewer.Allows(RoleEditor) {
+		t.Error("role ranks are not ordered owner > editor > viewer")
+	}
+	if Role("").Allows(RoleViewer) {
+		t.Error("empty role must not allow anything")
+	}
+	if Max(RoleViewer, RoleOwner) != RoleOwner || Max("", RoleViewer) != RoleViewer {
+		t.Error("Max must return the higher role")
+	}
+	if _, err := ParseRole("admin"); !errors.Is(err, ErrInvalidRole) {
+		t.Errorf("Err -> \nWant: %v\nGot : %v", ErrInvalidRole, err)
+	}
+	if IsPrincipal("alice") || !IsPrincipal(Principal("acme", "ops")) {
+		t.Error("principal login must be recognized")
+	}
+}